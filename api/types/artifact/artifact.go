@@ -0,0 +1,23 @@
+package artifact
+
+import ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+
+// PullResponse contains the response of Engine API:
+// POST "/artifacts/{name:.*}/pull"
+type PullResponse struct {
+	// Reference is the normalized reference the artifact was pulled from.
+	Reference string
+
+	// Descriptor is the OCI descriptor of the artifact's root manifest.
+	Descriptor ocispec.Descriptor
+}
+
+// PushResponse contains the response of Engine API:
+// POST "/artifacts/{name:.*}/push"
+type PushResponse struct {
+	// Reference is the normalized reference the artifact was pushed to.
+	Reference string
+
+	// Descriptor is the OCI descriptor of the artifact's root manifest.
+	Descriptor ocispec.Descriptor
+}
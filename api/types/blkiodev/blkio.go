@@ -21,3 +21,14 @@ type ThrottleDevice struct {
 func (t *ThrottleDevice) String() string {
 	return fmt.Sprintf("%s:%d", t.Path, t.Rate)
 }
+
+// LatencyDevice is a structure that holds a device:target_latency pair,
+// with the target latency in microseconds.
+type LatencyDevice struct {
+	Path       string
+	TargetUsec uint64
+}
+
+func (l *LatencyDevice) String() string {
+	return fmt.Sprintf("%s:%d", l.Path, l.TargetUsec)
+}
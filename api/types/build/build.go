@@ -74,6 +74,54 @@ type ImageBuildOptions struct {
 	// Outputs defines configurations for exporting build results. Only supported
 	// in BuildKit mode
 	Outputs []ImageBuildOutput
+	// Secrets are inline build secrets made available to the build through
+	// `RUN --mount=type=secret` mounts. They are forwarded to the builder
+	// over the build session and are never stored in the image, the build
+	// cache, or the daemon's logs. Only supported in BuildKit mode.
+	Secrets []BuildSecret
+	// SecretIDs references build secrets previously registered on the
+	// daemon through "POST /build/secrets". Referenced secrets are removed
+	// from the daemon once the build has picked them up. Only supported in
+	// BuildKit mode.
+	SecretIDs []string
+	// SSHSources exposes SSH agents to `RUN --mount=type=ssh` mounts,
+	// forwarded to the build over the build session. Only supported in
+	// BuildKit mode.
+	SSHSources []SSHSource
+}
+
+// BuildSecret is a build secret passed inline with a build request. Its data
+// is exposed to the build only through secret mounts, and is redacted from
+// request logging.
+type BuildSecret struct {
+	// ID is the identifier the Dockerfile references the secret by.
+	ID string
+	// Data holds the secret value.
+	Data []byte
+}
+
+// SSHSource exposes an SSH agent socket on the daemon's host to the build.
+type SSHSource struct {
+	// ID is the identifier the Dockerfile references the agent by.
+	// Defaults to "default" when empty.
+	ID string
+	// AgentSocket is the path, on the daemon's host, of the SSH agent
+	// socket to forward.
+	AgentSocket string
+}
+
+// SecretsRequest is the request body for "POST /build/secrets". It registers
+// build secrets and SSH agent sockets on the daemon ahead of a build, for
+// clients that cannot serve them over the session gRPC protocol. Registered
+// entries are referenced by ID in a subsequent build request and are removed
+// from the daemon once a build references them.
+type SecretsRequest struct {
+	// Secrets are build secrets, referenced through the repeatable
+	// "secretid" build parameter.
+	Secrets []BuildSecret `json:",omitempty"`
+	// SSHSources are SSH agent sockets on the daemon's host, referenced by
+	// ID through the "ssh" build parameter. AgentSocket must be set.
+	SSHSources []SSHSource `json:",omitempty"`
 }
 
 // ImageBuildOutput defines configuration for exporting a build result
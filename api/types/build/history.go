@@ -0,0 +1,47 @@
+package build
+
+import "time"
+
+// HistoryRecord describes one build from the daemon's build history.
+type HistoryRecord struct {
+	// Ref is the unique reference of the build.
+	Ref string
+	// Frontend that was used for the build (e.g. "dockerfile.v0").
+	Frontend string
+	// FrontendAttrs holds the options that were passed to the frontend.
+	FrontendAttrs map[string]string `json:",omitempty"`
+	// Exporters lists the types of the exporters used for the build.
+	Exporters []string `json:",omitempty"`
+	// Error holds the error message if the build failed.
+	Error string `json:",omitempty"`
+	// CreatedAt is the time the build was started.
+	CreatedAt time.Time
+	// CompletedAt is the time the build finished. It is zero while the
+	// build is still running.
+	CompletedAt time.Time `json:",omitempty"`
+	// Duration is the time the build took. It is zero while the build is
+	// still running.
+	Duration time.Duration `json:",omitempty"`
+	// Pinned records are excluded from history pruning.
+	Pinned bool `json:",omitempty"`
+	// Generation is incremented every time the record is updated.
+	Generation int32 `json:",omitempty"`
+	// NumCachedSteps is the number of steps that were served from cache.
+	NumCachedSteps int32
+	// NumTotalSteps is the total number of steps of the build.
+	NumTotalSteps int32
+	// NumCompletedSteps is the number of steps that completed.
+	NumCompletedSteps int32
+	// NumWarnings is the number of warnings the build produced.
+	NumWarnings int32 `json:",omitempty"`
+	// ExporterResponse holds the metadata returned by the exporters, such
+	// as the image ID.
+	ExporterResponse map[string]string `json:",omitempty"`
+}
+
+// HistoryPruneReport contains the response for Engine API:
+// POST "/build/history/prune"
+type HistoryPruneReport struct {
+	// RecordsDeleted lists the refs of the deleted build history records.
+	RecordsDeleted []string
+}
@@ -17,3 +17,15 @@ type DeleteOptions struct {
 	CheckpointID  string
 	CheckpointDir string
 }
+
+// ExportOptions holds parameters to export a checkpoint from a container.
+type ExportOptions struct {
+	CheckpointID  string
+	CheckpointDir string
+}
+
+// ImportOptions holds parameters to import a checkpoint into a container.
+type ImportOptions struct {
+	CheckpointID  string
+	CheckpointDir string
+}
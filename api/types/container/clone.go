@@ -0,0 +1,20 @@
+package container
+
+// CloneOptions holds parameters for POST /containers/{id}/clone, which
+// creates a new stopped container from an existing container's config and
+// host config.
+type CloneOptions struct {
+	// Name for the new container. A name is generated when empty.
+	Name string `json:",omitempty"`
+
+	// Env, when non-nil, replaces the environment of the source container.
+	Env []string `json:",omitempty"`
+
+	// Cmd, when non-nil, replaces the command of the source container.
+	Cmd []string `json:",omitempty"`
+
+	// CopyVolumes copies the contents of the source container's anonymous
+	// volumes into the new container's anonymous volumes. Named volumes
+	// are always shared with the source container.
+	CopyVolumes bool `json:",omitempty"`
+}
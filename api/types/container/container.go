@@ -92,6 +92,26 @@ type State struct {
 	StartedAt  string
 	FinishedAt string
 	Health     *Health `json:",omitempty"`
+
+	// ResourceUsage is a summary of the resources consumed by the container
+	// over its lifetime, captured from cgroup statistics when the container
+	// exited. It is omitted while the container is running and on platforms
+	// where no statistics could be collected at exit.
+	ResourceUsage *ResourceUsage `json:",omitempty"`
+}
+
+// ResourceUsage is a summary of the resources a container or exec process
+// consumed over its lifetime. It is captured once, at exit, so short-lived
+// processes get usage accounting without continuous stats polling.
+type ResourceUsage struct {
+	// MaxMemoryBytes is the peak memory usage, in bytes.
+	MaxMemoryBytes uint64 `json:",omitempty"`
+	// CPUTotalNs is the cumulative CPU time consumed, in nanoseconds.
+	CPUTotalNs uint64 `json:",omitempty"`
+	// CPUUserNs is the cumulative user-mode CPU time consumed, in nanoseconds.
+	CPUUserNs uint64 `json:",omitempty"`
+	// CPUSystemNs is the cumulative kernel-mode CPU time consumed, in nanoseconds.
+	CPUSystemNs uint64 `json:",omitempty"`
 }
 
 // Summary contains response of Engine API:
@@ -129,28 +149,60 @@ type Summary struct {
 // held fields that were added in API 1.19 and up. Given that the minimum
 // supported API version is now 1.24, we no longer use the separate type.
 type ContainerJSONBase struct {
-	ID              string `json:"Id"`
-	Created         string
-	Path            string
-	Args            []string
-	State           *State
-	Image           string
-	ResolvConfPath  string
-	HostnamePath    string
-	HostsPath       string
-	LogPath         string
-	Name            string
-	RestartCount    int
-	Driver          string
-	Platform        string
-	MountLabel      string
-	ProcessLabel    string
-	AppArmorProfile string
-	ExecIDs         []string
-	HostConfig      *HostConfig
-	GraphDriver     storage.DriverData
-	SizeRw          *int64 `json:",omitempty"`
-	SizeRootFs      *int64 `json:",omitempty"`
+	ID      string `json:"Id"`
+	Created string
+	Path    string
+	Args    []string
+	State   *State
+	Image   string
+	// ImageDigest is the digested reference the container's image was
+	// resolved to when the container was created with digest pinning
+	// requested.
+	ImageDigest string `json:",omitempty"`
+	// BuildID is the ID of the BuildKit build history record that produced
+	// the container's image. Only set when the image was built locally by
+	// the daemon's embedded builder.
+	BuildID        string `json:",omitempty"`
+	ResolvConfPath string
+	HostnamePath   string
+	HostsPath      string
+	LogPath        string
+	// LogSamplingDropped is the number of log lines dropped by the
+	// "sample-rate" log option. It is omitted when sampling is not
+	// enabled for the container.
+	LogSamplingDropped *uint64 `json:",omitempty"`
+	Name               string
+	RestartCount       int
+	Driver             string
+	Platform           string
+	MountLabel         string
+	ProcessLabel       string
+	AppArmorProfile    string
+	// SeccompProfile is the effective seccomp profile for the container,
+	// resolving daemon-side per-runtime and daemon-wide defaults. Only set
+	// on Linux.
+	SeccompProfile string `json:",omitempty"`
+	// CredentialSpec is the status of the credential spec applied via the
+	// "credentialspec" security option. Only set on Windows.
+	CredentialSpec *CredentialSpecStatus `json:",omitempty"`
+	ExecIDs        []string
+	HostConfig     *HostConfig
+	GraphDriver    storage.DriverData
+	SizeRw         *int64 `json:",omitempty"`
+	SizeRootFs     *int64 `json:",omitempty"`
+}
+
+// CredentialSpecStatus describes the credential spec a Windows container was
+// started with and whether its source has since been updated.
+type CredentialSpecStatus struct {
+	// Source is the value of the "credentialspec" security option the
+	// credential spec was resolved from (e.g. "registry://my-spec").
+	Source string
+
+	// Stale is set when the credential spec source holds different content
+	// than what was applied when the container was started. The container
+	// must be restarted for the updated credential spec to take effect.
+	Stale bool
 }
 
 // InspectResponse is the response for the GET "/containers/{name:.*}/json"
@@ -162,4 +214,7 @@ type InspectResponse struct {
 	NetworkSettings *NetworkSettings
 	// ImageManifestDescriptor is the descriptor of a platform-specific manifest of the image used to create the container.
 	ImageManifestDescriptor *ocispec.Descriptor `json:"ImageManifestDescriptor,omitempty"`
+	// Warnings holds daemon-generated warnings about the container's setup,
+	// such as its working directory having been auto-created.
+	Warnings []string `json:",omitempty"`
 }
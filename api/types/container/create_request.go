@@ -10,4 +10,22 @@ type CreateRequest struct {
 	*Config
 	HostConfig       *HostConfig               `json:"HostConfig,omitempty"`
 	NetworkingConfig *network.NetworkingConfig `json:"NetworkingConfig,omitempty"`
+
+	// LabelFiles lists files on the daemon host containing KEY=VALUE lines
+	// that are merged into the container labels, allowing large label sets
+	// that exceed client-side argument limits. The daemon enforces size
+	// limits on the files and the resulting label set.
+	LabelFiles []string `json:"LabelFiles,omitempty"`
+
+	// AnnotationFiles lists files on the daemon host containing KEY=VALUE
+	// lines that are merged into HostConfig.Annotations, with the same
+	// size limits as LabelFiles.
+	AnnotationFiles []string `json:"AnnotationFiles,omitempty"`
+
+	// PinDigest makes the daemon resolve the image reference to a digest
+	// at create time and record the digested reference on the container.
+	// The container always runs the image resolved at create time, even
+	// if the tag has since been moved to another image. The recorded
+	// reference is reported by container inspect as ImageDigest.
+	PinDigest bool `json:"PinDigest,omitempty"`
 }
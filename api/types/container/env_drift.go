@@ -0,0 +1,18 @@
+package container
+
+// EnvDriftResponse is the response of GET "/containers/{id}/env-drift". It
+// reports how the environment of the container's running init process differs
+// from the environment the daemon configured it with. Only variable names are
+// included; values are deliberately omitted so that the report can be shared
+// without leaking secrets.
+type EnvDriftResponse struct {
+	// Added holds the names of variables that are present in the running
+	// process but were not part of the configured environment.
+	Added []string `json:",omitempty"`
+	// Removed holds the names of configured variables that are no longer
+	// present in the running process.
+	Removed []string `json:",omitempty"`
+	// Changed holds the names of variables whose value in the running
+	// process differs from the configured value.
+	Changed []string `json:",omitempty"`
+}
@@ -23,6 +23,17 @@ type ExecOptions struct {
 	WorkingDir   string   // Working directory
 	Cmd          []string // Execution commands and args
 
+	// NanoCPUs is a CPU limit in units of 10^-9 CPUs applied to the exec
+	// process. If set, the exec process is placed in its own sub-cgroup of
+	// the container's cgroup, so it is constrained independently of the
+	// container's own limits. Only supported on Linux hosts using cgroup v2.
+	NanoCPUs int64 `json:",omitempty"`
+	// MemoryBytes is a memory limit in bytes applied to the exec process.
+	// If set, the exec process is placed in its own sub-cgroup of the
+	// container's cgroup, so it is constrained independently of the
+	// container's own limits. Only supported on Linux hosts using cgroup v2.
+	MemoryBytes int64 `json:",omitempty"`
+
 	// Deprecated: the Detach field is not used, and will be removed in a future release.
 	Detach bool
 }
@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/docker/go-units"
 	"github.com/moby/moby/api/types/blkiodev"
@@ -275,6 +276,24 @@ type DeviceMapping struct {
 type RestartPolicy struct {
 	Name              RestartPolicyMode
 	MaximumRetryCount int
+
+	// InitialDelay is the delay before the first automatic restart, and
+	// the base for the exponential backoff applied to subsequent restarts.
+	// Zero means the default of 100 milliseconds.
+	InitialDelay time.Duration `json:",omitempty"`
+
+	// MaximumDelay caps the exponential backoff between restarts. Zero
+	// means the default of one minute.
+	MaximumDelay time.Duration `json:",omitempty"`
+
+	// Jitter is the maximum fraction of the backoff delay that is added
+	// to it as a random offset, between 0 and 1. Zero disables jitter.
+	Jitter float64 `json:",omitempty"`
+
+	// ResetWindow is how long the container must run for the backoff
+	// delay to be reset to InitialDelay on the next restart. Zero means
+	// the default of 10 seconds.
+	ResetWindow time.Duration `json:",omitempty"`
 }
 
 type RestartPolicyMode string
@@ -313,11 +332,34 @@ func (rp *RestartPolicy) IsUnlessStopped() bool {
 
 // IsSame compares two RestartPolicy to see if they are the same
 func (rp *RestartPolicy) IsSame(tp *RestartPolicy) bool {
-	return rp.Name == tp.Name && rp.MaximumRetryCount == tp.MaximumRetryCount
+	return *rp == *tp
+}
+
+// AutoUpdatePolicy represents the auto-update policy of the container. When
+// enabled, the daemon periodically checks the registry for a new digest of
+// the image reference the container was created from; when the digest has
+// changed, it pulls the new image and recreates the container from it,
+// preserving the container's name, configuration and volumes.
+//
+// Checks and pulls are performed anonymously: images that require registry
+// credentials are not supported and fail the update check.
+type AutoUpdatePolicy struct {
+	// Interval is the time between registry digest checks. Zero disables
+	// auto-updates for the container.
+	Interval time.Duration `json:",omitempty"`
 }
 
 // ValidateRestartPolicy validates the given RestartPolicy.
 func ValidateRestartPolicy(policy RestartPolicy) error {
+	if policy.InitialDelay < 0 || policy.MaximumDelay < 0 || policy.ResetWindow < 0 {
+		return &errInvalidParameter{errors.New("invalid restart policy: delays cannot be negative")}
+	}
+	if policy.Jitter < 0 || policy.Jitter > 1 {
+		return &errInvalidParameter{errors.New("invalid restart policy: jitter must be a fraction between 0 and 1")}
+	}
+	if policy.MaximumDelay != 0 && policy.MaximumDelay < policy.InitialDelay {
+		return &errInvalidParameter{errors.New("invalid restart policy: maximum delay cannot be lower than initial delay")}
+	}
 	switch policy.Name {
 	case RestartPolicyAlways, RestartPolicyUnlessStopped, RestartPolicyDisabled:
 		if policy.MaximumRetryCount != 0 {
@@ -343,6 +385,20 @@ func ValidateRestartPolicy(policy RestartPolicy) error {
 	}
 }
 
+// OOMAction determines what the daemon does with a container when its
+// cgroup reports an out-of-memory event.
+type OOMAction string
+
+const (
+	// OOMActionKill leaves OOM handling to the kernel OOM killer (the default).
+	OOMActionKill OOMAction = "kill"
+	// OOMActionPause pauses the container on an OOM event, leaving it
+	// inspectable for debugging.
+	OOMActionPause OOMAction = "pause"
+	// OOMActionRestart restarts the container on an OOM event.
+	OOMActionRestart OOMAction = "restart"
+)
+
 // LogMode is a type to define the available modes for logging
 // These modes affect how logs are handled when log messages start piling up.
 type LogMode string
@@ -366,6 +422,23 @@ type LogConfig struct {
 // Users are recommended to use this alias instead of using [units.Ulimit] directly.
 type Ulimit = units.Ulimit
 
+// NetworkRate holds bandwidth limits that are applied to a container's
+// network interfaces. Only supported on Linux.
+type NetworkRate struct {
+	// EgressBps is the maximum transmit rate in bytes per second.
+	// Zero means unlimited.
+	EgressBps int64 `json:",omitempty"`
+
+	// IngressBps is the maximum receive rate in bytes per second.
+	// Zero means unlimited.
+	IngressBps int64 `json:",omitempty"`
+
+	// Burst is the number of bytes that may be sent or received in a burst
+	// above the configured rates. When zero, a burst of 10 milliseconds at
+	// the configured rate is allowed.
+	Burst int64 `json:",omitempty"`
+}
+
 // Resources contains container's resources (cgroups config, ulimits...)
 type Resources struct {
 	// Applicable to all platforms
@@ -381,32 +454,61 @@ type Resources struct {
 	BlkioDeviceWriteBps  []*blkiodev.ThrottleDevice
 	BlkioDeviceReadIOps  []*blkiodev.ThrottleDevice
 	BlkioDeviceWriteIOps []*blkiodev.ThrottleDevice
-	CPUPeriod            int64           `json:"CpuPeriod"`          // CPU CFS (Completely Fair Scheduler) period
-	CPUQuota             int64           `json:"CpuQuota"`           // CPU CFS (Completely Fair Scheduler) quota
-	CPURealtimePeriod    int64           `json:"CpuRealtimePeriod"`  // CPU real-time period
-	CPURealtimeRuntime   int64           `json:"CpuRealtimeRuntime"` // CPU real-time runtime
-	CpusetCpus           string          // CpusetCpus 0-2, 0,1
-	CpusetMems           string          // CpusetMems 0-2, 0,1
-	Devices              []DeviceMapping // List of devices to map inside the container
-	DeviceCgroupRules    []string        // List of rule to be added to the device cgroup
-	DeviceRequests       []DeviceRequest // List of device requests for device drivers
+
+	// IOLatencyTarget sets a per-device target I/O latency, backed by the
+	// cgroup v2 io.latency controller. It has no effect on cgroup v1 hosts.
+	IOLatencyTarget    []*blkiodev.LatencyDevice `json:",omitempty"`
+	CPUPeriod          int64                     `json:"CpuPeriod"`          // CPU CFS (Completely Fair Scheduler) period
+	CPUQuota           int64                     `json:"CpuQuota"`           // CPU CFS (Completely Fair Scheduler) quota
+	CPURealtimePeriod  int64                     `json:"CpuRealtimePeriod"`  // CPU real-time period
+	CPURealtimeRuntime int64                     `json:"CpuRealtimeRuntime"` // CPU real-time runtime
+	CpusetCpus         string                    // CpusetCpus 0-2, 0,1
+	CpusetMems         string                    // CpusetMems 0-2, 0,1
+
+	// NUMANode pins the container's CPUs and memory to a single NUMA node
+	// (cpuset.cpus and cpuset.mems). It is mutually exclusive with
+	// CpusetCpus, CpusetMems and CpusetAutoBalance. Linux only.
+	NUMANode *int64 `json:",omitempty"`
+
+	// CpusetAutoBalance makes the daemon pin the container to the NUMA
+	// node with the fewest containers already pinned to it. It is mutually
+	// exclusive with NUMANode, CpusetCpus and CpusetMems. Linux only.
+	CpusetAutoBalance bool `json:",omitempty"`
+
+	Devices           []DeviceMapping // List of devices to map inside the container
+	DeviceCgroupRules []string        // List of rule to be added to the device cgroup
+	DeviceRequests    []DeviceRequest // List of device requests for device drivers
 
 	// KernelMemory specifies the kernel memory limit (in bytes) for the container.
 	// Deprecated: kernel 5.4 deprecated kmem.limit_in_bytes.
-	KernelMemory      int64     `json:",omitempty"`
-	KernelMemoryTCP   int64     `json:",omitempty"` // Hard limit for kernel TCP buffer memory (in bytes)
-	MemoryReservation int64     // Memory soft limit (in bytes)
-	MemorySwap        int64     // Total memory usage (memory + swap); set `-1` to enable unlimited swap
-	MemorySwappiness  *int64    // Tuning container memory swappiness behaviour
-	OomKillDisable    *bool     // Whether to disable OOM Killer or not
-	PidsLimit         *int64    // Setting PIDs limit for a container; Set `0` or `-1` for unlimited, or `null` to not change.
-	Ulimits           []*Ulimit // List of ulimits to be set in the container
+	KernelMemory      int64        `json:",omitempty"`
+	KernelMemoryTCP   int64        `json:",omitempty"` // Hard limit for kernel TCP buffer memory (in bytes)
+	MemoryReservation int64        // Memory soft limit (in bytes)
+	MemorySwap        int64        // Total memory usage (memory + swap); set `-1` to enable unlimited swap
+	MemorySwappiness  *int64       // Tuning container memory swappiness behaviour
+	OomKillDisable    *bool        // Whether to disable OOM Killer or not
+	PidsLimit         *int64       // Setting PIDs limit for a container; Set `0` or `-1` for unlimited, or `null` to not change.
+	Ulimits           []*Ulimit    // List of ulimits to be set in the container
+	NetworkRate       *NetworkRate `json:",omitempty"` // Bandwidth limits for the container's network interfaces. A `null` value means no change on update.
 
 	// Applicable to Windows
-	CPUCount           int64  `json:"CpuCount"`   // CPU count
-	CPUPercent         int64  `json:"CpuPercent"` // CPU percent
-	IOMaximumIOps      uint64 // Maximum IOps for the container system drive
-	IOMaximumBandwidth uint64 // Maximum IO in bytes per second for the container system drive
+	CPUCount           int64              `json:"CpuCount"`   // CPU count
+	CPUPercent         int64              `json:"CpuPercent"` // CPU percent
+	IOMaximumIOps      uint64             // Maximum IOps for the container system drive
+	IOMaximumBandwidth uint64             // Maximum IO in bytes per second for the container system drive
+	CPUGroupAffinity   []CPUGroupAffinity `json:"CpuGroupAffinity,omitempty"` // Processor groups and logical processors the container is pinned to
+}
+
+// CPUGroupAffinity pins a container to a set of logical processors within a
+// Windows processor group. Hosts with more than 64 logical processors expose
+// them in multiple groups; an entry is needed per group the container may
+// run on.
+type CPUGroupAffinity struct {
+	// Group is the processor group number, as reported by the host topology
+	// in the info endpoint.
+	Group uint32
+	// Mask is a bitmask of logical processors within the group.
+	Mask uint64
 }
 
 // UpdateConfig holds the mutable attributes of a Container.
@@ -415,6 +517,27 @@ type UpdateConfig struct {
 	// Contains container's resources (cgroups, ulimits)
 	Resources
 	RestartPolicy RestartPolicy
+	// CgroupnsMode updates the container's cgroup namespace mode ("host" or
+	// "private"). A running container keeps its current namespace; the new
+	// mode takes effect the next time the container is started.
+	CgroupnsMode CgroupnsMode `json:",omitempty"`
+}
+
+// CoreDumpConfig configures core dump handling for processes in a container.
+// The daemon maps it to RLIMIT_CORE and, when Directory is set, a writable
+// bind mount of the host directory at /cores inside the container. Core dumps
+// are only written there if the host's kernel.core_pattern names a path below
+// /cores.
+type CoreDumpConfig struct {
+	// Enabled allows processes in the container to write core dumps. When
+	// false, RLIMIT_CORE is set to zero.
+	Enabled bool
+	// SizeLimit is the maximum size of a core dump in bytes (RLIMIT_CORE).
+	// Zero means no limit.
+	SizeLimit int64 `json:",omitempty"`
+	// Directory is a host directory that is bind-mounted at /cores inside
+	// the container, giving core dumps a place to be written.
+	Directory string `json:",omitempty"`
 }
 
 // HostConfig the non-portable Config structure of a container.
@@ -433,11 +556,15 @@ type HostConfig struct {
 	VolumesFrom     []string          // List of volumes to take from other container
 	ConsoleSize     [2]uint           // Initial console size (height,width)
 	Annotations     map[string]string `json:",omitempty"` // Arbitrary non-identifying metadata attached to container and provided to the runtime
+	StartDeadline   time.Duration     `json:",omitempty"` // Maximum time for the container's health to become healthy after a start before the daemon kills it. Requires a healthcheck. Zero means no deadline.
+	AutoUpdate      AutoUpdatePolicy  // Auto-update policy to be used for the container
+	DependsOn       []string          `json:",omitempty"` // Names or IDs of containers that must be started (and healthy, if they define a health check) before this one when the daemon restarts containers at boot
 
 	// Applicable to UNIX platforms
 	CapAdd          strslice.StrSlice // List of kernel capabilities to add to the container
 	CapDrop         strslice.StrSlice // List of kernel capabilities to remove from the container
 	CgroupnsMode    CgroupnsMode      // Cgroup namespace mode to use for the container
+	CoreDump        *CoreDumpConfig   `json:",omitempty"` // Core dump policy for processes in the container. Linux only.
 	DNS             []string          `json:"Dns"`        // List of DNS server to lookup
 	DNSOptions      []string          `json:"DnsOptions"` // List of DNSOption to look for
 	DNSSearch       []string          `json:"DnsSearch"`  // List of DNSSearch to look for
@@ -446,6 +573,7 @@ type HostConfig struct {
 	IpcMode         IpcMode           // IPC namespace to use for the container
 	Cgroup          CgroupSpec        // Cgroup to use for the container
 	Links           []string          // List of links (in the name:alias form)
+	OOMAction       OOMAction         `json:",omitempty"` // What the daemon does with the container on an OOM event (kill, pause or restart). Empty means kill.
 	OomScoreAdj     int               // Container preference for OOM-killing
 	PidMode         PidMode           // PID namespace to use for the container
 	Privileged      bool              // Is the container in privileged mode
@@ -458,6 +586,7 @@ type HostConfig struct {
 	UsernsMode      UsernsMode        // The user namespace to use for the container
 	ShmSize         int64             // Total shm memory usage
 	Sysctls         map[string]string `json:",omitempty"` // List of Namespaced sysctls used for the container
+	NetworkProfile  string            `json:",omitempty"` // Name of a network sysctl profile from the daemon configuration to apply to the container. Sysctls set explicitly on the container take precedence.
 	Runtime         string            `json:",omitempty"` // Runtime to use with this container
 
 	// Applicable to Windows
@@ -2,6 +2,7 @@ package container
 
 import (
 	"testing"
+	"time"
 
 	"gotest.tools/v3/assert"
 	is "gotest.tools/v3/assert/cmp"
@@ -87,6 +88,35 @@ func TestValidateRestartPolicy(t *testing.T) {
 			input:       RestartPolicy{Name: "unknown"},
 			expectedErr: "invalid restart policy: unknown policy 'unknown'; use one of 'no', 'always', 'on-failure', or 'unless-stopped'",
 		},
+		{
+			name:  "always with backoff configuration",
+			input: RestartPolicy{Name: RestartPolicyAlways, InitialDelay: time.Second, MaximumDelay: time.Minute, Jitter: 0.5, ResetWindow: time.Minute},
+		},
+		{
+			name:        "negative InitialDelay",
+			input:       RestartPolicy{Name: RestartPolicyAlways, InitialDelay: -time.Second},
+			expectedErr: "invalid restart policy: delays cannot be negative",
+		},
+		{
+			name:        "negative MaximumDelay",
+			input:       RestartPolicy{Name: RestartPolicyAlways, MaximumDelay: -time.Second},
+			expectedErr: "invalid restart policy: delays cannot be negative",
+		},
+		{
+			name:        "negative ResetWindow",
+			input:       RestartPolicy{Name: RestartPolicyAlways, ResetWindow: -time.Second},
+			expectedErr: "invalid restart policy: delays cannot be negative",
+		},
+		{
+			name:        "jitter out of range",
+			input:       RestartPolicy{Name: RestartPolicyAlways, Jitter: 1.5},
+			expectedErr: "invalid restart policy: jitter must be a fraction between 0 and 1",
+		},
+		{
+			name:        "maximum delay lower than initial delay",
+			input:       RestartPolicy{Name: RestartPolicyAlways, InitialDelay: time.Minute, MaximumDelay: time.Second},
+			expectedErr: "invalid restart policy: maximum delay cannot be lower than initial delay",
+		},
 	}
 
 	for _, tc := range tests {
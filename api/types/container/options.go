@@ -64,4 +64,21 @@ type LogsOptions struct {
 	Follow     bool
 	Tail       string
 	Details    bool
+
+	// TailBytes limits the returned log data to approximately the given
+	// number of bytes from the end of the log. It may be combined with
+	// Tail, in which case the more restrictive of the two applies. An
+	// empty value means no byte limit.
+	TailBytes string
+
+	// Grep is an RE2 regular expression. When set, only log lines matching
+	// the expression are returned. Filtering is performed by the daemon so
+	// that large logs do not have to be transferred to the client.
+	Grep string
+
+	// Fields is a comma-separated list of dotted field paths (e.g.
+	// ".level,.request.id") to extract from JSON-formatted log lines. When
+	// set, each returned line is a JSON object containing only the selected
+	// fields; lines that are not valid JSON are omitted.
+	Fields string
 }
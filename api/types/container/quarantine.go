@@ -0,0 +1,15 @@
+package container
+
+import "time"
+
+// QuarantineEntry describes a container directory that the daemon moved to
+// its quarantine area because the container configuration could not be
+// parsed at startup.
+type QuarantineEntry struct {
+	// ID is the ID of the quarantined container.
+	ID string
+	// Reason is the load error that caused the container to be quarantined.
+	Reason string
+	// QuarantinedAt is the time at which the container was quarantined.
+	QuarantinedAt time.Time
+}
@@ -0,0 +1,21 @@
+package container
+
+import "time"
+
+// RestartSimulation describes the decision the daemon's restart manager
+// would take for a hypothetical container exit. It is returned by
+// GET "/containers/{id}/restart/simulate".
+type RestartSimulation struct {
+	// WouldRestart is true if the restart policy would restart the
+	// container after an exit with the given exit code.
+	WouldRestart bool
+	// Delay is the backoff delay that would be applied before the restart.
+	// It does not include the random jitter offset applied to real
+	// restarts. It is zero if the container would not be restarted.
+	Delay time.Duration
+	// RestartCount is the number of times the container has already been
+	// restarted by its restart policy.
+	RestartCount int
+	// Policy is the restart policy the simulation was evaluated against.
+	Policy RestartPolicy
+}
@@ -138,6 +138,31 @@ type NetworkStats struct {
 	InstanceID string `json:"instance_id,omitempty"`
 }
 
+// NetDetailStats holds socket statistics gathered from inside the
+// container's network namespace. It is only populated when detailed network
+// stats are requested (the "net-detail" query parameter), as collecting it
+// has a cost per stats frame. Linux only.
+type NetDetailStats struct {
+	// TCPEstablished is the number of TCP connections currently in the
+	// ESTABLISHED state.
+	TCPEstablished uint64 `json:"tcp_established"`
+	// TCPRetransSegs is the total number of TCP segments retransmitted.
+	TCPRetransSegs uint64 `json:"tcp_retrans_segs"`
+	// TCPListenOverflows is the number of times the listen queue of a
+	// socket overflowed.
+	TCPListenOverflows uint64 `json:"tcp_listen_overflows"`
+	// TCPListenDrops is the number of SYNs to LISTEN sockets that were
+	// dropped.
+	TCPListenDrops uint64 `json:"tcp_listen_drops"`
+	// UDPInDatagrams is the total number of UDP datagrams received.
+	UDPInDatagrams uint64 `json:"udp_in_datagrams"`
+	// UDPOutDatagrams is the total number of UDP datagrams sent.
+	UDPOutDatagrams uint64 `json:"udp_out_datagrams"`
+	// UDPRcvbufErrors is the number of UDP datagrams lost because the
+	// receiving socket's buffer was full.
+	UDPRcvbufErrors uint64 `json:"udp_rcvbuf_errors"`
+}
+
 // PidsStats contains the stats of a container's pids
 type PidsStats struct {
 	// Current is the number of pids in the cgroup
@@ -169,4 +194,9 @@ type StatsResponse struct {
 	PreCPUStats CPUStats                `json:"precpu_stats,omitempty"` // "Pre"="Previous"
 	MemoryStats MemoryStats             `json:"memory_stats,omitempty"`
 	Networks    map[string]NetworkStats `json:"networks,omitempty"`
+
+	// NetDetail holds socket statistics from the container's network
+	// namespace. Only populated when detailed network stats are requested.
+	// Linux only.
+	NetDetail *NetDetailStats `json:"net_detail,omitempty"`
 }
@@ -0,0 +1,38 @@
+package container
+
+// WatchEventType is the kind of change carried by a [WatchEvent].
+type WatchEventType string
+
+const (
+	// WatchEventSync is sent for each existing container when a watch is
+	// established without a resume point.
+	WatchEventSync WatchEventType = "sync"
+	// WatchEventCreate is sent when a container is created.
+	WatchEventCreate WatchEventType = "create"
+	// WatchEventUpdate is sent when a container's state changes.
+	WatchEventUpdate WatchEventType = "update"
+	// WatchEventDelete is sent when a container is removed.
+	WatchEventDelete WatchEventType = "delete"
+)
+
+// WatchEvent is a single entry in the stream produced by the container watch
+// endpoint (GET "/containers/watch"). A watch delivers the current state of
+// all containers as "sync" events followed by incremental "create", "update",
+// and "delete" events as state changes.
+//
+// ResourceVersion increases monotonically with every change. A disconnected
+// watcher can resume from the last version it observed by passing it as the
+// "resourceVersion" query parameter; if the daemon no longer retains the
+// changes since that version the request fails and the watch must be
+// restarted without a resume point.
+type WatchEvent struct {
+	Type            WatchEventType `json:"type"`
+	ResourceVersion uint64         `json:"resourceVersion"`
+
+	// ID is the ID of the container the event relates to.
+	ID string `json:"id"`
+
+	// Container holds the container's state after the change. It is omitted
+	// for "delete" events.
+	Container *Summary `json:"container,omitempty"`
+}
@@ -61,8 +61,29 @@ const (
 	ActionConnect      Action = "connect"
 	ActionDisconnect   Action = "disconnect"
 	ActionReload       Action = "reload"
+	ActionResume       Action = "resume"
 	ActionMount        Action = "mount"
 	ActionUnmount      Action = "unmount"
+	ActionClone        Action = "clone"
+	ActionSnapshot     Action = "snapshot"
+	ActionRestore      Action = "restore"
+	ActionMaintenance  Action = "maintenance"
+	ActionAutoUpdate   Action = "auto-update"
+	ActionCPURebalance Action = "cpu-rebalance"
+
+	// ActionPullProgress and ActionPushProgress are generated for an image
+	// while it is being pulled or pushed, each time a layer transitions to
+	// a new phase of the transfer (such as "Downloading" or "Extracting").
+	// The layer ID, phase, and byte counts at the time of the transition
+	// are in the "layer", "phase", "current" and "total" attributes.
+	ActionPullProgress Action = "pull-progress"
+	ActionPushProgress Action = "push-progress"
+
+	// ActionEventsDropped is generated in a subscriber's event stream in
+	// place of events that were dropped because the subscriber did not
+	// keep up with the stream. The number of dropped events is in the
+	// "count" attribute.
+	ActionEventsDropped Action = "events-dropped"
 
 	// ActionExecCreate is the prefix used for exec_create events. These
 	// event-actions are commonly followed by a colon and space (": "),
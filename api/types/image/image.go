@@ -9,6 +9,13 @@ import (
 type Metadata struct {
 	// LastTagTime is the date and time at which the image was last tagged.
 	LastTagTime time.Time `json:",omitempty"`
+
+	// LastPulled is the date and time at which the image was last pulled.
+	LastPulled time.Time `json:",omitempty"`
+
+	// LastUsed is the date and time at which a container was last created
+	// from the image.
+	LastUsed time.Time `json:",omitempty"`
 }
 
 // PruneReport contains the response for Engine API:
@@ -139,4 +139,12 @@ type InspectResponse struct {
 	// WARNING: This is experimental and may change at any time without any backward
 	// compatibility.
 	Manifests []ManifestSummary `json:"Manifests,omitempty"`
+
+	// Artifacts lists the artifacts associated with this image through the
+	// OCI referrers mechanism, such as signatures, SBOMs and attestations,
+	// with their digests and media types.
+	//
+	// Only present when the client requests artifacts and the image has a
+	// repository digest; empty if the registry does not advertise any.
+	Artifacts []ocispec.Descriptor `json:"Artifacts,omitempty"`
 }
@@ -35,6 +35,15 @@ type Summary struct {
 	// Required: true
 	Labels map[string]string `json:"Labels"`
 
+	// Date and time at which the image was last pulled as a Unix timestamp
+	// (number of seconds since EPOCH). Zero if the image was never pulled.
+	LastPulled int64 `json:"LastPulled,omitempty"`
+
+	// Date and time at which a container was last created from the image as
+	// a Unix timestamp (number of seconds since EPOCH). Zero if the image
+	// was never used.
+	LastUsed int64 `json:"LastUsed,omitempty"`
+
 	// ID of the parent image.
 	//
 	// Depending on how the image was created, this field may be empty and
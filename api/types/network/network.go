@@ -90,6 +90,20 @@ type Inspect struct {
 	Labels     map[string]string           // Labels holds metadata specific to the network being created
 	Peers      []PeerInfo                  `json:",omitempty"` // List of peer nodes for an overlay network
 	Services   map[string]ServiceInfo      `json:",omitempty"`
+	Usage      *Usage                      `json:",omitempty"` // Usage holds per-network usage counters. It is only included when requested.
+}
+
+// Usage contains resource-usage counters for a network. It is only included
+// in inspect responses when size reporting is requested.
+type Usage struct {
+	// Endpoints is the number of endpoints attached to the network.
+	Endpoints int
+	// AllocatedIPs is the number of addresses allocated from the network's
+	// IPv4 pools, including gateway and auxiliary addresses.
+	AllocatedIPs int64
+	// FreeIPs is the number of addresses still available in the network's
+	// IPv4 pools. It is zero for networks without an IPv4 pool.
+	FreeIPs int64
 }
 
 // Summary is used as response when listing networks. It currently is an alias
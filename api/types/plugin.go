@@ -39,8 +39,25 @@ type Plugin struct {
 	// settings
 	// Required: true
 	Settings PluginSettings `json:"Settings"`
+
+	// Health status of the plugin, when enabled and probed by the daemon.
+	// One of "healthy", "unhealthy" or "restarting".
+	// Example: healthy
+	Status string `json:"Status,omitempty"`
 }
 
+// Health statuses the daemon reports for an enabled plugin.
+const (
+	// PluginStatusHealthy means the plugin responds to liveness probes.
+	PluginStatusHealthy = "healthy"
+	// PluginStatusUnhealthy means the plugin failed consecutive liveness
+	// probes and is about to be restarted.
+	PluginStatusUnhealthy = "unhealthy"
+	// PluginStatusRestarting means the daemon is restarting the plugin
+	// after it became unhealthy.
+	PluginStatusRestarting = "restarting"
+)
+
 // PluginConfig The config of a plugin.
 //
 // swagger:model PluginConfig
@@ -74,6 +91,9 @@ type PluginConfig struct {
 	// Required: true
 	Env []PluginEnv `json:"Env"`
 
+	// healthcheck
+	Healthcheck *PluginConfigHealthcheck `json:"Healthcheck,omitempty"`
+
 	// interface
 	// Required: true
 	Interface PluginConfigInterface `json:"Interface"`
@@ -141,6 +161,26 @@ type PluginConfigArgs struct {
 	Value []string `json:"Value"`
 }
 
+// PluginConfigHealthcheck How the daemon probes the plugin for liveness.
+// When omitted, the daemon only dials the plugin's socket.
+//
+// swagger:model PluginConfigHealthcheck
+type PluginConfigHealthcheck struct {
+
+	// HTTP path on the plugin's socket to probe. A response status code
+	// below 400 is considered healthy. When empty, the probe only dials
+	// the socket.
+	// Example: /Plugin.Health
+	Path string `json:"Path,omitempty"`
+
+	// Time between probes, in nanoseconds. Defaults to 30 seconds.
+	Interval int64 `json:"Interval,omitempty"`
+
+	// Number of consecutive probe failures after which the plugin is
+	// considered unhealthy and is restarted. Defaults to 3.
+	Retries int `json:"Retries,omitempty"`
+}
+
 // PluginConfigInterface The interface between Docker and the plugin
 //
 // swagger:model PluginConfigInterface
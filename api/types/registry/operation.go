@@ -0,0 +1,29 @@
+package registry
+
+import "time"
+
+// Valid values for [DistributionOperation.Type].
+const (
+	// DistributionOperationPull is an image pull.
+	DistributionOperationPull = "pull"
+	// DistributionOperationPush is an image push.
+	DistributionOperationPush = "push"
+)
+
+// DistributionOperation describes an in-flight image distribution operation
+// (a pull or a push), as returned by GET "/distribution/operations".
+type DistributionOperation struct {
+	// ID uniquely identifies the operation for the lifetime of the daemon.
+	// It can be used to cancel the operation through
+	// POST "/distribution/operations/{id}/cancel".
+	ID string
+
+	// Type is the type of the operation, either "pull" or "push".
+	Type string
+
+	// Image is the image reference being transferred.
+	Image string
+
+	// StartedAt is the time the operation was started.
+	StartedAt time.Time
+}
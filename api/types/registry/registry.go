@@ -113,4 +113,16 @@ type DistributionInspect struct {
 	// Platforms contains the list of platforms supported by the image,
 	// obtained by parsing the manifest
 	Platforms []ocispec.Platform
+
+	// Manifests contains the descriptors of the platform-specific manifests
+	// referenced by the image index or manifest list, including platform
+	// details and annotations. It is empty for single-platform images.
+	Manifests []ocispec.Descriptor `json:",omitempty"`
+
+	// Annotations contains the annotations of the image index or manifest.
+	Annotations map[string]string `json:",omitempty"`
+
+	// Referrers contains the descriptors of artifacts in the registry that
+	// refer to this image, such as signatures and attestations.
+	Referrers []ocispec.Descriptor `json:",omitempty"`
 }
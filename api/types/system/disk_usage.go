@@ -37,4 +37,47 @@ type DiskUsage struct {
 	Volumes     []*volume.Volume
 	BuildCache  []*build.CacheRecord
 	BuilderSize int64 `json:",omitempty"` // Deprecated: deprecated in API 1.38, and no longer used since API 1.40.
+
+	// Storage describes usage of the image storage backend. It is only set
+	// when the daemon uses the containerd image store.
+	Storage *StorageUsage `json:",omitempty"`
+}
+
+// StorageUsage describes disk usage of the image storage backend. It is
+// only available when the daemon uses the containerd image store.
+type StorageUsage struct {
+	// Snapshotters holds usage of each snapshotter known to the daemon.
+	Snapshotters []SnapshotterUsage `json:",omitempty"`
+
+	// SharedSize is the number of bytes saved by snapshots being shared
+	// between images; layers used by more than one image are stored once.
+	SharedSize int64
+
+	// Blobs is the number of blobs in the content store.
+	Blobs int64
+
+	// BlobsSize is the total number of bytes used by the content store.
+	BlobsSize int64
+}
+
+// SnapshotterUsage describes disk usage of a single snapshotter.
+type SnapshotterUsage struct {
+	// Name is the name of the snapshotter (e.g. "overlayfs").
+	Name string
+
+	// Snapshots is the number of snapshots held by the snapshotter.
+	Snapshots int64
+
+	// Size is the total number of bytes used by the snapshots.
+	Size int64
+
+	// SharedSize is the number of bytes in snapshots that are used by
+	// more than one snapshot chain, counted once. This is the equivalent
+	// of an image's SharedSize for a single snapshotter.
+	SharedSize int64
+
+	// UniqueSize is the number of bytes in snapshots that are used by at
+	// most one snapshot chain. Size is the sum of SharedSize and
+	// UniqueSize.
+	UniqueSize int64
 }
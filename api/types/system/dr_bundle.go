@@ -0,0 +1,14 @@
+package system
+
+// BundleRestoreReport describes the outcome of restoring a disaster
+// recovery bundle exported from another daemon.
+type BundleRestoreReport struct {
+	// Networks is the number of networks that were re-created.
+	Networks int
+	// Volumes is the number of volumes that were re-created.
+	Volumes int
+	// Containers is the number of containers that were re-created.
+	Containers int
+	// Warnings lists objects that could not be restored, and why.
+	Warnings []string `json:",omitempty"`
+}
@@ -1,6 +1,8 @@
 package system
 
 import (
+	"time"
+
 	"github.com/moby/moby/api/types/container"
 	"github.com/moby/moby/api/types/registry"
 	"github.com/moby/moby/api/types/swarm"
@@ -75,6 +77,21 @@ type Info struct {
 	CDISpecDirs         []string
 	DiscoveredDevices   []DeviceInfo `json:",omitempty"`
 
+	// DefaultPullPlatform is the platform used by default for image pulls
+	// that do not request an explicit platform, when the daemon is
+	// configured to override the host platform.
+	DefaultPullPlatform string `json:",omitempty"`
+
+	// RegistryPullPlatforms maps registry hostnames to the platform used
+	// by default for image pulls from that registry, taking precedence
+	// over DefaultPullPlatform.
+	RegistryPullPlatforms map[string]string `json:",omitempty"`
+
+	// ProcessorGroups lists the number of active logical processors in each
+	// processor group on the host, indexed by group number. Hosts with more
+	// than 64 logical processors have multiple groups. Only set on Windows.
+	ProcessorGroups []uint32 `json:",omitempty"`
+
 	Containerd *ContainerdInfo `json:",omitempty"`
 
 	// Warnings contains a slice of warnings that occurred  while collecting
@@ -90,6 +107,21 @@ type ContainerdInfo struct {
 	Address string `json:",omitempty"`
 	// Namespaces is the containerd namespaces used by the daemon.
 	Namespaces ContainerdNamespaces
+	// Health reports the state of the daemon's connection to containerd.
+	Health *ContainerdHealth `json:",omitempty"`
+}
+
+// ContainerdHealth describes the state of the daemon's connection to
+// containerd.
+type ContainerdHealth struct {
+	// Connected indicates whether the last health-check of the containerd
+	// connection succeeded.
+	Connected bool
+	// Since is the time at which the connection entered its current state.
+	Since time.Time
+	// ReconnectAttempts is the number of failed health-checks since the
+	// connection was lost. It is zero while connected.
+	ReconnectAttempts int
 }
 
 // ContainerdNamespaces reflects the containerd namespaces used by the daemon.
@@ -0,0 +1,18 @@
+package system
+
+// Maintenance holds the daemon's maintenance-mode state, configured through
+// the `POST /system/maintenance` endpoint.
+type Maintenance struct {
+	// Enabled indicates whether the daemon is in maintenance mode. While
+	// enabled, new container creates and image pulls are rejected;
+	// read-only operations and container stops keep working.
+	Enabled bool
+
+	// Message is included in the error returned to clients whose
+	// requests are rejected while maintenance mode is enabled.
+	Message string `json:",omitempty"`
+
+	// Since is the RFC 3339 time at which maintenance mode was entered.
+	// It is empty when maintenance mode is disabled.
+	Since string `json:",omitempty"`
+}
@@ -0,0 +1,20 @@
+package system
+
+// PreloadedImage describes one entry of the daemon's preload-images-dir
+// and the result of importing it at daemon startup.
+type PreloadedImage struct {
+	// Path is the name of the tarball or OCI layout directory, relative
+	// to the preload directory.
+	Path string
+
+	// Digest identifies the entry's contents; entries with a digest that
+	// was already imported by an earlier daemon start are skipped.
+	Digest string
+
+	// Loaded is true when the entry was imported during the last daemon
+	// start, and false when it had been imported before.
+	Loaded bool
+
+	// Error holds the import error, if the entry failed to load.
+	Error string `json:",omitempty"`
+}
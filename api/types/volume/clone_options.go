@@ -0,0 +1,24 @@
+package volume
+
+// CloneOptions holds the parameters used to clone an existing volume into
+// a new one through the `POST /volumes/{name}/clone` endpoint.
+type CloneOptions struct {
+	// The new volume's name. If not specified, Docker generates a name.
+	Name string `json:"Name,omitempty"`
+
+	// Name of the volume driver to create the clone with. Defaults to the
+	// source volume's driver.
+	Driver string `json:"Driver,omitempty"`
+
+	// A mapping of driver options and values for the clone. These options
+	// are passed directly to the driver and are driver specific.
+	DriverOpts map[string]string `json:"DriverOpts,omitempty"`
+
+	// User-defined key/value metadata for the clone. Defaults to the
+	// source volume's labels.
+	Labels map[string]string `json:"Labels,omitempty"`
+
+	// Name of a snapshot of the source volume to clone from. If not
+	// specified, the volume's current data is cloned.
+	Snapshot string `json:"Snapshot,omitempty"`
+}
@@ -0,0 +1,33 @@
+package volume
+
+// Snapshot describes a point-in-time snapshot of a volume's data.
+type Snapshot struct {
+	// Name is the snapshot's name, unique within its volume.
+	Name string `json:"Name"`
+
+	// CreatedAt is the date/time the snapshot was taken, formatted in
+	// RFC 3339 format with nano-seconds.
+	CreatedAt string `json:"CreatedAt,omitempty"`
+}
+
+// SnapshotCreateOptions holds the parameters used to take a snapshot of a
+// volume through the `POST /volumes/{name}/snapshots` endpoint.
+type SnapshotCreateOptions struct {
+	// The new snapshot's name. If not specified, Docker generates a name.
+	Name string `json:"Name,omitempty"`
+}
+
+// SnapshotRestoreOptions holds the parameters used to restore a volume's
+// data from one of its snapshots through the `POST /volumes/{name}/restore`
+// endpoint.
+type SnapshotRestoreOptions struct {
+	// Name is the name of the snapshot to restore the volume's data from.
+	Name string `json:"Name"`
+}
+
+// SnapshotListResponse is the response of the
+// `GET /volumes/{name}/snapshots` endpoint.
+type SnapshotListResponse struct {
+	// Snapshots is the list of the volume's snapshots.
+	Snapshots []*Snapshot `json:"Snapshots"`
+}
@@ -66,11 +66,25 @@ type Volume struct {
 }
 
 // UsageData Usage details about the volume. This information is used by the
-// `GET /system/df` endpoint, and omitted in other endpoints.
+// `GET /system/df` endpoint, and by `GET /volumes` when attachment metadata
+// is available or the `size` parameter is set. It is omitted in other
+// endpoints.
 //
 // swagger:model UsageData
 type UsageData struct {
 
+	// The number of containers currently attached to (mounted on) this
+	// volume. This counter is maintained by the daemon and resets when
+	// the daemon restarts. Omitted when the volume has never been
+	// attached since the daemon started.
+	AttachCount int64 `json:"AttachCount,omitempty"`
+
+	// Date/Time the volume was last attached to a container, in RFC 3339
+	// format. Only attachments made since the daemon started are tracked;
+	// omitted when the volume has not been attached in that period.
+	// Example: 2016-06-07T20:31:11.853781916Z
+	LastAttachedAt string `json:"LastAttachedAt,omitempty"`
+
 	// The number of containers referencing this volume. This field
 	// is set to `-1` if the reference-count is not available.
 	//
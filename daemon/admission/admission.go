@@ -0,0 +1,128 @@
+// Package admission implements the daemon's admission controller hook. An
+// external webhook, configured in daemon.json, is consulted before image
+// pulls and container creation and can deny the operation or mutate the
+// image reference (e.g. pin it to a digest), allowing signature or
+// provenance policies to be enforced without wrapping the API socket.
+package admission
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/moby/moby/v2/errdefs"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/pkg/errors"
+)
+
+// Operations submitted for admission review.
+const (
+	// OperationPull is an image pull.
+	OperationPull = "pull"
+	// OperationCreate is a container creation.
+	OperationCreate = "create"
+)
+
+const (
+	// signatureHeader carries an HMAC-SHA256 signature of the request body,
+	// keyed with the configured secret, in the form "sha256=<hex digest>".
+	// It is only set when a secret is configured.
+	signatureHeader = "X-Docker-Signature-256"
+
+	webhookTimeout = 30 * time.Second
+)
+
+// Request describes an operation submitted to the webhook for review.
+type Request struct {
+	// Operation is the operation under review, either "pull" or "create".
+	Operation string
+	// Image is the image reference the operation applies to.
+	Image string
+	// Digest is the digest the reference resolves to, if known.
+	Digest string `json:",omitempty"`
+	// Platform is the platform requested for the image, if any.
+	Platform *ocispec.Platform `json:",omitempty"`
+}
+
+// Response is the webhook's verdict on a [Request].
+type Response struct {
+	// Allow indicates whether the operation may proceed.
+	Allow bool
+	// Reason is an optional human-readable explanation of a denial,
+	// returned to the client.
+	Reason string `json:",omitempty"`
+	// Image optionally replaces the image reference of an allowed
+	// operation, e.g. to pin it to a digest.
+	Image string `json:",omitempty"`
+}
+
+// Webhook reviews operations by POSTing them to an external HTTP(S)
+// endpoint. Operations are denied when the endpoint cannot be reached or
+// returns a malformed response, so that policy enforcement fails closed.
+type Webhook struct {
+	url    string
+	secret string
+	client *http.Client
+}
+
+// NewWebhook creates a webhook-backed admission controller that reviews
+// operations against the given URL. When secret is non-empty each request is
+// signed with HMAC-SHA256.
+func NewWebhook(url, secret string) *Webhook {
+	return &Webhook{
+		url:    url,
+		secret: secret,
+		client: &http.Client{Timeout: webhookTimeout},
+	}
+}
+
+// Admit submits req to the webhook and returns the image reference the
+// operation should proceed with: the original reference, or the one the
+// webhook substituted for it. It returns an error if the operation was
+// denied or the webhook could not be consulted.
+func (w *Webhook) Admit(ctx context.Context, req *Request) (string, error) {
+	body, err := json.Marshal(req)
+	if err != nil {
+		return "", errdefs.System(errors.Wrap(err, "error encoding admission request"))
+	}
+
+	hr, err := http.NewRequestWithContext(ctx, http.MethodPost, w.url, bytes.NewReader(body))
+	if err != nil {
+		return "", errdefs.System(err)
+	}
+	hr.Header.Set("Content-Type", "application/json")
+	if w.secret != "" {
+		mac := hmac.New(sha256.New, []byte(w.secret))
+		mac.Write(body)
+		hr.Header.Set(signatureHeader, "sha256="+hex.EncodeToString(mac.Sum(nil)))
+	}
+
+	resp, err := w.client.Do(hr)
+	if err != nil {
+		return "", errdefs.System(errors.Wrap(err, "error consulting the admission webhook"))
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", errdefs.System(errors.Errorf("admission webhook returned unexpected status: %s", resp.Status))
+	}
+
+	var verdict Response
+	if err := json.NewDecoder(resp.Body).Decode(&verdict); err != nil {
+		return "", errdefs.System(errors.Wrap(err, "error decoding admission response"))
+	}
+	if !verdict.Allow {
+		if verdict.Reason != "" {
+			return "", errdefs.Forbidden(errors.Errorf("%s of %s denied by admission policy: %s", req.Operation, req.Image, verdict.Reason))
+		}
+		return "", errdefs.Forbidden(errors.Errorf("%s of %s denied by admission policy", req.Operation, req.Image))
+	}
+	if verdict.Image != "" {
+		return verdict.Image, nil
+	}
+	return req.Image, nil
+}
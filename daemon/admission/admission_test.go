@@ -0,0 +1,74 @@
+package admission
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	cerrdefs "github.com/containerd/errdefs"
+	"gotest.tools/v3/assert"
+	is "gotest.tools/v3/assert/cmp"
+)
+
+func TestWebhookAdmit(t *testing.T) {
+	var (
+		received  Request
+		signature string
+		verdict   Response
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		assert.NilError(t, err)
+		assert.NilError(t, json.Unmarshal(body, &received))
+		signature = r.Header.Get("X-Docker-Signature-256")
+
+		mac := hmac.New(sha256.New, []byte("sekrit"))
+		mac.Write(body)
+		assert.Check(t, is.Equal(signature, "sha256="+hex.EncodeToString(mac.Sum(nil))))
+
+		assert.NilError(t, json.NewEncoder(w).Encode(verdict))
+	}))
+	defer srv.Close()
+
+	w := NewWebhook(srv.URL, "sekrit")
+
+	t.Run("allow", func(t *testing.T) {
+		verdict = Response{Allow: true}
+		img, err := w.Admit(context.Background(), &Request{Operation: OperationPull, Image: "docker.io/library/busybox:latest"})
+		assert.NilError(t, err)
+		assert.Check(t, is.Equal(img, "docker.io/library/busybox:latest"))
+		assert.Check(t, is.Equal(received.Operation, OperationPull))
+		assert.Check(t, signature != "")
+	})
+
+	t.Run("mutate", func(t *testing.T) {
+		verdict = Response{Allow: true, Image: "docker.io/library/busybox@sha256:deadbeef"}
+		img, err := w.Admit(context.Background(), &Request{Operation: OperationCreate, Image: "docker.io/library/busybox:latest"})
+		assert.NilError(t, err)
+		assert.Check(t, is.Equal(img, "docker.io/library/busybox@sha256:deadbeef"))
+	})
+
+	t.Run("deny", func(t *testing.T) {
+		verdict = Response{Allow: false, Reason: "unsigned image"}
+		_, err := w.Admit(context.Background(), &Request{Operation: OperationPull, Image: "docker.io/library/busybox:latest"})
+		assert.Check(t, cerrdefs.IsPermissionDenied(err))
+		assert.Check(t, is.ErrorContains(err, "unsigned image"))
+	})
+}
+
+func TestWebhookAdmitUnreachable(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	srv.Close()
+
+	// Policy enforcement fails closed: an unreachable webhook denies the
+	// operation.
+	w := NewWebhook(srv.URL, "")
+	_, err := w.Admit(context.Background(), &Request{Operation: OperationPull, Image: "docker.io/library/busybox:latest"})
+	assert.Check(t, err != nil)
+}
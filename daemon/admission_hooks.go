@@ -0,0 +1,62 @@
+package daemon
+
+import (
+	"context"
+
+	"github.com/distribution/reference"
+	"github.com/moby/moby/v2/daemon/admission"
+	"github.com/moby/moby/v2/errdefs"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/pkg/errors"
+)
+
+// admitPull submits an image pull to the configured admission webhook and
+// returns the reference the pull should proceed with, which may differ from
+// ref if the webhook mutated it (e.g. to pin it to a digest). It returns ref
+// unchanged if no admission webhook is configured.
+func (daemon *Daemon) admitPull(ctx context.Context, ref reference.Named, platform *ocispec.Platform) (reference.Named, error) {
+	if daemon.admission == nil {
+		return ref, nil
+	}
+	req := &admission.Request{
+		Operation: admission.OperationPull,
+		Image:     ref.String(),
+		Platform:  platform,
+	}
+	if c, ok := ref.(reference.Canonical); ok {
+		req.Digest = c.Digest().String()
+	}
+	img, err := daemon.admission.Admit(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	if img == req.Image {
+		return ref, nil
+	}
+	newRef, err := reference.ParseNormalizedNamed(img)
+	if err != nil {
+		return nil, errdefs.System(errors.Wrapf(err, "admission webhook returned an invalid image reference %q", img))
+	}
+	return newRef, nil
+}
+
+// admitCreate submits a container creation to the configured admission
+// webhook and returns the image reference the container should be created
+// from, which may differ from img if the webhook mutated it. It returns img
+// unchanged if no admission webhook is configured.
+func (daemon *Daemon) admitCreate(ctx context.Context, img string, platform *ocispec.Platform) (string, error) {
+	if daemon.admission == nil {
+		return img, nil
+	}
+	req := &admission.Request{
+		Operation: admission.OperationCreate,
+		Image:     img,
+		Platform:  platform,
+	}
+	if ref, err := reference.ParseNormalizedNamed(img); err == nil {
+		if c, ok := ref.(reference.Canonical); ok {
+			req.Digest = c.Digest().String()
+		}
+	}
+	return daemon.admission.Admit(ctx, req)
+}
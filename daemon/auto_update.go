@@ -0,0 +1,254 @@
+package daemon
+
+import (
+	"context"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/containerd/log"
+	"github.com/distribution/reference"
+	containertypes "github.com/moby/moby/api/types/container"
+	eventtypes "github.com/moby/moby/api/types/events"
+	registrytypes "github.com/moby/moby/api/types/registry"
+	"github.com/moby/moby/v2/daemon/container"
+	"github.com/moby/moby/v2/daemon/internal/distribution"
+	"github.com/moby/moby/v2/daemon/internal/stringid"
+	"github.com/moby/moby/v2/daemon/server/backend"
+	"github.com/moby/moby/v2/errdefs"
+	"github.com/opencontainers/go-digest"
+	"github.com/pkg/errors"
+)
+
+// autoUpdateResolution is how often the auto-update monitor wakes up to
+// find containers whose check interval has elapsed.
+const autoUpdateResolution = time.Minute
+
+// autoUpdateLoop periodically checks the registry for a new digest of the
+// image of every running container with an auto-update policy, and
+// recreates containers whose image has been updated. The loop runs until
+// the daemon shuts down.
+func (daemon *Daemon) autoUpdateLoop() {
+	ticker := time.NewTicker(autoUpdateResolution)
+	defer ticker.Stop()
+
+	lastCheck := make(map[string]time.Time)
+	for {
+		var now time.Time
+		select {
+		case <-daemon.shutdownStarted:
+			return
+		case now = <-ticker.C:
+		}
+		seen := make(map[string]struct{})
+		for _, ctr := range daemon.List() {
+			interval := ctr.HostConfig.AutoUpdate.Interval
+			if interval <= 0 {
+				continue
+			}
+			seen[ctr.ID] = struct{}{}
+			if last, ok := lastCheck[ctr.ID]; ok && now.Sub(last) < interval {
+				continue
+			}
+			lastCheck[ctr.ID] = now
+			if !ctr.IsRunning() {
+				continue
+			}
+			if err := daemon.autoUpdateContainer(context.TODO(), ctr); err != nil {
+				log.G(context.TODO()).WithFields(log.Fields{
+					"error":     err,
+					"container": ctr.ID,
+				}).Warn("Auto-update check failed")
+			}
+		}
+		// Drop state for containers that were removed or had their
+		// policy cleared.
+		for id := range lastCheck {
+			if _, ok := seen[id]; !ok {
+				delete(lastCheck, id)
+			}
+		}
+	}
+}
+
+// autoUpdateContainer checks the registry for a new digest of the image
+// reference the container was created from and, when it changed, pulls the
+// new image and recreates the container from it. Registry access is
+// anonymous: the daemon stores no credentials, so images that require
+// authentication are rejected with an error explaining the limitation.
+func (daemon *Daemon) autoUpdateContainer(ctx context.Context, ctr *container.Container) error {
+	taggedRef, ok, err := autoUpdateRef(ctr.Config.Image)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return nil
+	}
+
+	remoteDigest, err := daemon.resolveRemoteDigest(ctx, taggedRef)
+	if err != nil {
+		if errdefs.IsUnauthorized(err) {
+			return errors.Wrap(err, "the image requires registry credentials, which auto-update does not support")
+		}
+		return errors.Wrap(err, "error checking the registry for a new image digest")
+	}
+	localDigest, err := daemon.localImageDigest(ctx, taggedRef)
+	if err != nil {
+		return err
+	}
+	if localDigest == remoteDigest {
+		return nil
+	}
+
+	log.G(ctx).WithFields(log.Fields{
+		"container":  ctr.ID,
+		"image":      reference.FamiliarString(taggedRef),
+		"digest.old": localDigest,
+		"digest.new": remoteDigest,
+	}).Info("Auto-updating container to new image digest")
+
+	if err := daemon.imageService.PullImage(ctx, taggedRef, nil, nil, &registrytypes.AuthConfig{}, io.Discard); err != nil {
+		if errdefs.IsUnauthorized(err) {
+			return errors.Wrap(err, "the image requires registry credentials, which auto-update does not support")
+		}
+		return errors.Wrap(err, "error pulling the updated image")
+	}
+	updated, err := daemon.recreateContainer(ctx, ctr)
+	if err != nil {
+		return err
+	}
+	daemon.LogContainerEventWithAttributes(updated, eventtypes.ActionAutoUpdate, map[string]string{
+		"digest.old": localDigest.String(),
+		"digest.new": remoteDigest.String(),
+	})
+	return nil
+}
+
+// autoUpdateRef parses the image reference a container was created from into
+// the tagged reference whose registry digest is compared against the local
+// image. Digest-pinned references can never resolve to a new image; for
+// those ok is false and the container is skipped.
+func autoUpdateRef(image string) (ref reference.NamedTagged, ok bool, _ error) {
+	named, err := reference.ParseNormalizedNamed(image)
+	if err != nil {
+		return nil, false, err
+	}
+	if _, isCanonical := named.(reference.Canonical); isCanonical {
+		return nil, false, nil
+	}
+	taggedRef, isTagged := reference.TagNameOnly(named).(reference.NamedTagged)
+	if !isTagged {
+		return nil, false, errors.Errorf("image reference not tagged: %s", image)
+	}
+	return taggedRef, true, nil
+}
+
+// resolveRemoteDigest fetches the digest the reference's tag currently
+// points to in the registry. If a mirror is configured it is tried first,
+// falling back to upstream.
+func (daemon *Daemon) resolveRemoteDigest(ctx context.Context, ref reference.NamedTagged) (digest.Digest, error) {
+	repos, err := distribution.GetRepositories(ctx, ref, &distribution.ImagePullConfig{
+		Config: distribution.Config{
+			AuthConfig:      &registrytypes.AuthConfig{},
+			RegistryService: daemon.registryService,
+		},
+	})
+	if err != nil {
+		return "", err
+	}
+	var lastErr error
+	for _, repo := range repos {
+		desc, err := repo.Tags(ctx).Get(ctx, ref.Tag())
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		return desc.Digest, nil
+	}
+	return "", lastErr
+}
+
+// localImageDigest returns the repository digest recorded for the local
+// image the reference resolves to.
+func (daemon *Daemon) localImageDigest(ctx context.Context, ref reference.Named) (digest.Digest, error) {
+	digested, err := daemon.imageService.ResolveDigestReference(ctx, ref.String())
+	if err != nil {
+		return "", errors.Wrap(err, "error resolving the local image digest")
+	}
+	parsed, err := reference.ParseNormalizedNamed(digested)
+	if err != nil {
+		return "", err
+	}
+	canonical, ok := parsed.(reference.Canonical)
+	if !ok {
+		return "", errors.Errorf("reference is not canonical: %s", digested)
+	}
+	return canonical.Digest(), nil
+}
+
+// recreateContainer replaces the container with a fresh one created from
+// the same configuration (and therefore from the updated image its tag now
+// resolves to), preserving the container's name, binds and named volumes.
+// The old container is kept, stopped and renamed, until the replacement
+// has started, so that the update can be rolled back.
+func (daemon *Daemon) recreateContainer(ctx context.Context, ctr *container.Container) (*container.Container, error) {
+	name := strings.TrimPrefix(ctr.Name, "/")
+	wasRunning := ctr.IsRunning()
+
+	// Shallow copies are fine here: ContainerCreate builds a fresh container
+	// from the values and does not retain the structs.
+	config := *ctr.Config
+	hostConfig := *ctr.HostConfig
+
+	if wasRunning {
+		if err := daemon.ContainerStop(ctx, ctr.ID, containertypes.StopOptions{}); err != nil {
+			return nil, errors.Wrap(err, "error stopping the outdated container")
+		}
+	}
+	// Move the old container out of the way so that the replacement can
+	// take over its name.
+	retiredName := name + "-old-" + stringid.TruncateID(ctr.ID)
+	if err := daemon.ContainerRename(name, retiredName); err != nil {
+		return nil, errors.Wrap(err, "error renaming the outdated container")
+	}
+	rollback := func() {
+		if err := daemon.ContainerRename(retiredName, name); err != nil {
+			log.G(ctx).WithError(err).WithField("container", ctr.ID).
+				Warn("Failed to restore the name of the outdated container")
+			return
+		}
+		if wasRunning {
+			if err := daemon.ContainerStart(ctx, ctr.ID, "", ""); err != nil {
+				log.G(ctx).WithError(err).WithField("container", ctr.ID).
+					Warn("Failed to restart the outdated container after rollback")
+			}
+		}
+	}
+
+	created, err := daemon.ContainerCreate(ctx, backend.ContainerCreateConfig{
+		Name:       name,
+		Config:     &config,
+		HostConfig: &hostConfig,
+	})
+	if err != nil {
+		rollback()
+		return nil, errors.Wrap(err, "error creating the replacement container")
+	}
+	if wasRunning {
+		if err := daemon.ContainerStart(ctx, created.ID, "", ""); err != nil {
+			if rmErr := daemon.ContainerRm(created.ID, &backend.ContainerRmConfig{ForceRemove: true}); rmErr != nil {
+				log.G(ctx).WithError(rmErr).WithField("container", created.ID).
+					Warn("Failed to remove replacement container after rollback")
+			}
+			rollback()
+			return nil, errors.Wrap(err, "error starting the replacement container")
+		}
+	}
+	// The replacement reuses the binds and named volumes of the old
+	// container, so its volumes are not removed with it.
+	if err := daemon.ContainerRm(ctr.ID, &backend.ContainerRmConfig{ForceRemove: true}); err != nil {
+		log.G(ctx).WithError(err).WithField("container", ctr.ID).
+			Warn("The replacement container is running, but the outdated container could not be removed")
+	}
+	return daemon.GetContainer(created.ID)
+}
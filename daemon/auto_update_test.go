@@ -0,0 +1,63 @@
+package daemon
+
+import (
+	"testing"
+
+	"gotest.tools/v3/assert"
+	is "gotest.tools/v3/assert/cmp"
+)
+
+func TestAutoUpdateRef(t *testing.T) {
+	tests := []struct {
+		doc         string
+		image       string
+		expectedRef string
+		skipped     bool
+		expectedErr string
+	}{
+		{
+			doc:         "bare name gets the latest tag",
+			image:       "busybox",
+			expectedRef: "docker.io/library/busybox:latest",
+		},
+		{
+			doc:         "tagged reference",
+			image:       "example.com/app:v2",
+			expectedRef: "example.com/app:v2",
+		},
+		{
+			doc:     "digest-pinned reference is skipped",
+			image:   "busybox@sha256:e2af53b8e2f1ebb9a978f3a0ca8e3ea2a4111b3bba9f84c0986da5ec85b298bb",
+			skipped: true,
+		},
+		{
+			doc:     "tagged and digest-pinned reference is skipped",
+			image:   "busybox:1.36@sha256:e2af53b8e2f1ebb9a978f3a0ca8e3ea2a4111b3bba9f84c0986da5ec85b298bb",
+			skipped: true,
+		},
+		{
+			doc:         "invalid reference",
+			image:       "UPPERCASE:tag",
+			expectedErr: "invalid reference format",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.doc, func(t *testing.T) {
+			ref, ok, err := autoUpdateRef(tc.image)
+			if tc.expectedErr != "" {
+				assert.Check(t, is.ErrorContains(err, tc.expectedErr))
+				assert.Check(t, !ok)
+				return
+			}
+			assert.NilError(t, err)
+			if tc.skipped {
+				assert.Check(t, !ok)
+				assert.Check(t, is.Nil(ref))
+				return
+			}
+			assert.Assert(t, ok)
+			assert.Check(t, is.Equal(ref.String(), tc.expectedRef))
+		})
+	}
+}
@@ -3,8 +3,10 @@ package daemon
 import (
 	"context"
 
+	"github.com/containerd/log"
 	"github.com/distribution/reference"
 	"github.com/moby/moby/api/types/events"
+	"github.com/moby/moby/v2/daemon/internal/image"
 	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
 )
 
@@ -22,3 +24,15 @@ func (daemon *Daemon) ImageExportedByBuildkit(ctx context.Context, id string, de
 func (daemon *Daemon) ImageNamedByBuildkit(ctx context.Context, ref reference.NamedTagged, desc ocispec.Descriptor) {
 	daemon.imageService.LogImageEvent(ctx, desc.Digest.String(), reference.FamiliarString(ref), events.ActionTag)
 }
+
+// ImageBuiltByBuildkit is a callback that is called when a build completes.
+// It records the ID of the build history record on the image it produced, so
+// that containers created from the image can be traced back to the build.
+func (daemon *Daemon) ImageBuiltByBuildkit(ctx context.Context, imgID string, buildID string) {
+	if err := daemon.imageService.SetImageBuildID(ctx, image.ID(imgID), buildID); err != nil {
+		log.G(ctx).WithError(err).WithFields(log.Fields{
+			"image": imgID,
+			"build": buildID,
+		}).Warn("failed to record build ID on image")
+	}
+}
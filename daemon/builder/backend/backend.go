@@ -14,6 +14,7 @@ import (
 	"github.com/moby/moby/v2/daemon/internal/image"
 	"github.com/moby/moby/v2/daemon/internal/stringid"
 	"github.com/moby/moby/v2/daemon/server/backend"
+	"github.com/moby/moby/v2/errdefs"
 	"github.com/pkg/errors"
 	"google.golang.org/grpc"
 )
@@ -42,6 +43,16 @@ func NewBackend(components ImageComponent, builder Builder, buildkit *buildkit.B
 	return &Backend{imageComponent: components, builder: builder, buildkit: buildkit, eventsService: es}, nil
 }
 
+// RegisterBuildSecrets registers build secrets and SSH agent sockets ahead
+// of a build, for clients that cannot serve them over the session gRPC
+// protocol.
+func (b *Backend) RegisterBuildSecrets(_ context.Context, req build.SecretsRequest) error {
+	if b.buildkit == nil {
+		return errdefs.NotImplemented(errors.New("build secret registration requires BuildKit"))
+	}
+	return b.buildkit.RegisterSecrets(req)
+}
+
 // RegisterGRPC registers buildkit controller to the grpc server.
 func (b *Backend) RegisterGRPC(s *grpc.Server) {
 	if b.buildkit != nil {
@@ -115,6 +126,21 @@ func (b *Backend) Cancel(ctx context.Context, id string) error {
 	return b.buildkit.Cancel(ctx, id)
 }
 
+// BuildHistory returns the recorded build history. If ref is non-empty, only
+// the record for that build is returned.
+func (b *Backend) BuildHistory(ctx context.Context, ref string) ([]*build.HistoryRecord, error) {
+	return b.buildkit.BuildHistory(ctx, ref)
+}
+
+// PruneBuildHistory removes all completed, unpinned build history records.
+func (b *Backend) PruneBuildHistory(ctx context.Context) (*build.HistoryPruneReport, error) {
+	deleted, err := b.buildkit.PruneBuildHistory(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to prune build history")
+	}
+	return &build.HistoryPruneReport{RecordsDeleted: deleted}, nil
+}
+
 func squashBuild(build *builder.Result, imageComponent ImageComponent) (string, error) {
 	var fromID string
 	if build.FromImage != nil {
@@ -3,9 +3,12 @@ package daemon
 import (
 	"context"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
 
+	"github.com/moby/go-archive"
+	"github.com/moby/go-archive/chrootarchive"
 	"github.com/moby/moby/api/types/checkpoint"
 	"github.com/moby/moby/api/types/events"
 	"github.com/moby/moby/v2/daemon/names"
@@ -98,6 +101,57 @@ func (daemon *Daemon) CheckpointDelete(name string, config checkpoint.DeleteOpti
 	return err
 }
 
+// CheckpointExport writes the specified checkpoint as an uncompressed tar
+// stream to out, so it can be imported on another daemon for migration.
+func (daemon *Daemon) CheckpointExport(name string, config checkpoint.ExportOptions, out io.Writer) error {
+	container, err := daemon.GetContainer(name)
+	if err != nil {
+		return err
+	}
+
+	checkpointDir, err := getCheckpointDir(config.CheckpointDir, config.CheckpointID, name, container.ID, container.CheckpointDir(), false)
+	if err != nil {
+		return fmt.Errorf("cannot export checkpoint for container %s: %s", name, err)
+	}
+
+	archv, err := chrootarchive.Tar(checkpointDir, &archive.TarOptions{
+		Compression: archive.Uncompressed,
+	}, checkpointDir)
+	if err != nil {
+		return fmt.Errorf("cannot export checkpoint for container %s: %s", name, err)
+	}
+	defer archv.Close()
+
+	_, err = io.Copy(out, archv)
+	return err
+}
+
+// CheckpointImport reads a tar stream produced by CheckpointExport from in
+// and creates the specified checkpoint from it, so a container can be started
+// from a checkpoint taken on another daemon.
+func (daemon *Daemon) CheckpointImport(name string, config checkpoint.ImportOptions, in io.Reader) error {
+	container, err := daemon.GetContainer(name)
+	if err != nil {
+		return err
+	}
+
+	if !validCheckpointNamePattern.MatchString(config.CheckpointID) {
+		return fmt.Errorf("Invalid checkpoint ID (%s), only %s are allowed", config.CheckpointID, validCheckpointNameChars)
+	}
+
+	checkpointDir, err := getCheckpointDir(config.CheckpointDir, config.CheckpointID, name, container.ID, container.CheckpointDir(), true)
+	if err != nil {
+		return fmt.Errorf("cannot import checkpoint for container %s: %s", name, err)
+	}
+
+	if err := chrootarchive.Untar(in, checkpointDir, nil); err != nil {
+		os.RemoveAll(checkpointDir)
+		return fmt.Errorf("cannot import checkpoint for container %s: %s", name, err)
+	}
+
+	return nil
+}
+
 // CheckpointList lists all checkpoints of the specified container
 func (daemon *Daemon) CheckpointList(name string, config checkpoint.ListOptions) ([]checkpoint.Summary, error) {
 	var out []checkpoint.Summary
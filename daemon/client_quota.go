@@ -0,0 +1,113 @@
+package daemon
+
+import (
+	"context"
+
+	"github.com/moby/moby/api/types/filters"
+	"github.com/moby/moby/v2/daemon/config"
+	"github.com/moby/moby/v2/daemon/server/backend"
+	"github.com/moby/moby/v2/daemon/server/httputils"
+	"github.com/moby/moby/v2/daemon/server/middleware"
+	"github.com/moby/moby/v2/errdefs"
+	"github.com/pkg/errors"
+)
+
+// clientIdentityLabel records which client identity created a container or
+// volume, so client quotas can be accounted across daemon restarts.
+const clientIdentityLabel = "com.docker.client-identity"
+
+// clientIdentity returns the identity of the client the request belongs to,
+// as derived by the client identity middleware. Internal operations without
+// an identity share the default identity.
+func clientIdentity(ctx context.Context) string {
+	if id := httputils.ClientIdentityFromContext(ctx); id != "" {
+		return id
+	}
+	return middleware.DefaultClientIdentity
+}
+
+// quotaFor returns the quota that applies to the given identity: the
+// identity's own entry when present, or the "default" entry otherwise.
+func quotaFor(cfg *config.Config, identity string) (config.ClientQuota, bool) {
+	if q, ok := cfg.ClientQuotas[identity]; ok {
+		return q, true
+	}
+	q, ok := cfg.ClientQuotas[middleware.DefaultClientIdentity]
+	return q, ok
+}
+
+// checkContainerQuota stamps the container being created with the client's
+// identity and rejects the creation when it would exceed the identity's
+// container or memory-reservation quota.
+func (daemon *Daemon) checkContainerQuota(ctx context.Context, cfg *config.Config, params *backend.ContainerCreateConfig) error {
+	identity := clientIdentity(ctx)
+
+	// Stamp the identity even when no quota applies, so containers are
+	// accounted when a quota is added later.
+	if params.Config.Labels == nil {
+		params.Config.Labels = map[string]string{}
+	}
+	params.Config.Labels[clientIdentityLabel] = identity
+
+	q, ok := quotaFor(cfg, identity)
+	if !ok || (q.MaxContainers == 0 && q.MaxMemory == 0) {
+		return nil
+	}
+
+	var count, memory int64
+	for _, c := range daemon.containers.List() {
+		if c.Config == nil || c.Config.Labels[clientIdentityLabel] != identity {
+			continue
+		}
+		count++
+		if c.HostConfig != nil {
+			memory += containerMemoryReservation(c.HostConfig.MemoryReservation, c.HostConfig.Memory)
+		}
+	}
+
+	if q.MaxContainers > 0 && count >= q.MaxContainers {
+		return errdefs.Forbidden(errors.Errorf("client %q has reached its quota of %d containers", identity, q.MaxContainers))
+	}
+	if q.MaxMemory > 0 && params.HostConfig != nil {
+		requested := containerMemoryReservation(params.HostConfig.MemoryReservation, params.HostConfig.Memory)
+		if memory+requested > q.MaxMemory {
+			return errdefs.Forbidden(errors.Errorf("client %q would exceed its memory reservation quota of %d bytes", identity, q.MaxMemory))
+		}
+	}
+	return nil
+}
+
+// containerMemoryReservation is the memory a container counts against its
+// client's quota: the explicit reservation when set, the hard limit
+// otherwise.
+func containerMemoryReservation(reservation, limit int64) int64 {
+	if reservation > 0 {
+		return reservation
+	}
+	if limit > 0 {
+		return limit
+	}
+	return 0
+}
+
+// volumeCreateCheck is registered with the volume service. It labels every
+// new volume with the client's identity and rejects the creation when the
+// identity's volume quota is exhausted.
+func (daemon *Daemon) volumeCreateCheck(ctx context.Context) (map[string]string, error) {
+	identity := clientIdentity(ctx)
+	labels := map[string]string{clientIdentityLabel: identity}
+
+	q, ok := quotaFor(&daemon.config().Config, identity)
+	if !ok || q.MaxVolumes == 0 {
+		return labels, nil
+	}
+
+	vols, _, err := daemon.volumes.List(ctx, filters.NewArgs(filters.Arg("label", clientIdentityLabel+"="+identity)))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(vols)) >= q.MaxVolumes {
+		return nil, errdefs.Forbidden(errors.Errorf("client %q has reached its quota of %d volumes", identity, q.MaxVolumes))
+	}
+	return labels, nil
+}
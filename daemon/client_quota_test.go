@@ -0,0 +1,76 @@
+package daemon
+
+import (
+	"context"
+	"testing"
+
+	cerrdefs "github.com/containerd/errdefs"
+	containertypes "github.com/moby/moby/api/types/container"
+	"github.com/moby/moby/v2/daemon/config"
+	"github.com/moby/moby/v2/daemon/container"
+	"github.com/moby/moby/v2/daemon/server/backend"
+	"github.com/moby/moby/v2/daemon/server/httputils"
+	"gotest.tools/v3/assert"
+	is "gotest.tools/v3/assert/cmp"
+)
+
+func quotaTestContainer(id, identity string, memory int64) *container.Container {
+	return &container.Container{
+		ID:         id,
+		Config:     &containertypes.Config{Labels: map[string]string{clientIdentityLabel: identity}},
+		HostConfig: &containertypes.HostConfig{Resources: containertypes.Resources{MemoryReservation: memory}},
+	}
+}
+
+func TestCheckContainerQuota(t *testing.T) {
+	d := &Daemon{containers: container.NewMemoryStore()}
+	d.containers.Add("c1", quotaTestContainer("c1", "alice", 512))
+	d.containers.Add("c2", quotaTestContainer("c2", "alice", 512))
+	d.containers.Add("c3", quotaTestContainer("c3", "bob", 512))
+
+	cfg := &config.Config{}
+	cfg.ClientQuotas = map[string]config.ClientQuota{
+		"alice":   {MaxContainers: 2, MaxMemory: 2048},
+		"default": {MaxContainers: 10},
+	}
+
+	ctx := context.WithValue(context.Background(), httputils.ClientIdentityKey{}, "alice")
+	params := &backend.ContainerCreateConfig{Config: &containertypes.Config{}}
+
+	// alice is at her container quota.
+	err := d.checkContainerQuota(ctx, cfg, params)
+	assert.Assert(t, cerrdefs.IsPermissionDenied(err), err)
+
+	// bob falls back to the default quota and is under it; his container
+	// gets stamped with his identity.
+	ctx = context.WithValue(context.Background(), httputils.ClientIdentityKey{}, "bob")
+	assert.NilError(t, d.checkContainerQuota(ctx, cfg, params))
+	assert.Check(t, is.Equal(params.Config.Labels[clientIdentityLabel], "bob"))
+
+	// Raising the container limit exposes the memory quota.
+	cfg.ClientQuotas["alice"] = config.ClientQuota{MaxContainers: 10, MaxMemory: 2048}
+	ctx = context.WithValue(context.Background(), httputils.ClientIdentityKey{}, "alice")
+	params = &backend.ContainerCreateConfig{
+		Config:     &containertypes.Config{},
+		HostConfig: &containertypes.HostConfig{Resources: containertypes.Resources{MemoryReservation: 2048}},
+	}
+	err = d.checkContainerQuota(ctx, cfg, params)
+	assert.Assert(t, cerrdefs.IsPermissionDenied(err), err)
+
+	params.HostConfig.MemoryReservation = 1024
+	assert.NilError(t, d.checkContainerQuota(ctx, cfg, params))
+}
+
+func TestQuotaForFallback(t *testing.T) {
+	cfg := &config.Config{}
+	cfg.ClientQuotas = map[string]config.ClientQuota{
+		"default": {MaxVolumes: 3},
+	}
+
+	q, ok := quotaFor(cfg, "unknown")
+	assert.Assert(t, ok)
+	assert.Check(t, is.Equal(q.MaxVolumes, int64(3)))
+
+	_, ok = quotaFor(&config.Config{}, "unknown")
+	assert.Check(t, !ok)
+}
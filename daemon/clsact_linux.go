@@ -0,0 +1,128 @@
+package daemon
+
+import (
+	"strconv"
+	"sync"
+	"sync/atomic"
+
+	"github.com/pkg/errors"
+	"github.com/vishvananda/netlink"
+	"golang.org/x/sys/unix"
+)
+
+// Filter priorities on the shared clsact qdisc. Each feature attaching
+// filters uses its own priority so that it can delete exactly its filters
+// on teardown without touching the other's.
+const (
+	// clsactRatePriority is used by the network-rate ingress police filter.
+	clsactRatePriority = 10
+	// clsactMirrorPriority is used by the traffic-mirroring mirred filters.
+	clsactMirrorPriority = 20
+)
+
+// clsactUsers tracks, per sandbox interface, which daemon features currently
+// attach filters to the interface's clsact qdisc. The kernel refuses to
+// install clsact next to the legacy ingress qdisc (both claim handle ffff:),
+// so the network-rate policer and traffic mirroring share one clsact
+// instance: the qdisc is added by the first user, deleted by the last, and
+// never deleted at all when it was already present on the interface.
+var clsactUsers = struct {
+	sync.Mutex
+	ifaces map[clsactKey]*clsactState
+}{ifaces: make(map[clsactKey]*clsactState)}
+
+type clsactKey struct {
+	sandboxID string
+	ifName    string
+}
+
+type clsactState struct {
+	users map[string]struct{}
+	// owned records whether the daemon added the qdisc and may delete it.
+	owned bool
+}
+
+// Seams for the netlink calls, for tests.
+var (
+	clsactQdiscAdd = netlink.QdiscAdd
+	clsactQdiscDel = netlink.QdiscDel
+)
+
+var clsactUserSeq atomic.Uint64
+
+// newClsactUser returns a token identifying one user of a shared clsact
+// qdisc, for pairing acquireClsact with releaseClsact.
+func newClsactUser(prefix string) string {
+	return prefix + "-" + strconv.FormatUint(clsactUserSeq.Add(1), 10)
+}
+
+func clsactQdisc(linkIndex int) *netlink.Clsact {
+	return &netlink.Clsact{
+		QdiscAttrs: netlink.QdiscAttrs{
+			LinkIndex: linkIndex,
+			Parent:    netlink.HANDLE_CLSACT,
+			Handle:    netlink.MakeHandle(0xffff, 0),
+		},
+	}
+}
+
+// acquireClsact ensures a clsact qdisc exists on the link and registers user
+// as holding a reference to it. Acquiring is idempotent per user. It must be
+// called from inside the sandbox's network namespace.
+func acquireClsact(sandboxID string, linkIndex int, ifName, user string) error {
+	clsactUsers.Lock()
+	defer clsactUsers.Unlock()
+	key := clsactKey{sandboxID: sandboxID, ifName: ifName}
+	st := clsactUsers.ifaces[key]
+	if st == nil {
+		st = &clsactState{users: make(map[string]struct{})}
+		switch err := clsactQdiscAdd(clsactQdisc(linkIndex)); {
+		case err == nil:
+			st.owned = true
+		case errors.Is(err, unix.EEXIST):
+			// A clsact qdisc is already installed (by an operator, or left
+			// over from a previous daemon); attach filters to it, but do
+			// not delete it on release.
+		default:
+			return errors.Wrap(err, "failed to add clsact qdisc")
+		}
+		clsactUsers.ifaces[key] = st
+	}
+	st.users[user] = struct{}{}
+	return nil
+}
+
+// releaseClsact drops user's reference on the interface's clsact qdisc and
+// deletes the qdisc when user was the last reference and the daemon added
+// it. Releasing an unknown user is a no-op. It must be called from inside
+// the sandbox's network namespace.
+func releaseClsact(sandboxID string, linkIndex int, ifName, user string) {
+	clsactUsers.Lock()
+	defer clsactUsers.Unlock()
+	key := clsactKey{sandboxID: sandboxID, ifName: ifName}
+	st := clsactUsers.ifaces[key]
+	if st == nil {
+		return
+	}
+	delete(st.users, user)
+	if len(st.users) > 0 {
+		return
+	}
+	delete(clsactUsers.ifaces, key)
+	if st.owned {
+		_ = clsactQdiscDel(clsactQdisc(linkIndex))
+	}
+}
+
+// forgetClsactSandbox drops the clsact bookkeeping of every interface in the
+// sandbox. It is called when the sandbox is deleted: its network namespace
+// takes the qdiscs with it, so nothing is left to clean up.
+func forgetClsactSandbox(sandboxID string) {
+	clsactUsers.Lock()
+	defer clsactUsers.Unlock()
+	for key := range clsactUsers.ifaces {
+		if key.sandboxID == sandboxID {
+			delete(clsactUsers.ifaces, key)
+		}
+	}
+}
@@ -0,0 +1,77 @@
+package daemon
+
+import (
+	"testing"
+
+	"github.com/vishvananda/netlink"
+	"golang.org/x/sys/unix"
+	"gotest.tools/v3/assert"
+	is "gotest.tools/v3/assert/cmp"
+)
+
+func TestClsactRefcounting(t *testing.T) {
+	var adds, dels int
+	var addErr error
+	clsactQdiscAdd = func(netlink.Qdisc) error { adds++; return addErr }
+	clsactQdiscDel = func(netlink.Qdisc) error { dels++; return nil }
+	defer func() {
+		clsactQdiscAdd = netlink.QdiscAdd
+		clsactQdiscDel = netlink.QdiscDel
+		clsactUsers.ifaces = make(map[clsactKey]*clsactState)
+	}()
+	reset := func() {
+		adds, dels, addErr = 0, 0, nil
+		clsactUsers.ifaces = make(map[clsactKey]*clsactState)
+	}
+
+	t.Run("added by first user, deleted by last", func(t *testing.T) {
+		reset()
+		assert.NilError(t, acquireClsact("sb", 2, "eth0", "mirror-1"))
+		assert.NilError(t, acquireClsact("sb", 2, "eth0", clsactRateUser))
+		assert.Check(t, is.Equal(adds, 1))
+
+		releaseClsact("sb", 2, "eth0", "mirror-1")
+		assert.Check(t, is.Equal(dels, 0))
+		releaseClsact("sb", 2, "eth0", clsactRateUser)
+		assert.Check(t, is.Equal(dels, 1))
+	})
+
+	t.Run("acquire is idempotent per user", func(t *testing.T) {
+		reset()
+		assert.NilError(t, acquireClsact("sb", 2, "eth0", clsactRateUser))
+		assert.NilError(t, acquireClsact("sb", 2, "eth0", clsactRateUser))
+		releaseClsact("sb", 2, "eth0", clsactRateUser)
+		assert.Check(t, is.Equal(dels, 1))
+	})
+
+	t.Run("pre-existing qdisc is never deleted", func(t *testing.T) {
+		reset()
+		addErr = unix.EEXIST
+		assert.NilError(t, acquireClsact("sb", 2, "eth0", "mirror-1"))
+		releaseClsact("sb", 2, "eth0", "mirror-1")
+		assert.Check(t, is.Equal(dels, 0))
+	})
+
+	t.Run("add failure is returned", func(t *testing.T) {
+		reset()
+		addErr = unix.EPERM
+		err := acquireClsact("sb", 2, "eth0", "mirror-1")
+		assert.Check(t, is.ErrorContains(err, "failed to add clsact qdisc"))
+		assert.Check(t, is.Len(clsactUsers.ifaces, 0))
+	})
+
+	t.Run("release of unknown user is a no-op", func(t *testing.T) {
+		reset()
+		releaseClsact("sb", 2, "eth0", "mirror-1")
+		assert.Check(t, is.Equal(dels, 0))
+	})
+
+	t.Run("forgetting a sandbox drops state without deleting", func(t *testing.T) {
+		reset()
+		assert.NilError(t, acquireClsact("sb", 2, "eth0", "mirror-1"))
+		assert.NilError(t, acquireClsact("other", 3, "eth0", "mirror-2"))
+		forgetClsactSandbox("sb")
+		assert.Check(t, is.Equal(dels, 0))
+		assert.Check(t, is.Len(clsactUsers.ifaces, 1))
+	})
+}
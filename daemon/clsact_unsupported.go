@@ -0,0 +1,7 @@
+//go:build !linux
+
+package daemon
+
+// forgetClsactSandbox is only relevant on Linux, where tc filters are
+// attached to sandbox interfaces.
+func forgetClsactSandbox(sandboxID string) {}
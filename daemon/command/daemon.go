@@ -37,8 +37,10 @@ import (
 	dopts "github.com/moby/moby/v2/daemon/pkg/opts"
 	"github.com/moby/moby/v2/daemon/pkg/plugin"
 	apiserver "github.com/moby/moby/v2/daemon/server"
+	"github.com/moby/moby/v2/daemon/server/backend"
 	"github.com/moby/moby/v2/daemon/server/middleware"
 	"github.com/moby/moby/v2/daemon/server/router"
+	artifactrouter "github.com/moby/moby/v2/daemon/server/router/artifact"
 	"github.com/moby/moby/v2/daemon/server/router/build"
 	checkpointrouter "github.com/moby/moby/v2/daemon/server/router/checkpoint"
 	"github.com/moby/moby/v2/daemon/server/router/container"
@@ -237,6 +239,8 @@ func (cli *daemonCLI) start(ctx context.Context) (err error) {
 
 	tp, otelShutdown := otelutil.NewTracerProvider(ctx, true)
 	otel.SetTracerProvider(tp)
+	mp, otelMetricsShutdown := otelutil.NewMeterProvider(ctx, true)
+	otel.SetMeterProvider(mp)
 	log.G(ctx).Logger.AddHook(tracing.NewLogrusHook())
 
 	pluginStore := plugin.NewStore()
@@ -360,6 +364,9 @@ func (cli *daemonCLI) start(ctx context.Context) (err error) {
 	if err := otelShutdown(context.WithoutCancel(ctx)); err != nil {
 		log.G(ctx).WithError(err).Error("Failed to shutdown OTEL tracing")
 	}
+	if err := otelMetricsShutdown(context.WithoutCancel(ctx)); err != nil {
+		log.G(ctx).WithError(err).Error("Failed to shutdown OTEL metrics")
+	}
 
 	log.G(ctx).Info("Daemon shutdown complete")
 	return nil
@@ -425,6 +432,7 @@ func initBuildkit(ctx context.Context, d *daemon.Daemon, cdiCache *cdi.Cache) (_
 		Callbacks: exporter.BuildkitCallbacks{
 			Exported: d.ImageExportedByBuildkit,
 			Named:    d.ImageNamedByBuildkit,
+			Built:    d.ImageBuiltByBuildkit,
 		},
 		CDICache: cdiCache,
 	})
@@ -709,6 +717,7 @@ func buildRouters(opts routerOptions) []router.Router {
 		image.NewRouter(
 			opts.daemon.ImageService(),
 			opts.daemon.RegistryService(),
+			backend.Compression(opts.daemon.Config().ImageCompression),
 		),
 		systemrouter.NewRouter(opts.daemon, opts.cluster, opts.builder.buildkit, opts.daemon.Features),
 		volume.NewRouter(opts.daemon.VolumesService(), opts.cluster),
@@ -717,6 +726,7 @@ func buildRouters(opts routerOptions) []router.Router {
 		swarmrouter.NewRouter(opts.cluster),
 		pluginrouter.NewRouter(opts.daemon.PluginManager()),
 		distributionrouter.NewRouter(opts.daemon.ImageBackend()),
+		artifactrouter.NewRouter(opts.daemon.ImageService()),
 		network.NewRouter(opts.daemon, opts.cluster),
 		debugrouter.NewRouter(),
 	}
@@ -742,6 +752,8 @@ func initMiddlewares(_ context.Context, s *apiserver.Server, cfg *config.Config,
 	exp := middleware.NewExperimentalMiddleware(cfg.Experimental)
 	s.UseMiddleware(exp)
 
+	s.UseMiddleware(middleware.ClientIdentityMiddleware{})
+
 	vm, err := middleware.NewVersionMiddleware(dockerversion.Version, config.DefaultAPIVersion, cfg.MinAPIVersion)
 	if err != nil {
 		return nil, err
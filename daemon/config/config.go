@@ -13,6 +13,7 @@ import (
 
 	"dario.cat/mergo"
 	"github.com/containerd/log"
+	"github.com/containerd/platforms"
 	"github.com/moby/moby/api"
 	"github.com/moby/moby/api/types/versions"
 	dopts "github.com/moby/moby/v2/daemon/internal/opts"
@@ -34,6 +35,10 @@ const (
 	// maximum number of uploads that
 	// may take place at a time.
 	DefaultMaxConcurrentUploads = 5
+	// DefaultMaxConcurrentLoads is the default value for
+	// maximum number of images that
+	// may be extracted at a time from a "docker load" archive.
+	DefaultMaxConcurrentLoads = 3
 	// DefaultDownloadAttempts is the default value for
 	// maximum number of attempts that
 	// may take place at a time for each pull when the connection is lost.
@@ -56,6 +61,21 @@ const (
 	DefaultContainersNamespace = "moby"
 	// DefaultPluginNamespace is the name of the default containerd namespace used for plugins.
 	DefaultPluginNamespace = "plugins.moby"
+	// ContainerdUnavailableFailFast makes API calls that require containerd
+	// fail immediately while the connection to containerd is down.
+	ContainerdUnavailableFailFast = "fail-fast"
+	// ContainerdUnavailableWait makes API calls that require containerd
+	// queue briefly while the connection to containerd is down.
+	ContainerdUnavailableWait = "wait"
+	// WorkdirCreateAsUser makes the daemon create a missing working
+	// directory owned by the container's user. It is the default policy.
+	WorkdirCreateAsUser = "create-as-container-user"
+	// WorkdirCreateAsRoot makes the daemon create a missing working
+	// directory owned by root, matching the behavior of older releases.
+	WorkdirCreateAsRoot = "create-as-root"
+	// WorkdirCreateFail makes container creation fail when the working
+	// directory does not exist in the container's filesystem.
+	WorkdirCreateFail = "fail"
 	// DefaultAPIVersion is the highest REST API version supported by the daemon.
 	//
 	// This version may be lower than the [api.DefaultVersion], which is the default
@@ -157,6 +177,10 @@ type NetworkConfig struct {
 	// implementation. Currently only used on Linux, it is an error to
 	// supply a value for other platforms.
 	FirewallBackend string `json:"firewall-backend,omitempty"`
+	// NetworkProfiles maps profile names to sets of network sysctls
+	// (e.g. "net.core.somaxconn") that containers can opt into instead
+	// of repeating the individual sysctls on every container.
+	NetworkProfiles map[string]map[string]string `json:"network-profiles,omitempty"`
 }
 
 // TLSOptions defines TLS configuration for the daemon server.
@@ -177,6 +201,78 @@ type DNSConfig struct {
 	HostGatewayIPs []netip.Addr `json:"host-gateway-ips,omitempty"`
 }
 
+// EventSinkConfig configures a webhook endpoint that the daemon delivers
+// events to as CloudEvents, without requiring a long-lived events stream
+// consumer.
+type EventSinkConfig struct {
+	// URL is the HTTP(S) endpoint events are POSTed to.
+	URL string `json:"url"`
+	// Secret, when set, is used to sign each request body with HMAC-SHA256.
+	Secret string `json:"secret,omitempty"`
+	// Retries is the number of delivery attempts per event before the
+	// event is dropped for this sink. Defaults to 3 when unset.
+	Retries int `json:"retries,omitempty"`
+	// Filters restricts the events delivered to this sink, using the same
+	// keys and values as the events API (e.g. "type", "event", "label").
+	Filters map[string][]string `json:"filters,omitempty"`
+}
+
+// AdmissionWebhookConfig configures an external admission controller that
+// reviews image pulls and container creation before they proceed, and can
+// deny the operation or mutate the image reference.
+type AdmissionWebhookConfig struct {
+	// URL is the HTTP(S) endpoint admission requests are POSTed to.
+	URL string `json:"url"`
+	// Secret, when set, is used to sign each request body with HMAC-SHA256.
+	Secret string `json:"secret,omitempty"`
+}
+
+// LazyPullConfig enables lazy image pulling through a remote snapshotter
+// such as stargz. Images pulled from the configured registries are unpacked
+// by the remote snapshotter, which fetches layer content on demand so
+// containers can start before the full image is downloaded. The snapshotter
+// must be registered with containerd (e.g. as a proxy plugin backed by
+// stargz-store).
+type LazyPullConfig struct {
+	// Snapshotter is the name of the remote snapshotter used for lazy
+	// pulls. Defaults to "stargz" when unset.
+	Snapshotter string `json:"snapshotter,omitempty"`
+	// Registries lists the registry hosts for which lazy pulling is
+	// enabled. An empty list disables lazy pulling.
+	Registries []string `json:"registries,omitempty"`
+}
+
+// EventJournalConfig configures the optional on-disk event journal that
+// preserves event history across daemon restarts, so that requests to
+// "/events?since=" can return events emitted before the daemon was last
+// started.
+type EventJournalConfig struct {
+	// Enabled turns the journal on. When disabled, event history is
+	// limited to the daemon's in-memory buffer.
+	Enabled bool `json:"enabled,omitempty"`
+	// MaxFileSize is the maximum size, in bytes, of a single journal file
+	// before it is rotated. Defaults to 10 MiB when unset.
+	MaxFileSize int64 `json:"max-file-size,omitempty"`
+	// MaxFiles is the number of journal files retained, including the one
+	// currently written to; older files are deleted on rotation. Defaults
+	// to 5 when unset.
+	MaxFiles int `json:"max-files,omitempty"`
+}
+
+// ClientQuota describes resource limits enforced for containers and volumes
+// created by a single client identity.
+type ClientQuota struct {
+	// MaxContainers is the maximum number of containers the identity may
+	// have at once. Zero means unlimited.
+	MaxContainers int64 `json:"max-containers,omitempty"`
+	// MaxMemory is the maximum total memory reservation, in bytes, across
+	// the identity's containers. Zero means unlimited.
+	MaxMemory int64 `json:"max-memory,omitempty"`
+	// MaxVolumes is the maximum number of volumes the identity may have
+	// at once. Zero means unlimited.
+	MaxVolumes int64 `json:"max-volumes,omitempty"`
+}
+
 // CommonConfig defines the configuration of a docker daemon which is
 // common across platforms.
 // It includes json tags to deserialize configuration from a file
@@ -212,6 +308,15 @@ type CommonConfig struct {
 	// may take place at a time for each push.
 	MaxConcurrentUploads int `json:"max-concurrent-uploads,omitempty"`
 
+	// MaxConcurrentLoads is the maximum number of images that may be
+	// extracted at a time from a single "docker load" archive.
+	MaxConcurrentLoads int `json:"max-concurrent-loads,omitempty"`
+
+	// ImageCompression is the default algorithm used to (re)compress image
+	// layers when pushing or exporting images ("gzip" or "zstd"). It can
+	// be overridden per request with the "compression" parameter.
+	ImageCompression string `json:"image-compression,omitempty"`
+
 	// MaxDownloadAttempts is the maximum number of attempts that
 	// may take place at a time for each push.
 	MaxDownloadAttempts int `json:"max-download-attempts,omitempty"`
@@ -283,6 +388,68 @@ type CommonConfig struct {
 	ContainerdNamespace       string `json:"containerd-namespace,omitempty"`
 	ContainerdPluginNamespace string `json:"containerd-plugin-namespace,omitempty"`
 
+	// ContainerdUnavailableAction configures how API calls that require
+	// containerd behave while the connection to containerd is down:
+	// "fail-fast" (the default) fails them immediately, "wait" queues them
+	// briefly until the connection is restored.
+	ContainerdUnavailableAction string `json:"containerd-unavailable-action,omitempty"`
+
+	// ContainerdTenantNamespaces maps tenant names (selected through the
+	// "com.docker.tenant" container label) to dedicated containerd
+	// namespaces, isolating those tenants' containers at the containerd
+	// level.
+	ContainerdTenantNamespaces map[string]string `json:"containerd-tenant-namespaces,omitempty"`
+
+	// EventSinks is the list of webhook endpoints that daemon events are
+	// delivered to as CloudEvents.
+	EventSinks []EventSinkConfig `json:"event-sinks,omitempty"`
+
+	// AdmissionWebhook, when set, configures an external admission
+	// controller that reviews image pulls and container creation.
+	AdmissionWebhook *AdmissionWebhookConfig `json:"admission-webhook,omitempty"`
+
+	// EventJournal configures the optional on-disk event journal that
+	// preserves event history across daemon restarts.
+	EventJournal EventJournalConfig `json:"event-journal,omitempty"`
+
+	// JournaldEvents mirrors daemon events to the systemd journal as
+	// structured entries, with a stable MESSAGE_ID per event type and
+	// action. It has no effect on hosts without journald.
+	JournaldEvents bool `json:"journald-events,omitempty"`
+
+	// DefaultPullPlatform, when set, overrides the host platform as the
+	// default platform for image pulls that do not request an explicit
+	// platform (e.g. "linux/amd64").
+	DefaultPullPlatform string `json:"default-pull-platform,omitempty"`
+
+	// RegistryPullPlatforms maps registry hostnames to the default
+	// platform for image pulls from that registry, taking precedence
+	// over DefaultPullPlatform.
+	RegistryPullPlatforms map[string]string `json:"registry-pull-platforms,omitempty"`
+
+	// PreloadImagesDir is a directory of image tarballs and OCI layouts
+	// that are imported automatically at daemon startup. Entries already
+	// imported by an earlier start are skipped.
+	PreloadImagesDir string `json:"preload-images-dir,omitempty"`
+
+	// LazyPull, when set, enables lazy image pulling through a remote
+	// snapshotter for the configured registries. It is only effective
+	// with the containerd image store.
+	LazyPull *LazyPullConfig `json:"lazy-pull,omitempty"`
+
+	// WorkdirCreatePolicy configures how the daemon handles a working
+	// directory that does not exist in the container's filesystem:
+	// "create-as-container-user" (the default) creates it owned by the
+	// container's user, "create-as-root" creates it owned by root, and
+	// "fail" rejects the container instead of creating it.
+	WorkdirCreatePolicy string `json:"workdir-create-policy,omitempty"`
+
+	// ClientQuotas limits the containers and volumes that each client
+	// identity (the Common Name of the client's TLS certificate, or
+	// "default" for clients without one) may create. The "default" entry
+	// also applies to identities without an entry of their own.
+	ClientQuotas map[string]ClientQuota `json:"client-quotas,omitempty"`
+
 	DefaultRuntime string `json:"default-runtime,omitempty"`
 
 	// CDISpecDirs is a list of directories in which CDI specifications can be found.
@@ -328,6 +495,7 @@ func New() (*Config, error) {
 			},
 			MaxConcurrentDownloads: DefaultMaxConcurrentDownloads,
 			MaxConcurrentUploads:   DefaultMaxConcurrentUploads,
+			MaxConcurrentLoads:     DefaultMaxConcurrentLoads,
 			MaxDownloadAttempts:    DefaultDownloadAttempts,
 			BridgeConfig: BridgeConfig{
 				DefaultBridgeConfig: DefaultBridgeConfig{
@@ -711,6 +879,128 @@ func Validate(config *Config) error {
 		}
 	}
 
+	// validate image-compression
+	switch config.ImageCompression {
+	case "", "gzip", "zstd":
+		// These are valid.
+	default:
+		return errors.Errorf("invalid image-compression: %s", config.ImageCompression)
+	}
+
+	// validate containerd-unavailable-action
+	switch config.ContainerdUnavailableAction {
+	case "", ContainerdUnavailableFailFast, ContainerdUnavailableWait:
+		// These are valid.
+	default:
+		return errors.Errorf("invalid containerd-unavailable-action: %s", config.ContainerdUnavailableAction)
+	}
+
+	// validate containerd-tenant-namespaces
+	for tenant, ns := range config.ContainerdTenantNamespaces {
+		if tenant == "" {
+			return errors.New("invalid containerd-tenant-namespaces: tenant name must not be empty")
+		}
+		if ns == "" {
+			return errors.Errorf("invalid containerd-tenant-namespaces: no namespace for tenant %s", tenant)
+		}
+		if ns == config.ContainerdNamespace || ns == config.ContainerdPluginNamespace {
+			return errors.Errorf("invalid containerd-tenant-namespaces: namespace %s for tenant %s is reserved for the daemon", ns, tenant)
+		}
+	}
+
+	// validate workdir-create-policy
+	switch config.WorkdirCreatePolicy {
+	case "", WorkdirCreateAsUser, WorkdirCreateAsRoot, WorkdirCreateFail:
+		// These are valid.
+	default:
+		return errors.Errorf("invalid workdir-create-policy: %s", config.WorkdirCreatePolicy)
+	}
+
+	// validate network-profiles
+	for name, sysctls := range config.NetworkProfiles {
+		if name == "" {
+			return errors.New("invalid network-profiles: profile name must not be empty")
+		}
+		for k := range sysctls {
+			if !strings.HasPrefix(k, "net.") {
+				return errors.Errorf("invalid network-profiles: sysctl %s in profile %s is not a network sysctl", k, name)
+			}
+		}
+	}
+
+	// validate lazy-pull
+	if config.LazyPull != nil {
+		for _, host := range config.LazyPull.Registries {
+			if host == "" {
+				return errors.New("invalid lazy-pull: registry must not be empty")
+			}
+		}
+	}
+
+	// validate event-sinks
+	for _, sink := range config.EventSinks {
+		if sink.URL == "" {
+			return errors.New("invalid event-sinks: url must not be empty")
+		}
+		u, err := url.Parse(sink.URL)
+		if err != nil {
+			return errors.Wrapf(err, "invalid event-sinks: invalid url %s", sink.URL)
+		}
+		if u.Scheme != "http" && u.Scheme != "https" {
+			return errors.Errorf("invalid event-sinks: unsupported scheme in url %s (must be http or https)", sink.URL)
+		}
+		if sink.Retries < 0 {
+			return errors.Errorf("invalid event-sinks: negative retries for url %s", sink.URL)
+		}
+	}
+
+	// validate admission-webhook
+	if config.AdmissionWebhook != nil {
+		if config.AdmissionWebhook.URL == "" {
+			return errors.New("invalid admission-webhook: url must not be empty")
+		}
+		u, err := url.Parse(config.AdmissionWebhook.URL)
+		if err != nil {
+			return errors.Wrapf(err, "invalid admission-webhook: invalid url %s", config.AdmissionWebhook.URL)
+		}
+		if u.Scheme != "http" && u.Scheme != "https" {
+			return errors.Errorf("invalid admission-webhook: unsupported scheme in url %s (must be http or https)", config.AdmissionWebhook.URL)
+		}
+	}
+
+	// validate event-journal
+	if config.EventJournal.MaxFileSize < 0 {
+		return errors.Errorf("invalid event-journal: negative max-file-size: %d", config.EventJournal.MaxFileSize)
+	}
+	if config.EventJournal.MaxFiles < 0 {
+		return errors.Errorf("invalid event-journal: negative max-files: %d", config.EventJournal.MaxFiles)
+	}
+
+	// validate pull-platform defaults
+	if config.DefaultPullPlatform != "" {
+		if _, err := platforms.Parse(config.DefaultPullPlatform); err != nil {
+			return errors.Wrapf(err, "invalid default-pull-platform: %s", config.DefaultPullPlatform)
+		}
+	}
+	for host, platform := range config.RegistryPullPlatforms {
+		if host == "" {
+			return errors.New("invalid registry-pull-platforms: registry hostname must not be empty")
+		}
+		if _, err := platforms.Parse(platform); err != nil {
+			return errors.Wrapf(err, "invalid registry-pull-platforms: invalid platform %s for registry %s", platform, host)
+		}
+	}
+
+	// validate client-quotas
+	for identity, quota := range config.ClientQuotas {
+		if identity == "" {
+			return errors.New("invalid client-quotas: identity must not be empty")
+		}
+		if quota.MaxContainers < 0 || quota.MaxMemory < 0 || quota.MaxVolumes < 0 {
+			return errors.Errorf("invalid client-quotas: negative limit for identity %s", identity)
+		}
+	}
+
 	// validate DNSSearch
 	for _, dnsSearch := range config.DNSSearch {
 		if _, err := opts.ValidateDNSSearch(dnsSearch); err != nil {
@@ -740,6 +1030,9 @@ func Validate(config *Config) error {
 	if config.MaxConcurrentUploads < 0 {
 		return errors.Errorf("invalid max concurrent uploads: %d", config.MaxConcurrentUploads)
 	}
+	if config.MaxConcurrentLoads < 0 {
+		return errors.Errorf("invalid max concurrent loads: %d", config.MaxConcurrentLoads)
+	}
 	if config.MaxDownloadAttempts < 0 {
 		return errors.Errorf("invalid max download attempts: %d", config.MaxDownloadAttempts)
 	}
@@ -87,10 +87,14 @@ type Config struct {
 	Init                 bool                         `json:"init,omitempty"`
 	InitPath             string                       `json:"init-path,omitempty"`
 	SeccompProfile       string                       `json:"seccomp-profile,omitempty"`
-	ShmSize              opts.MemBytes                `json:"default-shm-size,omitempty"`
-	NoNewPrivileges      bool                         `json:"no-new-privileges,omitempty"`
-	IpcMode              string                       `json:"default-ipc-mode,omitempty"`
-	CgroupNamespaceMode  string                       `json:"default-cgroupns-mode,omitempty"`
+	// RuntimeSeccompProfiles maps runtime names to default seccomp
+	// profiles, overriding SeccompProfile for containers that use the
+	// runtime. Values use the same syntax as SeccompProfile.
+	RuntimeSeccompProfiles map[string]string `json:"runtime-seccomp-profiles,omitempty"`
+	ShmSize                opts.MemBytes     `json:"default-shm-size,omitempty"`
+	NoNewPrivileges        bool              `json:"no-new-privileges,omitempty"`
+	IpcMode                string            `json:"default-ipc-mode,omitempty"`
+	CgroupNamespaceMode    string            `json:"default-cgroupns-mode,omitempty"`
 	// ResolvConf is the path to the configuration of the host resolver
 	ResolvConf string `json:"resolv-conf,omitempty"`
 	Rootless   bool   `json:"rootless,omitempty"`
@@ -439,6 +439,40 @@ func TestValidateConfigurationErrors(t *testing.T) {
 			},
 			expectedErr: `invalid mirror: unsupported scheme "ftp" in "ftp://example.com": must use either 'https://' or 'http://'`,
 		},
+		{
+			name: "registry-pull-platforms empty hostname",
+			config: &Config{
+				CommonConfig: CommonConfig{
+					RegistryPullPlatforms: map[string]string{"": "linux/amd64"},
+				},
+			},
+			expectedErr: "invalid registry-pull-platforms: registry hostname must not be empty",
+		},
+		{
+			name: "lazy-pull empty registry",
+			config: &Config{
+				CommonConfig: CommonConfig{
+					LazyPull: &LazyPullConfig{
+						Snapshotter: "stargz",
+						Registries:  []string{"docker.io", ""},
+					},
+				},
+			},
+			expectedErr: "invalid lazy-pull: registry must not be empty",
+		},
+		{
+			name: "network-profiles non-network sysctl",
+			config: &Config{
+				CommonConfig: CommonConfig{
+					NetworkConfig: NetworkConfig{
+						NetworkProfiles: map[string]map[string]string{
+							"high-throughput": {"kernel.msgmax": "65536"},
+						},
+					},
+				},
+			},
+			expectedErr: "invalid network-profiles: sysctl kernel.msgmax in profile high-throughput is not a network sysctl",
+		},
 	}
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
@@ -579,6 +613,24 @@ func TestValidateConfiguration(t *testing.T) {
 				},
 			},
 		},
+		{
+			name:  "with default-pull-platform",
+			field: "DefaultPullPlatform",
+			config: &Config{
+				CommonConfig: CommonConfig{
+					DefaultPullPlatform: "linux/amd64",
+				},
+			},
+		},
+		{
+			name:  "with registry-pull-platforms",
+			field: "RegistryPullPlatforms",
+			config: &Config{
+				CommonConfig: CommonConfig{
+					RegistryPullPlatforms: map[string]string{"registry.example.com": "linux/arm64"},
+				},
+			},
+		},
 	}
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"maps"
+	"math"
 	"os"
 	"path/filepath"
 	"runtime"
@@ -75,6 +76,7 @@ func (daemon *Daemon) GetContainer(prefixOrName string) (*container.Container, e
 // This is typically done at startup.
 func (daemon *Daemon) load(id string) (*container.Container, error) {
 	ctr := container.NewBaseContainer(id, filepath.Join(daemon.repository, id))
+	ctr.SetConfigStore(daemon.containerConfigs)
 	if err := ctr.FromDisk(); err != nil {
 		return nil, err
 	}
@@ -156,6 +158,7 @@ func (daemon *Daemon) newContainer(name string, platform ocispec.Platform, confi
 	entrypoint, args := getEntrypointAndArgs(config.Entrypoint, config.Cmd)
 
 	base := container.NewBaseContainer(id, filepath.Join(daemon.repository, id))
+	base.SetConfigStore(daemon.containerConfigs)
 	base.Created = time.Now().UTC()
 	base.Managed = managed
 	base.Path = entrypoint
@@ -265,6 +268,12 @@ func (daemon *Daemon) verifyContainerSettings(daemonCfg *configStore, hostConfig
 		return warnings, err
 	}
 
+	if hostConfig != nil && hostConfig.StartDeadline > 0 {
+		if config == nil || config.Healthcheck == nil || len(config.Healthcheck.Test) == 0 || config.Healthcheck.Test[0] == "NONE" {
+			return warnings, errors.Errorf("StartDeadline requires a healthcheck")
+		}
+	}
+
 	// Now do platform-specific verification
 	warns, err = verifyPlatformContainerSettings(daemon, daemonCfg, hostConfig, update)
 	warnings = append(warnings, warns...)
@@ -336,6 +345,28 @@ func validateHostConfig(hostConfig *containertypes.HostConfig) (warnings []strin
 			return warnings, errors.Errorf("invalid Annotations: the empty string is not permitted as an annotation key")
 		}
 	}
+	if hostConfig.StartDeadline < 0 {
+		return warnings, errors.Errorf("StartDeadline cannot be negative")
+	}
+	if i := hostConfig.AutoUpdate.Interval; i != 0 && i < time.Minute {
+		return warnings, errors.Errorf("AutoUpdate interval cannot be shorter than one minute")
+	}
+	if rate := hostConfig.NetworkRate; rate != nil {
+		if rate.EgressBps < 0 || rate.IngressBps < 0 || rate.Burst < 0 {
+			return warnings, errors.Errorf("NetworkRate values cannot be negative")
+		}
+		if rate.IngressBps > math.MaxUint32 {
+			return warnings, errors.Errorf("NetworkRate ingress rate cannot exceed %d bytes per second", math.MaxUint32)
+		}
+		if rate.Burst > 0 && rate.EgressBps == 0 && rate.IngressBps == 0 {
+			return warnings, errors.Errorf("NetworkRate burst requires an egress or ingress rate")
+		}
+	}
+	switch hostConfig.OOMAction {
+	case "", containertypes.OOMActionKill, containertypes.OOMActionPause, containertypes.OOMActionRestart:
+	default:
+		return warnings, errors.Errorf("invalid OOM action (%s): must be one of %q, %q or %q", hostConfig.OOMAction, containertypes.OOMActionKill, containertypes.OOMActionPause, containertypes.OOMActionRestart)
+	}
 	return warnings, nil
 }
 
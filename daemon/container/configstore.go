@@ -0,0 +1,116 @@
+package container
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sync"
+
+	containertypes "github.com/moby/moby/api/types/container"
+	"github.com/moby/sys/atomicwriter"
+	"github.com/opencontainers/go-digest"
+	"github.com/pkg/errors"
+)
+
+// ConfigStore is a content-addressed store for container configurations.
+// Identical configurations are stored once, keyed by the digest of their
+// canonical JSON encoding, so hosts running many containers created from
+// the same configuration only pay for a single blob on disk.
+//
+// Blobs are immutable: changing a container's configuration stores a new
+// blob under a new digest (copy-on-write) and releases the old one. The
+// store keeps in-memory reference counts, rebuilt as containers are loaded
+// at daemon startup, and unlinks a blob when its last reference is released.
+type ConfigStore struct {
+	mu   sync.Mutex
+	root string
+	refs map[digest.Digest]int
+}
+
+// NewConfigStore creates a content-addressed config store rooted at root.
+func NewConfigStore(root string) (*ConfigStore, error) {
+	if err := os.MkdirAll(filepath.Join(root, digest.SHA256.String()), 0o700); err != nil {
+		return nil, errors.Wrap(err, "error creating container config store")
+	}
+	return &ConfigStore{
+		root: root,
+		refs: make(map[digest.Digest]int),
+	}, nil
+}
+
+// Put stores cfg, writing a new blob only if no identical configuration is
+// already present, and returns the digest under which the configuration is
+// stored. It does not take a reference; use Retain for that.
+func (s *ConfigStore) Put(cfg *containertypes.Config) (digest.Digest, error) {
+	data, err := json.Marshal(cfg)
+	if err != nil {
+		return "", errors.Wrap(err, "error marshaling container config")
+	}
+	dgst := digest.FromBytes(data)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	pth := s.blobPath(dgst)
+	if _, err := os.Stat(pth); err != nil {
+		if !os.IsNotExist(err) {
+			return "", err
+		}
+		if err := atomicwriter.WriteFile(pth, data, 0o600); err != nil {
+			return "", errors.Wrap(err, "error writing container config blob")
+		}
+	}
+	return dgst, nil
+}
+
+// Get returns a fresh copy of the configuration stored under dgst. It does
+// not take a reference; use Retain for that.
+func (s *ConfigStore) Get(dgst digest.Digest) (*containertypes.Config, error) {
+	if err := dgst.Validate(); err != nil {
+		return nil, errors.Wrapf(err, "invalid container config digest %s", dgst)
+	}
+	data, err := os.ReadFile(s.blobPath(dgst))
+	if err != nil {
+		return nil, errors.Wrapf(err, "error reading container config %s", dgst)
+	}
+	var cfg containertypes.Config
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return nil, errors.Wrapf(err, "error parsing container config %s", dgst)
+	}
+	return &cfg, nil
+}
+
+// Retain takes a reference on the configuration stored under dgst. It is
+// called when a container starts referencing a configuration, and when
+// containers are loaded from disk to rebuild reference counts.
+func (s *ConfigStore) Retain(dgst digest.Digest) {
+	s.mu.Lock()
+	s.refs[dgst]++
+	s.mu.Unlock()
+}
+
+// Release drops a reference on the configuration stored under dgst, and
+// unlinks the blob when no containers reference it anymore.
+func (s *ConfigStore) Release(dgst digest.Digest) error {
+	if dgst == "" {
+		return nil
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.refs[dgst] > 0 {
+		s.refs[dgst]--
+	}
+	if s.refs[dgst] > 0 {
+		return nil
+	}
+	delete(s.refs, dgst)
+	if err := os.Remove(s.blobPath(dgst)); err != nil && !os.IsNotExist(err) {
+		return errors.Wrapf(err, "error removing container config %s", dgst)
+	}
+	return nil
+}
+
+func (s *ConfigStore) blobPath(dgst digest.Digest) string {
+	return filepath.Join(s.root, dgst.Algorithm().String(), dgst.Encoded())
+}
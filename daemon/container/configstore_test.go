@@ -0,0 +1,84 @@
+package container
+
+import (
+	"encoding/json"
+	"os"
+	"testing"
+
+	containertypes "github.com/moby/moby/api/types/container"
+	"gotest.tools/v3/assert"
+	is "gotest.tools/v3/assert/cmp"
+)
+
+func TestConfigStoreDeduplicates(t *testing.T) {
+	store, err := NewConfigStore(t.TempDir())
+	assert.NilError(t, err)
+
+	cfg := &containertypes.Config{Image: "busybox", Cmd: []string{"top"}}
+	d1, err := store.Put(cfg)
+	assert.NilError(t, err)
+	d2, err := store.Put(&containertypes.Config{Image: "busybox", Cmd: []string{"top"}})
+	assert.NilError(t, err)
+	assert.Equal(t, d1, d2)
+
+	d3, err := store.Put(&containertypes.Config{Image: "busybox", Cmd: []string{"sleep"}})
+	assert.NilError(t, err)
+	assert.Assert(t, d1 != d3)
+
+	got, err := store.Get(d1)
+	assert.NilError(t, err)
+	assert.Check(t, is.DeepEqual(cfg, got))
+}
+
+func TestConfigStoreRelease(t *testing.T) {
+	store, err := NewConfigStore(t.TempDir())
+	assert.NilError(t, err)
+
+	dgst, err := store.Put(&containertypes.Config{Image: "busybox"})
+	assert.NilError(t, err)
+	store.Retain(dgst)
+	store.Retain(dgst)
+
+	assert.NilError(t, store.Release(dgst))
+	_, err = os.Stat(store.blobPath(dgst))
+	assert.NilError(t, err)
+
+	assert.NilError(t, store.Release(dgst))
+	_, err = os.Stat(store.blobPath(dgst))
+	assert.Check(t, os.IsNotExist(err))
+
+	// Releasing an already-released digest must not error.
+	assert.NilError(t, store.Release(dgst))
+	assert.NilError(t, store.Release(""))
+}
+
+func TestConfigStoreContainerRoundTrip(t *testing.T) {
+	store, err := NewConfigStore(t.TempDir())
+	assert.NilError(t, err)
+
+	c := NewBaseContainer("deadbeef", t.TempDir())
+	c.SetConfigStore(store)
+	c.Config = &containertypes.Config{Image: "busybox", Cmd: []string{"top"}}
+	c.HostConfig = &containertypes.HostConfig{}
+
+	deepCopy, err := c.toDisk()
+	assert.NilError(t, err)
+	assert.Check(t, is.DeepEqual(c.Config, deepCopy.Config))
+	assert.Equal(t, c.ConfigDigest, deepCopy.ConfigDigest)
+
+	// The persisted file must reference the config by digest instead of
+	// embedding a copy.
+	pth, err := c.ConfigPath()
+	assert.NilError(t, err)
+	data, err := os.ReadFile(pth)
+	assert.NilError(t, err)
+	var onDisk map[string]json.RawMessage
+	assert.NilError(t, json.Unmarshal(data, &onDisk))
+	assert.Check(t, is.Equal(string(onDisk["ConfigDigest"]), `"`+c.ConfigDigest.String()+`"`))
+	assert.Check(t, is.Equal(string(onDisk["Config"]), "null"))
+
+	loaded := NewBaseContainer(c.ID, c.Root)
+	loaded.SetConfigStore(store)
+	assert.NilError(t, loaded.FromDisk())
+	assert.Check(t, is.DeepEqual(c.Config, loaded.Config))
+}
@@ -9,6 +9,7 @@ import (
 	"os"
 	"path/filepath"
 	"runtime"
+	"strconv"
 	"strings"
 	"syscall"
 	"time"
@@ -40,6 +41,7 @@ import (
 	"github.com/moby/sys/signal"
 	"github.com/moby/sys/symlink"
 	"github.com/moby/sys/user"
+	"github.com/opencontainers/go-digest"
 	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
 	"github.com/pkg/errors"
 	"go.opentelemetry.io/otel"
@@ -72,22 +74,43 @@ type Container struct {
 	//
 	// State also provides a [sync.Mutex] which is used as lock for both
 	// the Container and State.
-	*State          `json:"State"`
-	Root            string  `json:"-"` // Path to the "home" of the container, including metadata.
-	BaseFS          string  `json:"-"` // Path to the graphdriver mountpoint
-	RWLayer         RWLayer `json:"-"`
-	ID              string
-	Created         time.Time
-	Managed         bool
-	Path            string
-	Args            []string
-	Config          *containertypes.Config
-	ImageID         image.ID `json:"Image"`
-	ImageManifest   *ocispec.Descriptor
+	*State  `json:"State"`
+	Root    string  `json:"-"` // Path to the "home" of the container, including metadata.
+	BaseFS  string  `json:"-"` // Path to the graphdriver mountpoint
+	RWLayer RWLayer `json:"-"`
+	ID      string
+	Created time.Time
+	Managed bool
+	Path    string
+	Args    []string
+	Config  *containertypes.Config
+	// ConfigDigest addresses Config in the daemon's content-addressed
+	// config store. When set, config.v2.json stores the digest instead of
+	// an inline copy of the configuration.
+	ConfigDigest  digest.Digest `json:",omitempty"`
+	ImageID       image.ID      `json:"Image"`
+	ImageManifest *ocispec.Descriptor
+	// ImageDigest is the digested reference the image was resolved to when
+	// the container was created with digest pinning requested. The
+	// container always runs the image recorded at create time, even if
+	// the tag has since been moved to another image.
+	ImageDigest string `json:",omitempty"`
+	// BuildID is the ID of the BuildKit build history record that produced
+	// the container's image, if the image was built locally.
+	BuildID         string `json:",omitempty"`
 	NetworkSettings *network.Settings
 	LogPath         string
 	Name            string
 	Driver          string
+	// ContainerdNamespace is the containerd namespace the container lives
+	// in when it was created for a tenant (see the
+	// containerd-tenant-namespaces daemon configuration). Empty for
+	// containers in the daemon's default namespace.
+	ContainerdNamespace string `json:",omitempty"`
+	// Warnings holds daemon-generated warnings about the container's setup
+	// (such as the working directory having been auto-created). They are
+	// surfaced through the inspect endpoint.
+	Warnings []string `json:",omitempty"`
 
 	// Deprecated: use [ImagePlatform.OS] instead.
 	// TODO: Remove, see https://github.com/moby/moby/issues/48892
@@ -110,6 +133,7 @@ type Container struct {
 	LogCopier      *logger.Copier `json:"-"`
 	restartManager *restartmanager.RestartManager
 	attachContext  *attachContext
+	configStore    *ConfigStore
 
 	// Fields here are specific to Unix platforms
 	SecurityOptions
@@ -122,6 +146,10 @@ type Container struct {
 	NetworkSharedContainerID string            `json:"-"`
 	SharedEndpointList       []string          `json:"-"`
 	LocalLogCacheMeta        localLogCacheMeta `json:",omitempty"`
+	// CredentialSpecDigest is the digest of the credential spec applied when
+	// the container was last started. It is compared against the credential
+	// spec source on inspect to detect rotated credential specs.
+	CredentialSpecDigest string `json:",omitempty"`
 }
 
 type SecurityOptions struct {
@@ -152,6 +180,14 @@ func NewBaseContainer(id, root string) *Container {
 	}
 }
 
+// SetConfigStore attaches the daemon's content-addressed config store to
+// the container. It must be called before FromDisk or toDisk; when set,
+// the container configuration is deduplicated through the store instead of
+// being embedded in config.v2.json.
+func (container *Container) SetConfigStore(store *ConfigStore) {
+	container.configStore = store
+}
+
 // FromDisk loads the container configuration stored in the host.
 func (container *Container) FromDisk() error {
 	pth, err := container.ConfigPath()
@@ -172,6 +208,19 @@ func (container *Container) FromDisk() error {
 		return err
 	}
 
+	if container.Config == nil && container.ConfigDigest != "" {
+		if container.configStore == nil {
+			return errors.Errorf("container %s references config %s but no config store is attached", container.ID, container.ConfigDigest)
+		}
+		container.Config, err = container.configStore.Get(container.ConfigDigest)
+		if err != nil {
+			return err
+		}
+		container.configStore.Retain(container.ConfigDigest)
+	}
+	// Containers written before the content-addressed config store have
+	// their configuration inline; they are migrated on the next checkpoint.
+
 	if container.OS != "" {
 		// OS was deprecated in favor of ImagePlatform
 		// Make sure we migrate the OS to ImagePlatform.OS.
@@ -196,6 +245,22 @@ func (container *Container) toDisk() (*Container, error) {
 		return nil, err
 	}
 
+	if container.configStore != nil && container.Config != nil {
+		// Store the configuration content-addressed so that identical
+		// configurations share a single blob, and persist only its digest.
+		dgst, err := container.configStore.Put(container.Config)
+		if err != nil {
+			return nil, err
+		}
+		if dgst != container.ConfigDigest {
+			container.configStore.Retain(dgst)
+			if err := container.configStore.Release(container.ConfigDigest); err != nil {
+				log.G(context.TODO()).WithError(err).WithField("container", container.ID).Warn("Error releasing old container config")
+			}
+			container.ConfigDigest = dgst
+		}
+	}
+
 	// Save container settings
 	f, err := atomicwriter.New(pth, 0o600)
 	if err != nil {
@@ -203,9 +268,16 @@ func (container *Container) toDisk() (*Container, error) {
 	}
 	defer f.Close()
 
+	// Encode a shallow copy so that the inline configuration can be omitted
+	// without touching the live container: Config is read lock-free
+	// throughout the daemon on the assumption it never changes after create.
+	shadow := *container
+	if container.configStore != nil && container.ConfigDigest != "" {
+		shadow.Config = nil
+	}
 	var buf bytes.Buffer
 	w := io.MultiWriter(&buf, f)
-	if err := json.NewEncoder(w).Encode(container); err != nil {
+	if err := json.NewEncoder(w).Encode(&shadow); err != nil {
 		return nil, err
 	}
 
@@ -213,6 +285,12 @@ func (container *Container) toDisk() (*Container, error) {
 	if err := json.NewDecoder(&buf).Decode(&deepCopy); err != nil {
 		return nil, err
 	}
+	if deepCopy.Config == nil && deepCopy.ConfigDigest != "" {
+		deepCopy.Config, err = container.configStore.Get(deepCopy.ConfigDigest)
+		if err != nil {
+			return nil, err
+		}
+	}
 	deepCopy.HostConfig, err = container.WriteHostConfig()
 	if err != nil {
 		return nil, err
@@ -320,28 +398,41 @@ func (container *Container) CommitInMemory(store *ViewDB) error {
 	return store.Save(&deepCopy)
 }
 
-// SetupWorkingDirectory sets up the container's working directory as set in container.Config.WorkingDir
-func (container *Container) SetupWorkingDirectory(uid int, gid int) error {
+// SetupWorkingDirectory sets up the container's working directory as set in
+// container.Config.WorkingDir, creating missing directories owned by uid:gid.
+// When mustExist is set, a missing working directory is an error instead of
+// being created. It reports whether the directory was created.
+func (container *Container) SetupWorkingDirectory(uid int, gid int, mustExist bool) (created bool, _ error) {
 	if container.Config.WorkingDir == "" {
-		return nil
+		return false, nil
 	}
 
 	workdir := filepath.Clean(container.Config.WorkingDir)
 	pth, err := container.GetResourcePath(workdir)
 	if err != nil {
-		return err
+		return false, err
+	}
+
+	if _, err := os.Stat(pth); err != nil {
+		if !os.IsNotExist(err) {
+			return false, err
+		}
+		if mustExist {
+			return false, errors.Errorf("working directory %s does not exist in the container's filesystem", container.Config.WorkingDir)
+		}
+		created = true
 	}
 
 	if err := user.MkdirAllAndChown(pth, 0o755, uid, gid, user.WithOnlyNew); err != nil {
 		pthInfo, err2 := os.Stat(pth)
 		if err2 == nil && pthInfo != nil && !pthInfo.IsDir() {
-			return errors.Errorf("Cannot mkdir: %s is not a directory", container.Config.WorkingDir)
+			return false, errors.Errorf("Cannot mkdir: %s is not a directory", container.Config.WorkingDir)
 		}
 
-		return err
+		return false, err
 	}
 
-	return nil
+	return created, nil
 }
 
 // GetResourcePath evaluates `path` in the scope of the container's BaseFS, with proper path
@@ -534,7 +625,7 @@ func (container *Container) GetExecIDs() []string {
 // ShouldRestart decides whether the daemon should restart the container or not.
 // This is based on the container's restart policy.
 func (container *Container) ShouldRestart() bool {
-	shouldRestart, _, _ := container.RestartManager().ShouldRestart(uint32(container.ExitCode()), container.HasBeenManuallyStopped, container.FinishedAt.Sub(container.StartedAt))
+	shouldRestart, _, _ := container.RestartManager().ShouldRestart(uint32(container.ExitCode()), container.HasBeenManuallyStopped, container.ExecutionDuration())
 	return shouldRestart
 }
 
@@ -680,6 +771,20 @@ func (container *Container) startLogging() error {
 	}
 
 	copier := logger.NewCopier(map[string]io.Reader{"stdout": container.StdoutPipe(), "stderr": container.StderrPipe()}, l)
+	if s := container.HostConfig.LogConfig.Config["sample-rate"]; s != "" {
+		rate, err := strconv.ParseFloat(s, 64)
+		if err != nil {
+			return fmt.Errorf("failed to parse log sample-rate: %v", err)
+		}
+		var burst int
+		if b := container.HostConfig.LogConfig.Config["sample-burst"]; b != "" {
+			burst, err = strconv.Atoi(b)
+			if err != nil {
+				return fmt.Errorf("failed to parse log sample-burst: %v", err)
+			}
+		}
+		copier.ConfigureSampling(rate, burst)
+	}
 	container.LogCopier = copier
 	copier.Run()
 	container.LogDriver = l
@@ -314,8 +314,25 @@ func (container *Container) UpdateContainer(hostConfig *containertypes.HostConfi
 	if resources.PidsLimit != nil {
 		cResources.PidsLimit = resources.PidsLimit
 	}
+	if resources.NetworkRate != nil {
+		cResources.NetworkRate = resources.NetworkRate
+	}
+	if len(resources.Ulimits) > 0 {
+		cResources.Ulimits = resources.Ulimits
+	}
+	if len(resources.DeviceCgroupRules) > 0 {
+		cResources.DeviceCgroupRules = resources.DeviceCgroupRules
+	}
 
 	// update HostConfig of container
+	if !hostConfig.CgroupnsMode.IsEmpty() {
+		if !hostConfig.CgroupnsMode.Valid() {
+			return conflictingUpdateOptions("invalid cgroup namespace mode: " + string(hostConfig.CgroupnsMode))
+		}
+		// A running container keeps its current namespace; the updated
+		// mode is applied the next time the container is started.
+		container.HostConfig.CgroupnsMode = hostConfig.CgroupnsMode
+	}
 	if hostConfig.RestartPolicy.Name != "" {
 		if container.HostConfig.AutoRemove && !hostConfig.RestartPolicy.IsNone() {
 			return conflictingUpdateOptions("Restart policy cannot be updated because AutoRemove is enabled for the container")
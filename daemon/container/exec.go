@@ -37,6 +37,12 @@ type ExecConfig struct {
 	Env          []string
 	Process      types.Process
 	ConsoleSize  *[2]uint
+	NanoCPUs     int64
+	MemoryBytes  int64
+	// CgroupPath is the sub-cgroup of the container's cgroup that the exec
+	// process was placed in to apply its resource limits, if any. It is only
+	// set on Linux hosts using cgroup v2.
+	CgroupPath string
 }
 
 // NewExecConfig initializes the a new exec configuration
@@ -44,11 +44,24 @@ type State struct {
 	StartedAt         time.Time
 	FinishedAt        time.Time
 	Health            *Health
-	Removed           bool `json:"-"`
+	// ResourceUsage is a summary of the resources consumed by the last run
+	// of the container, captured from cgroup statistics when its task
+	// exited. It is nil while the container is running.
+	ResourceUsage *container.ResourceUsage `json:",omitempty"`
+	Removed       bool                     `json:"-"`
 
 	stopWaiters       []chan<- StateStatus
 	removeOnlyWaiters []chan<- StateStatus
 
+	// startedMonotonic and finishedMonotonic record when the daemon
+	// observed the container transition to running and stopped. Unlike
+	// StartedAt and FinishedAt they carry Go's monotonic clock reading,
+	// so durations derived from them are immune to wall-clock jumps
+	// (e.g. after a host suspend/resume cycle or an NTP step). They are
+	// zero for containers that were not started by this daemon process.
+	startedMonotonic  time.Time
+	finishedMonotonic time.Time
+
 	// The libcontainerd reference fields are unexported to force consumers
 	// to access them through the getter methods with multi-valued returns
 	// so that they can't forget to nil-check: the code won't compile unless
@@ -280,17 +293,42 @@ func (s *State) setRunning(ctr libcontainerdtypes.Container, tsk libcontainerdty
 		s.Pid = 0
 	}
 	s.OOMKilled = false
+	s.ResourceUsage = nil
+	s.startedMonotonic = time.Now()
+	s.finishedMonotonic = time.Time{}
 	if start != nil {
 		s.StartedAt = start.UTC()
 	}
 }
 
+// ExecutionDuration returns how long the container has been running (when
+// still running), or ran for (when stopped). It prefers the monotonic clock
+// readings taken when this daemon process observed the state transitions, so
+// the result is not skewed by wall-clock jumps. It falls back to the
+// persisted wall-clock timestamps for containers started by a previous
+// daemon process.
+func (s *State) ExecutionDuration() time.Duration {
+	if !s.startedMonotonic.IsZero() {
+		if s.finishedMonotonic.IsZero() {
+			return time.Since(s.startedMonotonic)
+		}
+		return s.finishedMonotonic.Sub(s.startedMonotonic)
+	}
+	d := s.FinishedAt.Sub(s.StartedAt)
+	if d < 0 {
+		// The wall clock stepped backwards between start and stop.
+		return 0
+	}
+	return d
+}
+
 // SetStopped sets the container state to "stopped" without locking.
 func (s *State) SetStopped(exitStatus *ExitStatus) {
 	s.Running = false
 	s.Paused = false
 	s.Restarting = false
 	s.Pid = 0
+	s.finishedMonotonic = time.Now()
 	if exitStatus.ExitedAt.IsZero() {
 		s.FinishedAt = time.Now().UTC()
 	} else {
@@ -310,6 +348,7 @@ func (s *State) SetRestarting(exitStatus *ExitStatus) {
 	s.Restarting = true
 	s.Paused = false
 	s.Pid = 0
+	s.finishedMonotonic = time.Now()
 	s.FinishedAt = time.Now().UTC()
 	s.ExitCodeValue = exitStatus.ExitCode
 
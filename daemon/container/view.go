@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"maps"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/containerd/log"
@@ -32,14 +33,17 @@ type Snapshot struct {
 
 	// additional info queries need to filter on
 	// preserve nanosec resolution for queries
-	CreatedAt    time.Time
-	StartedAt    time.Time
-	Name         string
-	Pid          int
-	ExitCode     int
-	Running      bool
-	Paused       bool
-	Managed      bool
+	CreatedAt time.Time
+	StartedAt time.Time
+	Name      string
+	Pid       int
+	ExitCode  int
+	Running   bool
+	Paused    bool
+	Managed   bool
+	// Stale is set for entries served from a persisted view snapshot
+	// before the corresponding container has been reloaded from disk.
+	Stale        bool
 	ExposedPorts container.PortSet
 	PortBindings container.PortSet
 	Health       container.HealthStatus
@@ -89,6 +93,19 @@ var schema = &memdb.DBSchema{
 // ViewDB provides an in-memory transactional (ACID) container store.
 type ViewDB struct {
 	store *memdb.MemDB
+
+	// staleMu guards stale, the read-only entries loaded from a persisted
+	// view snapshot at daemon startup. Entries are dropped as authoritative
+	// container state is written to the store.
+	staleMu sync.Mutex
+	stale   map[string]*Snapshot
+
+	// watchMu guards the watch state below; see watch.go. watchVersion is
+	// the store's current resource version, incremented on every change.
+	watchMu      sync.Mutex
+	watchVersion uint64
+	watchBuffer  []container.WatchEvent
+	watchers     map[chan container.WatchEvent]struct{}
 }
 
 // NewViewDB provides the default implementation, with the default schema
@@ -135,6 +152,7 @@ func (db *ViewDB) GetByPrefix(s string) (string, error) {
 func (db *ViewDB) Snapshot() *View {
 	return &View{
 		txn: db.store.Txn(false),
+		db:  db,
 	}
 }
 
@@ -152,14 +170,37 @@ func (db *ViewDB) withTxn(cb func(*memdb.Txn) error) error {
 // Save atomically updates the in-memory store state for a Container.
 // Only read only (deep) copies of containers may be passed in.
 func (db *ViewDB) Save(c *Container) error {
-	return db.withTxn(func(txn *memdb.Txn) error {
+	var existed bool
+	err := db.withTxn(func(txn *memdb.Txn) error {
+		prev, err := txn.First(memdbContainersTable, memdbIDIndex, c.ID)
+		if err != nil {
+			return errdefs.System(err)
+		}
+		existed = prev != nil
 		return txn.Insert(memdbContainersTable, c)
 	})
+	if err == nil {
+		db.dropStale(c.ID)
+		eventType := container.WatchEventCreate
+		if existed {
+			eventType = container.WatchEventUpdate
+		}
+		// State is always set for real containers (see NewBaseContainer);
+		// transform cannot produce a snapshot without it.
+		if c.State != nil {
+			if snapshot, err := db.Snapshot().Get(c.ID); err == nil {
+				db.notifyWatch(eventType, c.ID, snapshot)
+			}
+		}
+	}
+	return err
 }
 
 // Delete removes an item by ID
 func (db *ViewDB) Delete(c *Container) error {
-	return db.withTxn(func(txn *memdb.Txn) error {
+	db.dropStale(c.ID)
+	var existed bool
+	err := db.withTxn(func(txn *memdb.Txn) error {
 		view := &View{txn: txn}
 		names := view.getNames(c.ID)
 
@@ -167,11 +208,18 @@ func (db *ViewDB) Delete(c *Container) error {
 			txn.Delete(memdbNamesTable, nameAssociation{name: name})
 		}
 
+		prev, _ := txn.First(memdbContainersTable, memdbIDIndex, c.ID)
+		existed = prev != nil
+
 		// Ignore error - the container may not actually exist in the
 		// db, but we still need to clean up associated names.
 		txn.Delete(memdbContainersTable, NewBaseContainer(c.ID, c.Root))
 		return nil
 	})
+	if err == nil && existed {
+		db.notifyWatch(container.WatchEventDelete, c.ID, nil)
+	}
+	return err
 }
 
 // ReserveName registers a container ID to a name. ReserveName is idempotent,
@@ -204,6 +252,7 @@ func (db *ViewDB) ReleaseName(name string) error {
 // View provides a consistent read-only view of the database.
 type View struct {
 	txn *memdb.Txn
+	db  *ViewDB
 }
 
 // All returns a all items in this snapshot. Returned objects must never be modified.
@@ -213,14 +262,19 @@ func (v *View) All() ([]Snapshot, error) {
 	if err != nil {
 		return nil, errdefs.System(err)
 	}
+	seen := make(map[string]struct{})
 	for {
 		item := iter.Next()
 		if item == nil {
 			break
 		}
 		snapshot := v.transform(item.(*Container))
+		seen[snapshot.ID] = struct{}{}
 		all = append(all, *snapshot)
 	}
+	// Serve containers that have not been reloaded yet from the persisted
+	// view snapshot, if one was loaded at startup.
+	all = append(all, v.db.staleAll(seen)...)
 	return all, nil
 }
 
@@ -232,6 +286,9 @@ func (v *View) Get(id string) (*Snapshot, error) {
 		return nil, errdefs.System(err)
 	}
 	if s == nil {
+		if stale := v.db.staleGet(id); stale != nil {
+			return stale, nil
+		}
 		return nil, errdefs.NotFound(errors.New("No such container: " + id))
 	}
 	return v.transform(s.(*Container)), nil
@@ -0,0 +1,122 @@
+package container
+
+import (
+	"encoding/json"
+	"os"
+	"time"
+
+	"github.com/moby/moby/v2/errdefs"
+	"github.com/moby/sys/atomicwriter"
+	"github.com/pkg/errors"
+)
+
+// viewSnapshotVersion is the format version of persisted view snapshots.
+// It is recorded in the snapshot file and checked on load.
+const viewSnapshotVersion = 1
+
+// persistedView is the on-disk form of a ViewDB snapshot. It only contains
+// the transformed, read-only snapshots, not full container state; it exists
+// to serve list and inspect queries while containers are still being loaded
+// at daemon startup.
+type persistedView struct {
+	Version   int
+	SavedAt   time.Time
+	Snapshots []Snapshot
+}
+
+// SaveViewToDisk persists a lightweight snapshot of the current view to
+// path. The snapshot can be loaded with LoadStaleViewFromDisk on the next
+// daemon startup to serve read-only queries before all containers have
+// been reloaded.
+func (db *ViewDB) SaveViewToDisk(path string) error {
+	all, err := db.Snapshot().All()
+	if err != nil {
+		return err
+	}
+	data, err := json.Marshal(persistedView{
+		Version:   viewSnapshotVersion,
+		SavedAt:   time.Now().UTC(),
+		Snapshots: all,
+	})
+	if err != nil {
+		return errdefs.System(errors.Wrap(err, "error marshaling view snapshot"))
+	}
+	return atomicwriter.WriteFile(path, data, 0o600)
+}
+
+// LoadStaleViewFromDisk loads a view snapshot previously written by
+// SaveViewToDisk and makes its entries available as stale results for All
+// and Get until the corresponding containers are saved to the view (or the
+// stale view is cleared with ClearStaleView). The file is removed after a
+// load attempt so that a crash during startup cannot serve the same
+// snapshot twice. It returns the number of loaded entries; a missing file
+// is not an error.
+func (db *ViewDB) LoadStaleViewFromDisk(path string) (int, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return 0, nil
+		}
+		return 0, err
+	}
+	_ = os.Remove(path)
+
+	var pv persistedView
+	if err := json.Unmarshal(data, &pv); err != nil {
+		return 0, errors.Wrap(err, "error parsing view snapshot")
+	}
+	if pv.Version != viewSnapshotVersion {
+		return 0, errors.Errorf("unsupported view snapshot version %d", pv.Version)
+	}
+
+	db.staleMu.Lock()
+	defer db.staleMu.Unlock()
+	db.stale = make(map[string]*Snapshot, len(pv.Snapshots))
+	for i := range pv.Snapshots {
+		s := &pv.Snapshots[i]
+		s.Stale = true
+		// Flag the entry in human-readable output as well; the snapshot
+		// reflects the state recorded at the previous daemon shutdown.
+		s.Summary.Status += " (stale)"
+		db.stale[s.ID] = s
+	}
+	return len(db.stale), nil
+}
+
+// ClearStaleView drops any remaining entries loaded from a persisted view
+// snapshot. It is called once all containers have been reloaded.
+func (db *ViewDB) ClearStaleView() {
+	db.staleMu.Lock()
+	db.stale = nil
+	db.staleMu.Unlock()
+}
+
+// dropStale removes the stale entry for the given container ID, if any.
+// It is called when authoritative state for the container is written to
+// (or deleted from) the view.
+func (db *ViewDB) dropStale(id string) {
+	db.staleMu.Lock()
+	delete(db.stale, id)
+	db.staleMu.Unlock()
+}
+
+// staleAll returns the stale entries for containers not in seen.
+func (db *ViewDB) staleAll(seen map[string]struct{}) []Snapshot {
+	db.staleMu.Lock()
+	defer db.staleMu.Unlock()
+	var all []Snapshot
+	for id, s := range db.stale {
+		if _, ok := seen[id]; ok {
+			continue
+		}
+		all = append(all, *s)
+	}
+	return all
+}
+
+// staleGet returns the stale entry for the given container ID, if any.
+func (db *ViewDB) staleGet(id string) *Snapshot {
+	db.staleMu.Lock()
+	defer db.staleMu.Unlock()
+	return db.stale[id]
+}
@@ -444,3 +444,58 @@ func BenchmarkDBGetByPrefix500(b *testing.B) {
 		}
 	}
 }
+
+func TestViewSnapshotPersistence(t *testing.T) {
+	db, err := NewViewDB()
+	assert.NilError(t, err)
+
+	tmpDir := t.TempDir()
+	one := newContainer(t, tmpDir)
+	two := newContainer(t, tmpDir)
+	assert.NilError(t, one.CheckpointTo(context.Background(), db))
+	assert.NilError(t, two.CheckpointTo(context.Background(), db))
+
+	snapshotPath := filepath.Join(tmpDir, "view-snapshot.json")
+	assert.NilError(t, db.SaveViewToDisk(snapshotPath))
+
+	// A fresh ViewDB (as on daemon restart) serves the persisted entries,
+	// marked stale, until the containers are loaded again.
+	db2, err := NewViewDB()
+	assert.NilError(t, err)
+	n, err := db2.LoadStaleViewFromDisk(snapshotPath)
+	assert.NilError(t, err)
+	assert.Equal(t, n, 2)
+
+	// The snapshot file is consumed on load.
+	_, err = os.Stat(snapshotPath)
+	assert.Check(t, os.IsNotExist(err))
+
+	all, err := db2.Snapshot().All()
+	assert.NilError(t, err)
+	assert.Assert(t, is.Len(all, 2))
+	for _, s := range all {
+		assert.Check(t, s.Stale)
+	}
+
+	s, err := db2.Snapshot().Get(one.ID)
+	assert.NilError(t, err)
+	assert.Check(t, s.Stale)
+
+	// Saving authoritative state replaces the stale entry.
+	assert.NilError(t, one.CheckpointTo(context.Background(), db2))
+	s, err = db2.Snapshot().Get(one.ID)
+	assert.NilError(t, err)
+	assert.Check(t, !s.Stale)
+
+	db2.ClearStaleView()
+	all, err = db2.Snapshot().All()
+	assert.NilError(t, err)
+	assert.Assert(t, is.Len(all, 1))
+	_, err = db2.Snapshot().Get(two.ID)
+	assert.Check(t, cerrdefs.IsNotFound(err))
+
+	// A missing snapshot file is not an error.
+	n, err = db2.LoadStaleViewFromDisk(snapshotPath)
+	assert.NilError(t, err)
+	assert.Equal(t, n, 0)
+}
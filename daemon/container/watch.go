@@ -0,0 +1,117 @@
+package container
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/moby/moby/api/types/container"
+	"github.com/moby/moby/v2/errdefs"
+)
+
+const (
+	// watchBufferSize is the number of past change events retained for
+	// resuming watches from a resourceVersion.
+	watchBufferSize = 1024
+	// watcherChanSize is the per-watcher channel buffer. A watcher that
+	// falls this far behind is evicted (its channel is closed) rather than
+	// allowed to block change notifications.
+	watcherChanSize = 128
+)
+
+// Watch returns the current state of the store followed by a stream of
+// incremental change events, etcd-style.
+//
+// When sinceVersion is zero, the initial slice holds one "sync" event per
+// container at the store's current resource version. When sinceVersion is
+// non-zero, the initial slice instead replays the retained change events
+// with a greater version, so a disconnected watcher can resume without a
+// full relist; an [errdefs.InvalidParameter] error is returned when the
+// requested version is no longer retained (or has not happened yet), in
+// which case the caller must restart the watch without a resume point.
+//
+// The returned channel is closed when the watcher is evicted for not
+// keeping up with the stream. The cancel function releases the watcher.
+func (db *ViewDB) Watch(sinceVersion uint64) ([]container.WatchEvent, <-chan container.WatchEvent, func(), error) {
+	db.watchMu.Lock()
+	defer db.watchMu.Unlock()
+
+	var initial []container.WatchEvent
+	if sinceVersion == 0 {
+		all, err := db.Snapshot().All()
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		for i := range all {
+			initial = append(initial, container.WatchEvent{
+				Type:            container.WatchEventSync,
+				ResourceVersion: db.watchVersion,
+				ID:              all[i].ID,
+				Container:       &all[i].Summary,
+			})
+		}
+	} else {
+		if sinceVersion > db.watchVersion {
+			return nil, nil, nil, errdefs.InvalidParameter(fmt.Errorf("resource version %d is greater than the current version %d", sinceVersion, db.watchVersion))
+		}
+		if sinceVersion < db.watchVersion {
+			if len(db.watchBuffer) == 0 || db.watchBuffer[0].ResourceVersion > sinceVersion+1 {
+				return nil, nil, nil, errdefs.InvalidParameter(errors.New("resource version is too old; restart the watch without a resourceVersion"))
+			}
+			for _, ev := range db.watchBuffer {
+				if ev.ResourceVersion > sinceVersion {
+					initial = append(initial, ev)
+				}
+			}
+		}
+	}
+
+	l := make(chan container.WatchEvent, watcherChanSize)
+	if db.watchers == nil {
+		db.watchers = make(map[chan container.WatchEvent]struct{})
+	}
+	db.watchers[l] = struct{}{}
+
+	cancel := func() {
+		db.watchMu.Lock()
+		defer db.watchMu.Unlock()
+		if _, ok := db.watchers[l]; ok {
+			delete(db.watchers, l)
+			close(l)
+		}
+	}
+	return initial, l, cancel, nil
+}
+
+// notifyWatch assigns the next resource version to a change event, retains
+// it for resuming watchers, and fans it out to active watchers. Watchers
+// that cannot keep up are evicted rather than blocking the store.
+func (db *ViewDB) notifyWatch(eventType container.WatchEventType, id string, snapshot *Snapshot) {
+	db.watchMu.Lock()
+	defer db.watchMu.Unlock()
+
+	db.watchVersion++
+	ev := container.WatchEvent{
+		Type:            eventType,
+		ResourceVersion: db.watchVersion,
+		ID:              id,
+	}
+	if snapshot != nil {
+		ev.Container = &snapshot.Summary
+	}
+
+	if len(db.watchBuffer) == watchBufferSize {
+		db.watchBuffer = append(db.watchBuffer[:0], db.watchBuffer[1:]...)
+		db.watchBuffer[watchBufferSize-1] = ev
+	} else {
+		db.watchBuffer = append(db.watchBuffer, ev)
+	}
+
+	for l := range db.watchers {
+		select {
+		case l <- ev:
+		default:
+			delete(db.watchers, l)
+			close(l)
+		}
+	}
+}
@@ -0,0 +1,79 @@
+package container
+
+import (
+	"testing"
+
+	cerrdefs "github.com/containerd/errdefs"
+	"github.com/moby/moby/api/types/container"
+	"gotest.tools/v3/assert"
+	is "gotest.tools/v3/assert/cmp"
+)
+
+func TestViewWatch(t *testing.T) {
+	db, err := NewViewDB()
+	assert.NilError(t, err)
+
+	tmpDir := t.TempDir()
+	one := newContainer(t, tmpDir)
+	assert.NilError(t, db.Save(one))
+
+	initial, l, cancel, err := db.Watch(0)
+	assert.NilError(t, err)
+	defer cancel()
+
+	assert.Assert(t, is.Len(initial, 1))
+	assert.Check(t, is.Equal(initial[0].Type, container.WatchEventSync))
+	assert.Check(t, is.Equal(initial[0].ID, one.ID))
+
+	two := newContainer(t, tmpDir)
+	assert.NilError(t, db.Save(two))
+
+	ev := <-l
+	assert.Check(t, is.Equal(ev.Type, container.WatchEventCreate))
+	assert.Check(t, is.Equal(ev.ID, two.ID))
+	assert.Assert(t, ev.Container != nil)
+
+	two.Pid = 20
+	assert.NilError(t, db.Save(two))
+
+	ev = <-l
+	assert.Check(t, is.Equal(ev.Type, container.WatchEventUpdate))
+	assert.Check(t, is.Equal(ev.ID, two.ID))
+
+	assert.NilError(t, db.Delete(two))
+
+	ev = <-l
+	assert.Check(t, is.Equal(ev.Type, container.WatchEventDelete))
+	assert.Check(t, is.Equal(ev.ID, two.ID))
+	assert.Check(t, ev.Container == nil)
+}
+
+func TestViewWatchResume(t *testing.T) {
+	db, err := NewViewDB()
+	assert.NilError(t, err)
+
+	tmpDir := t.TempDir()
+	one := newContainer(t, tmpDir)
+	assert.NilError(t, db.Save(one))
+
+	initial, _, cancel, err := db.Watch(0)
+	assert.NilError(t, err)
+	version := initial[0].ResourceVersion
+	cancel()
+
+	two := newContainer(t, tmpDir)
+	assert.NilError(t, db.Save(two))
+
+	// Resuming from the last observed version must replay only the changes
+	// made since then.
+	replay, _, cancel, err := db.Watch(version)
+	assert.NilError(t, err)
+	defer cancel()
+	assert.Assert(t, is.Len(replay, 1))
+	assert.Check(t, is.Equal(replay[0].Type, container.WatchEventCreate))
+	assert.Check(t, is.Equal(replay[0].ID, two.ID))
+
+	// A version from the future is rejected.
+	_, _, _, err = db.Watch(replay[0].ResourceVersion + 1)
+	assert.Check(t, is.ErrorType(err, cerrdefs.IsInvalidArgument))
+}
@@ -128,20 +128,25 @@ func buildSandboxOptions(cfg *config.Config, ctr *container.Container) ([]libnet
 		})
 
 		for _, binding := range bindings[port] {
-			newP, err := nat.NewPort(nat.SplitProtoPort(binding.HostPort))
+			hostPort, disableProxy, err := splitPortBindingOptions(binding.HostPort)
 			var portStart, portEnd int
 			if err == nil {
-				portStart, portEnd, err = newP.Range()
+				var newP nat.Port
+				newP, err = nat.NewPort(nat.SplitProtoPort(hostPort))
+				if err == nil {
+					portStart, portEnd, err = newP.Range()
+				}
 			}
 			if err != nil {
 				return nil, fmt.Errorf("Error parsing HostPort value(%s):%v", binding.HostPort, err)
 			}
 			publishedPorts = append(publishedPorts, types.PortBinding{
-				Proto:       portProto,
-				Port:        portNum,
-				HostIP:      net.ParseIP(binding.HostIP),
-				HostPort:    uint16(portStart),
-				HostPortEnd: uint16(portEnd),
+				Proto:        portProto,
+				Port:         portNum,
+				HostIP:       net.ParseIP(binding.HostIP),
+				HostPort:     uint16(portStart),
+				HostPortEnd:  uint16(portEnd),
+				DisableProxy: disableProxy,
 			})
 		}
 
@@ -970,6 +975,9 @@ func (daemon *Daemon) releaseNetwork(ctx context.Context, ctr *container.Contain
 	if err := sb.Delete(ctx); err != nil {
 		log.G(ctx).Errorf("Error deleting sandbox id %s for container %s: %v", sid, ctr.ID, err)
 	}
+	// The sandbox's network namespace took any tc state on its interfaces
+	// with it.
+	forgetClsactSandbox(sid)
 
 	for _, nw := range networks {
 		daemon.tryDetachContainerFromClusterNetwork(nw, ctr)
@@ -0,0 +1,46 @@
+package containerd
+
+import (
+	"context"
+
+	containerd "github.com/containerd/containerd/v2/client"
+	"github.com/distribution/reference"
+	"github.com/moby/moby/api/types/events"
+	registrytypes "github.com/moby/moby/api/types/registry"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/pkg/errors"
+)
+
+// ArtifactPull fetches an arbitrary OCI artifact (SBOM, signature, WASM
+// module, ...) into the content store. Unlike PullImage it does not unpack
+// the content into a snapshot, so any manifest media type is accepted.
+func (i *ImageService) ArtifactPull(ctx context.Context, ref reference.Named, authConfig *registrytypes.AuthConfig) (ocispec.Descriptor, error) {
+	resolver, _ := i.newResolverFromAuthConfig(ctx, authConfig, ref)
+
+	img, err := i.client.Fetch(ctx, ref.String(), containerd.WithResolver(resolver))
+	if err != nil {
+		return ocispec.Descriptor{}, errors.Wrap(err, "failed to pull artifact")
+	}
+
+	i.LogImageEvent(ctx, img.Target.Digest.String(), reference.FamiliarString(ref), events.ActionPull)
+	return img.Target, nil
+}
+
+// ArtifactPush pushes previously pulled artifact content to a registry.
+// The artifact's manifest (or index) and all content it references must be
+// present in the content store.
+func (i *ImageService) ArtifactPush(ctx context.Context, ref reference.Named, authConfig *registrytypes.AuthConfig) (ocispec.Descriptor, error) {
+	img, err := i.resolveImage(ctx, ref.String())
+	if err != nil {
+		return ocispec.Descriptor{}, err
+	}
+
+	resolver, _ := i.newResolverFromAuthConfig(ctx, authConfig, ref)
+
+	if err := i.client.Push(ctx, ref.String(), img.Target, containerd.WithResolver(resolver)); err != nil {
+		return ocispec.Descriptor{}, errors.Wrap(err, "failed to push artifact")
+	}
+
+	i.LogImageEvent(ctx, img.Target.Digest.String(), reference.FamiliarString(ref), events.ActionPush)
+	return img.Target, nil
+}
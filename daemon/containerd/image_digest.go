@@ -0,0 +1,29 @@
+package containerd
+
+import (
+	"context"
+
+	"github.com/distribution/reference"
+	"github.com/moby/moby/v2/errdefs"
+	"github.com/pkg/errors"
+)
+
+// ResolveDigestReference resolves refOrID to a digested reference pinning
+// the image it currently points to. The digest is that of the image's
+// target descriptor (the manifest or manifest list), so the resulting
+// reference can be pulled from the registry the image came from.
+func (i *ImageService) ResolveDigestReference(ctx context.Context, refOrID string) (string, error) {
+	img, err := i.resolveImage(ctx, refOrID)
+	if err != nil {
+		return "", err
+	}
+	named, err := reference.ParseNormalizedNamed(img.Name)
+	if err != nil {
+		return "", errdefs.InvalidParameter(errors.Errorf("cannot pin %s by digest: image has no repository name", refOrID))
+	}
+	canonical, err := reference.WithDigest(reference.TrimNamed(named), img.Target.Digest)
+	if err != nil {
+		return "", errdefs.System(err)
+	}
+	return reference.FamiliarString(canonical), nil
+}
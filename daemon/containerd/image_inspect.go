@@ -39,9 +39,16 @@ func (i *ImageService) ImageInspect(ctx context.Context, refOrID string, opts ba
 	}
 
 	lastUpdated := time.Unix(0, 0)
-	for _, i := range tagged {
-		if i.UpdatedAt.After(lastUpdated) {
-			lastUpdated = i.UpdatedAt
+	var lastPulled, lastUsed time.Time
+	for _, rec := range tagged {
+		if rec.UpdatedAt.After(lastUpdated) {
+			lastUpdated = rec.UpdatedAt
+		}
+		if t := imageTimeLabel(rec, imageLabelLastPulledAt); t.After(lastPulled) {
+			lastPulled = t
+		}
+		if t := imageTimeLabel(rec, imageLabelLastUsedAt); t.After(lastUsed) {
+			lastUsed = t
 		}
 	}
 
@@ -82,6 +89,11 @@ func (i *ImageService) ImageInspect(ctx context.Context, refOrID string, opts ba
 
 	repoTags, repoDigests := collectRepoTagsAndDigests(ctx, tagged)
 
+	var artifacts []ocispec.Descriptor
+	if opts.Artifacts {
+		artifacts = i.imageArtifacts(ctx, repoDigests)
+	}
+
 	if requestedPlatform != nil {
 		target = multi.Best.Target()
 	}
@@ -101,7 +113,10 @@ func (i *ImageService) ImageInspect(ctx context.Context, refOrID string, opts ba
 		},
 		Metadata: imagetypes.Metadata{
 			LastTagTime: lastUpdated,
+			LastPulled:  lastPulled,
+			LastUsed:    lastUsed,
 		},
+		Artifacts: artifacts,
 	}
 
 	if multi.Best != nil {
@@ -132,6 +147,29 @@ func (i *ImageService) ImageInspect(ctx context.Context, refOrID string, opts ba
 	return resp, nil
 }
 
+// imageArtifacts lists the artifacts associated with the image's first
+// repository digest; all repository digests record the same manifest. A
+// registry lookup failure only means no artifacts are reported.
+func (i *ImageService) imageArtifacts(ctx context.Context, repoDigests []string) []ocispec.Descriptor {
+	if len(repoDigests) == 0 {
+		return nil
+	}
+	ref, err := reference.ParseNormalizedNamed(repoDigests[0])
+	if err != nil {
+		return nil
+	}
+	canonical, ok := ref.(reference.Canonical)
+	if !ok {
+		return nil
+	}
+	artifacts, err := i.referrers.Get(ctx, canonical, i.registryService)
+	if err != nil {
+		log.G(ctx).WithError(err).WithField("image", repoDigests[0]).Debug("failed to list image artifacts")
+		return nil
+	}
+	return artifacts
+}
+
 func collectRepoTagsAndDigests(ctx context.Context, tagged []c8dimages.Image) (repoTags []string, repoDigests []string) {
 	repoTags = make([]string, 0, len(tagged))
 	repoDigests = make([]string, 0, len(tagged))
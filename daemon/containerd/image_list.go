@@ -484,6 +484,12 @@ func (i *ImageService) singlePlatformImage(ctx context.Context, contentStore con
 	if cfg.Created != nil {
 		summary.Created = cfg.Created.Unix()
 	}
+	if t := imageTimeLabel(rawImg, imageLabelLastPulledAt); !t.IsZero() {
+		summary.LastPulled = t.Unix()
+	}
+	if t := imageTimeLabel(rawImg, imageLabelLastUsedAt); !t.IsZero() {
+		summary.LastUsed = t.Unix()
+	}
 
 	return summary, nil
 }
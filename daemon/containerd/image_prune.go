@@ -3,7 +3,9 @@ package containerd
 import (
 	"context"
 	"sort"
+	"strconv"
 	"strings"
+	"time"
 
 	c8dimages "github.com/containerd/containerd/v2/core/images"
 	"github.com/containerd/containerd/v2/core/leases"
@@ -22,10 +24,11 @@ import (
 )
 
 var imagesAcceptedFilters = map[string]bool{
-	"dangling": true,
-	"label":    true,
-	"label!":   true,
-	"until":    true,
+	"dangling":       true,
+	"label":          true,
+	"label!":         true,
+	"until":          true,
+	"keep-last-used": true,
 }
 
 // errPruneRunning is returned when a prune request is received while
@@ -56,6 +59,14 @@ func (i *ImageService) ImagesPrune(ctx context.Context, fltrs filters.Args) (*im
 		fltrs.Del("dangling", v)
 	}
 
+	keepLastUsed, err := getKeepLastUsed(fltrs)
+	if err != nil {
+		return nil, err
+	}
+	for _, v := range fltrs.Get("keep-last-used") {
+		fltrs.Del("keep-last-used", v)
+	}
+
 	filterFunc, err := i.setupFilters(ctx, fltrs)
 	if err != nil {
 		return nil, err
@@ -77,7 +88,26 @@ func (i *ImageService) ImagesPrune(ctx context.Context, fltrs filters.Args) (*im
 		}
 	}
 
-	return i.pruneUnused(ctx, filterFunc, danglingOnly)
+	return i.pruneUnused(ctx, filterFunc, danglingOnly, keepLastUsed)
+}
+
+// getKeepLastUsed parses the "keep-last-used" prune filter, which retains
+// the N most recently used images instead of pruning by age. Zero (or an
+// absent filter) keeps nothing.
+func getKeepLastUsed(fltrs filters.Args) (int, error) {
+	values := fltrs.Get("keep-last-used")
+	switch len(values) {
+	case 0:
+		return 0, nil
+	case 1:
+	default:
+		return 0, errdefs.InvalidParameter(errors.New("keep-last-used can be specified only once"))
+	}
+	n, err := strconv.Atoi(values[0])
+	if err != nil || n < 0 {
+		return 0, errdefs.InvalidParameter(errors.Errorf("invalid keep-last-used value: %s", values[0]))
+	}
+	return n, nil
 }
 
 // pruneUnused deletes images that are dangling or unused by any container.
@@ -93,7 +123,7 @@ func (i *ImageService) ImagesPrune(ctx context.Context, fltrs filters.Args) (*im
 //
 // In case a digested and tagged reference was used (e.g. `docker run alpine:latest@sha256:82d1e9d7ed48a7523bdebc18cf6290bdb97b82302a8a9c27d4fe885949ea94d1`),
 // the alpine:latest image will be kept.
-func (i *ImageService) pruneUnused(ctx context.Context, filterFunc imageFilterFunc, danglingOnly bool) (*image.PruneReport, error) {
+func (i *ImageService) pruneUnused(ctx context.Context, filterFunc imageFilterFunc, danglingOnly bool, keepLastUsed int) (*image.PruneReport, error) {
 	ctx, span := tracing.StartSpan(ctx, "ImageService.pruneUnused")
 	span.SetAttributes(tracing.Attribute("danglingOnly", danglingOnly))
 	defer span.End()
@@ -123,6 +153,12 @@ func (i *ImageService) pruneUnused(ctx context.Context, filterFunc imageFilterFu
 		}
 	}
 
+	if keepLastUsed > 0 {
+		for _, name := range mostRecentlyUsedImages(imagesToPrune, keepLastUsed) {
+			delete(imagesToPrune, name)
+		}
+	}
+
 	usedDigests := filterImagesUsedByContainers(ctx, i.containers.List(), imagesToPrune)
 
 	// Sort images by name to make the behavior deterministic and consistent with graphdrivers.
@@ -150,6 +186,39 @@ func (i *ImageService) pruneUnused(ctx context.Context, filterFunc imageFilterFu
 	return i.pruneAll(ctx, imagesToPrune)
 }
 
+// mostRecentlyUsedImages returns the names of the n most recently used
+// images among imgs, ranked by the last-used label recorded when a container
+// is created from an image, falling back to the image record's creation time
+// for images that were never used.
+func mostRecentlyUsedImages(imgs map[string]c8dimages.Image, n int) []string {
+	type imageUse struct {
+		name   string
+		usedAt time.Time
+	}
+	ranked := make([]imageUse, 0, len(imgs))
+	for name, img := range imgs {
+		usedAt := img.CreatedAt
+		if t := imageTimeLabel(img, imageLabelLastUsedAt); !t.IsZero() {
+			usedAt = t
+		}
+		ranked = append(ranked, imageUse{name: name, usedAt: usedAt})
+	}
+	sort.Slice(ranked, func(a, b int) bool {
+		if !ranked[a].usedAt.Equal(ranked[b].usedAt) {
+			return ranked[a].usedAt.After(ranked[b].usedAt)
+		}
+		return ranked[a].name < ranked[b].name
+	})
+	if n > len(ranked) {
+		n = len(ranked)
+	}
+	names := make([]string, 0, n)
+	for _, use := range ranked[:n] {
+		names = append(names, use.name)
+	}
+	return names
+}
+
 // filterImagesUsedByContainers removes image names that are used by containers
 // and returns a map of used image digests.
 func filterImagesUsedByContainers(ctx context.Context,
@@ -0,0 +1,47 @@
+package containerd
+
+import (
+	"testing"
+	"time"
+
+	c8dimages "github.com/containerd/containerd/v2/core/images"
+	"gotest.tools/v3/assert"
+	is "gotest.tools/v3/assert/cmp"
+)
+
+func TestMostRecentlyUsedImages(t *testing.T) {
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	imgs := map[string]c8dimages.Image{
+		// Used yesterday; most recent.
+		"docker.io/library/busybox:latest": {
+			CreatedAt: base,
+			Labels:    map[string]string{imageLabelLastUsedAt: base.AddDate(0, 0, 9).Format(time.RFC3339Nano)},
+		},
+		// Never used; falls back to creation time.
+		"docker.io/library/alpine:latest": {
+			CreatedAt: base.AddDate(0, 0, 5),
+		},
+		// Used once, but long ago.
+		"docker.io/library/debian:latest": {
+			CreatedAt: base,
+			Labels:    map[string]string{imageLabelLastUsedAt: base.AddDate(0, 0, 1).Format(time.RFC3339Nano)},
+		},
+		// Unparsable label; falls back to creation time.
+		"docker.io/library/ubuntu:latest": {
+			CreatedAt: base.AddDate(0, 0, 2),
+			Labels:    map[string]string{imageLabelLastUsedAt: "not-a-timestamp"},
+		},
+	}
+
+	assert.Check(t, is.DeepEqual(mostRecentlyUsedImages(imgs, 0), []string{}))
+	assert.Check(t, is.DeepEqual(mostRecentlyUsedImages(imgs, 2), []string{
+		"docker.io/library/busybox:latest",
+		"docker.io/library/alpine:latest",
+	}))
+	assert.Check(t, is.DeepEqual(mostRecentlyUsedImages(imgs, 10), []string{
+		"docker.io/library/busybox:latest",
+		"docker.io/library/alpine:latest",
+		"docker.io/library/ubuntu:latest",
+		"docker.io/library/debian:latest",
+	}))
+}
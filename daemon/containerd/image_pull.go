@@ -21,6 +21,7 @@ import (
 	"github.com/moby/moby/api/types/events"
 	registrytypes "github.com/moby/moby/api/types/registry"
 	"github.com/moby/moby/v2/daemon/internal/distribution"
+	progressutils "github.com/moby/moby/v2/daemon/internal/distribution/utils"
 	"github.com/moby/moby/v2/daemon/internal/metrics"
 	"github.com/moby/moby/v2/daemon/internal/stringid"
 	"github.com/moby/moby/v2/errdefs"
@@ -31,13 +32,32 @@ import (
 // PullImage initiates a pull operation. baseRef is the image to pull.
 // If reference is not tagged, all tags are pulled.
 func (i *ImageService) PullImage(ctx context.Context, baseRef reference.Named, platform *ocispec.Platform, metaHeaders map[string][]string, authConfig *registrytypes.AuthConfig, outStream io.Writer) (retErr error) {
+	if i.pullGate != nil {
+		if err := i.pullGate(); err != nil {
+			return err
+		}
+	}
+	if platform == nil && i.defaultPullPlatform != nil {
+		platform = i.defaultPullPlatform(baseRef)
+	}
+	if i.admitPull != nil {
+		var err error
+		baseRef, err = i.admitPull(ctx, baseRef, platform)
+		if err != nil {
+			return err
+		}
+	}
 	start := time.Now()
 	defer func() {
 		if retErr == nil {
 			metrics.ImageActions.WithValues("pull").UpdateSince(start)
 		}
+		metrics.RecordImagePull(ctx, time.Since(start), retErr)
 	}()
-	out := streamformatter.NewJSONProgressOutput(outStream, false)
+	out := progressutils.ProgressEventsOutput(streamformatter.NewJSONProgressOutput(outStream, false), i.eventsService, events.ActionPullProgress, reference.FamiliarString(baseRef))
+
+	ctx, opDone := i.transfers.Start(ctx, registrytypes.DistributionOperationPull, reference.FamiliarString(baseRef))
+	defer opDone()
 
 	ctx, done, err := i.withLease(ctx, true)
 	if err != nil {
@@ -124,10 +144,24 @@ func (i *ImageService) pullTag(ctx context.Context, ref reference.Named, platfor
 		return nil, nil
 	})))
 
+	// With lazy pulling, the configured remote snapshotter unpacks layers
+	// directly from the registry and fetches content on demand, so the
+	// container can start before the full image is downloaded.
+	pullSnapshotter := i.snapshotter
+	lazy := i.lazyPullEnabled(ref)
+	if lazy {
+		pullSnapshotter = i.lazyPullSnapshotter
+		log.G(ctx).WithFields(log.Fields{
+			"ref":         ref.String(),
+			"snapshotter": pullSnapshotter,
+		}).Debug("lazy pull enabled for registry")
+	}
+
 	pp := &pullProgress{
 		store:       i.content,
-		snapshotter: i.snapshotterService(i.snapshotter),
+		snapshotter: i.snapshotterService(pullSnapshotter),
 		showExists:  true,
+		lazy:        lazy,
 	}
 	finishProgress := pullJobs.showProgress(ctx, out, pp)
 
@@ -198,7 +232,7 @@ func (i *ImageService) pullTag(ctx context.Context, ref reference.Named, platfor
 
 	opts = append(opts, containerd.WithPullUnpack)
 	// TODO(thaJeztah): we may have to pass the snapshotter to use if the pull is part of a "docker run" (container create -> pull image if missing). See https://github.com/moby/moby/issues/45273
-	opts = append(opts, containerd.WithPullSnapshotter(i.snapshotter))
+	opts = append(opts, containerd.WithPullSnapshotter(pullSnapshotter))
 
 	// AppendInfoHandlerWrapper will annotate the image with basic information like manifest and layer digests as labels;
 	// this information is used to enable remote snapshotters like nydus and stargz to query a registry.
@@ -237,6 +271,10 @@ func (i *ImageService) pullTag(ctx context.Context, ref reference.Named, platfor
 	})
 	logger.Info("image pulled")
 
+	if err := i.markImagePulled(ctx, img.Target().Digest); err != nil {
+		logger.WithError(err).Warn("failed to record image last-pulled time")
+	}
+
 	// The pull succeeded, so try to remove any dangling image we have for this target
 	err = i.images.Delete(context.WithoutCancel(ctx), danglingImageName(img.Target().Digest))
 	if err != nil && !cerrdefs.IsNotFound(err) {
@@ -262,6 +300,16 @@ func writeStatus(out progress.Output, requestedTag string, newerDownloaded bool)
 	}
 }
 
+// lazyPullEnabled reports whether lazy pulling is configured for the
+// registry serving ref.
+func (i *ImageService) lazyPullEnabled(ref reference.Named) bool {
+	if i.lazyPullSnapshotter == "" {
+		return false
+	}
+	_, ok := i.lazyPullRegistries[reference.Domain(ref)]
+	return ok
+}
+
 func isModelMediaType(mediaType string) bool {
 	return strings.HasPrefix(strings.ToLower(mediaType), "application/vnd.docker.ai.")
 }
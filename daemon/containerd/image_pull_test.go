@@ -0,0 +1,38 @@
+package containerd
+
+import (
+	"testing"
+
+	"github.com/distribution/reference"
+	"gotest.tools/v3/assert"
+	is "gotest.tools/v3/assert/cmp"
+)
+
+func TestLazyPullEnabled(t *testing.T) {
+	svc := &ImageService{
+		snapshotter:         "overlayfs",
+		lazyPullSnapshotter: "stargz",
+		lazyPullRegistries: map[string]struct{}{
+			"ghcr.io":          {},
+			"example.com:5000": {},
+		},
+	}
+
+	for ref, expected := range map[string]bool{
+		"ghcr.io/foo/bar:latest":       true,
+		"example.com:5000/foo":         true,
+		"docker.io/library/busybox":    false,
+		"busybox":                      false, // normalizes to docker.io
+		"registry.example.com/foo/bar": false,
+	} {
+		named, err := reference.ParseNormalizedNamed(ref)
+		assert.NilError(t, err)
+		assert.Check(t, is.Equal(svc.lazyPullEnabled(named), expected), ref)
+	}
+
+	// Without a snapshotter configured, lazy pulling is disabled entirely.
+	svc.lazyPullSnapshotter = ""
+	named, err := reference.ParseNormalizedNamed("ghcr.io/foo/bar:latest")
+	assert.NilError(t, err)
+	assert.Check(t, !svc.lazyPullEnabled(named))
+}
@@ -22,7 +22,9 @@ import (
 	"github.com/moby/moby/api/types/auxprogress"
 	"github.com/moby/moby/api/types/events"
 	"github.com/moby/moby/api/types/registry"
+	progressutils "github.com/moby/moby/v2/daemon/internal/distribution/utils"
 	"github.com/moby/moby/v2/daemon/internal/metrics"
+	"github.com/moby/moby/v2/daemon/server/backend"
 	"github.com/moby/moby/v2/errdefs"
 	"github.com/opencontainers/go-digest"
 	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
@@ -40,16 +42,25 @@ import (
 // pointing to the new target repository. This will allow subsequent pushes
 // to perform cross-repo mounts of the shared content when pushing to a different
 // repository on the same registry.
-func (i *ImageService) PushImage(ctx context.Context, sourceRef reference.Named, platform *ocispec.Platform, metaHeaders map[string][]string, authConfig *registry.AuthConfig, outStream io.Writer) (retErr error) {
+func (i *ImageService) PushImage(ctx context.Context, sourceRef reference.Named, platform *ocispec.Platform, metaHeaders map[string][]string, authConfig *registry.AuthConfig, outStream io.Writer, options backend.PushOptions) (retErr error) {
+	if options.Compression == backend.CompressionZstd {
+		// The containerd image store pushes the image's existing blobs
+		// as-is; recompressing them would change their digests and
+		// invalidate the manifest.
+		return errdefs.NotImplemented(errors.New("zstd (re)compression on push is not supported with the containerd image store"))
+	}
 	start := time.Now()
 	defer func() {
 		if retErr == nil {
 			metrics.ImageActions.WithValues("push").UpdateSince(start)
 		}
 	}()
-	out := streamformatter.NewJSONProgressOutput(outStream, false)
+	out := progressutils.ProgressEventsOutput(streamformatter.NewJSONProgressOutput(outStream, false), i.eventsService, events.ActionPushProgress, reference.FamiliarString(sourceRef))
 	progress.Messagef(out, "", "The push refers to repository [%s]", sourceRef.Name())
 
+	ctx, opDone := i.transfers.Start(ctx, registry.DistributionOperationPush, reference.FamiliarString(sourceRef))
+	defer opDone()
+
 	if _, tagged := sourceRef.(reference.Tagged); !tagged {
 		if _, digested := sourceRef.(reference.Digested); !digested {
 			// Image is not tagged nor digested, that means all tags push was requested.
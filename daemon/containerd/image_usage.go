@@ -0,0 +1,92 @@
+package containerd
+
+import (
+	"context"
+	"time"
+
+	c8dimages "github.com/containerd/containerd/v2/core/images"
+	cerrdefs "github.com/containerd/errdefs"
+	"github.com/moby/moby/v2/daemon/internal/image"
+	"github.com/opencontainers/go-digest"
+)
+
+const (
+	// imageLabelLastUsedAt is the image label that records the last time a
+	// container was created from the image, in RFC3339Nano format. It feeds
+	// the "keep-last-used" prune filter.
+	imageLabelLastUsedAt = "org.mobyproject.image.lastUsedAt"
+
+	// imageLabelLastPulledAt is the image label that records the last time
+	// the image was pulled, in RFC3339Nano format.
+	imageLabelLastPulledAt = "org.mobyproject.image.lastPulledAt"
+
+	// imageLabelBuildID is the image label that records the ID of the
+	// build history record that produced the image.
+	imageLabelBuildID = "org.mobyproject.image.buildID"
+)
+
+// MarkImageUsed records the current time as the last time a container was
+// created from the image, on every image record sharing the image's target.
+func (i *ImageService) MarkImageUsed(ctx context.Context, id image.ID) error {
+	return i.setImageTimeLabel(ctx, id.Digest(), imageLabelLastUsedAt)
+}
+
+// markImagePulled records the current time as the last time the image was
+// pulled, on every image record sharing the target digest.
+func (i *ImageService) markImagePulled(ctx context.Context, dgst digest.Digest) error {
+	return i.setImageTimeLabel(ctx, dgst, imageLabelLastPulledAt)
+}
+
+// SetImageBuildID records the ID of the build history record that produced
+// the image, on every image record sharing the image's target.
+func (i *ImageService) SetImageBuildID(ctx context.Context, id image.ID, buildID string) error {
+	return i.setImageLabel(ctx, id.Digest(), imageLabelBuildID, buildID)
+}
+
+// GetImageBuildID returns the ID of the build history record that produced
+// the image, or an empty string if the image wasn't built by the embedded
+// BuildKit.
+func (i *ImageService) GetImageBuildID(ctx context.Context, id image.ID) (string, error) {
+	imgs, err := i.images.List(ctx, "target.digest=="+id.Digest().String())
+	if err != nil {
+		return "", err
+	}
+	for _, img := range imgs {
+		if buildID, ok := img.Labels[imageLabelBuildID]; ok {
+			return buildID, nil
+		}
+	}
+	return "", nil
+}
+
+func (i *ImageService) setImageTimeLabel(ctx context.Context, dgst digest.Digest, key string) error {
+	return i.setImageLabel(ctx, dgst, key, time.Now().UTC().Format(time.RFC3339Nano))
+}
+
+func (i *ImageService) setImageLabel(ctx context.Context, dgst digest.Digest, key, value string) error {
+	imgs, err := i.images.List(ctx, "target.digest=="+dgst.String())
+	if err != nil {
+		return err
+	}
+	for _, img := range imgs {
+		if img.Labels == nil {
+			img.Labels = map[string]string{}
+		}
+		img.Labels[key] = value
+		if _, err := i.images.Update(ctx, img, "labels."+key); err != nil && !cerrdefs.IsNotFound(err) {
+			return err
+		}
+	}
+	return nil
+}
+
+// imageTimeLabel returns the time recorded in the named label of the image
+// record, or the zero time if the label is absent or malformed.
+func imageTimeLabel(img c8dimages.Image, key string) time.Time {
+	if v, ok := img.Labels[key]; ok {
+		if t, err := time.Parse(time.RFC3339Nano, v); err == nil {
+			return t
+		}
+	}
+	return time.Time{}
+}
@@ -117,6 +117,10 @@ type pullProgress struct {
 	snapshotter snapshots.Snapshotter
 	layers      []ocispec.Descriptor
 	unpackStart map[digest.Digest]time.Time
+
+	// lazy indicates the pull uses a remote snapshotter that may commit
+	// layer snapshots without downloading their content.
+	lazy bool
 }
 
 func (p *pullProgress) UpdateProgress(ctx context.Context, ongoing *jobs, out progress.Output, start time.Time) error {
@@ -159,6 +163,20 @@ func (p *pullProgress) UpdateProgress(ctx context.Context, ongoing *jobs, out pr
 			if !cerrdefs.IsNotFound(err) {
 				return err
 			}
+			// With a remote snapshotter the layer content may never reach
+			// the content store; the snapshot is populated directly from
+			// the registry. Track the snapshot instead of the blob.
+			if p.lazy && c8dimages.IsLayerType(j.MediaType) {
+				if _, err := findMatchingSnapshot(ctx, p.snapshotter, j); err == nil {
+					out.WriteProgress(progress.Progress{
+						ID:         stringid.TruncateID(j.Digest.Encoded()),
+						Action:     "Download deferred",
+						HideCounts: true,
+					})
+					p.finished(ctx, out, j)
+					ongoing.Remove(j)
+				}
+			}
 		} else if info.CreatedAt.After(start) {
 			out.WriteProgress(progress.Progress{
 				ID:         stringid.TruncateID(j.Digest.Encoded()),
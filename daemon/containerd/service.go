@@ -3,6 +3,7 @@ package containerd
 import (
 	"context"
 	"fmt"
+	"sort"
 	"sync/atomic"
 
 	containerd "github.com/containerd/containerd/v2/client"
@@ -14,10 +15,14 @@ import (
 	cerrdefs "github.com/containerd/errdefs"
 	"github.com/containerd/log"
 	"github.com/containerd/platforms"
+	"github.com/distribution/reference"
+	"github.com/moby/moby/api/types/registry"
+	systemtype "github.com/moby/moby/api/types/system"
 	"github.com/moby/moby/v2/daemon/container"
 	daemonevents "github.com/moby/moby/v2/daemon/events"
 	dimages "github.com/moby/moby/v2/daemon/images"
 	"github.com/moby/moby/v2/daemon/internal/distribution"
+	"github.com/moby/moby/v2/daemon/internal/transferops"
 	"github.com/moby/moby/v2/daemon/snapshotter"
 	"github.com/moby/moby/v2/errdefs"
 	"github.com/moby/sys/user"
@@ -36,10 +41,17 @@ type ImageService struct {
 	snapshotter         string
 	registryHosts       docker.RegistryHosts
 	registryService     distribution.RegistryResolver
+	referrers           distribution.ReferrersCache
 	eventsService       *daemonevents.Events
 	pruneRunning        atomic.Bool
 	refCountMounter     snapshotter.Mounter
 	idMapping           user.IdentityMapping
+	pullGate            func() error
+	defaultPullPlatform func(reference.Named) *ocispec.Platform
+	admitPull           func(context.Context, reference.Named, *ocispec.Platform) (reference.Named, error)
+	lazyPullSnapshotter string
+	lazyPullRegistries  map[string]struct{}
+	transfers           *transferops.Tracker
 
 	// defaultPlatformOverride is used in tests to override the host platform.
 	defaultPlatformOverride platforms.MatchComparer
@@ -54,10 +66,36 @@ type ImageServiceConfig struct {
 	EventsService   *daemonevents.Events
 	RefCountMounter snapshotter.Mounter
 	IDMapping       user.IdentityMapping
+	// PullGate, when set, is consulted before starting a pull; a non-nil
+	// error aborts the pull. It is used to reject pulls while the daemon
+	// is in maintenance mode.
+	PullGate func() error
+	// DefaultPullPlatform, when set, resolves the configured default
+	// platform for pulls of the given reference. It is consulted when a
+	// pull does not request an explicit platform; a nil result means the
+	// host platform.
+	DefaultPullPlatform func(reference.Named) *ocispec.Platform
+	// AdmitPull, when set, submits a pull to the daemon's admission
+	// controller before it starts. It may return a different reference
+	// to pull instead (e.g. pinned to a digest), or an error to deny
+	// the pull.
+	AdmitPull func(context.Context, reference.Named, *ocispec.Platform) (reference.Named, error)
+	// LazyPullSnapshotter is the name of the remote snapshotter used for
+	// lazy pulls from the registries in LazyPullRegistries. The snapshotter
+	// must be registered with containerd (e.g. stargz-store as a proxy
+	// plugin). Empty disables lazy pulling.
+	LazyPullSnapshotter string
+	// LazyPullRegistries lists the registry hosts for which lazy pulling
+	// is enabled.
+	LazyPullRegistries []string
 }
 
 // NewService creates a new ImageService.
 func NewService(config ImageServiceConfig) *ImageService {
+	lazyPullRegistries := make(map[string]struct{}, len(config.LazyPullRegistries))
+	for _, host := range config.LazyPullRegistries {
+		lazyPullRegistries[host] = struct{}{}
+	}
 	return &ImageService{
 		client:  config.Client,
 		images:  config.Client.ImageService(),
@@ -65,13 +103,19 @@ func NewService(config ImageServiceConfig) *ImageService {
 		snapshotterServices: map[string]snapshots.Snapshotter{
 			config.Snapshotter: config.Client.SnapshotService(config.Snapshotter),
 		},
-		containers:      config.Containers,
-		snapshotter:     config.Snapshotter,
-		registryHosts:   config.RegistryHosts,
-		registryService: config.Registry,
-		eventsService:   config.EventsService,
-		refCountMounter: config.RefCountMounter,
-		idMapping:       config.IDMapping,
+		containers:          config.Containers,
+		snapshotter:         config.Snapshotter,
+		registryHosts:       config.RegistryHosts,
+		registryService:     config.Registry,
+		eventsService:       config.EventsService,
+		refCountMounter:     config.RefCountMounter,
+		idMapping:           config.IDMapping,
+		pullGate:            config.PullGate,
+		defaultPullPlatform: config.DefaultPullPlatform,
+		admitPull:           config.AdmitPull,
+		lazyPullSnapshotter: config.LazyPullSnapshotter,
+		lazyPullRegistries:  lazyPullRegistries,
+		transfers:           transferops.NewTracker(),
 	}
 }
 
@@ -176,6 +220,65 @@ func (i *ImageService) layerDiskUsage(ctx context.Context) (allLayersSize int64,
 	return allLayersSize, err
 }
 
+// StorageDiskUsage returns detailed usage of the containerd stores backing
+// the image service: per-snapshotter snapshot counts and sizes, the bytes
+// saved by snapshots shared between images, and content-store blob counts.
+// called from disk_usage.go
+func (i *ImageService) StorageDiskUsage(ctx context.Context) (*systemtype.StorageUsage, error) {
+	usage := &systemtype.StorageUsage{}
+
+	names := make([]string, 0, len(i.snapshotterServices))
+	for name := range i.snapshotterServices {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		sn := i.snapshotterService(name)
+		su := systemtype.SnapshotterUsage{Name: name}
+		var (
+			sizes    = map[string]int64{}
+			children = map[string]int{}
+		)
+		err := sn.Walk(ctx, func(ctx context.Context, info snapshots.Info) error {
+			u, err := sn.Usage(ctx, info.Name)
+			if err != nil {
+				return err
+			}
+			su.Snapshots++
+			su.Size += u.Size
+			sizes[info.Name] = u.Size
+			if info.Parent != "" {
+				children[info.Parent]++
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+		// A snapshot with multiple children is stored once but used by
+		// multiple chains; without deduplication each extra child would
+		// need its own copy.
+		for name, refs := range children {
+			if refs > 1 {
+				usage.SharedSize += int64(refs-1) * sizes[name]
+				su.SharedSize += sizes[name]
+			}
+		}
+		su.UniqueSize = su.Size - su.SharedSize
+		usage.Snapshotters = append(usage.Snapshotters, su)
+	}
+
+	if err := i.content.Walk(ctx, func(info content.Info) error {
+		usage.Blobs++
+		usage.BlobsSize += info.Size
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	return usage, nil
+}
+
 // UpdateConfig values
 //
 // called from reload.go
@@ -183,6 +286,18 @@ func (i *ImageService) UpdateConfig(maxDownloads, maxUploads int) {
 	log.G(context.TODO()).Warn("max downloads and uploads is not yet implemented with the containerd store")
 }
 
+// DistributionOperations returns the in-flight image pulls and pushes,
+// oldest first.
+func (i *ImageService) DistributionOperations() []registry.DistributionOperation {
+	return i.transfers.List()
+}
+
+// CancelDistributionOperation cancels the in-flight image pull or push with
+// the given ID.
+func (i *ImageService) CancelDistributionOperation(id string) error {
+	return i.transfers.Cancel(id)
+}
+
 // GetContainerLayerSize returns the real size & virtual size of the container.
 func (i *ImageService) GetContainerLayerSize(ctx context.Context, containerID string) (int64, int64, error) {
 	ctr := i.containers.Get(containerID)
@@ -0,0 +1,169 @@
+package daemon
+
+import (
+	"context"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/containerd/log"
+	"github.com/moby/moby/api/types/events"
+	systemtypes "github.com/moby/moby/api/types/system"
+	"github.com/moby/moby/v2/daemon/config"
+	"github.com/moby/moby/v2/errdefs"
+	"github.com/pkg/errors"
+)
+
+const (
+	// containerdHealthInterval is how often the containerd connection is
+	// health-checked while it is believed to be up.
+	containerdHealthInterval = 10 * time.Second
+	// containerdReconnectInterval is how often a reconnect is attempted
+	// once the connection is known to be down.
+	containerdReconnectInterval = time.Second
+	// containerdHealthTimeout bounds a single health-check RPC.
+	containerdHealthTimeout = 5 * time.Second
+	// containerdWaitTimeout bounds how long an API call queues for the
+	// connection to come back when containerd-unavailable-action is "wait".
+	containerdWaitTimeout = 10 * time.Second
+)
+
+// containerdHealthMonitor tracks the health of the daemon's connection to
+// containerd. It periodically health-checks the connection, logs daemon
+// events on state transitions, reports the current state through
+// `docker info`, and lets API calls that require containerd either fail
+// fast or queue briefly while the connection is down.
+type containerdHealthMonitor struct {
+	daemon *Daemon
+
+	mu          sync.Mutex
+	connected   bool
+	since       time.Time
+	attempts    int
+	reconnected chan struct{} // closed when the connection is restored
+	stop        chan struct{}
+	stopOnce    sync.Once
+}
+
+// newContainerdHealthMonitor creates a monitor for the daemon's containerd
+// connection and starts its health-check loop.
+func newContainerdHealthMonitor(daemon *Daemon) *containerdHealthMonitor {
+	m := &containerdHealthMonitor{
+		daemon:      daemon,
+		connected:   true,
+		since:       time.Now().UTC(),
+		reconnected: make(chan struct{}),
+		stop:        make(chan struct{}),
+	}
+	go m.run()
+	return m
+}
+
+func (m *containerdHealthMonitor) run() {
+	for {
+		interval := containerdHealthInterval
+		m.mu.Lock()
+		if !m.connected {
+			interval = containerdReconnectInterval
+		}
+		m.mu.Unlock()
+
+		select {
+		case <-m.stop:
+			return
+		case <-time.After(interval):
+		}
+		m.check()
+	}
+}
+
+func (m *containerdHealthMonitor) check() {
+	ctx, cancel := context.WithTimeout(context.Background(), containerdHealthTimeout)
+	serving, err := m.daemon.containerdClient.IsServing(ctx)
+	cancel()
+	healthy := err == nil && serving
+
+	m.mu.Lock()
+	switch {
+	case healthy && !m.connected:
+		attempts := m.attempts
+		m.connected = true
+		m.since = time.Now().UTC()
+		m.attempts = 0
+		close(m.reconnected)
+		m.reconnected = make(chan struct{})
+		m.mu.Unlock()
+		log.G(context.TODO()).WithField("attempts", attempts).Info("Connection to containerd restored")
+		m.daemon.LogDaemonEventWithAttributes(events.ActionConnect, map[string]string{
+			"component": "containerd",
+			"attempts":  strconv.Itoa(attempts),
+		})
+	case !healthy && m.connected:
+		m.connected = false
+		m.since = time.Now().UTC()
+		m.attempts = 1
+		m.mu.Unlock()
+		log.G(context.TODO()).WithError(err).Warn("Connection to containerd lost; reconnecting")
+		m.daemon.LogDaemonEventWithAttributes(events.ActionDisconnect, map[string]string{
+			"component": "containerd",
+		})
+	case !healthy:
+		m.attempts++
+		m.mu.Unlock()
+	default:
+		m.mu.Unlock()
+	}
+}
+
+// Health reports the current state of the containerd connection.
+func (m *containerdHealthMonitor) Health() *systemtypes.ContainerdHealth {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return &systemtypes.ContainerdHealth{
+		Connected:         m.connected,
+		Since:             m.since,
+		ReconnectAttempts: m.attempts,
+	}
+}
+
+// WaitConnected gates an operation that requires a live containerd
+// connection. When the connection is down it either fails fast with an
+// [errdefs.Unavailable] error, or (with containerd-unavailable-action set
+// to "wait") queues briefly for the connection to be restored.
+func (m *containerdHealthMonitor) WaitConnected(ctx context.Context, action string) error {
+	m.mu.Lock()
+	if m.connected {
+		m.mu.Unlock()
+		return nil
+	}
+	reconnected := m.reconnected
+	m.mu.Unlock()
+
+	if action != config.ContainerdUnavailableWait {
+		return errdefs.Unavailable(errors.New("connection to containerd is down"))
+	}
+
+	select {
+	case <-reconnected:
+		return nil
+	case <-time.After(containerdWaitTimeout):
+		return errdefs.Unavailable(errors.New("timed out waiting for connection to containerd to be restored"))
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Close stops the health-check loop.
+func (m *containerdHealthMonitor) Close() {
+	m.stopOnce.Do(func() { close(m.stop) })
+}
+
+// waitContainerdAvailable blocks or fails according to the configured
+// containerd-unavailable-action when the connection to containerd is down.
+// It is a no-op when the daemon manages no containerd connection.
+func (daemon *Daemon) waitContainerdAvailable(ctx context.Context, cfg *config.Config) error {
+	if daemon.containerdHealth == nil {
+		return nil
+	}
+	return daemon.containerdHealth.WaitConnected(ctx, cfg.ContainerdUnavailableAction)
+}
@@ -0,0 +1,65 @@
+package daemon
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	cerrdefs "github.com/containerd/errdefs"
+	"github.com/moby/moby/v2/daemon/config"
+	"gotest.tools/v3/assert"
+	is "gotest.tools/v3/assert/cmp"
+)
+
+// newTestHealthMonitor builds a monitor in the given state without starting
+// the health-check loop, so the gating logic can be exercised directly.
+func newTestHealthMonitor(connected bool) *containerdHealthMonitor {
+	return &containerdHealthMonitor{
+		connected:   connected,
+		since:       time.Now().UTC(),
+		reconnected: make(chan struct{}),
+		stop:        make(chan struct{}),
+	}
+}
+
+func TestContainerdHealthWaitConnected(t *testing.T) {
+	t.Run("connected", func(t *testing.T) {
+		m := newTestHealthMonitor(true)
+		assert.NilError(t, m.WaitConnected(context.Background(), config.ContainerdUnavailableFailFast))
+	})
+
+	t.Run("disconnected fails fast", func(t *testing.T) {
+		m := newTestHealthMonitor(false)
+		err := m.WaitConnected(context.Background(), config.ContainerdUnavailableFailFast)
+		assert.Check(t, is.ErrorContains(err, "connection to containerd is down"))
+		assert.Check(t, cerrdefs.IsUnavailable(err))
+	})
+
+	t.Run("wait resumes on reconnect", func(t *testing.T) {
+		m := newTestHealthMonitor(false)
+		reconnected := m.reconnected
+		go func() {
+			time.Sleep(10 * time.Millisecond)
+			close(reconnected)
+		}()
+		assert.NilError(t, m.WaitConnected(context.Background(), config.ContainerdUnavailableWait))
+	})
+
+	t.Run("wait respects context", func(t *testing.T) {
+		m := newTestHealthMonitor(false)
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		err := m.WaitConnected(ctx, config.ContainerdUnavailableWait)
+		assert.Check(t, is.ErrorIs(err, context.Canceled))
+	})
+}
+
+func TestContainerdHealthReport(t *testing.T) {
+	m := newTestHealthMonitor(false)
+	m.attempts = 3
+
+	health := m.Health()
+	assert.Check(t, !health.Connected)
+	assert.Check(t, is.Equal(health.ReconnectAttempts, 3))
+	assert.Check(t, is.Equal(health.Since, m.since))
+}
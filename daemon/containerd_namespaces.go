@@ -0,0 +1,60 @@
+package daemon
+
+import (
+	"context"
+
+	"github.com/containerd/log"
+	"github.com/moby/moby/v2/daemon/config"
+	"github.com/moby/moby/v2/daemon/container"
+	libcontainerdtypes "github.com/moby/moby/v2/daemon/internal/libcontainerd/types"
+	"github.com/moby/moby/v2/errdefs"
+	"github.com/pkg/errors"
+)
+
+// tenantLabel is the container label used to select the tenant (and through
+// the containerd-tenant-namespaces daemon configuration, the containerd
+// namespace) a container is created in.
+const tenantLabel = "com.docker.tenant"
+
+// resolveTenantNamespace maps the tenant label in the given container labels
+// to a containerd namespace using the containerd-tenant-namespaces daemon
+// configuration. It returns an empty string when no tenant is requested, so
+// the container lands in the daemon's default namespace.
+func (daemon *Daemon) resolveTenantNamespace(cfg *config.Config, labels map[string]string) (string, error) {
+	tenant := labels[tenantLabel]
+	if tenant == "" {
+		return "", nil
+	}
+	ns, ok := cfg.ContainerdTenantNamespaces[tenant]
+	if !ok {
+		return "", errdefs.InvalidParameter(errors.Errorf("unknown tenant %q: not present in containerd-tenant-namespaces", tenant))
+	}
+	return ns, nil
+}
+
+// containerdFor returns the libcontainerd client for the containerd
+// namespace the given container lives in, lazily creating a client per
+// tenant namespace. Containers without a tenant namespace use the daemon's
+// default client.
+func (daemon *Daemon) containerdFor(c *container.Container) (libcontainerdtypes.Client, error) {
+	ns := c.ContainerdNamespace
+	if ns == "" {
+		return daemon.containerd, nil
+	}
+
+	daemon.containerdNSMu.Lock()
+	defer daemon.containerdNSMu.Unlock()
+	if cli, ok := daemon.containerdNSClients[ns]; ok {
+		return cli, nil
+	}
+	cli, err := daemon.newLibcontainerdClient(context.TODO(), &daemon.config().Config, ns)
+	if err != nil {
+		return nil, errors.Wrapf(err, "error creating containerd client for namespace %s", ns)
+	}
+	if daemon.containerdNSClients == nil {
+		daemon.containerdNSClients = make(map[string]libcontainerdtypes.Client)
+	}
+	daemon.containerdNSClients[ns] = cli
+	log.G(context.TODO()).WithField("namespace", ns).Debug("created containerd client for tenant namespace")
+	return cli, nil
+}
@@ -0,0 +1,180 @@
+package daemon
+
+import (
+	"context"
+	"math"
+	"runtime"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/containerd/log"
+	containertypes "github.com/moby/moby/api/types/container"
+	eventtypes "github.com/moby/moby/api/types/events"
+	"github.com/moby/moby/v2/daemon/container"
+)
+
+const (
+	// cpuRebalanceLabel marks a container as eligible for automatic cpuset
+	// rebalancing.
+	cpuRebalanceLabel = "com.docker.cpu-rebalance"
+
+	// cpuRebalanceFeature is the daemon feature flag ("features" in
+	// daemon.json) that enables the rebalancing service.
+	cpuRebalanceFeature = "cpu-rebalance"
+
+	// cpuRebalanceInterval is how often container CPU utilization is
+	// sampled and cpuset assignments are recomputed.
+	cpuRebalanceInterval = time.Minute
+)
+
+// cpuRebalanceCandidate is a container taking part in a rebalancing round,
+// with the CPUs worth of usage it was observed consuming over the last
+// sampling interval.
+type cpuRebalanceCandidate struct {
+	id    string
+	usage float64
+}
+
+// cpuRebalanceLoop periodically redistributes the cpusets of running
+// containers carrying the rebalancing label, so that busy containers end up
+// on CPUs of their own. The service is a no-op unless the "cpu-rebalance"
+// feature is enabled in the daemon configuration. The loop runs until the
+// daemon shuts down.
+func (daemon *Daemon) cpuRebalanceLoop() {
+	if runtime.GOOS == "windows" {
+		// Rebalancing drives cpuset.cpus, which has no Windows equivalent.
+		return
+	}
+	ticker := time.NewTicker(cpuRebalanceInterval)
+	defer ticker.Stop()
+
+	prevUsage := make(map[string]uint64)
+	for {
+		select {
+		case <-daemon.shutdownStarted:
+			return
+		case <-ticker.C:
+		}
+		if !daemon.config().Features[cpuRebalanceFeature] {
+			continue
+		}
+		daemon.rebalanceCPUSets(prevUsage)
+	}
+}
+
+// rebalanceCPUSets samples the CPU usage of the labelled containers,
+// computes a fresh cpuset layout and applies it through the regular update
+// path, emitting an event for every container whose assignment changed.
+// prevUsage carries the cumulative CPU usage counters from the previous
+// round and is updated in place.
+func (daemon *Daemon) rebalanceCPUSets(prevUsage map[string]uint64) {
+	var candidates []cpuRebalanceCandidate
+	ctrs := make(map[string]*containerForRebalance)
+	seen := make(map[string]struct{})
+
+	for _, ctr := range daemon.List() {
+		if ctr.Config.Labels[cpuRebalanceLabel] != "true" || !ctr.IsRunning() {
+			continue
+		}
+		st, err := daemon.stats(ctr)
+		if err != nil {
+			log.G(context.TODO()).WithError(err).WithField("container", ctr.ID).Debug("CPU rebalance: failed to collect stats")
+			continue
+		}
+		total := st.CPUStats.CPUUsage.TotalUsage
+		seen[ctr.ID] = struct{}{}
+		prev, ok := prevUsage[ctr.ID]
+		prevUsage[ctr.ID] = total
+		if !ok || total < prev {
+			// First sample, or the counters were reset by a restart;
+			// nothing to compare against yet.
+			continue
+		}
+		candidates = append(candidates, cpuRebalanceCandidate{
+			id:    ctr.ID,
+			usage: float64(total-prev) / float64(cpuRebalanceInterval.Nanoseconds()),
+		})
+		ctrs[ctr.ID] = &containerForRebalance{ctr: ctr, cpuset: ctr.HostConfig.CpusetCpus}
+	}
+	// Drop counters of containers that stopped or lost the label.
+	for id := range prevUsage {
+		if _, ok := seen[id]; !ok {
+			delete(prevUsage, id)
+		}
+	}
+	if len(candidates) == 0 {
+		return
+	}
+
+	for id, cpuset := range assignCPUSets(runtime.NumCPU(), candidates) {
+		c := ctrs[id]
+		if cpuset == c.cpuset {
+			continue
+		}
+		if _, err := daemon.ContainerUpdate(id, &containertypes.HostConfig{
+			Resources: containertypes.Resources{CpusetCpus: cpuset},
+		}); err != nil {
+			log.G(context.TODO()).WithError(err).WithField("container", id).Warn("CPU rebalance: failed to update cpuset")
+			continue
+		}
+		daemon.LogContainerEventWithAttributes(c.ctr, eventtypes.ActionCPURebalance, map[string]string{
+			"cpuset.old": c.cpuset,
+			"cpuset.new": cpuset,
+		})
+	}
+}
+
+type containerForRebalance struct {
+	ctr    *container.Container
+	cpuset string
+}
+
+// assignCPUSets packs the candidates onto the host's CPUs, heaviest first,
+// giving each an exclusive share sized after its observed utilization. When
+// the total demand exceeds the number of host CPUs the shares are scaled
+// down and CPUs are shared round-robin.
+func assignCPUSets(numCPU int, candidates []cpuRebalanceCandidate) map[string]string {
+	if numCPU <= 0 || len(candidates) == 0 {
+		return nil
+	}
+
+	sorted := append([]cpuRebalanceCandidate(nil), candidates...)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].usage > sorted[j].usage })
+
+	want := make([]int, len(sorted))
+	total := 0
+	for i, cand := range sorted {
+		w := int(math.Ceil(cand.usage))
+		if w < 1 {
+			w = 1
+		}
+		if w > numCPU {
+			w = numCPU
+		}
+		want[i] = w
+		total += w
+	}
+	if total > numCPU {
+		for i := range want {
+			if w := want[i] * numCPU / total; w > 1 {
+				want[i] = w
+			} else {
+				want[i] = 1
+			}
+		}
+	}
+
+	out := make(map[string]string, len(sorted))
+	next := 0
+	for i, cand := range sorted {
+		cpus := make([]string, want[i])
+		for j := range cpus {
+			cpus[j] = strconv.Itoa(next % numCPU)
+			next++
+		}
+		out[cand.id] = strings.Join(cpus, ",")
+	}
+	return out
+}
@@ -0,0 +1,36 @@
+package daemon
+
+import (
+	"testing"
+
+	"gotest.tools/v3/assert"
+	is "gotest.tools/v3/assert/cmp"
+)
+
+func TestAssignCPUSets(t *testing.T) {
+	t.Run("exclusive shares sized after usage", func(t *testing.T) {
+		out := assignCPUSets(4, []cpuRebalanceCandidate{
+			{id: "light", usage: 0.2},
+			{id: "heavy", usage: 2.5},
+		})
+		// The heavy container is laid out first and gets three CPUs of
+		// its own; the light one gets the remaining CPU.
+		assert.Check(t, is.Equal(out["heavy"], "0,1,2"))
+		assert.Check(t, is.Equal(out["light"], "3"))
+	})
+
+	t.Run("demand exceeding host CPUs wraps around", func(t *testing.T) {
+		out := assignCPUSets(2, []cpuRebalanceCandidate{
+			{id: "a", usage: 1.5},
+			{id: "b", usage: 1.2},
+			{id: "c", usage: 0.1},
+		})
+		assert.Check(t, is.Equal(out["a"], "0"))
+		assert.Check(t, is.Equal(out["b"], "1"))
+		assert.Check(t, is.Equal(out["c"], "0"))
+	})
+
+	t.Run("no candidates", func(t *testing.T) {
+		assert.Check(t, is.Nil(assignCPUSets(4, nil)))
+	})
+}
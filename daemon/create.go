@@ -9,10 +9,12 @@ import (
 	"sync"
 	"time"
 
+	continuityfs "github.com/containerd/continuity/fs"
 	"github.com/containerd/log"
 	"github.com/containerd/platforms"
 	containertypes "github.com/moby/moby/api/types/container"
 	"github.com/moby/moby/api/types/events"
+	mounttypes "github.com/moby/moby/api/types/mount"
 	networktypes "github.com/moby/moby/api/types/network"
 	"github.com/moby/moby/v2/daemon/config"
 	"github.com/moby/moby/v2/daemon/container"
@@ -22,6 +24,7 @@ import (
 	"github.com/moby/moby/v2/daemon/internal/multierror"
 	"github.com/moby/moby/v2/daemon/internal/otelutil"
 	"github.com/moby/moby/v2/daemon/server/backend"
+	"github.com/moby/moby/v2/daemon/volume"
 	"github.com/moby/moby/v2/errdefs"
 	"github.com/moby/sys/user"
 	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
@@ -62,6 +65,101 @@ func (daemon *Daemon) ContainerCreateIgnoreImagesArgsEscaped(ctx context.Context
 	})
 }
 
+// ContainerClone creates a new stopped container from the named container's
+// config and host config. Named volumes are shared with the source container;
+// anonymous volumes get fresh volumes for the clone, optionally populated
+// with a copy of the source's data.
+func (daemon *Daemon) ContainerClone(ctx context.Context, name string, options containertypes.CloneOptions) (containertypes.CreateResponse, error) {
+	source, err := daemon.GetContainer(name)
+	if err != nil {
+		return containertypes.CreateResponse{}, err
+	}
+
+	// Shallow copies are enough: ContainerCreate builds a fresh container
+	// from the values and does not retain the structs.
+	config := *source.Config
+	hostConfig := *source.HostConfig
+	if options.Env != nil {
+		config.Env = options.Env
+	}
+	if options.Cmd != nil {
+		config.Cmd = options.Cmd
+	}
+	// The stored hostname defaults to the source's ID; reset it so the
+	// clone derives its own.
+	config.Hostname = ""
+
+	imgPlatform := source.ImagePlatform
+	created, err := daemon.ContainerCreate(ctx, backend.ContainerCreateConfig{
+		Name:       options.Name,
+		Config:     &config,
+		HostConfig: &hostConfig,
+		Platform:   &imgPlatform,
+	})
+	if err != nil {
+		return created, err
+	}
+
+	if options.CopyVolumes {
+		clone, err := daemon.GetContainer(created.ID)
+		if err != nil {
+			return created, err
+		}
+		if err := daemon.copyAnonymousVolumes(ctx, source, clone); err != nil {
+			if rmErr := daemon.ContainerRm(created.ID, &backend.ContainerRmConfig{ForceRemove: true, RemoveVolume: true}); rmErr != nil {
+				log.G(ctx).WithError(rmErr).WithField("container", created.ID).Warn("Failed to remove clone after volume copy error")
+			}
+			return containertypes.CreateResponse{}, err
+		}
+	}
+	return created, nil
+}
+
+// copyAnonymousVolumes copies the contents of the source container's
+// anonymous volumes into the clone's anonymous volumes mounted at the same
+// destinations.
+func (daemon *Daemon) copyAnonymousVolumes(ctx context.Context, source, clone *container.Container) error {
+	for dest, mp := range clone.MountPoints {
+		// Named mountpoints (`docker run -v <name>:/foo`) are shared with
+		// the source instead of copied.
+		if mp.Type != mounttypes.TypeVolume || mp.Volume == nil || mp.Spec.Source != "" {
+			continue
+		}
+		srcMP, ok := source.MountPoints[dest]
+		if !ok || srcMP.Type != mounttypes.TypeVolume || srcMP.Volume == nil {
+			continue
+		}
+		if err := daemon.copyVolumeContent(ctx, srcMP.Volume, mp.Volume, clone.ID); err != nil {
+			return fmt.Errorf("failed to copy volume for %s: %w", dest, err)
+		}
+	}
+	return nil
+}
+
+// copyVolumeContent mounts both volumes under the given reference and copies
+// the source volume's file tree into the destination volume.
+func (daemon *Daemon) copyVolumeContent(ctx context.Context, src, dst volume.Volume, ref string) error {
+	srcPath, err := src.Mount(ref)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := src.Unmount(ref); err != nil {
+			log.G(ctx).WithError(err).WithField("volume", src.Name()).Warn("Failed to unmount source volume after copy")
+		}
+	}()
+	dstPath, err := dst.Mount(ref)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := dst.Unmount(ref); err != nil {
+			log.G(ctx).WithError(err).WithField("volume", dst.Name()).Warn("Failed to unmount volume after copy")
+		}
+	}()
+	return continuityfs.CopyDir(dstPath, srcPath)
+}
+
 func (daemon *Daemon) containerCreate(ctx context.Context, daemonCfg *configStore, opts createOpts) (_ containertypes.CreateResponse, retErr error) {
 	ctx, span := otel.Tracer("").Start(ctx, "daemon.containerCreate", trace.WithAttributes(
 		labelsAsOTelAttributes(opts.params.Config.Labels)...,
@@ -72,9 +170,25 @@ func (daemon *Daemon) containerCreate(ctx context.Context, daemonCfg *configStor
 	}()
 
 	start := time.Now()
+	if err := daemon.maintenanceError(); err != nil {
+		return containertypes.CreateResponse{}, err
+	}
 	if opts.params.Config == nil {
 		return containertypes.CreateResponse{}, errdefs.InvalidParameter(errors.New("config cannot be empty in order to create a container"))
 	}
+	if err := daemon.checkContainerQuota(ctx, &daemonCfg.Config, &opts.params); err != nil {
+		return containertypes.CreateResponse{}, err
+	}
+	if err := resolveKVFiles(&opts.params); err != nil {
+		return containertypes.CreateResponse{}, err
+	}
+	if opts.params.Config.Image != "" {
+		img, err := daemon.admitCreate(ctx, opts.params.Config.Image, opts.params.Platform)
+		if err != nil {
+			return containertypes.CreateResponse{}, err
+		}
+		opts.params.Config.Image = img
+	}
 
 	// Normalize some defaults. Doing this "ad-hoc" here for now, as there's
 	// only one field to migrate, but we should consider having a better
@@ -168,6 +282,7 @@ func (daemon *Daemon) create(ctx context.Context, daemonCfg *config.Config, opts
 		img         *image.Image
 		imgManifest *ocispec.Descriptor
 		imgID       image.ID
+		imgDigest   string
 		err         error
 		platform    = platforms.DefaultSpec()
 	)
@@ -182,6 +297,14 @@ func (daemon *Daemon) create(ctx context.Context, daemonCfg *config.Config, opts
 		}
 		platform = img.Platform()
 		imgID = img.ID()
+		if opts.params.PinDigest {
+			imgDigest, err = daemon.imageService.ResolveDigestReference(ctx, opts.params.Config.Image)
+			if err != nil {
+				return nil, err
+			}
+		}
+	} else if opts.params.PinDigest {
+		return nil, errdefs.InvalidParameter(errors.New("digest pinning requires an image"))
 	} else if isWindows {
 		platform.OS = "linux" // 'scratch' case.
 	}
@@ -202,9 +325,15 @@ func (daemon *Daemon) create(ctx context.Context, daemonCfg *config.Config, opts
 		return nil, errdefs.InvalidParameter(err)
 	}
 
+	tenantNS, err := daemon.resolveTenantNamespace(daemonCfg, opts.params.Config.Labels)
+	if err != nil {
+		return nil, err
+	}
+
 	if ctr, err = daemon.newContainer(opts.params.Name, platform, opts.params.Config, opts.params.HostConfig, imgID, opts.managed); err != nil {
 		return nil, err
 	}
+	ctr.ContainerdNamespace = tenantNS
 	defer func() {
 		if retErr != nil {
 			err = daemon.cleanupContainer(ctr, backend.ContainerRmConfig{
@@ -226,6 +355,14 @@ func (daemon *Daemon) create(ctx context.Context, daemonCfg *config.Config, opts
 
 	ctr.HostConfig.StorageOpt = opts.params.HostConfig.StorageOpt
 	ctr.ImageManifest = imgManifest
+	ctr.ImageDigest = imgDigest
+	if imgID != "" {
+		// Best-effort; links the container back to the build history
+		// record that produced its image, if it was built locally.
+		if buildID, err := daemon.imageService.GetImageBuildID(ctx, imgID); err == nil {
+			ctr.BuildID = buildID
+		}
+	}
 
 	// Set RWLayer for container after mount labels have been set
 	rwLayer, err := daemon.imageService.CreateLayer(ctr, setupInitLayer(daemon.idMapping.RootPair()))
@@ -267,6 +404,13 @@ func (daemon *Daemon) create(ctx context.Context, daemonCfg *config.Config, opts
 	}
 	metrics.StateCtr.Set(ctr.ID, "stopped")
 	daemon.LogContainerEvent(ctr, events.ActionCreate)
+	if imgID != "" {
+		// Best-effort; the timestamp only feeds the "keep-last-used"
+		// prune filter.
+		if err := daemon.imageService.MarkImageUsed(ctx, imgID); err != nil {
+			log.G(ctx).WithError(err).WithField("image", imgID).Debug("failed to record image last-used time")
+		}
+	}
 	return ctr, nil
 }
 
@@ -378,6 +522,91 @@ func (daemon *Daemon) validateNetworkingConfig(nwConfig *networktypes.Networking
 	return nil
 }
 
+const (
+	// maxKVFileSize limits the size of each label or annotation file read
+	// by the daemon on behalf of a container create request.
+	maxKVFileSize = 1 << 20 // 1 MiB
+	// maxKVSetSize limits the combined size of the keys and values of a
+	// container's labels or annotations.
+	maxKVSetSize = 1 << 20 // 1 MiB
+)
+
+// resolveKVFiles merges label and annotation files referenced in the create
+// request into the container's labels and annotations, and enforces the
+// per-container size limits on the resulting sets.
+func resolveKVFiles(params *backend.ContainerCreateConfig) error {
+	labels, err := mergeKVFiles(params.Config.Labels, params.LabelFiles, "label")
+	if err != nil {
+		return err
+	}
+	if err := validateKVSetSize(labels, "labels"); err != nil {
+		return err
+	}
+	params.Config.Labels = labels
+
+	if len(params.AnnotationFiles) > 0 && params.HostConfig == nil {
+		params.HostConfig = &containertypes.HostConfig{}
+	}
+	if params.HostConfig != nil {
+		annotations, err := mergeKVFiles(params.HostConfig.Annotations, params.AnnotationFiles, "annotation")
+		if err != nil {
+			return err
+		}
+		if err := validateKVSetSize(annotations, "annotations"); err != nil {
+			return err
+		}
+		params.HostConfig.Annotations = annotations
+	}
+	return nil
+}
+
+// mergeKVFiles reads KEY=VALUE lines from the given files on the daemon host
+// and merges them into dst, later files overriding earlier ones and dst.
+// Empty lines and lines starting with "#" are skipped.
+func mergeKVFiles(dst map[string]string, files []string, what string) (map[string]string, error) {
+	for _, name := range files {
+		fi, err := os.Stat(name)
+		if err != nil {
+			return nil, errdefs.InvalidParameter(fmt.Errorf("reading %s file: %w", what, err))
+		}
+		if fi.Size() > maxKVFileSize {
+			return nil, errdefs.InvalidParameter(fmt.Errorf("%s file %s exceeds the maximum size of %d bytes", what, name, maxKVFileSize))
+		}
+		data, err := os.ReadFile(name)
+		if err != nil {
+			return nil, errdefs.InvalidParameter(fmt.Errorf("reading %s file: %w", what, err))
+		}
+		for _, line := range strings.Split(string(data), "\n") {
+			line = strings.TrimSpace(line)
+			if line == "" || strings.HasPrefix(line, "#") {
+				continue
+			}
+			k, v, ok := strings.Cut(line, "=")
+			if !ok {
+				return nil, errdefs.InvalidParameter(fmt.Errorf("invalid %s in file %s: %q (expected KEY=VALUE)", what, name, line))
+			}
+			if dst == nil {
+				dst = make(map[string]string)
+			}
+			dst[k] = v
+		}
+	}
+	return dst, nil
+}
+
+// validateKVSetSize rejects label or annotation sets whose combined key and
+// value size exceeds the per-container limit.
+func validateKVSetSize(kv map[string]string, what string) error {
+	var size int
+	for k, v := range kv {
+		size += len(k) + len(v)
+	}
+	if size > maxKVSetSize {
+		return errdefs.InvalidParameter(fmt.Errorf("%s exceed the maximum combined size of %d bytes", what, maxKVSetSize))
+	}
+	return nil
+}
+
 // maximumSpec returns the distribution platform with maximum compatibility for the current node.
 func maximumSpec() ocispec.Platform {
 	p := platforms.DefaultSpec()
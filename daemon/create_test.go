@@ -0,0 +1,49 @@
+package daemon
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	cerrdefs "github.com/containerd/errdefs"
+	"gotest.tools/v3/assert"
+	is "gotest.tools/v3/assert/cmp"
+)
+
+func TestMergeKVFiles(t *testing.T) {
+	dir := t.TempDir()
+	writeFile := func(name, content string) string {
+		p := filepath.Join(dir, name)
+		assert.NilError(t, os.WriteFile(p, []byte(content), 0o600))
+		return p
+	}
+
+	f1 := writeFile("labels1", "# comment\nfoo=bar\n\nempty=\n")
+	f2 := writeFile("labels2", "foo=override\nother=value\n")
+
+	labels, err := mergeKVFiles(map[string]string{"existing": "1"}, []string{f1, f2}, "label")
+	assert.NilError(t, err)
+	assert.Check(t, is.DeepEqual(labels, map[string]string{
+		"existing": "1",
+		"foo":      "override",
+		"empty":    "",
+		"other":    "value",
+	}))
+
+	bad := writeFile("badlabels", "no-separator\n")
+	_, err = mergeKVFiles(nil, []string{bad}, "label")
+	assert.Check(t, is.ErrorContains(err, "expected KEY=VALUE"))
+	assert.Check(t, cerrdefs.IsInvalidArgument(err))
+
+	_, err = mergeKVFiles(nil, []string{filepath.Join(dir, "missing")}, "label")
+	assert.Check(t, cerrdefs.IsInvalidArgument(err))
+}
+
+func TestValidateKVSetSize(t *testing.T) {
+	assert.NilError(t, validateKVSetSize(map[string]string{"foo": "bar"}, "labels"))
+
+	err := validateKVSetSize(map[string]string{"big": strings.Repeat("x", maxKVSetSize)}, "labels")
+	assert.Check(t, is.ErrorContains(err, "maximum combined size"))
+	assert.Check(t, cerrdefs.IsInvalidArgument(err))
+}
@@ -28,7 +28,7 @@ func (daemon *Daemon) createContainerOSSpecificSettings(ctx context.Context, con
 	}
 	defer daemon.Unmount(container)
 
-	if err := container.SetupWorkingDirectory(daemon.idMapping.RootPair()); err != nil {
+	if err := daemon.setupWorkingDirectory(&daemon.config().Config, container); err != nil {
 		return err
 	}
 
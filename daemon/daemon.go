@@ -26,6 +26,7 @@ import (
 	"github.com/containerd/containerd/v2/pkg/dialer"
 	cerrdefs "github.com/containerd/errdefs"
 	"github.com/containerd/log"
+	"github.com/containerd/platforms"
 	"github.com/distribution/reference"
 	dist "github.com/docker/distribution"
 	"github.com/moby/buildkit/util/grpcerrors"
@@ -36,7 +37,9 @@ import (
 	networktypes "github.com/moby/moby/api/types/network"
 	registrytypes "github.com/moby/moby/api/types/registry"
 	"github.com/moby/moby/api/types/swarm"
+	systemtype "github.com/moby/moby/api/types/system"
 	volumetypes "github.com/moby/moby/api/types/volume"
+	"github.com/moby/moby/v2/daemon/admission"
 	"github.com/moby/moby/v2/daemon/builder"
 	executorpkg "github.com/moby/moby/v2/daemon/cluster/executor"
 	"github.com/moby/moby/v2/daemon/config"
@@ -53,6 +56,7 @@ import (
 	libcontainerdtypes "github.com/moby/moby/v2/daemon/internal/libcontainerd/types"
 	"github.com/moby/moby/v2/daemon/internal/metrics"
 	pluginexec "github.com/moby/moby/v2/daemon/internal/plugin/executor/containerd"
+	"github.com/moby/moby/v2/daemon/internal/privhelper"
 	refstore "github.com/moby/moby/v2/daemon/internal/refstore"
 	"github.com/moby/moby/v2/daemon/libnetwork"
 	"github.com/moby/moby/v2/daemon/libnetwork/cluster"
@@ -73,6 +77,7 @@ import (
 	"github.com/moby/moby/v2/pkg/sysinfo"
 	"github.com/moby/sys/user"
 	"github.com/moby/sys/userns"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
 	"github.com/pkg/errors"
 	bolt "go.etcd.io/bbolt"
 	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
@@ -85,6 +90,11 @@ import (
 	"tags.cncf.io/container-device-interface/pkg/cdi"
 )
 
+// viewSnapshotFileName is the file (under the daemon root) holding the
+// persisted container view snapshot used to serve read-only queries while
+// containers are being reloaded at startup.
+const viewSnapshotFileName = "view-snapshot.json"
+
 type configStore struct {
 	config.Config
 
@@ -97,6 +107,7 @@ type Daemon struct {
 	repository        string
 	containers        container.Store
 	containersReplica *container.ViewDB
+	containerConfigs  *container.ConfigStore
 	execCommands      *container.ExecStore
 	imageService      ImageService
 	configStore       atomic.Pointer[configStore]
@@ -105,6 +116,12 @@ type Daemon struct {
 	defaultLogConfig  containertypes.LogConfig
 	registryService   *registry.Service
 	EventsService     *events.Events
+	eventSinks        []*events.WebhookSink
+	journaldSink      *events.JournaldSink
+	eventJournal      *events.Journal
+	admission         *admission.Webhook
+	maintenance       maintenanceState
+	preloadedImages   []systemtype.PreloadedImage
 	netController     *libnetwork.Controller
 	volumes           *volumesservice.VolumesService
 	root              string
@@ -116,17 +133,31 @@ type Daemon struct {
 	pluginManager     *plugin.Manager
 	linkIndex         *linkIndex
 	containerdClient  *containerd.Client
+	containerdHealth  *containerdHealthMonitor
 	containerd        libcontainerdtypes.Client
-	defaultIsolation  containertypes.Isolation // Default isolation mode on Windows
-	clusterProvider   cluster.Provider
-	cluster           Cluster
-	genericResources  []swarm.GenericResource
-	ReferenceStore    refstore.Store
+	// containerdNSClients holds lazily-created libcontainerd clients for
+	// tenant containerd namespaces (see containerd-tenant-namespaces).
+	containerdNSMu      sync.Mutex
+	containerdNSClients map[string]libcontainerdtypes.Client
+	defaultIsolation    containertypes.Isolation // Default isolation mode on Windows
+	clusterProvider     cluster.Provider
+	cluster             Cluster
+	genericResources    []swarm.GenericResource
+	ReferenceStore      refstore.Store
 
 	machineMemory uint64
 
 	seccompProfile     []byte
 	seccompProfilePath string
+	// runtimeSeccomp holds per-runtime default seccomp profiles from the
+	// daemon config, keyed by runtime name. They override the daemon-wide
+	// default profile for containers using that runtime.
+	runtimeSeccomp map[string]runtimeSeccompProfile
+
+	// fsFreezes tracks containers whose filesystems are frozen through the
+	// fsfreeze API, keyed by container ID.
+	fsFreezeMu sync.Mutex
+	fsFreezes  map[string]*fsFreeze
 
 	usageContainers singleflight.Group[struct{}, *containertypes.DiskUsage]
 	usageImages     singleflight.Group[struct{}, []*imagetypes.Summary]
@@ -136,6 +167,10 @@ type Daemon struct {
 	pruneRunning atomic.Bool
 	hosts        map[string]bool // hosts stores the addresses the daemon is listening on
 	startupDone  chan struct{}
+	// shutdownStarted is closed when Shutdown begins, stopping the daemon's
+	// background maintenance loops.
+	shutdownStarted chan struct{}
+	shutdownOnce    sync.Once
 
 	attachmentStore       network.AttachmentStore
 	attachableNetworkLock *locker.Locker
@@ -147,9 +182,29 @@ type Daemon struct {
 
 	usesSnapshotter bool
 
+	// privHelper is the privileged helper process used when the daemon
+	// runs with split privileges (the "split-privileges" feature flag).
+	// It is nil when the feature is disabled.
+	privHelper *privhelper.Client
+
 	CDICache *cdi.Cache
 }
 
+// runtimeSeccompProfile is a default seccomp profile configured for a
+// specific runtime. The content is only set for custom profiles loaded from
+// a file; built-in profile names are carried in path.
+type runtimeSeccompProfile struct {
+	path    string
+	content []byte
+}
+
+// fsFreeze records the filesystems frozen for a container through the
+// fsfreeze API, and the timer that thaws them when the freeze times out.
+type fsFreeze struct {
+	paths []string
+	timer *time.Timer
+}
+
 // ID returns the daemon id
 func (daemon *Daemon) ID() string {
 	return daemon.id
@@ -236,6 +291,16 @@ func (daemon *Daemon) restore(cfg *configStore) error {
 			c, err := daemon.load(id)
 			if err != nil {
 				logger.WithError(err).Error("failed to load container")
+				if isUnparseableContainerError(err) {
+					// Move the directory out of the repository so that it
+					// does not produce the same error on every startup. It
+					// can be recovered with RestoreQuarantinedContainer.
+					if qErr := daemon.quarantineContainer(id, err); qErr != nil {
+						logger.WithError(qErr).Error("failed to quarantine container")
+					} else {
+						logger.Warn("moved unparseable container to quarantine")
+					}
+				}
 				return
 			}
 			if c.Driver != daemon.imageService.StorageDriver() {
@@ -360,7 +425,12 @@ func (daemon *Daemon) restore(cfg *configStore) error {
 
 			var es *containerd.ExitStatus
 
-			if err := c.RestoreTask(context.Background(), daemon.containerd); err != nil && !cerrdefs.IsNotFound(err) {
+			tenantClient, err := daemon.containerdFor(c)
+			if err != nil {
+				baseLogger.WithError(err).Error("failed to create containerd client for container namespace")
+				return
+			}
+			if err := c.RestoreTask(context.Background(), tenantClient); err != nil && !cerrdefs.IsNotFound(err) {
 				logger(c).WithError(err).Error("failed to restore container with containerd")
 				return
 			}
@@ -561,13 +631,27 @@ func (daemon *Daemon) restore(cfg *configStore) error {
 	}
 	group.Wait()
 
+	depGraph := daemon.resolveRestartDependencies(restartContainers)
+	depCycles := restartDependencyCycles(depGraph)
+
 	for c, notifyChan := range restartContainers {
 		group.Add(1)
 		go func(c *container.Container, chNotify chan struct{}) {
-			_ = sem.Acquire(context.Background(), 1)
-
 			logger := log.G(context.TODO()).WithField("container", c.ID)
 
+			// Honor explicit DependsOn ordering before taking a start
+			// slot, so that dependents cannot starve their dependencies
+			// out of the semaphore.
+			if deps := depGraph[c]; len(deps) > 0 {
+				if depCycles[c] {
+					logger.Warn("container is part of a dependency cycle; starting it without waiting for its dependencies")
+				} else if err := waitForRestartDependencies(deps, restartContainers, restartDependencyTimeout); err != nil {
+					logger.WithError(err).Warn("starting container without waiting for its dependencies")
+				}
+			}
+
+			_ = sem.Acquire(context.Background(), 1)
+
 			logger.Debug("starting container")
 
 			// ignore errors here as this is a best effort to wait for children
@@ -646,6 +730,10 @@ func (daemon *Daemon) restore(cfg *configStore) error {
 	}
 	group.Wait()
 
+	// All containers are loaded; any entries still served from a persisted
+	// view snapshot refer to containers that no longer exist.
+	daemon.containersReplica.ClearStaleView()
+
 	log.G(context.TODO()).Info("Loading containers: done.")
 
 	return nil
@@ -823,8 +911,9 @@ func NewDaemon(ctx context.Context, config *config.Config, pluginStore *plugin.S
 	}
 
 	d := &Daemon{
-		PluginStore: pluginStore,
-		startupDone: make(chan struct{}),
+		PluginStore:     pluginStore,
+		startupDone:     make(chan struct{}),
+		shutdownStarted: make(chan struct{}),
 	}
 	cfgStore := &configStore{
 		Config:   *config,
@@ -839,6 +928,18 @@ func NewDaemon(ctx context.Context, config *config.Config, pluginStore *plugin.S
 		d.usesSnapshotter = config.Features["containerd-snapshotter"]
 	}
 
+	if config.Features["split-privileges"] {
+		// Spawn the privileged helper while we still hold full
+		// privileges; privileged operations are delegated to it over a
+		// narrow RPC so that they can be migrated out of this process.
+		helper, err := privhelper.Start()
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to start privileged helper")
+		}
+		d.privHelper = helper
+		log.G(ctx).Info("Running with split privileges; privileged helper started")
+	}
+
 	// Ensure the daemon is properly shutdown if there is a failure during
 	// initialization
 	defer func() {
@@ -928,7 +1029,12 @@ func NewDaemon(ctx context.Context, config *config.Config, pluginStore *plugin.S
 		// ------------------------------------------------------------------
 		// end of options copied from containerd client's default
 		// ------------------------------------------------------------------
-		grpc.WithStatsHandler(tracing.ClientStatsHandler(otelgrpc.WithTracerProvider(otel.GetTracerProvider()))),
+		// The stats handler also emits client RPC metrics (latencies per
+		// containerd method) when an OTLP metrics pipeline is configured.
+		grpc.WithStatsHandler(tracing.ClientStatsHandler(
+			otelgrpc.WithTracerProvider(otel.GetTracerProvider()),
+			otelgrpc.WithMeterProvider(otel.GetMeterProvider()),
+		)),
 		grpc.WithUnaryInterceptor(grpcerrors.UnaryClientInterceptor),
 		grpc.WithStreamInterceptor(grpcerrors.StreamClientInterceptor),
 	}
@@ -1024,10 +1130,30 @@ func NewDaemon(ctx context.Context, config *config.Config, pluginStore *plugin.S
 	if d.containersReplica, err = container.NewViewDB(); err != nil {
 		return nil, err
 	}
+	if d.containerConfigs, err = container.NewConfigStore(filepath.Join(cfgStore.Root, "container-configs")); err != nil {
+		return nil, err
+	}
+	if n, err := d.containersReplica.LoadStaleViewFromDisk(filepath.Join(cfgStore.Root, viewSnapshotFileName)); err != nil {
+		log.G(ctx).WithError(err).Warn("Failed to load container view snapshot")
+	} else if n > 0 {
+		log.G(ctx).WithField("containers", n).Info("Loaded container view snapshot; serving stale results until containers are loaded")
+	}
 	d.execCommands = container.NewExecStore()
 	d.statsCollector = d.newStatsCollector(1 * time.Second)
 
 	d.EventsService = events.New()
+	if cfgStore.EventJournal.Enabled {
+		journal, err := events.NewJournal(filepath.Join(cfgStore.Root, "events"), cfgStore.EventJournal.MaxFileSize, cfgStore.EventJournal.MaxFiles)
+		if err != nil {
+			return nil, errors.Wrap(err, "error opening event journal")
+		}
+		d.eventJournal = journal
+		d.EventsService.AttachJournal(journal)
+	}
+	d.startEventSinks(&cfgStore.Config)
+	if d.containerdClient != nil {
+		d.containerdHealth = newContainerdHealthMonitor(d)
+	}
 	d.root = cfgStore.Root
 	d.idMapping = idMapping
 
@@ -1050,6 +1176,11 @@ func NewDaemon(ctx context.Context, config *config.Config, pluginStore *plugin.S
 		driverName = cfgStore.GraphDriver
 	}
 
+	defaultPullPlatform := defaultPullPlatformResolver(&cfgStore.Config)
+	if wh := cfgStore.AdmissionWebhook; wh != nil {
+		d.admission = admission.NewWebhook(wh.URL, wh.Secret)
+	}
+
 	if d.UsesSnapshotter() {
 		if os.Getenv("TEST_INTEGRATION_USE_SNAPSHOTTER") != "" {
 			log.G(ctx).Warn("Enabling containerd snapshotter through the $TEST_INTEGRATION_USE_SNAPSHOTTER environment variable. This should only be used for testing.")
@@ -1066,15 +1197,29 @@ func NewDaemon(ctx context.Context, config *config.Config, pluginStore *plugin.S
 		if err := configureKernelSecuritySupport(&cfgStore.Config, driverName); err != nil {
 			return nil, err
 		}
+		var lazyPullSnapshotter string
+		var lazyPullRegistries []string
+		if lp := cfgStore.LazyPull; lp != nil && len(lp.Registries) > 0 {
+			lazyPullSnapshotter = lp.Snapshotter
+			if lazyPullSnapshotter == "" {
+				lazyPullSnapshotter = "stargz"
+			}
+			lazyPullRegistries = lp.Registries
+		}
 		d.imageService = ctrd.NewService(ctrd.ImageServiceConfig{
-			Client:          d.containerdClient,
-			Containers:      d.containers,
-			Snapshotter:     driverName,
-			RegistryHosts:   d.RegistryHosts,
-			Registry:        d.registryService,
-			EventsService:   d.EventsService,
-			IDMapping:       idMapping,
-			RefCountMounter: snapshotter.NewMounter(config.Root, driverName, idMapping),
+			Client:              d.containerdClient,
+			Containers:          d.containers,
+			Snapshotter:         driverName,
+			RegistryHosts:       d.RegistryHosts,
+			Registry:            d.registryService,
+			EventsService:       d.EventsService,
+			IDMapping:           idMapping,
+			RefCountMounter:     snapshotter.NewMounter(config.Root, driverName, idMapping),
+			PullGate:            d.maintenanceError,
+			DefaultPullPlatform: defaultPullPlatform,
+			AdmitPull:           d.admitPull,
+			LazyPullSnapshotter: lazyPullSnapshotter,
+			LazyPullRegistries:  lazyPullRegistries,
 		})
 	} else {
 		layerStore, err := layer.NewStoreFromOptions(layer.StoreOptions{
@@ -1134,10 +1279,14 @@ func NewDaemon(ctx context.Context, config *config.Config, pluginStore *plugin.S
 			LayerStore:                layerStore,
 			MaxConcurrentDownloads:    config.MaxConcurrentDownloads,
 			MaxConcurrentUploads:      config.MaxConcurrentUploads,
+			MaxConcurrentLoads:        config.MaxConcurrentLoads,
 			MaxDownloadAttempts:       config.MaxDownloadAttempts,
 			ReferenceStore:            rs,
 			RegistryService:           registryService,
 			ContentNamespace:          config.ContainerdNamespace,
+			PullGate:                  d.maintenanceError,
+			DefaultPullPlatform:       defaultPullPlatform,
+			AdmitPull:                 d.admitPull,
 		}
 
 		// containerd is not currently supported with Windows.
@@ -1163,7 +1312,19 @@ func NewDaemon(ctx context.Context, config *config.Config, pluginStore *plugin.S
 		log.G(ctx).Debugf("Max Download Attempts: %d", imgSvcConfig.MaxDownloadAttempts)
 	}
 
+	// The image service is needed to seed volumes created with the
+	// "from-image" option.
+	d.volumes.RegisterImageSeeder(d)
+	d.volumes.RegisterCreateCheck(d.volumeCreateCheck)
+
+	// Import images staged in the preload directory before containers are
+	// restored, so they are available as soon as the API comes up.
+	d.preloadImages(ctx, &cfgStore.Config)
+
 	go d.execCommandGC()
+	go d.monitorClockJumps()
+	go d.autoUpdateLoop()
+	go d.cpuRebalanceLoop()
 
 	if err := d.initLibcontainerd(ctx, &cfgStore.Config); err != nil {
 		return nil, err
@@ -1206,6 +1367,33 @@ func NewDaemon(ctx context.Context, config *config.Config, pluginStore *plugin.S
 	return d, nil
 }
 
+// defaultPullPlatformResolver builds the default-platform lookup for image
+// pulls from the daemon configuration. The returned function resolves the
+// platform to use for pulls of a reference that do not request an explicit
+// platform: the per-registry mapping takes precedence over the global
+// default, and a nil result means the host platform. It returns nil when no
+// defaults are configured. The configured values are validated at startup,
+// so unparsable entries are only guarded against here for safety.
+func defaultPullPlatformResolver(conf *config.Config) func(reference.Named) *ocispec.Platform {
+	if conf.DefaultPullPlatform == "" && len(conf.RegistryPullPlatforms) == 0 {
+		return nil
+	}
+	return func(ref reference.Named) *ocispec.Platform {
+		if spec, ok := conf.RegistryPullPlatforms[reference.Domain(ref)]; ok {
+			if p, err := platforms.Parse(spec); err == nil {
+				return &p
+			}
+			return nil
+		}
+		if conf.DefaultPullPlatform != "" {
+			if p, err := platforms.Parse(conf.DefaultPullPlatform); err == nil {
+				return &p
+			}
+		}
+		return nil
+	}
+}
+
 // DistributionServices returns services controlling daemon storage
 func (daemon *Daemon) DistributionServices() images.DistributionServices {
 	return daemon.imageService.DistributionServices()
@@ -1265,6 +1453,9 @@ func (daemon *Daemon) shutdownTimeout(cfg *config.Config) int {
 // Shutdown stops the daemon.
 func (daemon *Daemon) Shutdown(ctx context.Context) error {
 	daemon.shutdown = true
+	daemon.shutdownOnce.Do(func() {
+		close(daemon.shutdownStarted)
+	})
 	// Keep mounts and networking running on daemon shutdown if
 	// we are to keep containers running and restore them.
 
@@ -1298,6 +1489,15 @@ func (daemon *Daemon) Shutdown(ctx context.Context) error {
 		})
 	}
 
+	if daemon.containersReplica != nil {
+		// Persist a lightweight view snapshot so that the next daemon
+		// startup can serve read-only container queries (marked stale)
+		// while containers are being reloaded.
+		if err := daemon.containersReplica.SaveViewToDisk(filepath.Join(daemon.root, viewSnapshotFileName)); err != nil {
+			log.G(ctx).WithError(err).Error("Failed to persist container view snapshot")
+		}
+	}
+
 	if daemon.volumes != nil {
 		if err := daemon.volumes.Shutdown(); err != nil {
 			log.G(ctx).Errorf("Error shutting down volume store: %v", err)
@@ -1326,6 +1526,19 @@ func (daemon *Daemon) Shutdown(ctx context.Context) error {
 		daemon.netController.Stop()
 	}
 
+	daemon.closeEventSinks()
+
+	if daemon.eventJournal != nil {
+		daemon.EventsService.DetachJournal()
+		if err := daemon.eventJournal.Close(); err != nil {
+			log.G(ctx).WithError(err).Error("Error closing event journal")
+		}
+	}
+
+	if daemon.containerdHealth != nil {
+		daemon.containerdHealth.Close()
+	}
+
 	if daemon.containerdClient != nil {
 		daemon.containerdClient.Close()
 	}
@@ -1334,6 +1547,12 @@ func (daemon *Daemon) Shutdown(ctx context.Context) error {
 		daemon.mdDB.Close()
 	}
 
+	if daemon.privHelper != nil {
+		if err := daemon.privHelper.Close(); err != nil {
+			log.G(ctx).WithError(err).Error("Error shutting down privileged helper")
+		}
+	}
+
 	return daemon.cleanupMounts(cfg)
 }
 
@@ -10,7 +10,9 @@ import (
 	"testing"
 
 	cerrdefs "github.com/containerd/errdefs"
+	"github.com/distribution/reference"
 	containertypes "github.com/moby/moby/api/types/container"
+	"github.com/moby/moby/v2/daemon/config"
 	"github.com/moby/moby/v2/daemon/container"
 	"github.com/moby/moby/v2/daemon/internal/idtools"
 	"github.com/moby/moby/v2/daemon/libnetwork"
@@ -430,3 +432,49 @@ func TestResolveSymlinkedDirectoryToFile(t *testing.T) {
 		t.Errorf("failed to remove symlink: %s", err)
 	}
 }
+
+func TestDefaultPullPlatformResolver(t *testing.T) {
+	mustParse := func(s string) reference.Named {
+		ref, err := reference.ParseNormalizedNamed(s)
+		assert.NilError(t, err)
+		return ref
+	}
+
+	t.Run("no defaults configured", func(t *testing.T) {
+		resolve := defaultPullPlatformResolver(&config.Config{})
+		assert.Check(t, is.Nil(resolve))
+	})
+
+	t.Run("global default", func(t *testing.T) {
+		resolve := defaultPullPlatformResolver(&config.Config{
+			CommonConfig: config.CommonConfig{
+				DefaultPullPlatform: "linux/amd64",
+			},
+		})
+		assert.Assert(t, resolve != nil)
+		p := resolve(mustParse("busybox:latest"))
+		assert.Assert(t, p != nil)
+		assert.Check(t, is.Equal(p.OS, "linux"))
+		assert.Check(t, is.Equal(p.Architecture, "amd64"))
+	})
+
+	t.Run("per-registry overrides global", func(t *testing.T) {
+		resolve := defaultPullPlatformResolver(&config.Config{
+			CommonConfig: config.CommonConfig{
+				DefaultPullPlatform: "linux/amd64",
+				RegistryPullPlatforms: map[string]string{
+					"registry.example.com": "linux/arm64",
+				},
+			},
+		})
+		assert.Assert(t, resolve != nil)
+
+		p := resolve(mustParse("registry.example.com/app:latest"))
+		assert.Assert(t, p != nil)
+		assert.Check(t, is.Equal(p.Architecture, "arm64"))
+
+		p = resolve(mustParse("other.example.com/app:latest"))
+		assert.Assert(t, p != nil)
+		assert.Check(t, is.Equal(p.Architecture, "amd64"))
+	})
+}
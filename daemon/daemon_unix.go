@@ -29,6 +29,7 @@ import (
 	"github.com/moby/moby/v2/daemon/container"
 	"github.com/moby/moby/v2/daemon/initlayer"
 	"github.com/moby/moby/v2/daemon/internal/libcontainerd/remote"
+	libcontainerdtypes "github.com/moby/moby/v2/daemon/internal/libcontainerd/types"
 	"github.com/moby/moby/v2/daemon/internal/otelutil"
 	"github.com/moby/moby/v2/daemon/internal/usergroup"
 	"github.com/moby/moby/v2/daemon/libnetwork"
@@ -205,6 +206,26 @@ func getBlkioWeightDevices(config containertypes.Resources) ([]specs.LinuxWeight
 	return blkioWeightDevices, nil
 }
 
+// getIOLatencyUnified converts the container's I/O latency targets into the
+// value for the cgroup v2 io.latency interface file: one "MAJ:MIN target=N"
+// entry per device, with the target latency in microseconds.
+func getIOLatencyUnified(config containertypes.Resources) (string, error) {
+	var stat unix.Stat_t
+	entries := make([]string, 0, len(config.IOLatencyTarget))
+
+	for _, latencyDevice := range config.IOLatencyTarget {
+		if err := unix.Stat(latencyDevice.Path, &stat); err != nil {
+			return "", errors.WithStack(&os.PathError{Op: "stat", Path: latencyDevice.Path, Err: err})
+		}
+		// The type is 32bit on mips.
+		major := unix.Major(uint64(stat.Rdev)) //nolint: unconvert
+		minor := unix.Minor(uint64(stat.Rdev)) //nolint: unconvert
+		entries = append(entries, fmt.Sprintf("%d:%d target=%d", major, minor, latencyDevice.TargetUsec))
+	}
+
+	return strings.Join(entries, "\n"), nil
+}
+
 func (daemon *Daemon) parseSecurityOpt(cfg *config.Config, securityOptions *container.SecurityOptions, hostConfig *containertypes.HostConfig) error {
 	securityOptions.NoNewPrivileges = cfg.NoNewPrivileges
 	return parseSecurityOpt(securityOptions, hostConfig)
@@ -364,6 +385,14 @@ func (daemon *Daemon) adaptContainerSettings(daemonCfg *config.Config, hostConfi
 		}
 	}
 
+	if err := daemon.setNUMAPlacement(hostConfig); err != nil {
+		return err
+	}
+
+	if err := applyNetworkProfile(daemonCfg, hostConfig); err != nil {
+		return err
+	}
+
 	adaptSharedNamespaceContainer(daemon, hostConfig)
 
 	var err error
@@ -380,6 +409,59 @@ func (daemon *Daemon) adaptContainerSettings(daemonCfg *config.Config, hostConfi
 	return nil
 }
 
+// runtimeAnnotationPrefixes maps annotation key namespaces that configure a
+// specific OCI runtime to substrings identifying that runtime's usual names,
+// so that annotations the selected runtime will silently ignore can be
+// flagged at create time.
+var runtimeAnnotationPrefixes = map[string][]string{
+	"io.katacontainers.": {"kata"},
+	"dev.gvisor.":        {"runsc", "gvisor"},
+}
+
+// verifyRuntimeAnnotations warns about annotations that configure a runtime
+// other than the one the container uses. The runtime in hostConfig must
+// already be resolved.
+func verifyRuntimeAnnotations(hostConfig *containertypes.HostConfig) (warnings []string) {
+	for key := range hostConfig.Annotations {
+		for prefix, hints := range runtimeAnnotationPrefixes {
+			if !strings.HasPrefix(key, prefix) {
+				continue
+			}
+			if !slices.ContainsFunc(hints, func(hint string) bool {
+				return strings.Contains(hostConfig.Runtime, hint)
+			}) {
+				warnings = append(warnings, fmt.Sprintf("Annotation %s is specific to the %s runtimes and is likely to be ignored by runtime %s", key, strings.TrimSuffix(prefix, "."), hostConfig.Runtime))
+			}
+		}
+	}
+	return warnings
+}
+
+// applyNetworkProfile merges the sysctls of the network profile selected by
+// the container into its host config. Sysctls set explicitly on the container
+// take precedence over the profile's values.
+func applyNetworkProfile(daemonCfg *config.Config, hostConfig *containertypes.HostConfig) error {
+	if hostConfig.NetworkProfile == "" {
+		return nil
+	}
+	var profile map[string]string
+	if daemonCfg != nil {
+		profile = daemonCfg.NetworkProfiles[hostConfig.NetworkProfile]
+	}
+	if profile == nil {
+		return errdefs.InvalidParameter(fmt.Errorf("unknown network profile %s", hostConfig.NetworkProfile))
+	}
+	if hostConfig.Sysctls == nil {
+		hostConfig.Sysctls = make(map[string]string, len(profile))
+	}
+	for k, v := range profile {
+		if _, ok := hostConfig.Sysctls[k]; !ok {
+			hostConfig.Sysctls[k] = v
+		}
+	}
+	return nil
+}
+
 // adaptSharedNamespaceContainer replaces container name with its ID in hostConfig.
 // To be more precisely, it modifies `container:name` to `container:ID` of PidMode, IpcMode
 // and NetworkMode.
@@ -563,6 +645,18 @@ func verifyPlatformContainerResources(resources *containertypes.Resources, sysIn
 		warnings = append(warnings, "Your kernel does not support Block I/O weight_device or the cgroup is not mounted. Weight-device discarded.")
 		resources.BlkioWeightDevice = []*blkiodev.WeightDevice{}
 	}
+	if len(resources.IOLatencyTarget) > 0 {
+		if !sysInfo.IOLatency {
+			warnings = append(warnings, "Your kernel does not support the io.latency controller or the cgroup is not mounted. I/O latency target discarded.")
+			resources.IOLatencyTarget = []*blkiodev.LatencyDevice{}
+		} else {
+			for _, latencyDevice := range resources.IOLatencyTarget {
+				if latencyDevice.TargetUsec == 0 {
+					return warnings, errors.New("I/O latency target must be greater than 0")
+				}
+			}
+		}
+	}
 	if len(resources.BlkioDeviceReadBps) > 0 && !sysInfo.BlkioReadBpsDevice {
 		warnings = append(warnings, "Your kernel does not support BPS Block I/O read limit or the cgroup is not mounted. Block I/O BPS read limit discarded.")
 		resources.BlkioDeviceReadBps = []*blkiodev.ThrottleDevice{}
@@ -713,6 +807,7 @@ func verifyPlatformContainerSettings(daemon *Daemon, daemonCfg *configStore, hos
 	if _, _, err := daemonCfg.Runtimes.Get(hostConfig.Runtime); err != nil {
 		return warnings, err
 	}
+	warnings = append(warnings, verifyRuntimeAnnotations(hostConfig)...)
 
 	parser := volumemounts.NewParser()
 	for dest := range hostConfig.Tmpfs {
@@ -721,6 +816,22 @@ func verifyPlatformContainerSettings(daemon *Daemon, daemonCfg *configStore, hos
 		}
 	}
 
+	if cd := hostConfig.CoreDump; cd != nil {
+		if cd.SizeLimit < 0 {
+			return warnings, errors.New("core dump size limit can not be less than 0")
+		}
+		if cd.Directory != "" {
+			if !filepath.IsAbs(cd.Directory) {
+				return warnings, errors.Errorf("core dump directory %s is not an absolute path", cd.Directory)
+			}
+			// kernel.core_pattern isn't namespaced; if the host pipes core
+			// dumps to a handler, they'll never reach the mounted directory.
+			if pattern, err := os.ReadFile("/proc/sys/kernel/core_pattern"); err == nil && strings.HasPrefix(string(pattern), "|") {
+				warnings = append(warnings, "kernel.core_pattern pipes core dumps to a handler on the host. Core dumps will not be written to the configured core dump directory.")
+			}
+		}
+	}
+
 	if !hostConfig.CgroupnsMode.Valid() {
 		return warnings, fmt.Errorf("invalid cgroup namespace mode: %v", hostConfig.CgroupnsMode)
 	}
@@ -1633,6 +1744,25 @@ func (daemon *Daemon) setupSeccompProfile(cfg *config.Config) error {
 		}
 		daemon.seccompProfile = b
 	}
+	for rt, profile := range cfg.RuntimeSeccompProfiles {
+		p := runtimeSeccompProfile{path: profile}
+		switch profile {
+		case "", config.SeccompProfileDefault:
+			p.path = config.SeccompProfileDefault
+		case config.SeccompProfileUnconfined:
+			// no profile to load
+		default:
+			b, err := os.ReadFile(profile)
+			if err != nil {
+				return fmt.Errorf("opening seccomp profile (%s) for runtime %s failed: %v", profile, rt, err)
+			}
+			p.content = b
+		}
+		if daemon.runtimeSeccomp == nil {
+			daemon.runtimeSeccomp = make(map[string]runtimeSeccompProfile)
+		}
+		daemon.runtimeSeccomp[rt] = p
+	}
 	return nil
 }
 
@@ -1648,14 +1778,20 @@ func getSysInfo(cfg *config.Config) *sysinfo.SysInfo {
 
 func (daemon *Daemon) initLibcontainerd(ctx context.Context, cfg *config.Config) error {
 	var err error
-	daemon.containerd, err = remote.NewClient(
+	daemon.containerd, err = daemon.newLibcontainerdClient(ctx, cfg, cfg.ContainerdNamespace)
+	return err
+}
+
+// newLibcontainerdClient creates a libcontainerd client bound to the given
+// containerd namespace.
+func (daemon *Daemon) newLibcontainerdClient(ctx context.Context, cfg *config.Config, ns string) (libcontainerdtypes.Client, error) {
+	return remote.NewClient(
 		ctx,
 		daemon.containerdClient,
 		filepath.Join(cfg.ExecRoot, "containerd"),
-		cfg.ContainerdNamespace,
+		ns,
 		daemon,
 	)
-	return err
 }
 
 func recursiveUnmount(target string) error {
@@ -4,6 +4,7 @@ package daemon
 
 import (
 	"errors"
+	"fmt"
 	"os"
 	"path/filepath"
 	"testing"
@@ -350,6 +351,88 @@ func TestGetBlkioWeightDevices(t *testing.T) {
 	})
 }
 
+func TestGetIOLatencyUnified(t *testing.T) {
+	deviceTypeMock(t, func(tempFile string) {
+		mockResource := containertypes.Resources{
+			IOLatencyTarget: []*blkiodev.LatencyDevice{{Path: tempFile, TargetUsec: 5000}},
+		}
+
+		ioLatency, err := getIOLatencyUnified(mockResource)
+
+		assert.NilError(t, err, "getIOLatencyUnified")
+		assert.Check(t, is.Equal(ioLatency, fmt.Sprintf("%d:%d target=5000", MAJOR, MINOR)), "get io.latency value")
+	})
+}
+
+func TestVerifyRuntimeAnnotations(t *testing.T) {
+	warnings := verifyRuntimeAnnotations(&containertypes.HostConfig{
+		Runtime: "runc",
+		Annotations: map[string]string{
+			"io.katacontainers.config.hypervisor.default_vcpus": "2",
+			"com.example.team": "backend",
+		},
+	})
+	assert.Assert(t, is.Len(warnings, 1))
+	assert.Check(t, is.Contains(warnings[0], "io.katacontainers"))
+
+	warnings = verifyRuntimeAnnotations(&containertypes.HostConfig{
+		Runtime: "kata-runtime",
+		Annotations: map[string]string{
+			"io.katacontainers.config.hypervisor.default_vcpus": "2",
+		},
+	})
+	assert.Check(t, is.Len(warnings, 0))
+
+	warnings = verifyRuntimeAnnotations(&containertypes.HostConfig{
+		Runtime: "runc",
+		Annotations: map[string]string{
+			"dev.gvisor.internal.platform": "kvm",
+		},
+	})
+	assert.Assert(t, is.Len(warnings, 1))
+	assert.Check(t, is.Contains(warnings[0], "dev.gvisor"))
+}
+
+func TestApplyNetworkProfile(t *testing.T) {
+	cfg := &config.Config{
+		CommonConfig: config.CommonConfig{
+			NetworkConfig: config.NetworkConfig{
+				NetworkProfiles: map[string]map[string]string{
+					"high-throughput": {
+						"net.core.somaxconn":  "4096",
+						"net.ipv4.tcp_rmem":   "4096 87380 16777216",
+						"net.core.rmem_max":   "16777216",
+						"net.ipv4.ip_forward": "1",
+					},
+				},
+			},
+		},
+	}
+
+	t.Run("no profile", func(t *testing.T) {
+		hostConfig := &containertypes.HostConfig{}
+		assert.NilError(t, applyNetworkProfile(cfg, hostConfig))
+		assert.Check(t, is.Len(hostConfig.Sysctls, 0))
+	})
+
+	t.Run("unknown profile", func(t *testing.T) {
+		hostConfig := &containertypes.HostConfig{NetworkProfile: "missing"}
+		err := applyNetworkProfile(cfg, hostConfig)
+		assert.Check(t, is.ErrorContains(err, "unknown network profile missing"))
+	})
+
+	t.Run("explicit sysctls take precedence", func(t *testing.T) {
+		hostConfig := &containertypes.HostConfig{
+			NetworkProfile: "high-throughput",
+			Sysctls:        map[string]string{"net.core.somaxconn": "128"},
+		}
+		assert.NilError(t, applyNetworkProfile(cfg, hostConfig))
+		assert.Check(t, is.Equal(hostConfig.Sysctls["net.core.somaxconn"], "128"))
+		assert.Check(t, is.Equal(hostConfig.Sysctls["net.core.rmem_max"], "16777216"))
+		assert.Check(t, is.Len(hostConfig.Sysctls, 4))
+	})
+}
+
 func TestGetBlkioThrottleDevices(t *testing.T) {
 	deviceTypeMock(t, func(tempFile string) {
 		mockDevs := []*blkiodev.ThrottleDevice{{Path: tempFile, Rate: WEIGHT}}
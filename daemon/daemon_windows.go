@@ -18,6 +18,7 @@ import (
 	"github.com/moby/moby/v2/daemon/container"
 	"github.com/moby/moby/v2/daemon/internal/libcontainerd/local"
 	"github.com/moby/moby/v2/daemon/internal/libcontainerd/remote"
+	libcontainerdtypes "github.com/moby/moby/v2/daemon/internal/libcontainerd/types"
 	"github.com/moby/moby/v2/daemon/internal/system"
 	"github.com/moby/moby/v2/daemon/libnetwork"
 	nwconfig "github.com/moby/moby/v2/daemon/libnetwork/config"
@@ -167,15 +168,60 @@ func verifyPlatformContainerResources(resources *containertypes.Resources, isHyp
 	if len(resources.Ulimits) != 0 {
 		return warnings, fmt.Errorf("invalid option: Windows does not support Ulimits")
 	}
+	if err := verifyCPUGroupAffinity(resources.CPUGroupAffinity); err != nil {
+		return warnings, err
+	}
 	return warnings, nil
 }
 
+// verifyCPUGroupAffinity validates the requested processor-group affinity
+// against the host's processor topology, so a bad group number or a mask
+// selecting processors the group does not have fails with a clear error
+// instead of an HCS error at start.
+func verifyCPUGroupAffinity(affinity []containertypes.CPUGroupAffinity) error {
+	if len(affinity) == 0 {
+		return nil
+	}
+	groups := processorGroups()
+	for _, a := range affinity {
+		if a.Mask == 0 {
+			return fmt.Errorf("invalid CPUGroupAffinity: mask for processor group %d must not be empty", a.Group)
+		}
+		if a.Group >= uint32(len(groups)) {
+			return fmt.Errorf("invalid CPUGroupAffinity: processor group %d does not exist, the host has %d processor group(s)", a.Group, len(groups))
+		}
+		if procs := groups[a.Group]; procs < 64 && a.Mask>>procs != 0 {
+			return fmt.Errorf("invalid CPUGroupAffinity: mask %#x selects processors beyond the %d logical processor(s) in group %d", a.Mask, procs, a.Group)
+		}
+	}
+	return nil
+}
+
+// processorGroups returns the number of active logical processors in each
+// processor group on the host, indexed by group number.
+func processorGroups() []uint32 {
+	var groups []uint32
+	// Processor groups are numbered contiguously from 0; stop at the first
+	// group with no active processors.
+	for group := uint16(0); ; group++ {
+		n := windows.GetActiveProcessorCount(group)
+		if n == 0 {
+			break
+		}
+		groups = append(groups, n)
+	}
+	return groups
+}
+
 // verifyPlatformContainerSettings performs platform-specific validation of the
 // hostconfig and config structures.
 func verifyPlatformContainerSettings(daemon *Daemon, daemonCfg *configStore, hostConfig *containertypes.HostConfig, update bool) (warnings []string, _ error) {
 	if hostConfig == nil {
 		return nil, nil
 	}
+	if hostConfig.CoreDump != nil {
+		return warnings, errors.New("core dump policy is not supported on Windows")
+	}
 	return verifyPlatformContainerResources(&hostConfig.Resources, daemon.runAsHyperVContainer(hostConfig))
 }
 
@@ -342,8 +388,13 @@ func (daemon *Daemon) initNetworkController(daemonCfg *config.Config, activeSand
 		drvOptions := make(map[string]string)
 		var labels map[string]string
 		nid := ""
+		// The stored network's driver may differ from the HNS network type
+		// backing it (e.g. "ipvlan" networks are backed by L2Bridge), so
+		// prefer the stored driver when re-adding a known network.
+		driverName := strings.ToLower(v.Type)
 		if n != nil {
 			nid = n.ID()
+			driverName = n.Type()
 
 			// global networks should not be deleted by local HNS
 			if n.Scope() == scope.Global {
@@ -401,7 +452,7 @@ func (daemon *Daemon) initNetworkController(daemonCfg *config.Config, activeSand
 		}
 
 		v6Conf := []*libnetwork.IpamConf{}
-		_, err := daemon.netController.NewNetwork(context.TODO(), strings.ToLower(v.Type), name, nid,
+		_, err := daemon.netController.NewNetwork(context.TODO(), driverName, name, nid,
 			libnetwork.NetworkOptionGeneric(options.Generic{
 				netlabel.GenericData: netOption,
 				netlabel.EnableIPv4:  true,
@@ -602,3 +653,19 @@ func (daemon *Daemon) initLibcontainerd(ctx context.Context, cfg *config.Config)
 
 	return err
 }
+
+// newLibcontainerdClient creates a libcontainerd client bound to the given
+// containerd namespace. It requires containerd; the v1 (HCS) runtime has no
+// notion of namespaces.
+func (daemon *Daemon) newLibcontainerdClient(ctx context.Context, cfg *config.Config, ns string) (libcontainerdtypes.Client, error) {
+	if cfg.ContainerdAddr == "" {
+		return nil, errors.New("containerd namespaces require containerd")
+	}
+	return remote.NewClient(
+		ctx,
+		daemon.containerdClient,
+		filepath.Join(cfg.ExecRoot, "containerd"),
+		ns,
+		daemon,
+	)
+}
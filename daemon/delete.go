@@ -169,6 +169,11 @@ func (daemon *Daemon) cleanupContainer(ctr *container.Container, config backend.
 	selinux.ReleaseLabel(ctr.ProcessLabel)
 	daemon.containers.Delete(ctr.ID)
 	daemon.containersReplica.Delete(ctr)
+	if daemon.containerConfigs != nil {
+		if err := daemon.containerConfigs.Release(ctr.ConfigDigest); err != nil {
+			log.G(context.TODO()).WithError(err).WithField("container", ctr.ID).Warn("Error releasing container config")
+		}
+	}
 	if err := daemon.removeMountPoints(ctr, config.RemoveVolume); err != nil {
 		log.G(context.TODO()).Error(err)
 	}
@@ -126,6 +126,10 @@ func (daemon *Daemon) SystemDiskUsage(ctx context.Context, opts backend.DiskUsag
 			layersSize, err = daemon.layerDiskUsage(ctx)
 			return err
 		})
+		eg.Go(func() (err error) {
+			du.Storage, err = daemon.imageService.StorageDiskUsage(ctx)
+			return err
+		})
 	}
 
 	if opts.Volumes {
@@ -0,0 +1,258 @@
+package daemon
+
+import (
+	"archive/tar"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/containerd/log"
+	containertypes "github.com/moby/moby/api/types/container"
+	"github.com/moby/moby/api/types/filters"
+	networktypes "github.com/moby/moby/api/types/network"
+	systemtypes "github.com/moby/moby/api/types/system"
+	volumetypes "github.com/moby/moby/api/types/volume"
+	"github.com/moby/moby/v2/daemon/network"
+	"github.com/moby/moby/v2/daemon/server/backend"
+	volumeopts "github.com/moby/moby/v2/daemon/volume/service/opts"
+	"github.com/moby/moby/v2/errdefs"
+	"github.com/pkg/errors"
+)
+
+// bundleVersion is the format version of disaster recovery bundles. It is
+// recorded in the bundle manifest and checked on import.
+const bundleVersion = 1
+
+// maxBundleEntrySize is the maximum size of a single entry in a disaster
+// recovery bundle. Entries hold JSON-encoded configuration, so anything
+// larger than this is not a valid bundle and is rejected on import rather
+// than read into memory.
+const maxBundleEntrySize = 10 << 20 // 10 MiB
+
+// bundleManifest is the first entry of a disaster recovery bundle.
+type bundleManifest struct {
+	Version  int
+	DaemonID string
+	Created  time.Time
+}
+
+// bundleContainer is the configuration of a single container stored in a
+// disaster recovery bundle. Only configuration is captured; container
+// writable-layer data and volume contents are deliberately excluded.
+type bundleContainer struct {
+	ID         string
+	Name       string
+	Created    time.Time
+	Config     *containertypes.Config
+	HostConfig *containertypes.HostConfig
+}
+
+// SystemExportBundle writes a disaster recovery bundle to w: a tar stream
+// containing the daemon configuration, user-defined network definitions,
+// volume metadata and container configurations (not data). The bundle can
+// be re-imported on a fresh host with SystemImportBundle.
+func (daemon *Daemon) SystemExportBundle(ctx context.Context, w io.Writer) error {
+	tw := tar.NewWriter(w)
+
+	writeJSON := func(name string, v any) error {
+		data, err := json.MarshalIndent(v, "", "    ")
+		if err != nil {
+			return errors.Wrapf(err, "error marshaling %s", name)
+		}
+		if err := tw.WriteHeader(&tar.Header{
+			Name:    name,
+			Mode:    0o600,
+			Size:    int64(len(data)),
+			ModTime: time.Now().UTC(),
+		}); err != nil {
+			return err
+		}
+		_, err = tw.Write(data)
+		return err
+	}
+
+	if err := writeJSON("manifest.json", bundleManifest{
+		Version:  bundleVersion,
+		DaemonID: daemon.id,
+		Created:  time.Now().UTC(),
+	}); err != nil {
+		return err
+	}
+
+	if err := writeJSON("daemon-config.json", daemon.config().Config); err != nil {
+		return err
+	}
+
+	networks, err := daemon.GetNetworks(filters.NewArgs(), backend.NetworkListConfig{})
+	if err != nil {
+		return err
+	}
+	userNetworks := networks[:0]
+	for _, nw := range networks {
+		if network.IsPredefined(nw.Name) {
+			continue
+		}
+		userNetworks = append(userNetworks, nw)
+	}
+	if err := writeJSON("networks.json", userNetworks); err != nil {
+		return err
+	}
+
+	volumes, warnings, err := daemon.volumes.List(ctx, filters.NewArgs())
+	if err != nil {
+		return err
+	}
+	for _, warning := range warnings {
+		log.G(ctx).WithField("warning", warning).Warn("Warning while listing volumes for DR bundle")
+	}
+	if err := writeJSON("volumes.json", volumes); err != nil {
+		return err
+	}
+
+	for _, ctr := range daemon.containers.List() {
+		ctr.Lock()
+		entry := bundleContainer{
+			ID:         ctr.ID,
+			Name:       ctr.Name,
+			Created:    ctr.Created,
+			Config:     ctr.Config,
+			HostConfig: ctr.HostConfig,
+		}
+		ctr.Unlock()
+		if err := writeJSON(path.Join("containers", entry.ID+".json"), entry); err != nil {
+			return err
+		}
+	}
+
+	return tw.Close()
+}
+
+// SystemImportBundle restores a disaster recovery bundle from r, re-creating
+// user-defined networks, volumes and containers from their stored
+// configurations. Objects that cannot be restored (for example containers
+// whose image is not present) are reported as warnings rather than failing
+// the whole import.
+func (daemon *Daemon) SystemImportBundle(ctx context.Context, r io.Reader) (*systemtypes.BundleRestoreReport, error) {
+	tr := tar.NewReader(r)
+	report := &systemtypes.BundleRestoreReport{}
+	sawManifest := false
+
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, errdefs.InvalidParameter(errors.Wrap(err, "error reading bundle"))
+		}
+		if hdr.Size > maxBundleEntrySize {
+			return nil, errdefs.InvalidParameter(errors.Errorf("bundle entry %s exceeds the maximum size of %d bytes", hdr.Name, maxBundleEntrySize))
+		}
+		data, err := io.ReadAll(io.LimitReader(tr, maxBundleEntrySize))
+		if err != nil {
+			return nil, errdefs.InvalidParameter(errors.Wrapf(err, "error reading bundle entry %s", hdr.Name))
+		}
+
+		name := path.Clean(hdr.Name)
+		switch {
+		case name == "manifest.json":
+			var manifest bundleManifest
+			if err := json.Unmarshal(data, &manifest); err != nil {
+				return nil, errdefs.InvalidParameter(errors.Wrap(err, "error parsing bundle manifest"))
+			}
+			if manifest.Version != bundleVersion {
+				return nil, errdefs.InvalidParameter(errors.Errorf("unsupported bundle version %d", manifest.Version))
+			}
+			sawManifest = true
+		case name == "daemon-config.json":
+			// The daemon configuration is included for operators to
+			// compare against; it is not applied automatically.
+		case name == "networks.json":
+			var networks []networktypes.Inspect
+			if err := json.Unmarshal(data, &networks); err != nil {
+				return nil, errdefs.InvalidParameter(errors.Wrap(err, "error parsing bundle networks"))
+			}
+			daemon.restoreBundleNetworks(ctx, networks, report)
+		case name == "volumes.json":
+			var volumes []*volumetypes.Volume
+			if err := json.Unmarshal(data, &volumes); err != nil {
+				return nil, errdefs.InvalidParameter(errors.Wrap(err, "error parsing bundle volumes"))
+			}
+			daemon.restoreBundleVolumes(ctx, volumes, report)
+		case path.Dir(name) == "containers":
+			var ctr bundleContainer
+			if err := json.Unmarshal(data, &ctr); err != nil {
+				return nil, errdefs.InvalidParameter(errors.Wrapf(err, "error parsing bundle entry %s", name))
+			}
+			daemon.restoreBundleContainer(ctx, &ctr, report)
+		default:
+			report.Warnings = append(report.Warnings, fmt.Sprintf("ignoring unknown bundle entry %s", name))
+		}
+	}
+
+	if !sawManifest {
+		return nil, errdefs.InvalidParameter(errors.New("bundle has no manifest"))
+	}
+	return report, nil
+}
+
+func (daemon *Daemon) restoreBundleNetworks(ctx context.Context, networks []networktypes.Inspect, report *systemtypes.BundleRestoreReport) {
+	for _, nw := range networks {
+		if nw.Ingress {
+			report.Warnings = append(report.Warnings, fmt.Sprintf("network %s: ingress networks are managed by swarm and are not restored", nw.Name))
+			continue
+		}
+		nw := nw
+		_, err := daemon.CreateNetwork(ctx, networktypes.CreateRequest{
+			Name: nw.Name,
+			CreateOptions: networktypes.CreateOptions{
+				Driver:     nw.Driver,
+				EnableIPv4: &nw.EnableIPv4,
+				EnableIPv6: &nw.EnableIPv6,
+				IPAM:       &nw.IPAM,
+				Internal:   nw.Internal,
+				Attachable: nw.Attachable,
+				ConfigOnly: nw.ConfigOnly,
+				Options:    nw.Options,
+				Labels:     nw.Labels,
+			},
+		})
+		if err != nil {
+			report.Warnings = append(report.Warnings, fmt.Sprintf("network %s: %v", nw.Name, err))
+			continue
+		}
+		report.Networks++
+	}
+}
+
+func (daemon *Daemon) restoreBundleVolumes(ctx context.Context, volumes []*volumetypes.Volume, report *systemtypes.BundleRestoreReport) {
+	for _, v := range volumes {
+		_, err := daemon.volumes.Create(ctx, v.Name, v.Driver,
+			volumeopts.WithCreateOptions(v.Options),
+			volumeopts.WithCreateLabels(v.Labels),
+		)
+		if err != nil {
+			report.Warnings = append(report.Warnings, fmt.Sprintf("volume %s: %v", v.Name, err))
+			continue
+		}
+		report.Volumes++
+	}
+}
+
+func (daemon *Daemon) restoreBundleContainer(ctx context.Context, ctr *bundleContainer, report *systemtypes.BundleRestoreReport) {
+	name := strings.TrimPrefix(ctr.Name, "/")
+	_, err := daemon.ContainerCreate(ctx, backend.ContainerCreateConfig{
+		Name:       name,
+		Config:     ctr.Config,
+		HostConfig: ctr.HostConfig,
+	})
+	if err != nil {
+		report.Warnings = append(report.Warnings, fmt.Sprintf("container %s: %v", name, err))
+		return
+	}
+	report.Containers++
+}
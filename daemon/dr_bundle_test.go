@@ -0,0 +1,109 @@
+package daemon
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	cerrdefs "github.com/containerd/errdefs"
+	"gotest.tools/v3/assert"
+	is "gotest.tools/v3/assert/cmp"
+)
+
+// bundleEntry is a single tar entry of a test bundle. A negative size
+// overrides the header size without writing more data, to exercise the
+// size cap without building a huge archive.
+type bundleEntry struct {
+	name string
+	data string
+	size int64
+}
+
+func makeBundle(t *testing.T, entries []bundleEntry) *bytes.Buffer {
+	t.Helper()
+	var buf bytes.Buffer
+	tw := tar.NewWriter(&buf)
+	for _, e := range entries {
+		size := int64(len(e.data))
+		if e.size > 0 {
+			size = e.size
+		}
+		assert.NilError(t, tw.WriteHeader(&tar.Header{
+			Name:    e.name,
+			Mode:    0o600,
+			Size:    size,
+			ModTime: time.Now().UTC(),
+		}))
+		_, err := tw.Write([]byte(e.data))
+		assert.NilError(t, err)
+	}
+	// The trailing blocks are deliberately not flushed for oversized
+	// entries; validation fails on the header before reading the body.
+	_ = tw.Close()
+	return &buf
+}
+
+func TestSystemImportBundleValidation(t *testing.T) {
+	manifest := bundleEntry{name: "manifest.json", data: `{"Version": 1, "DaemonID": "abc"}`}
+
+	tests := []struct {
+		doc         string
+		entries     []bundleEntry
+		expectedErr string
+	}{
+		{
+			doc:         "empty bundle",
+			entries:     nil,
+			expectedErr: "bundle has no manifest",
+		},
+		{
+			doc:         "missing manifest",
+			entries:     []bundleEntry{{name: "daemon-config.json", data: `{}`}},
+			expectedErr: "bundle has no manifest",
+		},
+		{
+			doc:         "malformed manifest",
+			entries:     []bundleEntry{{name: "manifest.json", data: `{]`}},
+			expectedErr: "error parsing bundle manifest",
+		},
+		{
+			doc:         "unsupported version",
+			entries:     []bundleEntry{{name: "manifest.json", data: `{"Version": 99}`}},
+			expectedErr: "unsupported bundle version 99",
+		},
+		{
+			doc:         "oversized entry",
+			entries:     []bundleEntry{manifest, {name: "networks.json", size: maxBundleEntrySize + 1}},
+			expectedErr: "exceeds the maximum size",
+		},
+		{
+			doc:         "malformed container entry",
+			entries:     []bundleEntry{manifest, {name: "containers/abc.json", data: `{]`}},
+			expectedErr: "error parsing bundle entry containers/abc.json",
+		},
+	}
+
+	d := &Daemon{}
+	for _, tc := range tests {
+		t.Run(tc.doc, func(t *testing.T) {
+			_, err := d.SystemImportBundle(context.Background(), makeBundle(t, tc.entries))
+			assert.Check(t, is.ErrorContains(err, tc.expectedErr))
+			assert.Check(t, cerrdefs.IsInvalidArgument(err))
+		})
+	}
+}
+
+func TestSystemImportBundleWarnsOnUnknownEntries(t *testing.T) {
+	bundle := makeBundle(t, []bundleEntry{
+		{name: "manifest.json", data: `{"Version": 1, "DaemonID": "abc"}`},
+		{name: "daemon-config.json", data: `{}`},
+		{name: "images/extra.json", data: `{}`},
+	})
+
+	report, err := (&Daemon{}).SystemImportBundle(context.Background(), bundle)
+	assert.NilError(t, err)
+	assert.Check(t, is.DeepEqual(report.Warnings, []string{"ignoring unknown bundle entry images/extra.json"}))
+	assert.Check(t, is.Equal(report.Containers, 0))
+}
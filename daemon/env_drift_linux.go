@@ -0,0 +1,85 @@
+package daemon
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	containertypes "github.com/moby/moby/api/types/container"
+	"github.com/moby/moby/v2/errdefs"
+	"github.com/pkg/errors"
+)
+
+// ContainerEnvDrift compares the environment the daemon configured for the
+// container with the environment of its running init process, read from
+// /proc/<pid>/environ, and reports variables that were added, removed or
+// changed since the process started. This is a diagnostic aid for spotting
+// entrypoint scripts that export secrets or otherwise mutate the environment.
+// It requires the daemon to be started with the "env-drift-check" feature
+// enabled.
+func (daemon *Daemon) ContainerEnvDrift(name string) (*containertypes.EnvDriftResponse, error) {
+	if !daemon.config().Features["env-drift-check"] {
+		return nil, errdefs.Forbidden(errors.New(`env drift detection is disabled; start the daemon with the "env-drift-check" feature enabled to use it`))
+	}
+
+	ctr, err := daemon.GetContainer(name)
+	if err != nil {
+		return nil, err
+	}
+	if !ctr.IsRunning() {
+		return nil, errNotRunning(ctr.ID)
+	}
+
+	linkedEnv, err := daemon.setupLinkedContainers(ctr)
+	if err != nil {
+		return nil, err
+	}
+	configured := ctr.CreateDaemonEnvironment(ctr.Config.Tty, linkedEnv)
+
+	data, err := os.ReadFile(fmt.Sprintf("/proc/%d/environ", ctr.State.GetPID()))
+	if err != nil {
+		return nil, errdefs.System(errors.Wrap(err, "error reading the environment of the container process"))
+	}
+	var actual []string
+	for _, kv := range bytes.Split(data, []byte{0}) {
+		if len(kv) > 0 {
+			actual = append(actual, string(kv))
+		}
+	}
+
+	return envDrift(configured, actual), nil
+}
+
+// envDrift compares two environments given as KEY=value pairs and reports
+// the names of variables that differ between them.
+func envDrift(configured, actual []string) *containertypes.EnvDriftResponse {
+	parse := func(env []string) map[string]string {
+		m := make(map[string]string, len(env))
+		for _, kv := range env {
+			k, v, _ := strings.Cut(kv, "=")
+			m[k] = v
+		}
+		return m
+	}
+	want, got := parse(configured), parse(actual)
+
+	drift := &containertypes.EnvDriftResponse{}
+	for k, v := range got {
+		if wv, ok := want[k]; !ok {
+			drift.Added = append(drift.Added, k)
+		} else if v != wv {
+			drift.Changed = append(drift.Changed, k)
+		}
+	}
+	for k := range want {
+		if _, ok := got[k]; !ok {
+			drift.Removed = append(drift.Removed, k)
+		}
+	}
+	sort.Strings(drift.Added)
+	sort.Strings(drift.Removed)
+	sort.Strings(drift.Changed)
+	return drift
+}
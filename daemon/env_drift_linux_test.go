@@ -0,0 +1,34 @@
+package daemon
+
+import (
+	"testing"
+
+	"gotest.tools/v3/assert"
+	is "gotest.tools/v3/assert/cmp"
+)
+
+func TestEnvDrift(t *testing.T) {
+	configured := []string{
+		"PATH=/usr/local/sbin:/usr/local/bin:/usr/sbin:/usr/bin:/sbin:/bin",
+		"HOSTNAME=deadbeef",
+		"FOO=bar",
+		"DROPPED=yes",
+	}
+	actual := []string{
+		"PATH=/usr/local/sbin:/usr/local/bin:/usr/sbin:/usr/bin:/sbin:/bin",
+		"HOSTNAME=deadbeef",
+		"FOO=baz",
+		"SECRET_TOKEN=hunter2",
+		"API_KEY=12345",
+	}
+
+	drift := envDrift(configured, actual)
+	assert.Check(t, is.DeepEqual(drift.Added, []string{"API_KEY", "SECRET_TOKEN"}))
+	assert.Check(t, is.DeepEqual(drift.Removed, []string{"DROPPED"}))
+	assert.Check(t, is.DeepEqual(drift.Changed, []string{"FOO"}))
+
+	drift = envDrift(configured, configured)
+	assert.Check(t, is.Nil(drift.Added))
+	assert.Check(t, is.Nil(drift.Removed))
+	assert.Check(t, is.Nil(drift.Changed))
+}
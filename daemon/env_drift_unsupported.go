@@ -0,0 +1,15 @@
+//go:build !linux
+
+package daemon
+
+import (
+	"errors"
+
+	containertypes "github.com/moby/moby/api/types/container"
+	"github.com/moby/moby/v2/errdefs"
+)
+
+// ContainerEnvDrift is not supported on this platform.
+func (daemon *Daemon) ContainerEnvDrift(name string) (*containertypes.EnvDriftResponse, error) {
+	return nil, errdefs.NotImplemented(errors.New("env drift detection is not supported on this platform"))
+}
@@ -10,12 +10,54 @@ import (
 	gogotypes "github.com/gogo/protobuf/types"
 	"github.com/moby/moby/api/types/events"
 	"github.com/moby/moby/api/types/filters"
+	"github.com/moby/moby/v2/daemon/config"
 	"github.com/moby/moby/v2/daemon/container"
 	daemonevents "github.com/moby/moby/v2/daemon/events"
 	"github.com/moby/moby/v2/daemon/libnetwork"
 	swarmapi "github.com/moby/swarmkit/v2/api"
 )
 
+// startEventSinks attaches the webhook event sinks configured through
+// "event-sinks" to the daemon's event service.
+func (daemon *Daemon) startEventSinks(conf *config.Config) {
+	for _, sc := range conf.EventSinks {
+		f := filters.NewArgs()
+		for key, values := range sc.Filters {
+			for _, v := range values {
+				f.Add(key, v)
+			}
+		}
+		var ef *daemonevents.Filter
+		if f.Len() > 0 {
+			ef = daemonevents.NewFilter(f)
+		}
+		sink := daemonevents.NewWebhookSink(sc.URL, sc.Secret, sc.Retries, ef)
+		sink.Attach(daemon.EventsService)
+		daemon.eventSinks = append(daemon.eventSinks, sink)
+	}
+	if conf.JournaldEvents {
+		if sink := daemonevents.NewJournaldSink(); sink != nil {
+			sink.Attach(daemon.EventsService)
+			daemon.journaldSink = sink
+		} else {
+			log.G(context.TODO()).Warn(`Option "journald-events" is enabled, but the systemd journal is not available`)
+		}
+	}
+}
+
+// closeEventSinks detaches all webhook event sinks and the journald sink,
+// waiting for in-flight deliveries to finish.
+func (daemon *Daemon) closeEventSinks() {
+	for _, sink := range daemon.eventSinks {
+		sink.Close()
+	}
+	daemon.eventSinks = nil
+	if daemon.journaldSink != nil {
+		daemon.journaldSink.Close()
+		daemon.journaldSink = nil
+	}
+}
+
 // LogContainerEvent generates an event related to a container with only the default attributes.
 func (daemon *Daemon) LogContainerEvent(container *container.Container, action events.Action) {
 	daemon.LogContainerEventWithAttributes(container, action, map[string]string{})
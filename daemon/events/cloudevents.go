@@ -0,0 +1,45 @@
+package events
+
+import (
+	"fmt"
+	"time"
+
+	eventtypes "github.com/moby/moby/api/types/events"
+)
+
+// cloudEventSource is the CloudEvents "source" attribute used for events
+// emitted by the daemon.
+const cloudEventSource = "/moby/daemon"
+
+// CloudEvent is a CloudEvents v1.0 envelope in its JSON representation, as
+// delivered by webhook event sinks. The original event message is carried
+// unmodified in the "data" attribute.
+//
+// See https://github.com/cloudevents/spec/blob/v1.0.2/cloudevents/spec.md
+type CloudEvent struct {
+	SpecVersion     string             `json:"specversion"`
+	ID              string             `json:"id"`
+	Source          string             `json:"source"`
+	Type            string             `json:"type"`
+	Subject         string             `json:"subject,omitempty"`
+	Time            string             `json:"time,omitempty"`
+	DataContentType string             `json:"datacontenttype,omitempty"`
+	Data            eventtypes.Message `json:"data"`
+}
+
+// ToCloudEvent wraps an event message in a CloudEvents v1.0 envelope. The
+// "type" attribute is derived from the event type and action (for example
+// "com.docker.container.start"), and the "subject" is the ID of the actor
+// the event relates to.
+func ToCloudEvent(m eventtypes.Message) CloudEvent {
+	return CloudEvent{
+		SpecVersion:     "1.0",
+		ID:              fmt.Sprintf("%d-%s-%s", m.TimeNano, m.Type, m.Actor.ID),
+		Source:          cloudEventSource,
+		Type:            fmt.Sprintf("com.docker.%s.%s", m.Type, m.Action),
+		Subject:         m.Actor.ID,
+		Time:            time.Unix(0, m.TimeNano).UTC().Format(time.RFC3339Nano),
+		DataContentType: "application/json",
+		Data:            m,
+	}
+}
@@ -1,31 +1,102 @@
 package events
 
 import (
+	"context"
+	"math"
+	"strconv"
 	"sync"
 	"time"
 
+	"github.com/containerd/log"
 	eventtypes "github.com/moby/moby/api/types/events"
+	"github.com/moby/moby/v2/daemon/internal/clock"
 	"github.com/moby/moby/v2/daemon/internal/metrics"
-	"github.com/moby/pubsub"
 )
 
 const (
 	eventsLimit = 256
 	bufferSize  = 1024
+
+	// maxDroppedEvents is the number of consecutive undelivered events
+	// after which a subscriber is considered stuck and is disconnected.
+	// With the default bufferSize this means the consumer did not drain
+	// a single event while two full buffers' worth were published.
+	maxDroppedEvents = bufferSize
+
+	// journalQueueDepth is the capacity of the queue feeding the journal
+	// writer goroutine. Events published while the queue is full are not
+	// journaled, so a slow disk cannot stall publishers.
+	journalQueueDepth = 1024
 )
 
+// subscriber is a single events listener with its own bounded queue, so a
+// slow consumer can neither block delivery to other subscribers nor cause
+// unbounded memory growth in the daemon.
+type subscriber struct {
+	ch    chan interface{}
+	topic func(m interface{}) bool
+	// dropped counts events not delivered since the last successful send.
+	// It is surfaced to the consumer as an ActionEventsDropped message
+	// once there is room in the queue again.
+	dropped uint64
+}
+
 // Events is pubsub channel for events generated by the engine.
 type Events struct {
-	mu     sync.Mutex
-	events []eventtypes.Message
-	pub    *pubsub.Publisher
+	mu          sync.Mutex
+	events      []eventtypes.Message
+	subs        map[chan interface{}]*subscriber
+	journal     *Journal
+	journalCh   chan eventtypes.Message
+	journalDone chan struct{}
+	clock       clock.Clock
 }
 
 // New returns new *Events instance
 func New() *Events {
 	return &Events{
 		events: make([]eventtypes.Message, 0, eventsLimit),
-		pub:    pubsub.NewPublisher(100*time.Millisecond, bufferSize),
+		subs:   make(map[chan interface{}]*subscriber),
+		clock:  clock.System(),
+	}
+}
+
+// AttachJournal makes e persist every published event to j, and serve
+// event history from it for time ranges that predate the in-memory buffer.
+// Events are handed to a writer goroutine so that publishers never wait on
+// disk I/O; DetachJournal drains the writer and must be called before the
+// journal is closed.
+func (e *Events) AttachJournal(j *Journal) {
+	ch := make(chan eventtypes.Message, journalQueueDepth)
+	done := make(chan struct{})
+	e.mu.Lock()
+	e.journal = j
+	e.journalCh = ch
+	e.journalDone = done
+	e.mu.Unlock()
+
+	go func() {
+		defer close(done)
+		for m := range ch {
+			if err := j.Append(m); err != nil {
+				log.G(context.TODO()).WithError(err).Warn("error writing event to journal")
+			}
+		}
+	}()
+}
+
+// DetachJournal stops journaling events, draining any queued ones to disk
+// first.
+func (e *Events) DetachJournal() {
+	e.mu.Lock()
+	ch, done := e.journalCh, e.journalDone
+	e.journal = nil
+	e.journalCh = nil
+	e.journalDone = nil
+	e.mu.Unlock()
+	if ch != nil {
+		close(ch)
+		<-done
 	}
 }
 
@@ -35,10 +106,11 @@ func New() *Events {
 // to stop the stream of events.
 func (e *Events) Subscribe() ([]eventtypes.Message, chan interface{}, func()) {
 	metrics.EventSubscribers.Inc()
+	metrics.AddEventSubscriber(context.TODO(), 1)
 	e.mu.Lock()
 	current := make([]eventtypes.Message, len(e.events))
 	copy(current, e.events)
-	l := e.pub.Subscribe()
+	l := e.subscribe(nil)
 	e.mu.Unlock()
 
 	cancel := func() {
@@ -52,6 +124,7 @@ func (e *Events) Subscribe() ([]eventtypes.Message, chan interface{}, func()) {
 // of interface{}, so you need type assertion).
 func (e *Events) SubscribeTopic(since, until time.Time, ef *Filter) ([]eventtypes.Message, chan interface{}) {
 	metrics.EventSubscribers.Inc()
+	metrics.AddEventSubscriber(context.TODO(), 1)
 	e.mu.Lock()
 
 	var topic func(m interface{}) bool
@@ -60,28 +133,65 @@ func (e *Events) SubscribeTopic(since, until time.Time, ef *Filter) ([]eventtype
 	}
 
 	buffered := e.loadBufferedEvents(since, until, topic)
-
-	var ch chan interface{}
-	if topic != nil {
-		ch = e.pub.SubscribeTopic(topic)
-	} else {
-		// Subscribe to all events if there are no filters
-		ch = e.pub.Subscribe()
+	bufferStartNano := int64(math.MaxInt64)
+	if len(e.events) > 0 {
+		bufferStartNano = e.events[0].TimeNano
 	}
+	j := e.journal
+	ch := e.subscribe(topic)
 
 	e.mu.Unlock()
+
+	// The requested range may start before the in-memory buffer does (for
+	// example when it spans a daemon restart); serve the part of it that
+	// the buffer cannot from the journal. Journal files are read outside
+	// the lock so that replaying history does not stall publishers. Events
+	// published meanwhile are delivered through ch, which is already
+	// subscribed, and never overlap the replayed range.
+	if j != nil && !since.IsZero() {
+		sinceNanoUnix := since.UnixNano()
+		beforeNano := bufferStartNano
+		if !until.IsZero() && until.UnixNano() < beforeNano {
+			// until is inclusive, the journal's upper bound is not.
+			beforeNano = until.UnixNano() + 1
+		}
+		if sinceNanoUnix < beforeNano {
+			buffered = append(j.load(sinceNanoUnix, beforeNano, topic), buffered...)
+		}
+	}
 	return buffered, ch
 }
 
-// Evict evicts listener from pubsub
+// subscribe registers a new subscriber with its own bounded queue.
+// Callers must hold e.mu.
+func (e *Events) subscribe(topic func(m interface{}) bool) chan interface{} {
+	l := make(chan interface{}, bufferSize)
+	e.subs[l] = &subscriber{ch: l, topic: topic}
+	return l
+}
+
+// Evict evicts listener from pubsub. It is safe to call for listeners that
+// were already disconnected by the daemon.
 func (e *Events) Evict(l chan interface{}) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.evict(l)
+}
+
+// evict removes the listener and closes its channel. Callers must hold e.mu.
+func (e *Events) evict(l chan interface{}) {
+	if _, exists := e.subs[l]; !exists {
+		return
+	}
+	delete(e.subs, l)
+	close(l)
 	metrics.EventSubscribers.Dec()
-	e.pub.Evict(l)
+	metrics.AddEventSubscriber(context.TODO(), -1)
 }
 
 // Log creates a local scope message and publishes it
 func (e *Events) Log(action eventtypes.Action, eventType eventtypes.Type, actor eventtypes.Actor) {
-	now := time.Now().UTC()
+	now := e.clock.Now().UTC()
 	jm := eventtypes.Message{
 		Action:   action,
 		Type:     eventType,
@@ -107,12 +217,17 @@ func (e *Events) Log(action eventtypes.Action, eventType eventtypes.Type, actor
 	e.PublishMessage(jm)
 }
 
-// PublishMessage broadcasts event to listeners. Each listener has 100 milliseconds to
-// receive the event or it will be skipped.
+// PublishMessage broadcasts event to listeners. Each listener has a bounded
+// queue of bufferSize events; events published while the queue is full are
+// dropped for that listener only, and the number of dropped events is
+// surfaced in its stream once it catches up. Listeners that keep falling
+// behind are disconnected.
 func (e *Events) PublishMessage(jm eventtypes.Message) {
 	metrics.EventsCounter.Inc()
+	metrics.RecordEventLogged(context.TODO())
 
 	e.mu.Lock()
+	defer e.mu.Unlock()
 	if len(e.events) == cap(e.events) {
 		// discard oldest event
 		copy(e.events, e.events[1:])
@@ -120,13 +235,60 @@ func (e *Events) PublishMessage(jm eventtypes.Message) {
 	} else {
 		e.events = append(e.events, jm)
 	}
-	e.mu.Unlock()
-	e.pub.Publish(jm)
+
+	if e.journalCh != nil {
+		select {
+		case e.journalCh <- jm:
+		default:
+			// The journal writer is stalled on disk I/O; losing the entry
+			// is preferable to blocking every publisher in the daemon.
+			log.G(context.TODO()).Warn("Event journal is falling behind; event not journaled")
+		}
+	}
+
+	for l, sub := range e.subs {
+		if sub.topic != nil && !sub.topic(jm) {
+			continue
+		}
+		if sub.dropped > 0 {
+			// Surface the gap to the consumer before resuming delivery.
+			select {
+			case sub.ch <- droppedNotice(sub.dropped, e.clock.Now().UTC()):
+				sub.dropped = 0
+			default:
+			}
+		}
+		select {
+		case sub.ch <- jm:
+		default:
+			sub.dropped++
+			if sub.dropped >= maxDroppedEvents {
+				e.evict(l)
+			}
+		}
+	}
+}
+
+// droppedNotice creates the message injected in a subscriber's stream in
+// place of the n events that were dropped for it.
+func droppedNotice(n uint64, now time.Time) eventtypes.Message {
+	return eventtypes.Message{
+		Action: eventtypes.ActionEventsDropped,
+		Type:   eventtypes.DaemonEventType,
+		Actor: eventtypes.Actor{
+			Attributes: map[string]string{"count": strconv.FormatUint(n, 10)},
+		},
+		Scope:    "local",
+		Time:     now.Unix(),
+		TimeNano: now.UnixNano(),
+	}
 }
 
 // SubscribersCount returns number of event listeners
 func (e *Events) SubscribersCount() int {
-	return e.pub.Len()
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return len(e.subs)
 }
 
 // loadBufferedEvents iterates over the cached events in the buffer
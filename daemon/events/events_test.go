@@ -8,6 +8,7 @@ import (
 	"github.com/moby/moby/api/types/events"
 	timetypes "github.com/moby/moby/api/types/time"
 	eventstestutils "github.com/moby/moby/v2/daemon/events/testutils"
+	"github.com/moby/moby/v2/daemon/internal/clock"
 	"gotest.tools/v3/assert"
 	is "gotest.tools/v3/assert/cmp"
 )
@@ -224,3 +225,67 @@ func TestIgnoreBufferedWhenNoTimes(t *testing.T) {
 	messages := evts.loadBufferedEvents(since, until, nil)
 	assert.Assert(t, is.Len(messages, 0))
 }
+
+func TestSlowSubscriberDropAccounting(t *testing.T) {
+	e := New()
+	_, l, cancel := e.Subscribe()
+	defer cancel()
+
+	// Fill the subscriber's queue and overflow it by a few events.
+	for i := 0; i < bufferSize+5; i++ {
+		e.Log("overflow", events.ContainerEventType, events.Actor{ID: "cont"})
+	}
+	for i := 0; i < bufferSize; i++ {
+		<-l
+	}
+
+	// Once the consumer caught up, the gap is surfaced in the stream
+	// before delivery resumes.
+	e.Log("resumed", events.ContainerEventType, events.Actor{ID: "cont"})
+
+	notice, ok := (<-l).(events.Message)
+	assert.Assert(t, ok)
+	assert.Check(t, is.Equal(notice.Action, events.ActionEventsDropped))
+	assert.Check(t, is.Equal(notice.Type, events.DaemonEventType))
+	assert.Check(t, is.Equal(notice.Actor.Attributes["count"], "5"))
+
+	msg, ok := (<-l).(events.Message)
+	assert.Assert(t, ok)
+	assert.Check(t, is.Equal(msg.Action, events.Action("resumed")))
+}
+
+func TestStuckSubscriberDisconnected(t *testing.T) {
+	e := New()
+	_, l, cancel := e.Subscribe()
+	defer cancel()
+
+	// A consumer that does not drain anything while its queue overflows
+	// by maxDroppedEvents is disconnected.
+	for i := 0; i < bufferSize+maxDroppedEvents; i++ {
+		e.Log("flood", events.ContainerEventType, events.Actor{ID: "cont"})
+	}
+	assert.Check(t, is.Equal(e.SubscribersCount(), 0))
+
+	// The channel was closed; the queued events remain readable.
+	var received int
+	for range l {
+		received++
+	}
+	assert.Check(t, is.Equal(received, bufferSize))
+}
+
+func TestEventTimestampsUseClock(t *testing.T) {
+	e := New()
+	now := time.Date(2024, 1, 1, 12, 0, 0, 123456789, time.UTC)
+	e.clock = clock.NewFake(now)
+
+	e.Log("test", events.ContainerEventType, events.Actor{ID: "cont"})
+
+	current, l, cancel := e.Subscribe()
+	defer cancel()
+	defer e.Evict(l)
+
+	assert.Assert(t, is.Len(current, 1))
+	assert.Check(t, is.Equal(current[0].Time, now.Unix()))
+	assert.Check(t, is.Equal(current[0].TimeNano, now.UnixNano()))
+}
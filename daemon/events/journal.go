@@ -0,0 +1,170 @@
+package events
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/containerd/log"
+	eventtypes "github.com/moby/moby/api/types/events"
+	"github.com/pkg/errors"
+)
+
+const (
+	journalFileName = "events.log"
+
+	defaultJournalMaxFileSize int64 = 10 << 20 // 10 MiB
+	defaultJournalMaxFiles          = 5
+
+	// journalMaxEventSize bounds a single JSON-encoded event line when
+	// reading the journal back; longer lines abort replay of that file.
+	journalMaxEventSize = 1 << 20 // 1 MiB
+)
+
+// Journal persists events to disk as JSON lines so that event history
+// survives daemon restarts. The journal is written to a fixed-name file
+// that is rotated once it exceeds the configured size, keeping a bounded
+// number of rotated files ("events.log.1" being the most recently rotated).
+type Journal struct {
+	mu          sync.Mutex
+	path        string
+	maxFileSize int64
+	maxFiles    int
+	f           *os.File
+	size        int64
+}
+
+// NewJournal opens (or creates) an event journal in dir. maxFileSize is the
+// size, in bytes, at which the journal file is rotated, and maxFiles is the
+// total number of files retained including the active one; values <= 0
+// select the defaults.
+func NewJournal(dir string, maxFileSize int64, maxFiles int) (*Journal, error) {
+	if maxFileSize <= 0 {
+		maxFileSize = defaultJournalMaxFileSize
+	}
+	if maxFiles <= 0 {
+		maxFiles = defaultJournalMaxFiles
+	}
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, errors.Wrap(err, "error creating event journal directory")
+	}
+	j := &Journal{
+		path:        filepath.Join(dir, journalFileName),
+		maxFileSize: maxFileSize,
+		maxFiles:    maxFiles,
+	}
+	if err := j.open(); err != nil {
+		return nil, err
+	}
+	return j, nil
+}
+
+// open opens the active journal file for appending. Callers must hold j.mu
+// (or hold the only reference to j).
+func (j *Journal) open() error {
+	f, err := os.OpenFile(j.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o600)
+	if err != nil {
+		return errors.Wrap(err, "error opening event journal")
+	}
+	fi, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return errors.Wrap(err, "error opening event journal")
+	}
+	j.f = f
+	j.size = fi.Size()
+	return nil
+}
+
+// Append writes the event to the journal, rotating the journal file first
+// if appending would exceed the configured maximum size.
+func (j *Journal) Append(m eventtypes.Message) error {
+	b, err := json.Marshal(m)
+	if err != nil {
+		return errors.Wrap(err, "error encoding event")
+	}
+	b = append(b, '\n')
+
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	if j.size > 0 && j.size+int64(len(b)) > j.maxFileSize {
+		if err := j.rotate(); err != nil {
+			return err
+		}
+	}
+	n, err := j.f.Write(b)
+	j.size += int64(n)
+	return err
+}
+
+// rotate shifts the rotated journal files up by one, discarding the oldest,
+// and starts a fresh active file. Callers must hold j.mu.
+func (j *Journal) rotate() error {
+	if err := j.f.Close(); err != nil {
+		return errors.Wrap(err, "error rotating event journal")
+	}
+	for i := j.maxFiles - 1; i > 1; i-- {
+		if err := os.Rename(fmt.Sprintf("%s.%d", j.path, i-1), fmt.Sprintf("%s.%d", j.path, i)); err != nil && !os.IsNotExist(err) {
+			return errors.Wrap(err, "error rotating event journal")
+		}
+	}
+	if j.maxFiles > 1 {
+		if err := os.Rename(j.path, j.path+".1"); err != nil && !os.IsNotExist(err) {
+			return errors.Wrap(err, "error rotating event journal")
+		}
+	} else if err := os.Remove(j.path); err != nil && !os.IsNotExist(err) {
+		return errors.Wrap(err, "error rotating event journal")
+	}
+	return j.open()
+}
+
+// load returns the journaled events with a timestamp in the half-open
+// interval [sinceNano, beforeNano), oldest first, filtered by topic when
+// topic is not nil. Events that fail to decode (for example after a partial
+// write on an unclean shutdown) are skipped.
+func (j *Journal) load(sinceNano, beforeNano int64, topic func(interface{}) bool) []eventtypes.Message {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	var loaded []eventtypes.Message
+	for i := j.maxFiles - 1; i >= 0; i-- {
+		name := j.path
+		if i > 0 {
+			name = fmt.Sprintf("%s.%d", j.path, i)
+		}
+		f, err := os.Open(name)
+		if err != nil {
+			continue
+		}
+		scanner := bufio.NewScanner(f)
+		scanner.Buffer(make([]byte, 0, 64*1024), journalMaxEventSize)
+		for scanner.Scan() {
+			var ev eventtypes.Message
+			if err := json.Unmarshal(scanner.Bytes(), &ev); err != nil {
+				continue
+			}
+			if ev.TimeNano < sinceNano || ev.TimeNano >= beforeNano {
+				continue
+			}
+			if topic == nil || topic(ev) {
+				loaded = append(loaded, ev)
+			}
+		}
+		if err := scanner.Err(); err != nil {
+			log.G(context.TODO()).WithError(err).WithField("file", name).Warn("Error reading event journal; replayed history may be incomplete")
+		}
+		f.Close()
+	}
+	return loaded
+}
+
+// Close closes the journal's active file.
+func (j *Journal) Close() error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	return j.f.Close()
+}
@@ -0,0 +1,79 @@
+package events
+
+import (
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/moby/moby/api/types/events"
+	"gotest.tools/v3/assert"
+	is "gotest.tools/v3/assert/cmp"
+)
+
+func TestJournalSpansRestart(t *testing.T) {
+	dir := t.TempDir()
+	since := time.Now().Add(-time.Minute)
+
+	journal, err := NewJournal(dir, 0, 0)
+	assert.NilError(t, err)
+
+	e := New()
+	e.AttachJournal(journal)
+	e.Log("create", events.ContainerEventType, events.Actor{ID: "cont1"})
+	e.Log("die", events.ContainerEventType, events.Actor{ID: "cont1"})
+	e.DetachJournal()
+	assert.NilError(t, journal.Close())
+
+	// Simulate a daemon restart: a fresh Events instance with an empty
+	// in-memory buffer, backed by the same journal directory.
+	journal, err = NewJournal(dir, 0, 0)
+	assert.NilError(t, err)
+	defer journal.Close()
+
+	e = New()
+	e.AttachJournal(journal)
+	e.Log("start", events.ContainerEventType, events.Actor{ID: "cont2"})
+
+	buffered, l := e.SubscribeTopic(since, time.Time{}, nil)
+	defer e.Evict(l)
+	assert.Assert(t, is.Len(buffered, 3))
+	assert.Check(t, is.Equal(buffered[0].Action, events.Action("create")))
+	assert.Check(t, is.Equal(buffered[1].Action, events.Action("die")))
+	assert.Check(t, is.Equal(buffered[2].Action, events.Action("start")))
+
+	// Without a since filter no history is replayed, as before.
+	buffered, l = e.SubscribeTopic(time.Time{}, time.Time{}, nil)
+	defer e.Evict(l)
+	assert.Check(t, is.Len(buffered, 0))
+}
+
+func TestJournalRotation(t *testing.T) {
+	dir := t.TempDir()
+
+	// A tiny file size so that every event triggers a rotation, with two
+	// retained files: the journal must keep only the latest two events.
+	journal, err := NewJournal(dir, 1, 2)
+	assert.NilError(t, err)
+	defer journal.Close()
+
+	for i := 0; i < 5; i++ {
+		now := time.Now().UTC()
+		assert.NilError(t, journal.Append(events.Message{
+			Action:   events.Action(fmt.Sprintf("action%d", i)),
+			Type:     events.ContainerEventType,
+			Time:     now.Unix(),
+			TimeNano: now.UnixNano(),
+		}))
+	}
+
+	loaded := journal.load(0, math.MaxInt64, nil)
+	assert.Assert(t, is.Len(loaded, 2))
+	assert.Check(t, is.Equal(loaded[0].Action, events.Action("action3")))
+	assert.Check(t, is.Equal(loaded[1].Action, events.Action("action4")))
+
+	_, err = os.Stat(filepath.Join(dir, journalFileName+".2"))
+	assert.Check(t, is.ErrorIs(err, os.ErrNotExist))
+}
@@ -0,0 +1,121 @@
+package events
+
+import (
+	"context"
+	"crypto/md5"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github.com/containerd/log"
+	"github.com/coreos/go-systemd/v22/journal"
+	eventtypes "github.com/moby/moby/api/types/events"
+)
+
+// JournaldSink mirrors daemon events to the systemd journal as structured
+// entries, so host-level tooling can correlate journald with engine activity
+// without a separate events consumer. Each event type/action pair gets a
+// stable MESSAGE_ID, and actor metadata is attached as DOCKER_* fields.
+type JournaldSink struct {
+	send func(message string, priority journal.Priority, vars map[string]string) error
+
+	sub internalSink
+}
+
+// NewJournaldSink creates a journald event sink. It returns nil when the
+// systemd journal is not available on the host.
+func NewJournaldSink() *JournaldSink {
+	if !journal.Enabled() {
+		return nil
+	}
+	s := &JournaldSink{
+		send: journal.Send,
+	}
+	s.sub = internalSink{
+		name:   "journald",
+		handle: s.handle,
+		done:   make(chan struct{}),
+	}
+	return s
+}
+
+// Attach subscribes the sink to e and starts forwarding events in the
+// background until Close is called. The subscription survives slow-consumer
+// eviction: the sink resubscribes and continues with the events published
+// after the gap.
+func (s *JournaldSink) Attach(e *Events) {
+	s.sub.attach(e)
+}
+
+// Close unsubscribes the sink and waits for the entry in flight (if any) to
+// be written.
+func (s *JournaldSink) Close() {
+	s.sub.close()
+}
+
+func (s *JournaldSink) handle(m eventtypes.Message) {
+	if err := s.send(journalMessage(m), journal.PriInfo, journalFields(m)); err != nil {
+		log.G(context.TODO()).WithError(err).Warn("error writing event to the systemd journal")
+	}
+}
+
+func journalMessage(m eventtypes.Message) string {
+	msg := fmt.Sprintf("%s %s", m.Type, m.Action)
+	if m.Actor.ID != "" {
+		msg += " " + m.Actor.ID
+	}
+	if name, ok := m.Actor.Attributes["name"]; ok && name != "" {
+		msg += " (name=" + name + ")"
+	}
+	return msg
+}
+
+// journalFields builds the journal entry's structured fields from an event.
+func journalFields(m eventtypes.Message) map[string]string {
+	fields := map[string]string{
+		"MESSAGE_ID":          journalMessageID(m),
+		"DOCKER_EVENT_TYPE":   string(m.Type),
+		"DOCKER_EVENT_ACTION": string(m.Action),
+	}
+	if m.Actor.ID != "" {
+		fields["DOCKER_ACTOR_ID"] = m.Actor.ID
+	}
+	if m.Scope != "" {
+		fields["DOCKER_EVENT_SCOPE"] = m.Scope
+	}
+	for k, v := range m.Actor.Attributes {
+		if name := journalFieldName("DOCKER_ATTRIBUTE_" + k); name != "" {
+			fields[name] = v
+		}
+	}
+	return fields
+}
+
+// journalMessageID derives a stable 128-bit MESSAGE_ID for an event type and
+// action, so journal consumers can match on specific lifecycle actions
+// regardless of the message text.
+func journalMessageID(m eventtypes.Message) string {
+	sum := md5.Sum([]byte("github.com/moby/moby:" + string(m.Type) + ":" + string(m.Action)))
+	return hex.EncodeToString(sum[:])
+}
+
+// journalFieldName sanitizes s into a valid journal field name: uppercase
+// letters, digits and underscores, not starting with a digit or underscore.
+// It returns an empty string if nothing of the name remains.
+func journalFieldName(s string) string {
+	name := strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z':
+			return r - ('a' - 'A')
+		case (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9'):
+			return r
+		default:
+			return '_'
+		}
+	}, s)
+	name = strings.TrimLeft(name, "_0123456789")
+	if name == "" {
+		return ""
+	}
+	return name
+}
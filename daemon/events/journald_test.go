@@ -0,0 +1,80 @@
+package events
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/coreos/go-systemd/v22/journal"
+	"github.com/moby/moby/api/types/events"
+	"gotest.tools/v3/assert"
+	is "gotest.tools/v3/assert/cmp"
+	"gotest.tools/v3/poll"
+)
+
+func TestJournaldSink(t *testing.T) {
+	var (
+		mu   sync.Mutex
+		msgs []string
+		vars []map[string]string
+	)
+	sink := &JournaldSink{
+		send: func(message string, priority journal.Priority, fields map[string]string) error {
+			mu.Lock()
+			defer mu.Unlock()
+			msgs = append(msgs, message)
+			vars = append(vars, fields)
+			return nil
+		},
+	}
+	sink.sub = internalSink{
+		name:   "journald",
+		handle: sink.handle,
+		done:   make(chan struct{}),
+	}
+
+	e := New()
+	sink.Attach(e)
+	defer sink.Close()
+
+	e.Log(events.ActionStart, events.ContainerEventType, events.Actor{
+		ID:         "cont",
+		Attributes: map[string]string{"name": "web", "image": "nginx", "com.example.label": "v"},
+	})
+
+	poll.WaitOn(t, func(poll.LogT) poll.Result {
+		mu.Lock()
+		defer mu.Unlock()
+		if len(msgs) == 1 {
+			return poll.Success()
+		}
+		return poll.Continue("waiting for journal entry")
+	}, poll.WithTimeout(5*time.Second))
+
+	mu.Lock()
+	defer mu.Unlock()
+	assert.Check(t, is.Equal(msgs[0], "container start cont (name=web)"))
+	fields := vars[0]
+	assert.Check(t, is.Equal(fields["DOCKER_EVENT_TYPE"], "container"))
+	assert.Check(t, is.Equal(fields["DOCKER_EVENT_ACTION"], "start"))
+	assert.Check(t, is.Equal(fields["DOCKER_ACTOR_ID"], "cont"))
+	assert.Check(t, is.Equal(fields["DOCKER_ATTRIBUTE_NAME"], "web"))
+	assert.Check(t, is.Equal(fields["DOCKER_ATTRIBUTE_IMAGE"], "nginx"))
+	assert.Check(t, is.Equal(fields["DOCKER_ATTRIBUTE_COM_EXAMPLE_LABEL"], "v"))
+	assert.Check(t, is.Equal(fields["MESSAGE_ID"], journalMessageID(events.Message{Type: events.ContainerEventType, Action: events.ActionStart})))
+	assert.Check(t, is.Len(fields["MESSAGE_ID"], 32))
+}
+
+func TestJournalFieldName(t *testing.T) {
+	tests := []struct {
+		in, out string
+	}{
+		{"DOCKER_ATTRIBUTE_name", "DOCKER_ATTRIBUTE_NAME"},
+		{"DOCKER_ATTRIBUTE_com.example/key-1", "DOCKER_ATTRIBUTE_COM_EXAMPLE_KEY_1"},
+		{"_0_", ""},
+		{"9lives", "LIVES"},
+	}
+	for _, tc := range tests {
+		assert.Check(t, is.Equal(journalFieldName(tc.in), tc.out), tc.in)
+	}
+}
@@ -0,0 +1,80 @@
+package events
+
+import (
+	"context"
+	"sync"
+
+	"github.com/containerd/log"
+	eventtypes "github.com/moby/moby/api/types/events"
+)
+
+// internalSink is the subscription machinery shared by the daemon's built-in
+// event sinks (webhook, journald). Internal sinks are subject to the same
+// slow-consumer eviction as API subscribers, but unlike API clients nothing
+// ever reattaches them: an evicted sink would stay dead until the next daemon
+// restart. The internalSink therefore resubscribes itself when its channel is
+// closed by eviction, logging the gap, and only stops for good once close is
+// called.
+type internalSink struct {
+	// name identifies the sink in log messages.
+	name string
+	// handle is called for every event delivered to the sink.
+	handle func(eventtypes.Message)
+
+	mu     sync.Mutex
+	events *Events
+	cancel func()
+	closed bool
+	done   chan struct{}
+}
+
+// attach subscribes the sink to e and starts feeding events to handle in the
+// background until close is called.
+func (s *internalSink) attach(e *Events) {
+	s.mu.Lock()
+	s.events = e
+	_, l, cancel := e.Subscribe()
+	s.cancel = cancel
+	s.mu.Unlock()
+	go s.run(l)
+}
+
+// close unsubscribes the sink and waits for the handling of the event in
+// flight (if any) to finish.
+func (s *internalSink) close() {
+	s.mu.Lock()
+	if s.closed {
+		s.mu.Unlock()
+		return
+	}
+	s.closed = true
+	cancel := s.cancel
+	s.mu.Unlock()
+	if cancel != nil {
+		cancel()
+		<-s.done
+	}
+}
+
+func (s *internalSink) run(l chan interface{}) {
+	for {
+		for ev := range l {
+			if m, ok := ev.(eventtypes.Message); ok {
+				s.handle(m)
+			}
+		}
+		// The channel was closed: either the sink is shutting down, or it
+		// fell behind and was evicted as a slow consumer. In the latter
+		// case resubscribe, accepting the gap in delivery.
+		s.mu.Lock()
+		if s.closed {
+			s.mu.Unlock()
+			close(s.done)
+			return
+		}
+		log.G(context.TODO()).WithField("sink", s.name).
+			Warn("Event sink fell behind and was disconnected; resubscribing, undelivered events are lost")
+		_, l, s.cancel = s.events.Subscribe()
+		s.mu.Unlock()
+	}
+}
@@ -0,0 +1,57 @@
+package events
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	eventtypes "github.com/moby/moby/api/types/events"
+	"gotest.tools/v3/poll"
+)
+
+func TestInternalSinkResubscribesAfterEviction(t *testing.T) {
+	var (
+		mu  sync.Mutex
+		got []eventtypes.Message
+	)
+	sink := &internalSink{
+		name: "test",
+		handle: func(m eventtypes.Message) {
+			mu.Lock()
+			got = append(got, m)
+			mu.Unlock()
+		},
+		done: make(chan struct{}),
+	}
+
+	e := New()
+	sink.attach(e)
+	defer sink.close()
+
+	// Disconnect the subscription out from under the sink, as the
+	// slow-consumer eviction in PublishMessage would.
+	sink.mu.Lock()
+	cancel := sink.cancel
+	sink.mu.Unlock()
+	cancel()
+
+	// The sink must come back on its own and receive events published
+	// after the gap.
+	poll.WaitOn(t, func(poll.LogT) poll.Result {
+		if e.SubscribersCount() == 1 {
+			return poll.Success()
+		}
+		return poll.Continue("waiting for the sink to resubscribe")
+	}, poll.WithTimeout(5*time.Second))
+
+	e.Log(eventtypes.ActionStart, eventtypes.ContainerEventType, eventtypes.Actor{ID: "cont"})
+
+	poll.WaitOn(t, func(poll.LogT) poll.Result {
+		mu.Lock()
+		defer mu.Unlock()
+		if len(got) == 1 {
+			return poll.Success()
+		}
+		return poll.Continue("waiting for the event to be delivered")
+	}, poll.WithTimeout(5*time.Second))
+}
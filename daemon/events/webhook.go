@@ -0,0 +1,131 @@
+package events
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/containerd/log"
+	eventtypes "github.com/moby/moby/api/types/events"
+	"github.com/pkg/errors"
+)
+
+const (
+	// webhookSignatureHeader carries an HMAC-SHA256 signature of the request
+	// body, keyed with the sink's configured secret, in the form
+	// "sha256=<hex digest>". It is only set when a secret is configured.
+	webhookSignatureHeader = "X-Docker-Signature-256"
+
+	defaultWebhookRetries = 3
+	webhookTimeout        = 10 * time.Second
+	webhookRetryDelay     = 1 * time.Second
+)
+
+// WebhookSink delivers events to a single webhook endpoint as CloudEvents.
+// Events are delivered at most once per sink: failed deliveries are retried
+// with backoff a bounded number of times and then dropped, so a slow or
+// unreachable endpoint cannot stall the daemon's event stream.
+type WebhookSink struct {
+	url     string
+	secret  string
+	retries int
+	filter  *Filter
+	client  *http.Client
+
+	sub internalSink
+}
+
+// NewWebhookSink creates a sink that POSTs events matching ef (all events
+// when ef is nil) to the given URL. When secret is non-empty each request is
+// signed with HMAC-SHA256 over the request body. retries is the number of
+// delivery attempts per event; values < 1 select the default.
+func NewWebhookSink(url, secret string, retries int, ef *Filter) *WebhookSink {
+	if retries < 1 {
+		retries = defaultWebhookRetries
+	}
+	s := &WebhookSink{
+		url:     url,
+		secret:  secret,
+		retries: retries,
+		filter:  ef,
+		client:  &http.Client{Timeout: webhookTimeout},
+	}
+	s.sub = internalSink{
+		name:   "webhook:" + url,
+		handle: s.handle,
+		done:   make(chan struct{}),
+	}
+	return s
+}
+
+// Attach subscribes the sink to e and starts delivering events in the
+// background until Close is called. The subscription survives slow-consumer
+// eviction: the sink resubscribes and continues with the events published
+// after the gap.
+func (s *WebhookSink) Attach(e *Events) {
+	s.sub.attach(e)
+}
+
+// Close unsubscribes the sink and waits for the delivery of the event in
+// flight (if any) to finish.
+func (s *WebhookSink) Close() {
+	s.sub.close()
+}
+
+func (s *WebhookSink) handle(m eventtypes.Message) {
+	if s.filter != nil && !s.filter.Include(m) {
+		return
+	}
+	if err := s.deliver(m); err != nil {
+		log.G(context.TODO()).WithFields(log.Fields{
+			"error": err,
+			"url":   s.url,
+		}).Warn("dropping event after failed webhook delivery")
+	}
+}
+
+func (s *WebhookSink) deliver(m eventtypes.Message) error {
+	body, err := json.Marshal(ToCloudEvent(m))
+	if err != nil {
+		return errors.Wrap(err, "error encoding event")
+	}
+
+	var lastErr error
+	for attempt := 0; attempt < s.retries; attempt++ {
+		if attempt > 0 {
+			time.Sleep(webhookRetryDelay << (attempt - 1))
+		}
+		if lastErr = s.post(body); lastErr == nil {
+			return nil
+		}
+	}
+	return lastErr
+}
+
+func (s *WebhookSink) post(body []byte) error {
+	req, err := http.NewRequest(http.MethodPost, s.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/cloudevents+json")
+	if s.secret != "" {
+		mac := hmac.New(sha256.New, []byte(s.secret))
+		mac.Write(body)
+		req.Header.Set(webhookSignatureHeader, "sha256="+hex.EncodeToString(mac.Sum(nil)))
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return errors.Errorf("webhook returned unexpected status: %s", resp.Status)
+	}
+	return nil
+}
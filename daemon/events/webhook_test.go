@@ -0,0 +1,119 @@
+package events
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/moby/moby/api/types/events"
+	"github.com/moby/moby/api/types/filters"
+	"gotest.tools/v3/assert"
+	is "gotest.tools/v3/assert/cmp"
+	"gotest.tools/v3/poll"
+)
+
+func TestWebhookSinkDelivery(t *testing.T) {
+	const secret = "hook-secret"
+
+	var got atomic.Pointer[CloudEvent]
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		assert.NilError(t, err)
+		assert.Check(t, is.Equal(r.Header.Get("Content-Type"), "application/cloudevents+json"))
+
+		// The signature must be the HMAC-SHA256 of the request body,
+		// keyed with the configured secret.
+		mac := hmac.New(sha256.New, []byte(secret))
+		mac.Write(body)
+		assert.Check(t, is.Equal(r.Header.Get(webhookSignatureHeader), "sha256="+hex.EncodeToString(mac.Sum(nil))))
+
+		var ce CloudEvent
+		assert.NilError(t, json.Unmarshal(body, &ce))
+		got.Store(&ce)
+	}))
+	defer srv.Close()
+
+	e := New()
+	sink := NewWebhookSink(srv.URL, secret, 1, nil)
+	sink.Attach(e)
+	defer sink.Close()
+
+	e.Log(events.ActionStart, events.ContainerEventType, events.Actor{
+		ID:         "cont",
+		Attributes: map[string]string{"image": "image"},
+	})
+
+	poll.WaitOn(t, func(poll.LogT) poll.Result {
+		if got.Load() == nil {
+			return poll.Continue("waiting for webhook delivery")
+		}
+		return poll.Success()
+	}, poll.WithTimeout(5*time.Second))
+
+	ce := got.Load()
+	assert.Check(t, is.Equal(ce.SpecVersion, "1.0"))
+	assert.Check(t, is.Equal(ce.Type, "com.docker.container.start"))
+	assert.Check(t, is.Equal(ce.Subject, "cont"))
+	assert.Check(t, ce.ID != "")
+	assert.Check(t, is.Equal(ce.Data.Actor.ID, "cont"))
+	assert.Check(t, is.Equal(ce.Data.Action, events.ActionStart))
+}
+
+func TestWebhookSinkRetries(t *testing.T) {
+	var requests atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if requests.Add(1) == 1 {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+	}))
+	defer srv.Close()
+
+	e := New()
+	sink := NewWebhookSink(srv.URL, "", 2, nil)
+	sink.Attach(e)
+	defer sink.Close()
+
+	e.Log(events.ActionCreate, events.ContainerEventType, events.Actor{ID: "cont"})
+
+	poll.WaitOn(t, func(poll.LogT) poll.Result {
+		if requests.Load() < 2 {
+			return poll.Continue("waiting for retried delivery")
+		}
+		return poll.Success()
+	}, poll.WithTimeout(5*time.Second))
+}
+
+func TestWebhookSinkFilters(t *testing.T) {
+	var requests atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+	}))
+	defer srv.Close()
+
+	e := New()
+	f := filters.NewArgs(filters.Arg("type", "container"))
+	sink := NewWebhookSink(srv.URL, "", 1, NewFilter(f))
+	sink.Attach(e)
+	defer sink.Close()
+
+	e.Log(events.ActionCreate, events.ImageEventType, events.Actor{ID: "img"})
+	e.Log(events.ActionCreate, events.ContainerEventType, events.Actor{ID: "cont"})
+
+	poll.WaitOn(t, func(poll.LogT) poll.Result {
+		if requests.Load() < 1 {
+			return poll.Continue("waiting for webhook delivery")
+		}
+		return poll.Success()
+	}, poll.WithTimeout(5*time.Second))
+
+	// Only the container event should have been delivered.
+	assert.Check(t, is.Equal(requests.Load(), int32(1)))
+}
@@ -135,6 +135,11 @@ func (daemon *Daemon) ContainerExecCreate(name string, options *containertypes.E
 	execConfig.Privileged = options.Privileged
 	execConfig.User = options.User
 	execConfig.WorkingDir = options.WorkingDir
+	if err := validateExecResources(options); err != nil {
+		return "", err
+	}
+	execConfig.NanoCPUs = options.NanoCPUs
+	execConfig.MemoryBytes = options.MemoryBytes
 
 	linkedEnv, err := daemon.setupLinkedContainers(cntr)
 	if err != nil {
@@ -315,6 +320,20 @@ func (daemon *Daemon) ContainerExecStart(ctx context.Context, name string, optio
 	}
 	ec.Unlock()
 
+	if err := daemon.execSetupResourceCgroup(ec); err != nil {
+		// The exec process is already running; kill it rather than leave
+		// it running without the requested resource limits applied.
+		sigCtx, cancelFunc := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancelFunc()
+		if kerr := ec.Process.Kill(sigCtx, signal.SignalMap["KILL"]); kerr != nil {
+			log.G(ctx).WithError(kerr).WithFields(log.Fields{
+				"container": ec.Container.ID,
+				"exec":      ec.ID,
+			}).Error("Could not kill exec process after failing to apply resource limits")
+		}
+		return err
+	}
+
 	select {
 	case <-ctx.Done():
 		logger := log.G(ctx).WithFields(log.Fields{
@@ -2,14 +2,22 @@ package daemon
 
 import (
 	"context"
+	"path"
+	"time"
 
+	"github.com/containerd/cgroups/v3"
+	"github.com/containerd/cgroups/v3/cgroup2"
 	containerd "github.com/containerd/containerd/v2/client"
 	"github.com/containerd/containerd/v2/pkg/apparmor"
 	coci "github.com/containerd/containerd/v2/pkg/oci"
+	"github.com/containerd/log"
+	containertypes "github.com/moby/moby/api/types/container"
 	"github.com/moby/moby/v2/daemon/config"
 	"github.com/moby/moby/v2/daemon/container"
 	"github.com/moby/moby/v2/daemon/pkg/oci/caps"
+	"github.com/moby/moby/v2/errdefs"
 	"github.com/opencontainers/runtime-spec/specs-go"
+	"github.com/pkg/errors"
 )
 
 func getUserFromContainerd(ctx context.Context, containerdCli *containerd.Client, ec *container.ExecConfig) (specs.User, error) {
@@ -94,3 +102,81 @@ func (daemon *Daemon) execSetPlatformOpt(ctx context.Context, daemonCfg *config.
 	s := &specs.Spec{Process: p}
 	return withRlimits(daemon, daemonCfg, ec.Container)(ctx, nil, nil, s)
 }
+
+// validateExecResources checks that the resource limits requested for an exec
+// process can be applied on this host.
+func validateExecResources(options *containertypes.ExecOptions) error {
+	if options.NanoCPUs == 0 && options.MemoryBytes == 0 {
+		return nil
+	}
+	if options.NanoCPUs < 0 {
+		return errdefs.InvalidParameter(errors.New("exec CPU limit cannot be negative"))
+	}
+	if options.MemoryBytes < 0 {
+		return errdefs.InvalidParameter(errors.New("exec memory limit cannot be negative"))
+	}
+	if options.MemoryBytes > 0 && options.MemoryBytes < linuxMinMemory {
+		return errdefs.InvalidParameter(errors.New("exec memory limit should be 6MiB or greater"))
+	}
+	if cgroups.Mode() != cgroups.Unified {
+		return errdefs.InvalidParameter(errors.New("exec resource limits are only supported with cgroup v2"))
+	}
+	return nil
+}
+
+// execSetupResourceCgroup places the exec process in its own sub-cgroup of
+// the container's cgroup with the requested CPU and memory limits applied,
+// so that it is constrained independently of the container's own limits. It
+// is a no-op if the exec has no resource limits configured.
+func (daemon *Daemon) execSetupResourceCgroup(ec *container.ExecConfig) error {
+	if ec.NanoCPUs == 0 && ec.MemoryBytes == 0 {
+		return nil
+	}
+
+	pid := int(ec.Process.Pid())
+	group, err := cgroup2.PidGroupPath(pid)
+	if err != nil {
+		return errdefs.System(errors.Wrap(err, "error resolving the cgroup of the exec process"))
+	}
+
+	res := &cgroup2.Resources{}
+	if ec.NanoCPUs > 0 {
+		// https://www.kernel.org/doc/Documentation/scheduler/sched-bwc.txt
+		period := uint64(100 * time.Millisecond / time.Microsecond)
+		quota := ec.NanoCPUs * int64(period) / 1e9
+		res.CPU = &cgroup2.CPU{Max: cgroup2.NewCPUMax(&quota, &period)}
+	}
+	if ec.MemoryBytes > 0 {
+		maxMemory := ec.MemoryBytes
+		res.Memory = &cgroup2.Memory{Max: &maxMemory}
+	}
+
+	group = path.Join(group, "exec-"+ec.ID)
+	mgr, err := cgroup2.NewManager("/sys/fs/cgroup", group, res)
+	if err != nil {
+		return errdefs.System(errors.Wrap(err, "error creating cgroup for exec process"))
+	}
+	if err := mgr.AddProc(uint64(pid)); err != nil {
+		if derr := mgr.Delete(); derr != nil {
+			log.G(context.TODO()).WithError(derr).WithField("cgroup", group).Warn("failed to remove exec cgroup")
+		}
+		return errdefs.System(errors.Wrap(err, "error moving exec process into its cgroup"))
+	}
+	ec.CgroupPath = group
+	return nil
+}
+
+// removeExecCgroup removes the sub-cgroup that was created for a
+// resource-limited exec process, once the process has exited.
+func (daemon *Daemon) removeExecCgroup(ec *container.ExecConfig) {
+	if ec.CgroupPath == "" {
+		return
+	}
+	mgr, err := cgroup2.Load(ec.CgroupPath)
+	if err == nil {
+		err = mgr.Delete()
+	}
+	if err != nil {
+		log.G(context.TODO()).WithError(err).WithField("cgroup", ec.CgroupPath).Warn("failed to remove exec cgroup")
+	}
+}
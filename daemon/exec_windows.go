@@ -3,9 +3,12 @@ package daemon
 import (
 	"context"
 
+	containertypes "github.com/moby/moby/api/types/container"
 	"github.com/moby/moby/v2/daemon/config"
 	"github.com/moby/moby/v2/daemon/container"
+	"github.com/moby/moby/v2/errdefs"
 	"github.com/opencontainers/runtime-spec/specs-go"
+	"github.com/pkg/errors"
 )
 
 func (daemon *Daemon) execSetPlatformOpt(ctx context.Context, daemonCfg *config.Config, ec *container.ExecConfig, p *specs.Process) error {
@@ -14,3 +17,22 @@ func (daemon *Daemon) execSetPlatformOpt(ctx context.Context, daemonCfg *config.
 	}
 	return nil
 }
+
+// validateExecResources checks that the resource limits requested for an exec
+// process can be applied on this host. Exec resource limits are not supported
+// on Windows.
+func validateExecResources(options *containertypes.ExecOptions) error {
+	if options.NanoCPUs != 0 || options.MemoryBytes != 0 {
+		return errdefs.InvalidParameter(errors.New("exec resource limits are not supported on Windows"))
+	}
+	return nil
+}
+
+// execSetupResourceCgroup is a no-op on Windows; exec resource limits are
+// rejected when the exec is created.
+func (daemon *Daemon) execSetupResourceCgroup(ec *container.ExecConfig) error {
+	return nil
+}
+
+// removeExecCgroup is a no-op on Windows.
+func (daemon *Daemon) removeExecCgroup(ec *container.ExecConfig) {}
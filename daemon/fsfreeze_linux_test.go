@@ -0,0 +1,60 @@
+package daemon
+
+import (
+	"testing"
+
+	cerrdefs "github.com/containerd/errdefs"
+	"github.com/moby/moby/v2/daemon/container"
+	volumemounts "github.com/moby/moby/v2/daemon/volume/mounts"
+	volumetestutils "github.com/moby/moby/v2/daemon/volume/testutils"
+	"gotest.tools/v3/assert"
+	is "gotest.tools/v3/assert/cmp"
+)
+
+func TestFreezePaths(t *testing.T) {
+	ctr := &container.Container{
+		BaseFS: "/var/lib/docker/overlay2/abc/merged",
+		MountPoints: map[string]*volumemounts.MountPoint{
+			"/data": {
+				Destination: "/data",
+				Volume:      volumetestutils.NewFakeVolume("vol1", "fake"),
+			},
+			// Bind mounts have no Volume and must not be frozen.
+			"/host": {
+				Destination: "/host",
+				Source:      "/host/path",
+			},
+		},
+	}
+
+	paths := freezePaths(ctr)
+	assert.Check(t, is.DeepEqual(paths, []string{"/var/lib/docker/overlay2/abc/merged", "fake"}))
+
+	// A container without a mounted writable layer contributes no path for it.
+	ctr.BaseFS = ""
+	delete(ctr.MountPoints, "/data")
+	assert.Check(t, is.Len(freezePaths(ctr), 0))
+}
+
+func TestCheckFreezePaths(t *testing.T) {
+	devs := map[string]uint64{
+		"/var/lib/docker":   1,
+		"/mnt/volumes/vol1": 2,
+		"/mnt/volumes/vol2": 3,
+	}
+	restore := pathDev
+	pathDev = func(path string) (uint64, error) {
+		return devs[path], nil
+	}
+	defer func() { pathDev = restore }()
+
+	// Paths on their own filesystems may be frozen.
+	err := checkFreezePaths([]string{"/mnt/volumes/vol1", "/mnt/volumes/vol2"}, "/var/lib/docker")
+	assert.Check(t, err)
+
+	// A path sharing the daemon root's filesystem is refused.
+	devs["/mnt/volumes/vol2"] = 1
+	err = checkFreezePaths([]string{"/mnt/volumes/vol1", "/mnt/volumes/vol2"}, "/var/lib/docker")
+	assert.Check(t, is.ErrorContains(err, "shared with the daemon root"))
+	assert.Check(t, cerrdefs.IsConflict(err))
+}
@@ -0,0 +1,20 @@
+//go:build !linux
+
+package daemon
+
+import (
+	"errors"
+	"time"
+
+	"github.com/moby/moby/v2/errdefs"
+)
+
+// ContainerFSFreeze is not supported on this platform.
+func (daemon *Daemon) ContainerFSFreeze(name string, timeout time.Duration) error {
+	return errdefs.NotImplemented(errors.New("filesystem freeze is not supported on this platform"))
+}
+
+// ContainerFSThaw is not supported on this platform.
+func (daemon *Daemon) ContainerFSThaw(name string) error {
+	return errdefs.NotImplemented(errors.New("filesystem freeze is not supported on this platform"))
+}
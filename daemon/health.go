@@ -12,6 +12,7 @@ import (
 	containertypes "github.com/moby/moby/api/types/container"
 	"github.com/moby/moby/api/types/events"
 	"github.com/moby/moby/v2/daemon/container"
+	"github.com/moby/moby/v2/daemon/internal/clock"
 	"github.com/moby/moby/v2/daemon/internal/metrics"
 	"github.com/moby/moby/v2/daemon/server/backend"
 )
@@ -44,6 +45,11 @@ const (
 	maxLogEntries = 5
 )
 
+// monitorClock is the clock the health monitor schedules probes against.
+// It is a package-level variable so that tests can substitute a fake clock
+// and step through probe intervals without sleeping.
+var monitorClock clock.Clock = clock.System()
+
 const (
 	// Exit status codes that can be returned by the probe command.
 
@@ -255,12 +261,19 @@ func monitor(d *Daemon, c *container.Container, stop chan struct{}, probe probe)
 	startInterval := timeoutWithDefault(c.Config.Healthcheck.StartInterval, defaultStartInterval)
 	startPeriod := timeoutWithDefault(c.Config.Healthcheck.StartPeriod, defaultStartPeriod)
 
+	// Take a monotonic baseline for the start period so that wall-clock
+	// jumps (e.g. from a host suspend/resume cycle) neither cut it short
+	// nor extend it.
 	c.Lock()
-	started := c.State.StartedAt
+	alreadyRunFor := time.Since(c.State.StartedAt)
 	c.Unlock()
+	if alreadyRunFor < 0 {
+		alreadyRunFor = 0
+	}
+	monitorStarted := monitorClock.Now()
 
 	getInterval := func() time.Duration {
-		if time.Since(started) >= startPeriod {
+		if alreadyRunFor+monitorClock.Since(monitorStarted) >= startPeriod {
 			return probeInterval
 		}
 		c.Lock()
@@ -273,7 +286,15 @@ func monitor(d *Daemon, c *container.Container, stop chan struct{}, probe probe)
 		return probeInterval
 	}
 
-	intervalTimer := time.NewTimer(getInterval())
+	// Watch for the container exiting so the monitor stops as soon as the
+	// container is gone, instead of waking up for another probe only to
+	// discover there is nothing left to check. The wait is event-driven
+	// (fed by the containerd event stream), not a poll.
+	waitCtx, cancelWait := context.WithCancel(context.Background())
+	defer cancelWait()
+	exited := c.Wait(waitCtx, containertypes.WaitConditionNotRunning)
+
+	intervalTimer := monitorClock.NewTimer(getInterval())
 	defer intervalTimer.Stop()
 
 	for {
@@ -281,7 +302,10 @@ func monitor(d *Daemon, c *container.Container, stop chan struct{}, probe probe)
 		case <-stop:
 			log.G(context.TODO()).Debugf("Stop healthcheck monitoring for container %s (received while idle)", c.ID)
 			return
-		case <-intervalTimer.C:
+		case <-exited:
+			log.G(context.TODO()).Debugf("Stop healthcheck monitoring for container %s (container exited)", c.ID)
+			return
+		case <-intervalTimer.C():
 			log.G(context.TODO()).Debugf("Running health check for container %s ...", c.ID)
 			startTime := time.Now()
 			ctx, cancelProbe := context.WithCancel(context.Background())
@@ -387,6 +411,55 @@ func (daemon *Daemon) initHealthMonitor(c *container.Container) {
 	daemon.updateHealthMonitor(c)
 }
 
+// enforceStartDeadline kills the container if its health does not become
+// healthy within HostConfig.StartDeadline of it starting. It is a no-op
+// when no deadline or no healthcheck is configured.
+// Called from start.go with c locked, after the container is running.
+func (daemon *Daemon) enforceStartDeadline(c *container.Container) {
+	deadline := c.HostConfig.StartDeadline
+	if deadline <= 0 || getProbe(c) == nil {
+		return
+	}
+
+	startedAt := c.State.StartedAt
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), deadline)
+		defer cancel()
+
+		select {
+		case <-c.Wait(ctx, containertypes.WaitConditionNotRunning):
+			// The container exited on its own before the deadline;
+			// nothing for us to do.
+			return
+		case <-ctx.Done():
+		}
+
+		c.Lock()
+		if !c.Running || c.Paused || !c.State.StartedAt.Equal(startedAt) {
+			// The container stopped or was restarted while we were
+			// waiting; this deadline no longer applies.
+			c.Unlock()
+			return
+		}
+		if h := c.State.Health; h != nil && h.Status() == containertypes.Healthy {
+			c.Unlock()
+			return
+		}
+		c.SetError(fmt.Errorf("container did not become healthy within the start deadline of %s", deadline))
+		c.Unlock()
+
+		log.G(context.TODO()).WithField("container", c.ID).
+			Warnf("Killing container: did not become healthy within start deadline of %s", deadline)
+		daemon.LogContainerEventWithAttributes(c, events.ActionKill, map[string]string{
+			"reason": "start-deadline-exceeded",
+		})
+		if err := daemon.Kill(c); err != nil {
+			log.G(context.TODO()).WithError(err).WithField("container", c.ID).
+				Error("Failed to kill container after start deadline expired")
+		}
+	}()
+}
+
 // Called when the container is being stopped (whether because the health check is
 // failing or for any other reason).
 func (daemon *Daemon) stopHealthchecks(c *container.Container) {
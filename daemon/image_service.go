@@ -10,6 +10,7 @@ import (
 	"github.com/moby/moby/api/types/filters"
 	imagetype "github.com/moby/moby/api/types/image"
 	"github.com/moby/moby/api/types/registry"
+	systemtype "github.com/moby/moby/api/types/system"
 	"github.com/moby/moby/v2/daemon/builder"
 	"github.com/moby/moby/v2/daemon/container"
 	"github.com/moby/moby/v2/daemon/images"
@@ -27,7 +28,7 @@ type ImageService interface {
 	// Images
 
 	PullImage(ctx context.Context, ref reference.Named, platform *ocispec.Platform, metaHeaders map[string][]string, authConfig *registry.AuthConfig, outStream io.Writer) error
-	PushImage(ctx context.Context, ref reference.Named, platform *ocispec.Platform, metaHeaders map[string][]string, authConfig *registry.AuthConfig, outStream io.Writer) error
+	PushImage(ctx context.Context, ref reference.Named, platform *ocispec.Platform, metaHeaders map[string][]string, authConfig *registry.AuthConfig, outStream io.Writer, options backend.PushOptions) error
 	CreateImage(ctx context.Context, config []byte, parent string, contentStoreDigest digest.Digest) (builder.Image, error)
 	ImageDelete(ctx context.Context, imageRef string, options imagetype.RemoveOptions) ([]imagetype.DeleteResponse, error)
 	ExportImage(ctx context.Context, names []string, platformList []ocispec.Platform, outStream io.Writer) error
@@ -39,11 +40,21 @@ type ImageService interface {
 	ImportImage(ctx context.Context, ref reference.Named, platform *ocispec.Platform, msg string, layerReader io.Reader, changes []string) (image.ID, error)
 	TagImage(ctx context.Context, imageID image.ID, newTag reference.Named) error
 	GetImage(ctx context.Context, refOrID string, options backend.GetImageOpts) (*image.Image, error)
+	ResolveDigestReference(ctx context.Context, refOrID string) (string, error)
 	ImageHistory(ctx context.Context, name string, platform *ocispec.Platform) ([]*imagetype.HistoryResponseItem, error)
 	CommitImage(ctx context.Context, c backend.CommitConfig) (image.ID, error)
 	SquashImage(id, parent string) (string, error)
 	ImageInspect(ctx context.Context, refOrID string, opts backend.ImageInspectOpts) (*imagetype.InspectResponse, error)
 	ImageDiskUsage(ctx context.Context) (int64, error)
+	StorageDiskUsage(ctx context.Context) (*systemtype.StorageUsage, error)
+	MarkImageUsed(ctx context.Context, id image.ID) error
+	DistributionOperations() []registry.DistributionOperation
+	CancelDistributionOperation(id string) error
+
+	// Artifacts
+
+	ArtifactPull(ctx context.Context, ref reference.Named, authConfig *registry.AuthConfig) (ocispec.Descriptor, error)
+	ArtifactPush(ctx context.Context, ref reference.Named, authConfig *registry.AuthConfig) (ocispec.Descriptor, error)
 
 	// Layers
 
@@ -65,6 +76,8 @@ type ImageService interface {
 
 	MakeImageCache(ctx context.Context, cacheFrom []string) (builder.ImageCache, error)
 	CommitBuildStep(ctx context.Context, c backend.CommitConfig) (image.ID, error)
+	SetImageBuildID(ctx context.Context, id image.ID, buildID string) error
+	GetImageBuildID(ctx context.Context, id image.ID) (string, error)
 
 	// Other
 
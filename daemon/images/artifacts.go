@@ -0,0 +1,23 @@
+package images
+
+import (
+	"context"
+
+	"github.com/distribution/reference"
+	"github.com/moby/moby/api/types/registry"
+	"github.com/moby/moby/v2/errdefs"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/pkg/errors"
+)
+
+// ArtifactPull is not supported with the graphdriver image store; arbitrary
+// OCI artifacts require the containerd image store.
+func (i *ImageService) ArtifactPull(ctx context.Context, ref reference.Named, authConfig *registry.AuthConfig) (ocispec.Descriptor, error) {
+	return ocispec.Descriptor{}, errdefs.NotImplemented(errors.New("pulling OCI artifacts requires the containerd image store"))
+}
+
+// ArtifactPush is not supported with the graphdriver image store; arbitrary
+// OCI artifacts require the containerd image store.
+func (i *ImageService) ArtifactPush(ctx context.Context, ref reference.Named, authConfig *registry.AuthConfig) (ocispec.Descriptor, error) {
+	return ocispec.Descriptor{}, errdefs.NotImplemented(errors.New("pushing OCI artifacts requires the containerd image store"))
+}
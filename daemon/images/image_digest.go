@@ -0,0 +1,35 @@
+package images
+
+import (
+	"context"
+
+	"github.com/distribution/reference"
+	"github.com/moby/moby/v2/daemon/server/backend"
+	"github.com/moby/moby/v2/errdefs"
+	"github.com/pkg/errors"
+)
+
+// ResolveDigestReference resolves refOrID to a digested reference for the
+// image it currently points to. Only images pulled from a registry have a
+// repository digest recorded; locally built or imported images cannot be
+// resolved and produce an error.
+func (i *ImageService) ResolveDigestReference(ctx context.Context, refOrID string) (string, error) {
+	parsed, err := reference.ParseNormalizedNamed(refOrID)
+	if err != nil {
+		return "", errdefs.InvalidParameter(errors.Wrapf(err, "cannot resolve digest for %s", refOrID))
+	}
+	if canonical, ok := parsed.(reference.Canonical); ok {
+		return reference.FamiliarString(canonical), nil
+	}
+
+	img, err := i.GetImage(ctx, refOrID, backend.GetImageOpts{})
+	if err != nil {
+		return "", err
+	}
+	for _, ref := range i.referenceStore.References(img.ID().Digest()) {
+		if canonical, ok := ref.(reference.Canonical); ok && canonical.Name() == parsed.Name() {
+			return reference.FamiliarString(canonical), nil
+		}
+	}
+	return "", errdefs.InvalidParameter(errors.Errorf("no repository digest recorded for %s: only images pulled from a registry can be pinned by digest", refOrID))
+}
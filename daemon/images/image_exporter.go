@@ -40,6 +40,6 @@ func (i *ImageService) LoadImage(ctx context.Context, inTar io.ReadCloser, platf
 		platform = &platformList[0]
 	}
 
-	imageExporter := tarexport.NewTarExporter(i.imageStore, i.layerStore, i.referenceStore, i, platform)
+	imageExporter := tarexport.NewTarExporter(i.imageStore, i.layerStore, i.referenceStore, i, platform, tarexport.WithLoadConcurrency(i.maxConcurrentLoads))
 	return imageExporter.Load(ctx, inTar, outStream, quiet)
 }
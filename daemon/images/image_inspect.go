@@ -4,12 +4,14 @@ import (
 	"context"
 	"time"
 
+	"github.com/containerd/log"
 	"github.com/distribution/reference"
 	imagetypes "github.com/moby/moby/api/types/image"
 	"github.com/moby/moby/api/types/storage"
 	"github.com/moby/moby/v2/daemon/internal/image"
 	"github.com/moby/moby/v2/daemon/internal/layer"
 	"github.com/moby/moby/v2/daemon/server/backend"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
 )
 
 func (i *ImageService) ImageInspect(ctx context.Context, refOrID string, opts backend.ImageInspectOpts) (*imagetypes.InspectResponse, error) {
@@ -28,6 +30,16 @@ func (i *ImageService) ImageInspect(ctx context.Context, refOrID string, opts ba
 		return nil, err
 	}
 
+	lastPulled, err := i.imageStore.GetLastPulled(img.ID())
+	if err != nil {
+		return nil, err
+	}
+
+	lastUsed, err := i.imageStore.GetLastUsed(img.ID())
+	if err != nil {
+		return nil, err
+	}
+
 	var repoTags, repoDigests []string
 	for _, ref := range i.referenceStore.References(img.ID().Digest()) {
 		switch ref.(type) {
@@ -53,6 +65,11 @@ func (i *ImageService) ImageInspect(ctx context.Context, refOrID string, opts ba
 		layers = append(layers, l.String())
 	}
 
+	var artifacts []ocispec.Descriptor
+	if opts.Artifacts {
+		artifacts = i.imageArtifacts(ctx, repoDigests)
+	}
+
 	imgConfig := containerConfigToDockerOCIImageConfig(img.Config)
 	return &imagetypes.InspectResponse{
 		ID:              img.ID().String(),
@@ -81,10 +98,36 @@ func (i *ImageService) ImageInspect(ctx context.Context, refOrID string, opts ba
 		},
 		Metadata: imagetypes.Metadata{
 			LastTagTime: lastUpdated,
+			LastPulled:  lastPulled,
+			LastUsed:    lastUsed,
 		},
+		Artifacts: artifacts,
 	}, nil
 }
 
+// imageArtifacts lists the artifacts associated with the image's first
+// repository digest; all repository digests record the same manifest. A
+// registry lookup failure only means no artifacts are reported.
+func (i *ImageService) imageArtifacts(ctx context.Context, repoDigests []string) []ocispec.Descriptor {
+	if len(repoDigests) == 0 {
+		return nil
+	}
+	ref, err := reference.ParseNormalizedNamed(repoDigests[0])
+	if err != nil {
+		return nil
+	}
+	canonical, ok := ref.(reference.Canonical)
+	if !ok {
+		return nil
+	}
+	artifacts, err := i.referrers.Get(ctx, canonical, i.registryService)
+	if err != nil {
+		log.G(ctx).WithError(err).WithField("image", repoDigests[0]).Debug("failed to list image artifacts")
+		return nil
+	}
+	return artifacts
+}
+
 func (i *ImageService) getLayerSizeAndMetadata(img *image.Image) (int64, map[string]string, error) {
 	var size int64
 	var layerMetadata map[string]string
@@ -157,6 +157,12 @@ func (i *ImageService) Images(ctx context.Context, opts imagetypes.ListOptions)
 		}
 
 		summary := newImageSummary(img, size)
+		if lastPulled, err := i.imageStore.GetLastPulled(id); err == nil && !lastPulled.IsZero() {
+			summary.LastPulled = lastPulled.Unix()
+		}
+		if lastUsed, err := i.imageStore.GetLastUsed(id); err == nil && !lastUsed.IsZero() {
+			summary.LastUsed = lastUsed.Unix()
+		}
 
 		for _, ref := range i.referenceStore.References(id.Digest()) {
 			if opts.Filters.Contains("reference") {
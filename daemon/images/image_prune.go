@@ -2,6 +2,7 @@ package images
 
 import (
 	"context"
+	"sort"
 	"strconv"
 	"time"
 
@@ -20,10 +21,11 @@ import (
 )
 
 var imagesAcceptedFilters = map[string]bool{
-	"dangling": true,
-	"label":    true,
-	"label!":   true,
-	"until":    true,
+	"dangling":       true,
+	"label":          true,
+	"label!":         true,
+	"until":          true,
+	"keep-last-used": true,
 }
 
 // errPruneRunning is returned when a prune request is received while
@@ -55,6 +57,11 @@ func (i *ImageService) ImagesPrune(ctx context.Context, pruneFilters filters.Arg
 		return nil, err
 	}
 
+	keepLastUsed, err := getKeepLastUsed(pruneFilters)
+	if err != nil {
+		return nil, err
+	}
+
 	var allImages map[image.ID]*image.Image
 	if danglingOnly {
 		allImages = i.imageStore.Heads()
@@ -84,6 +91,12 @@ func (i *ImageService) ImagesPrune(ctx context.Context, pruneFilters filters.Arg
 		}
 	}
 
+	if keepLastUsed > 0 {
+		for _, id := range i.mostRecentlyUsedImages(topImages, keepLastUsed) {
+			delete(topImages, id)
+		}
+	}
+
 	canceled := false
 deleteImagesLoop:
 	for id := range topImages {
@@ -183,6 +196,58 @@ func matchLabels(pruneFilters filters.Args, labels map[string]string) bool {
 	return true
 }
 
+// getKeepLastUsed parses the "keep-last-used" filter, which specifies how
+// many of the most recently used images to retain. It returns 0 when the
+// filter is not set.
+func getKeepLastUsed(pruneFilters filters.Args) (int, error) {
+	if !pruneFilters.Contains("keep-last-used") {
+		return 0, nil
+	}
+	values := pruneFilters.Get("keep-last-used")
+	if len(values) > 1 {
+		return 0, errdefs.InvalidParameter(errors.New("keep-last-used can be specified only once"))
+	}
+	n, err := strconv.Atoi(values[0])
+	if err != nil || n < 0 {
+		return 0, errdefs.InvalidParameter(errors.Errorf("invalid keep-last-used value: %s", values[0]))
+	}
+	return n, nil
+}
+
+// mostRecentlyUsedImages returns the IDs of the n most recently used images
+// in imgs, ranked by the last time a container was created from them. Images
+// that were never used sort by their creation time instead.
+func (i *ImageService) mostRecentlyUsedImages(imgs map[image.ID]*image.Image, n int) []image.ID {
+	type usedImage struct {
+		id       image.ID
+		lastUsed time.Time
+	}
+	ranked := make([]usedImage, 0, len(imgs))
+	for id, img := range imgs {
+		lastUsed, err := i.imageStore.GetLastUsed(id)
+		if err != nil || lastUsed.IsZero() {
+			if img.Created != nil {
+				lastUsed = *img.Created
+			}
+		}
+		ranked = append(ranked, usedImage{id: id, lastUsed: lastUsed})
+	}
+	sort.Slice(ranked, func(a, b int) bool {
+		if ranked[a].lastUsed.Equal(ranked[b].lastUsed) {
+			return ranked[a].id < ranked[b].id
+		}
+		return ranked[a].lastUsed.After(ranked[b].lastUsed)
+	})
+	if n > len(ranked) {
+		n = len(ranked)
+	}
+	ids := make([]image.ID, 0, n)
+	for _, r := range ranked[:n] {
+		ids = append(ids, r.id)
+	}
+	return ids
+}
+
 func getUntilFromPruneFilters(pruneFilters filters.Args) (time.Time, error) {
 	until := time.Time{}
 	if !pruneFilters.Contains("until") {
@@ -12,6 +12,7 @@ import (
 	"github.com/distribution/reference"
 	"github.com/moby/moby/api/pkg/progress"
 	"github.com/moby/moby/api/pkg/streamformatter"
+	eventtypes "github.com/moby/moby/api/types/events"
 	"github.com/moby/moby/api/types/registry"
 	"github.com/moby/moby/v2/daemon/internal/distribution"
 	progressutils "github.com/moby/moby/v2/daemon/internal/distribution/utils"
@@ -24,14 +25,40 @@ import (
 // PullImage initiates a pull operation. image is the repository name to pull, and
 // tag may be either empty, or indicate a specific tag to pull.
 func (i *ImageService) PullImage(ctx context.Context, ref reference.Named, platform *ocispec.Platform, metaHeaders map[string][]string, authConfig *registry.AuthConfig, outStream io.Writer) error {
+	if i.pullGate != nil {
+		if err := i.pullGate(); err != nil {
+			return err
+		}
+	}
+	if platform == nil && i.defaultPullPlatform != nil {
+		platform = i.defaultPullPlatform(ref)
+	}
+	if i.admitPull != nil {
+		var err error
+		ref, err = i.admitPull(ctx, ref, platform)
+		if err != nil {
+			return err
+		}
+	}
 	start := time.Now()
 
+	ctx, done := i.transfers.Start(ctx, registry.DistributionOperationPull, reference.FamiliarString(ref))
+	defer done()
+
 	err := i.pullImageWithReference(ctx, ref, platform, metaHeaders, authConfig, outStream)
 	metrics.ImageActions.WithValues("pull").UpdateSince(start)
+	metrics.RecordImagePull(ctx, time.Since(start), err)
 	if err != nil {
 		return err
 	}
 
+	// Record the pull time; it is surfaced in image inspect and list.
+	if img, gErr := i.GetImage(ctx, ref.String(), backend.GetImageOpts{}); gErr == nil {
+		if sErr := i.imageStore.SetLastPulled(img.ID()); sErr != nil {
+			log.G(ctx).WithError(sErr).WithField("image", img.ID()).Warn("failed to record image last-pulled time")
+		}
+	}
+
 	if platform != nil {
 		// If --platform was specified, check that the image we pulled matches
 		// the expected platform. This check is for situations where the image
@@ -92,7 +119,7 @@ func (i *ImageService) pullImageWithReference(ctx context.Context, ref reference
 		Config: distribution.Config{
 			MetaHeaders:      metaHeaders,
 			AuthConfig:       authConfig,
-			ProgressOutput:   progress.ChanOutput(progressChan),
+			ProgressOutput:   progressutils.ProgressEventsOutput(progress.ChanOutput(progressChan), i.eventsService, eventtypes.ActionPullProgress, reference.FamiliarString(ref)),
 			RegistryService:  i.registryService,
 			ImageEventLogger: i.LogImageEvent,
 			MetadataStore:    i.distributionMetadataStore,
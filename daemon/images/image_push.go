@@ -8,6 +8,7 @@ import (
 	"github.com/distribution/reference"
 	"github.com/docker/distribution/manifest/schema2"
 	"github.com/moby/moby/api/pkg/progress"
+	eventtypes "github.com/moby/moby/api/types/events"
 	"github.com/moby/moby/api/types/registry"
 	"github.com/moby/moby/v2/daemon/internal/distribution"
 	progressutils "github.com/moby/moby/v2/daemon/internal/distribution/utils"
@@ -17,7 +18,7 @@ import (
 )
 
 // PushImage initiates a push operation on the repository named localName.
-func (i *ImageService) PushImage(ctx context.Context, ref reference.Named, platform *ocispec.Platform, metaHeaders map[string][]string, authConfig *registry.AuthConfig, outStream io.Writer) error {
+func (i *ImageService) PushImage(ctx context.Context, ref reference.Named, platform *ocispec.Platform, metaHeaders map[string][]string, authConfig *registry.AuthConfig, outStream io.Writer, options backend.PushOptions) error {
 	if platform != nil {
 		// Check if the image is actually the platform we want to push.
 		_, err := i.GetImage(ctx, ref.String(), backend.GetImageOpts{Platform: platform})
@@ -26,6 +27,10 @@ func (i *ImageService) PushImage(ctx context.Context, ref reference.Named, platf
 		}
 	}
 	start := time.Now()
+
+	ctx, done := i.transfers.Start(ctx, registry.DistributionOperationPush, reference.FamiliarString(ref))
+	defer done()
+
 	// Include a buffer so that slow client connections don't affect
 	// transfer performance.
 	progressChan := make(chan progress.Progress, 100)
@@ -43,16 +48,18 @@ func (i *ImageService) PushImage(ctx context.Context, ref reference.Named, platf
 		Config: distribution.Config{
 			MetaHeaders:      metaHeaders,
 			AuthConfig:       authConfig,
-			ProgressOutput:   progress.ChanOutput(progressChan),
+			ProgressOutput:   progressutils.ProgressEventsOutput(progress.ChanOutput(progressChan), i.eventsService, eventtypes.ActionPushProgress, reference.FamiliarString(ref)),
 			RegistryService:  i.registryService,
 			ImageEventLogger: i.LogImageEvent,
 			MetadataStore:    i.distributionMetadataStore,
 			ImageStore:       distribution.NewImageConfigStoreFromStore(i.imageStore),
 			ReferenceStore:   i.referenceStore,
 		},
-		ConfigMediaType: schema2.MediaTypeImageConfig,
-		LayerStores:     distribution.NewLayerProvidersFromStore(i.layerStore),
-		UploadManager:   i.uploadManager,
+		ConfigMediaType:  schema2.MediaTypeImageConfig,
+		LayerStores:      distribution.NewLayerProvidersFromStore(i.layerStore),
+		UploadManager:    i.uploadManager,
+		Compression:      options.Compression,
+		CompressionLevel: options.CompressionLevel,
 	}
 
 	err := distribution.Push(ctx, ref, imagePushConfig)
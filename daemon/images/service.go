@@ -9,6 +9,9 @@ import (
 	"github.com/containerd/containerd/v2/core/content"
 	"github.com/containerd/containerd/v2/core/leases"
 	"github.com/containerd/log"
+	"github.com/distribution/reference"
+	"github.com/moby/moby/api/types/registry"
+	systemtype "github.com/moby/moby/api/types/system"
 	"github.com/moby/moby/v2/daemon/container"
 	daemonevents "github.com/moby/moby/v2/daemon/events"
 	"github.com/moby/moby/v2/daemon/internal/distribution"
@@ -17,7 +20,9 @@ import (
 	"github.com/moby/moby/v2/daemon/internal/image"
 	"github.com/moby/moby/v2/daemon/internal/layer"
 	refstore "github.com/moby/moby/v2/daemon/internal/refstore"
+	"github.com/moby/moby/v2/daemon/internal/transferops"
 	"github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
 	"github.com/pkg/errors"
 )
 
@@ -40,12 +45,27 @@ type ImageServiceConfig struct {
 	LayerStore                layer.Store
 	MaxConcurrentDownloads    int
 	MaxConcurrentUploads      int
+	MaxConcurrentLoads        int
 	MaxDownloadAttempts       int
 	ReferenceStore            refstore.Store
 	RegistryService           distribution.RegistryResolver
 	ContentStore              content.Store
 	Leases                    leases.Manager
 	ContentNamespace          string
+	// PullGate, when set, is consulted before starting a pull; a non-nil
+	// error aborts the pull. It is used to reject pulls while the daemon
+	// is in maintenance mode.
+	PullGate func() error
+	// DefaultPullPlatform, when set, resolves the configured default
+	// platform for pulls of the given reference. It is consulted when a
+	// pull does not request an explicit platform; a nil result means the
+	// host platform.
+	DefaultPullPlatform func(reference.Named) *ocispec.Platform
+	// AdmitPull, when set, submits a pull to the daemon's admission
+	// controller before it starts. It may return a different reference
+	// to pull instead (e.g. pinned to a digest), or an error to deny
+	// the pull.
+	AdmitPull func(context.Context, reference.Named, *ocispec.Platform) (reference.Named, error)
 }
 
 // NewImageService returns a new ImageService from a configuration
@@ -60,9 +80,14 @@ func NewImageService(config ImageServiceConfig) *ImageService {
 		referenceStore:            config.ReferenceStore,
 		registryService:           config.RegistryService,
 		uploadManager:             xfer.NewLayerUploadManager(config.MaxConcurrentUploads),
+		maxConcurrentLoads:        config.MaxConcurrentLoads,
 		leases:                    config.Leases,
 		content:                   config.ContentStore,
 		contentNamespace:          config.ContentNamespace,
+		pullGate:                  config.PullGate,
+		defaultPullPlatform:       config.DefaultPullPlatform,
+		admitPull:                 config.AdmitPull,
+		transfers:                 transferops.NewTracker(),
 	}
 }
 
@@ -77,10 +102,16 @@ type ImageService struct {
 	pruneRunning              atomic.Bool
 	referenceStore            refstore.Store
 	registryService           distribution.RegistryResolver
+	referrers                 distribution.ReferrersCache
 	uploadManager             *xfer.LayerUploadManager
+	maxConcurrentLoads        int
 	leases                    leases.Manager
 	content                   content.Store
 	contentNamespace          string
+	pullGate                  func() error
+	defaultPullPlatform       func(reference.Named) *ocispec.Platform
+	admitPull                 func(context.Context, reference.Named, *ocispec.Platform) (reference.Named, error)
+	transfers                 *transferops.Tracker
 }
 
 // DistributionServices provides daemon image storage services
@@ -221,6 +252,46 @@ func (i *ImageService) ImageDiskUsage(ctx context.Context) (int64, error) {
 	return allLayersSize, nil
 }
 
+// StorageDiskUsage returns detailed usage of the image storage backend.
+// Snapshotter and content-store statistics are only available with the
+// containerd image store, so the graphdriver-based service has nothing to
+// report and the field is omitted from the response.
+func (i *ImageService) StorageDiskUsage(ctx context.Context) (*systemtype.StorageUsage, error) {
+	return nil, nil
+}
+
+// MarkImageUsed records the current time as the last time a container was
+// created from the image. The recorded time feeds the "keep-last-used"
+// prune filter.
+func (i *ImageService) MarkImageUsed(_ context.Context, id image.ID) error {
+	return i.imageStore.SetLastUsed(id)
+}
+
+// SetImageBuildID records the ID of the build history record that produced
+// the image.
+func (i *ImageService) SetImageBuildID(_ context.Context, id image.ID, buildID string) error {
+	return i.imageStore.SetBuildID(id, buildID)
+}
+
+// GetImageBuildID returns the ID of the build history record that produced
+// the image, or an empty string if the image wasn't built by the embedded
+// BuildKit.
+func (i *ImageService) GetImageBuildID(_ context.Context, id image.ID) (string, error) {
+	return i.imageStore.GetBuildID(id)
+}
+
+// DistributionOperations returns the in-flight image pulls and pushes,
+// oldest first.
+func (i *ImageService) DistributionOperations() []registry.DistributionOperation {
+	return i.transfers.List()
+}
+
+// CancelDistributionOperation cancels the in-flight image pull or push with
+// the given ID.
+func (i *ImageService) CancelDistributionOperation(id string) error {
+	return i.transfers.Cancel(id)
+}
+
 func (i *ImageService) getLayerRefs() map[layer.ChainID]int {
 	tmpImages := i.imageStore.Map()
 	layerRefs := map[layer.ChainID]int{}
@@ -73,6 +73,9 @@ func (daemon *Daemon) SystemInfo(ctx context.Context) (*system.Info, error) {
 		LiveRestoreEnabled: cfg.LiveRestoreEnabled,
 		Isolation:          daemon.defaultIsolation,
 		CDISpecDirs:        promoteNil(cfg.CDISpecDirs),
+
+		DefaultPullPlatform:   cfg.DefaultPullPlatform,
+		RegistryPullPlatforms: cfg.RegistryPullPlatforms,
 	}
 
 	daemon.fillContainerStates(v)
@@ -241,6 +244,9 @@ func (daemon *Daemon) fillContainerdInfo(v *system.Info, cfg *config.Config) {
 			Plugins:    cfg.ContainerdPluginNamespace,
 		},
 	}
+	if daemon.containerdHealth != nil {
+		v.Containerd.Health = daemon.containerdHealth.Health()
+	}
 }
 
 func (daemon *Daemon) fillAPIInfo(v *system.Info, cfg *config.Config) {
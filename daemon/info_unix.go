@@ -507,6 +507,14 @@ func populateInitVersion(ctx context.Context, cfg *configStore, v *types.Version
 // see https://github.com/opencontainers/runtime-spec/blob/main/features.md
 const ociRuntimeFeaturesKey = "org.opencontainers.runtime-spec.features"
 
+// Keys used in the runtime status map for the version reported by the
+// runtime binary and the error that makes the runtime unusable, both
+// probed at daemon start and on config reload.
+const (
+	runtimeVersionKey = "org.mobyproject.runtime.version"
+	runtimeErrorKey   = "org.mobyproject.runtime.error"
+)
+
 func (daemon *Daemon) runtimeStatus(ctx context.Context, cfg *configStore, runtimeName string) map[string]string {
 	m := make(map[string]string)
 	if runtimeName == "" {
@@ -519,5 +527,11 @@ func (daemon *Daemon) runtimeStatus(ctx context.Context, cfg *configStore, runti
 			log.G(ctx).WithFields(log.Fields{"error": err, "runtime": runtimeName}).Warn("Failed to call json.Marshal for the OCI features struct of runtime")
 		}
 	}
+	if v := cfg.Runtimes.Version(runtimeName); v != "" {
+		m[runtimeVersionKey] = v
+	}
+	if e := cfg.Runtimes.ProbeError(runtimeName); e != "" {
+		m[runtimeErrorKey] = e
+	}
 	return m
 }
@@ -15,6 +15,7 @@ func (daemon *Daemon) fillPlatformInfo(ctx context.Context, v *system.Info, sysI
 		v.Warnings = append(v.Warnings, `
 WARNING: Feature flag "windows-dns-proxy" has been removed, forwarding to external DNS resolvers is enabled.`)
 	}
+	v.ProcessorGroups = processorGroups()
 	return nil
 }
 
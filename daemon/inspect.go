@@ -89,6 +89,7 @@ func (daemon *Daemon) ContainerInspect(ctx context.Context, name string, options
 		Config:                  ctr.Config,
 		NetworkSettings:         networkSettings,
 		ImageManifestDescriptor: imageManifest,
+		Warnings:                ctr.Warnings,
 	}, nil
 }
 
@@ -128,18 +129,19 @@ func (daemon *Daemon) getInspectData(daemonCfg *config.Config, container *contai
 	}
 
 	containerState := &containertypes.State{
-		Status:     container.State.StateString(),
-		Running:    container.State.Running,
-		Paused:     container.State.Paused,
-		Restarting: container.State.Restarting,
-		OOMKilled:  container.State.OOMKilled,
-		Dead:       container.State.Dead,
-		Pid:        container.State.Pid,
-		ExitCode:   container.State.ExitCode(),
-		Error:      container.State.ErrorMsg,
-		StartedAt:  container.State.StartedAt.Format(time.RFC3339Nano),
-		FinishedAt: container.State.FinishedAt.Format(time.RFC3339Nano),
-		Health:     containerHealth,
+		Status:        container.State.StateString(),
+		Running:       container.State.Running,
+		Paused:        container.State.Paused,
+		Restarting:    container.State.Restarting,
+		OOMKilled:     container.State.OOMKilled,
+		Dead:          container.State.Dead,
+		Pid:           container.State.Pid,
+		ExitCode:      container.State.ExitCode(),
+		Error:         container.State.ErrorMsg,
+		StartedAt:     container.State.StartedAt.Format(time.RFC3339Nano),
+		FinishedAt:    container.State.FinishedAt.Format(time.RFC3339Nano),
+		Health:        containerHealth,
+		ResourceUsage: container.State.ResourceUsage,
 	}
 
 	contJSONBase := &containertypes.ContainerJSONBase{
@@ -149,6 +151,8 @@ func (daemon *Daemon) getInspectData(daemonCfg *config.Config, container *contai
 		Args:         container.Args,
 		State:        containerState,
 		Image:        container.ImageID.String(),
+		ImageDigest:  container.ImageDigest,
+		BuildID:      container.BuildID,
 		LogPath:      container.LogPath,
 		Name:         container.Name,
 		RestartCount: container.RestartCount,
@@ -160,8 +164,14 @@ func (daemon *Daemon) getInspectData(daemonCfg *config.Config, container *contai
 		HostConfig:   &hostConfig,
 	}
 
+	if container.LogCopier != nil {
+		if dropped, enabled := container.LogCopier.SamplingStats(); enabled {
+			contJSONBase.LogSamplingDropped = &dropped
+		}
+	}
+
 	// Now set any platform-specific fields
-	contJSONBase = setPlatformSpecificContainerFields(container, contJSONBase)
+	contJSONBase = daemon.setPlatformSpecificContainerFields(container, contJSONBase)
 
 	contJSONBase.GraphDriver.Name = container.Driver
 
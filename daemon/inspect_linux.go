@@ -1,16 +1,50 @@
 package daemon
 
 import (
+	"github.com/containerd/cgroups/v3"
 	"github.com/moby/moby/api/types/container"
 	containerpkg "github.com/moby/moby/v2/daemon/container"
 )
 
 // This sets platform-specific fields
-func setPlatformSpecificContainerFields(container *containerpkg.Container, contJSONBase *container.ContainerJSONBase) *container.ContainerJSONBase {
+func (daemon *Daemon) setPlatformSpecificContainerFields(container *containerpkg.Container, contJSONBase *container.ContainerJSONBase) *container.ContainerJSONBase {
 	contJSONBase.AppArmorProfile = container.AppArmorProfile
+	contJSONBase.SeccompProfile = daemon.effectiveSeccompProfile(container)
 	contJSONBase.ResolvConfPath = container.ResolvConfPath
 	contJSONBase.HostnamePath = container.HostnamePath
 	contJSONBase.HostsPath = container.HostsPath
 
+	// Surface the effective cgroup namespace mode for containers which do
+	// not have one stored in their HostConfig, so that the inspect output
+	// does not depend on knowing the daemon's cgroup v1/v2 defaults.
+	if contJSONBase.HostConfig.CgroupnsMode.IsEmpty() {
+		contJSONBase.HostConfig.CgroupnsMode = daemon.defaultCgroupnsMode()
+	}
+
 	return contJSONBase
 }
+
+// effectiveSeccompProfile returns the seccomp profile that applies to the
+// container, resolving the daemon's per-runtime and daemon-wide defaults when
+// the container does not specify a profile itself.
+func (daemon *Daemon) effectiveSeccompProfile(c *containerpkg.Container) string {
+	if c.SeccompProfile != "" {
+		return c.SeccompProfile
+	}
+	if p, ok := daemon.runtimeSeccomp[c.HostConfig.Runtime]; ok {
+		return p.path
+	}
+	return daemon.seccompProfilePath
+}
+
+// defaultCgroupnsMode returns the cgroup namespace mode applied to containers
+// which do not request one explicitly.
+func (daemon *Daemon) defaultCgroupnsMode() container.CgroupnsMode {
+	if m := container.CgroupnsMode(daemon.config().CgroupNamespaceMode); !m.IsEmpty() {
+		return m
+	}
+	if cgroups.Mode() == cgroups.Unified {
+		return container.CgroupnsModePrivate
+	}
+	return container.CgroupnsModeHost
+}
@@ -1,11 +1,45 @@
 package daemon
 
 import (
+	"context"
+	"strings"
+
+	"github.com/containerd/log"
 	"github.com/moby/moby/api/types/container"
 	containerpkg "github.com/moby/moby/v2/daemon/container"
+	"github.com/opencontainers/go-digest"
 )
 
 // This sets platform-specific fields
-func setPlatformSpecificContainerFields(container *containerpkg.Container, contJSONBase *container.ContainerJSONBase) *container.ContainerJSONBase {
+func (daemon *Daemon) setPlatformSpecificContainerFields(container *containerpkg.Container, contJSONBase *container.ContainerJSONBase) *container.ContainerJSONBase {
+	contJSONBase.CredentialSpec = daemon.credentialSpecStatus(container)
 	return contJSONBase
 }
+
+// credentialSpecStatus reports the credential spec the container was started
+// with, and whether the credential spec source (file, registry value or swarm
+// config) has been updated since. A stale credential spec requires a restart
+// of the container to take effect.
+func (daemon *Daemon) credentialSpecStatus(c *containerpkg.Container) *container.CredentialSpecStatus {
+	if c.CredentialSpecDigest == "" || c.HostConfig == nil {
+		return nil
+	}
+
+	status := &container.CredentialSpecStatus{}
+	for _, secOpt := range c.HostConfig.SecurityOpt {
+		if k, v, ok := strings.Cut(secOpt, "="); ok && strings.EqualFold(k, "credentialspec") {
+			status.Source = v
+		}
+	}
+
+	current, err := daemon.resolveCredentialSpec(c)
+	if err != nil {
+		// The source may be temporarily unavailable (e.g. a swarm config
+		// store that cannot be reached); report the spec as not stale
+		// rather than failing the inspect.
+		log.G(context.TODO()).WithError(err).WithField("container", c.ID).Debug("could not re-resolve credential spec to check for staleness")
+		return status
+	}
+	status.Stale = digest.FromString(current).String() != c.CredentialSpecDigest
+	return status
+}
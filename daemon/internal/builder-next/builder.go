@@ -106,10 +106,13 @@ type Builder struct {
 	controller     *control.Controller
 	dnsconfig      config.DNSConfig
 	reqBodyHandler *reqBodyHandler
+	sessionManager *session.Manager
 
 	mu             sync.Mutex
 	jobs           map[string]*buildJob
 	useSnapshotter bool
+	callbacks      exporter.BuildkitCallbacks
+	secretRegistry *secretRegistry
 }
 
 // New creates a new builder
@@ -124,8 +127,11 @@ func New(ctx context.Context, opt Opt) (*Builder, error) {
 		controller:     c,
 		dnsconfig:      opt.DNSConfig,
 		reqBodyHandler: reqHandler,
+		sessionManager: opt.SessionManager,
 		jobs:           map[string]*buildJob{},
 		useSnapshotter: opt.UseSnapshotter,
+		callbacks:      opt.Callbacks,
+		secretRegistry: newSecretRegistry(),
 	}
 	return b, nil
 }
@@ -241,6 +247,93 @@ func (b *Builder) Prune(ctx context.Context, opts build.CachePruneOptions) (int6
 	return size, cacheIDs, nil
 }
 
+// BuildHistory returns the recorded build history. If ref is non-empty, only
+// the record for that build is returned.
+func (b *Builder) BuildHistory(ctx context.Context, ref string) ([]*build.HistoryRecord, error) {
+	ch := make(chan *controlapi.BuildHistoryEvent)
+
+	eg, ctx := errgroup.WithContext(ctx)
+	eg.Go(func() error {
+		defer close(ch)
+		return b.controller.ListenBuildHistory(&controlapi.BuildHistoryRequest{
+			EarlyExit: true,
+			Ref:       ref,
+		}, &historyProxy{
+			streamProxy: streamProxy{ctx: ctx},
+			ch:          ch,
+		})
+	})
+
+	var records []*build.HistoryRecord
+	eg.Go(func() error {
+		for ev := range ch {
+			if ev.Record == nil || ev.Type == controlapi.BuildHistoryEventType_DELETED {
+				continue
+			}
+			records = append(records, toBuildHistoryRecord(ev.Record))
+		}
+		return nil
+	})
+
+	if err := eg.Wait(); err != nil {
+		return nil, err
+	}
+	return records, nil
+}
+
+// PruneBuildHistory deletes all completed, unpinned build history records,
+// and returns the refs of the deleted records.
+func (b *Builder) PruneBuildHistory(ctx context.Context) ([]string, error) {
+	records, err := b.BuildHistory(ctx, "")
+	if err != nil {
+		return nil, err
+	}
+
+	var deleted []string
+	for _, r := range records {
+		if r.Pinned || r.CompletedAt.IsZero() {
+			continue
+		}
+		if _, err := b.controller.UpdateBuildHistory(ctx, &controlapi.UpdateBuildHistoryRequest{
+			Ref:    r.Ref,
+			Delete: true,
+		}); err != nil {
+			return deleted, err
+		}
+		deleted = append(deleted, r.Ref)
+	}
+	return deleted, nil
+}
+
+func toBuildHistoryRecord(r *controlapi.BuildHistoryRecord) *build.HistoryRecord {
+	rec := &build.HistoryRecord{
+		Ref:               r.Ref,
+		Frontend:          r.Frontend,
+		FrontendAttrs:     r.FrontendAttrs,
+		Pinned:            r.Pinned,
+		Generation:        r.Generation,
+		NumCachedSteps:    r.NumCachedSteps,
+		NumTotalSteps:     r.NumTotalSteps,
+		NumCompletedSteps: r.NumCompletedSteps,
+		NumWarnings:       r.NumWarnings,
+		ExporterResponse:  r.ExporterResponse,
+	}
+	for _, e := range r.Exporters {
+		rec.Exporters = append(rec.Exporters, e.Type)
+	}
+	if r.Error != nil {
+		rec.Error = r.Error.Message
+	}
+	if r.CreatedAt != nil {
+		rec.CreatedAt = r.CreatedAt.AsTime()
+	}
+	if r.CompletedAt != nil {
+		rec.CompletedAt = r.CompletedAt.AsTime()
+		rec.Duration = rec.CompletedAt.Sub(rec.CreatedAt)
+	}
+	return rec
+}
+
 // Build executes a build request
 func (b *Builder) Build(ctx context.Context, opt backend.BuildConfig) (*builder.Result, error) {
 	if len(opt.Options.Outputs) > 1 {
@@ -405,6 +498,19 @@ func (b *Builder) Build(ctx context.Context, opt backend.BuildConfig) (*builder.
 		}
 	}
 
+	sessionID := opt.Options.SessionID
+	if len(opt.Options.Secrets) > 0 || len(opt.Options.SecretIDs) > 0 || len(opt.Options.SSHSources) > 0 {
+		if sessionID != "" {
+			return nil, errdefs.InvalidParameter(errors.New("inline build secrets and SSH sources cannot be combined with a client session; attach secret and SSH providers to the session instead"))
+		}
+		providerSession, err := b.newProviderSession(ctx, opt.Options)
+		if err != nil {
+			return nil, err
+		}
+		defer providerSession.Close()
+		sessionID = providerSession.ID()
+	}
+
 	id := identity.NewID()
 	req := &controlapi.SolveRequest{
 		Ref: id,
@@ -413,7 +519,7 @@ func (b *Builder) Build(ctx context.Context, opt backend.BuildConfig) (*builder.
 		},
 		Frontend:      "dockerfile.v0",
 		FrontendAttrs: frontendAttrs,
-		Session:       opt.Options.SessionID,
+		Session:       sessionID,
 		Cache:         cache,
 	}
 
@@ -438,6 +544,9 @@ func (b *Builder) Build(ctx context.Context, opt backend.BuildConfig) (*builder.
 			return errors.Errorf("missing image id")
 		}
 		out.ImageID = imgID
+		if b.callbacks.Built != nil {
+			b.callbacks.Built(ctx, id, imgID)
+		}
 		return aux.Emit("moby.image.id", build.Result{ID: imgID})
 	})
 
@@ -526,6 +635,22 @@ func (sp *pruneProxy) SendMsg(m interface{}) error {
 	return nil
 }
 
+type historyProxy struct {
+	streamProxy
+	ch chan *controlapi.BuildHistoryEvent
+}
+
+func (sp *historyProxy) Send(resp *controlapi.BuildHistoryEvent) error {
+	return sp.SendMsg(resp)
+}
+
+func (sp *historyProxy) SendMsg(m interface{}) error {
+	if ev, ok := m.(*controlapi.BuildHistoryEvent); ok {
+		sp.ch <- ev
+	}
+	return nil
+}
+
 type wrapRC struct {
 	io.ReadCloser
 	once   sync.Once
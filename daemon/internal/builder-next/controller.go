@@ -447,10 +447,12 @@ func newGraphDriverController(ctx context.Context, rt http.RoundTripper, opt Opt
 		CacheManager:     solver.NewCacheManager(ctx, "local", cacheStorage, worker.NewCacheResultStorage(wc)),
 		CacheStore:       cacheStorage,
 		ResolveCacheImporterFuncs: map[string]remotecache.ResolveCacheImporterFunc{
+			"gha":      gha.ResolveCacheImporterFunc(),
 			"registry": localinlinecache.ResolveCacheImporterFunc(opt.SessionManager, opt.RegistryHosts, store, dist.ReferenceStore, dist.ImageStore),
 			"local":    localremotecache.ResolveCacheImporterFunc(opt.SessionManager),
 		},
 		ResolveCacheExporterFuncs: map[string]remotecache.ResolveCacheExporterFunc{
+			"gha":    gha.ResolveCacheExporterFunc(),
 			"inline": inlineremotecache.ResolveCacheExporterFunc(),
 		},
 		Entitlements:   getEntitlements(opt.BuilderConfig),
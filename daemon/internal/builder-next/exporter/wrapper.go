@@ -20,6 +20,11 @@ type BuildkitCallbacks struct {
 	// Named is a callback that is called when an image is created in the
 	// containerd image store by buildkit.
 	Named func(ctx context.Context, ref reference.NamedTagged, desc ocispec.Descriptor)
+
+	// Built is a callback that is called when a build producing an image
+	// completes. It receives the ref of the build history record and the
+	// ID of the image that was exported.
+	Built func(ctx context.Context, buildRef string, imageID string)
 }
 
 // Wraps the containerimage exporter's Resolve method to apply moby-specific
@@ -0,0 +1,146 @@
+package buildkit
+
+import (
+	"sync"
+	"time"
+
+	"github.com/moby/moby/api/types/build"
+	"github.com/moby/moby/v2/errdefs"
+	"github.com/pkg/errors"
+)
+
+const (
+	// secretRegistryTTL is how long a registered entry is kept when no
+	// build references it. Registration is meant to happen just ahead of
+	// a build, so unreferenced entries are presumed abandoned.
+	secretRegistryTTL = 5 * time.Minute
+	// secretRegistryMaxBytes caps the combined size of all registered
+	// secret payloads and agent socket paths held in daemon memory.
+	secretRegistryMaxBytes = 4 << 20 // 4 MiB
+)
+
+// secretRegistry holds build secrets and SSH agent sockets registered through
+// "POST /build/secrets" ahead of a build, for clients that cannot serve them
+// over the session gRPC protocol. Entries live in the daemon's memory only
+// and are removed when a build references them, or after secretRegistryTTL
+// when none does.
+type secretRegistry struct {
+	mu      sync.Mutex
+	secrets map[string]secretEntry
+	ssh     map[string]sshEntry
+}
+
+type secretEntry struct {
+	data     []byte
+	deadline time.Time
+}
+
+type sshEntry struct {
+	sock     string
+	deadline time.Time
+}
+
+func newSecretRegistry() *secretRegistry {
+	return &secretRegistry{
+		secrets: make(map[string]secretEntry),
+		ssh:     make(map[string]sshEntry),
+	}
+}
+
+// expire discards entries whose TTL has lapsed. Callers must hold r.mu.
+func (r *secretRegistry) expire(now time.Time) {
+	for id, e := range r.secrets {
+		if now.After(e.deadline) {
+			delete(r.secrets, id)
+		}
+	}
+	for id, e := range r.ssh {
+		if now.After(e.deadline) {
+			delete(r.ssh, id)
+		}
+	}
+}
+
+// takeSecret removes and returns the registered secret with the given ID.
+func (r *secretRegistry) takeSecret(id string) ([]byte, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.expire(time.Now())
+	e, ok := r.secrets[id]
+	if ok {
+		delete(r.secrets, id)
+	}
+	return e.data, ok
+}
+
+// takeSSH removes and returns the registered agent socket with the given ID.
+func (r *secretRegistry) takeSSH(id string) (string, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.expire(time.Now())
+	e, ok := r.ssh[id]
+	if ok {
+		delete(r.ssh, id)
+	}
+	return e.sock, ok
+}
+
+// RegisterSecrets stores the build secrets and SSH agent sockets in req so
+// that a subsequent build request can reference them by ID. Registering an ID
+// again replaces the previous entry; an entry is removed once a build
+// references it, or after secretRegistryTTL when none does. The combined size
+// of all registered entries is capped.
+func (b *Builder) RegisterSecrets(req build.SecretsRequest) error {
+	for _, sec := range req.Secrets {
+		if sec.ID == "" {
+			return errdefs.InvalidParameter(errors.New("build secret is missing an ID"))
+		}
+	}
+	for _, src := range req.SSHSources {
+		if src.ID == "" || src.AgentSocket == "" {
+			return errdefs.InvalidParameter(errors.New("registered ssh source must have an ID and an agent socket"))
+		}
+	}
+
+	reg := b.secretRegistry
+	reg.mu.Lock()
+	defer reg.mu.Unlock()
+	now := time.Now()
+	reg.expire(now)
+
+	// Size the registry as it would be after this request, counting
+	// replaced entries once.
+	total := 0
+	newSecrets := make(map[string]struct{}, len(req.Secrets))
+	for _, sec := range req.Secrets {
+		newSecrets[sec.ID] = struct{}{}
+		total += len(sec.Data)
+	}
+	newSSH := make(map[string]struct{}, len(req.SSHSources))
+	for _, src := range req.SSHSources {
+		newSSH[src.ID] = struct{}{}
+		total += len(src.AgentSocket)
+	}
+	for id, e := range reg.secrets {
+		if _, ok := newSecrets[id]; !ok {
+			total += len(e.data)
+		}
+	}
+	for id, e := range reg.ssh {
+		if _, ok := newSSH[id]; !ok {
+			total += len(e.sock)
+		}
+	}
+	if total > secretRegistryMaxBytes {
+		return errdefs.InvalidParameter(errors.Errorf("registered build secrets exceed the maximum combined size of %d bytes", secretRegistryMaxBytes))
+	}
+
+	deadline := now.Add(secretRegistryTTL)
+	for _, sec := range req.Secrets {
+		reg.secrets[sec.ID] = secretEntry{data: sec.Data, deadline: deadline}
+	}
+	for _, src := range req.SSHSources {
+		reg.ssh[src.ID] = sshEntry{sock: src.AgentSocket, deadline: deadline}
+	}
+	return nil
+}
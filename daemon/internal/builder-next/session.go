@@ -0,0 +1,145 @@
+package buildkit
+
+import (
+	"context"
+	"net"
+	"os"
+
+	"github.com/containerd/log"
+	"github.com/moby/buildkit/session"
+	"github.com/moby/buildkit/session/secrets"
+	"github.com/moby/buildkit/session/sshforward"
+	"github.com/moby/moby/api/types/build"
+	"github.com/moby/moby/v2/errdefs"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// newProviderSession starts a daemon-side session serving inline and
+// pre-registered build secrets and daemon-local SSH agents for builds
+// submitted over the classic /build endpoint without a client session. The
+// caller must close the returned session once the build has finished.
+func (b *Builder) newProviderSession(ctx context.Context, opts *build.ImageBuildOptions) (*session.Session, error) {
+	s, err := session.NewSession(ctx, "moby-build-providers")
+	if err != nil {
+		return nil, err
+	}
+
+	if len(opts.Secrets) > 0 || len(opts.SecretIDs) > 0 {
+		store := make(map[string][]byte, len(opts.Secrets)+len(opts.SecretIDs))
+		for _, sec := range opts.Secrets {
+			if sec.ID == "" {
+				return nil, errdefs.InvalidParameter(errors.New("build secret is missing an ID"))
+			}
+			store[sec.ID] = sec.Data
+		}
+		for _, id := range opts.SecretIDs {
+			dt, ok := b.secretRegistry.takeSecret(id)
+			if !ok {
+				return nil, errdefs.NotFound(errors.Errorf("no build secret registered with ID %q", id))
+			}
+			store[id] = dt
+		}
+		s.Allow(&inlineSecretStore{secrets: store})
+	}
+
+	if len(opts.SSHSources) > 0 {
+		socks := make(map[string]string, len(opts.SSHSources))
+		for _, src := range opts.SSHSources {
+			id := src.ID
+			if id == "" {
+				id = sshforward.DefaultID
+			}
+			sock := src.AgentSocket
+			if sock == "" {
+				if registered, ok := b.secretRegistry.takeSSH(id); ok {
+					sock = registered
+				} else {
+					sock = os.Getenv("SSH_AUTH_SOCK")
+				}
+			}
+			if sock == "" {
+				return nil, errdefs.InvalidParameter(errors.Errorf("no agent socket for ssh source %q and SSH_AUTH_SOCK is not set on the daemon", id))
+			}
+			socks[id] = sock
+		}
+		s.Allow(&sshAgentForwarder{socks: socks})
+	}
+
+	go func() {
+		err := s.Run(ctx, func(ctx context.Context, proto string, meta map[string][]string) (net.Conn, error) {
+			c1, c2 := net.Pipe()
+			go func() {
+				if err := b.sessionManager.HandleConn(ctx, c2, meta); err != nil {
+					log.G(ctx).WithError(err).Debug("build provider session connection finished")
+				}
+			}()
+			return c1, nil
+		})
+		if err != nil {
+			log.G(ctx).WithError(err).Debug("build provider session finished")
+		}
+	}()
+	return s, nil
+}
+
+// inlineSecretStore serves secrets passed inline with a build request over
+// the build session. Secret data is kept in memory for the duration of the
+// build only.
+type inlineSecretStore struct {
+	secrets.UnimplementedSecretsServer
+	secrets map[string][]byte
+}
+
+func (s *inlineSecretStore) Register(server *grpc.Server) {
+	secrets.RegisterSecretsServer(server, s)
+}
+
+func (s *inlineSecretStore) GetSecret(_ context.Context, req *secrets.GetSecretRequest) (*secrets.GetSecretResponse, error) {
+	dt, ok := s.secrets[req.ID]
+	if !ok {
+		return nil, errors.Wrapf(secrets.ErrNotFound, "secret %s", req.ID)
+	}
+	return &secrets.GetSecretResponse{Data: dt}, nil
+}
+
+// sshAgentForwarder forwards SSH agent connections from the build to agent
+// sockets on the daemon's host.
+type sshAgentForwarder struct {
+	sshforward.UnimplementedSSHServer
+	socks map[string]string
+}
+
+func (f *sshAgentForwarder) Register(server *grpc.Server) {
+	sshforward.RegisterSSHServer(server, f)
+}
+
+func (f *sshAgentForwarder) CheckAgent(_ context.Context, req *sshforward.CheckAgentRequest) (*sshforward.CheckAgentResponse, error) {
+	id := req.ID
+	if id == "" {
+		id = sshforward.DefaultID
+	}
+	if _, ok := f.socks[id]; !ok {
+		return nil, errors.Errorf("unset ssh forward key %s", id)
+	}
+	return &sshforward.CheckAgentResponse{}, nil
+}
+
+func (f *sshAgentForwarder) ForwardAgent(stream sshforward.SSH_ForwardAgentServer) error {
+	id := sshforward.DefaultID
+	if md, ok := metadata.FromIncomingContext(stream.Context()); ok {
+		if v := md[sshforward.KeySSHID]; len(v) > 0 && v[0] != "" {
+			id = v[0]
+		}
+	}
+	sock, ok := f.socks[id]
+	if !ok {
+		return errors.Errorf("unset ssh forward key %s", id)
+	}
+	conn, err := net.Dial("unix", sock)
+	if err != nil {
+		return errors.Wrapf(err, "failed to connect to agent socket %s", sock)
+	}
+	return sshforward.Copy(stream.Context(), conn, stream, nil)
+}
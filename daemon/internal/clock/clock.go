@@ -0,0 +1,54 @@
+// Package clock provides a small abstraction over the parts of the standard
+// library time package that are awkward to drive from tests: reading the
+// current time and waiting on timers. Production code uses the system clock;
+// tests substitute a [Fake] clock and advance it manually, replacing
+// sleep-based tests with deterministic steps.
+package clock
+
+import "time"
+
+// Clock tells the current time and creates timers.
+type Clock interface {
+	// Now returns the current time.
+	Now() time.Time
+	// Since returns the time elapsed since t.
+	Since(t time.Time) time.Duration
+	// After waits for the duration to elapse and then sends the current
+	// time on the returned channel, like [time.After].
+	After(d time.Duration) <-chan time.Time
+	// NewTimer creates a Timer that fires after the duration, like
+	// [time.NewTimer].
+	NewTimer(d time.Duration) Timer
+}
+
+// Timer mirrors [time.Timer], with the channel behind an accessor so that
+// fake timers can implement it.
+type Timer interface {
+	// C returns the channel on which the timer fires.
+	C() <-chan time.Time
+	// Stop prevents the timer from firing, like [time.Timer.Stop].
+	Stop() bool
+	// Reset changes the timer to fire after the duration, like
+	// [time.Timer.Reset].
+	Reset(d time.Duration) bool
+}
+
+// System returns a Clock backed by the real system clock.
+func System() Clock {
+	return systemClock{}
+}
+
+type systemClock struct{}
+
+func (systemClock) Now() time.Time                         { return time.Now() }
+func (systemClock) Since(t time.Time) time.Duration        { return time.Since(t) }
+func (systemClock) After(d time.Duration) <-chan time.Time { return time.After(d) }
+func (systemClock) NewTimer(d time.Duration) Timer         { return &systemTimer{time.NewTimer(d)} }
+
+type systemTimer struct {
+	t *time.Timer
+}
+
+func (st *systemTimer) C() <-chan time.Time        { return st.t.C }
+func (st *systemTimer) Stop() bool                 { return st.t.Stop() }
+func (st *systemTimer) Reset(d time.Duration) bool { return st.t.Reset(d) }
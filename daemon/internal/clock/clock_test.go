@@ -0,0 +1,70 @@
+package clock
+
+import (
+	"testing"
+	"time"
+
+	"gotest.tools/v3/assert"
+	is "gotest.tools/v3/assert/cmp"
+)
+
+func TestFakeAdvance(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	fc := NewFake(start)
+
+	ch := fc.After(time.Minute)
+	select {
+	case <-ch:
+		t.Fatal("timer fired before the clock was advanced")
+	default:
+	}
+
+	fc.Advance(30 * time.Second)
+	select {
+	case <-ch:
+		t.Fatal("timer fired before its deadline")
+	default:
+	}
+	assert.Check(t, is.Equal(fc.Since(start), 30*time.Second))
+
+	fc.Advance(30 * time.Second)
+	select {
+	case now := <-ch:
+		assert.Check(t, is.Equal(now, start.Add(time.Minute)))
+	default:
+		t.Fatal("timer did not fire at its deadline")
+	}
+	assert.Check(t, is.Equal(fc.Timers(), 0))
+}
+
+func TestFakeTimerStopReset(t *testing.T) {
+	fc := NewFake(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+
+	tm := fc.NewTimer(time.Second)
+	assert.Check(t, tm.Stop())
+	assert.Check(t, !tm.Stop(), "second Stop should report an inactive timer")
+
+	fc.Advance(time.Second)
+	select {
+	case <-tm.C():
+		t.Fatal("stopped timer fired")
+	default:
+	}
+
+	assert.Check(t, !tm.Reset(time.Second), "Reset of a stopped timer should report it was inactive")
+	fc.Advance(time.Second)
+	select {
+	case <-tm.C():
+	default:
+		t.Fatal("reset timer did not fire")
+	}
+}
+
+func TestFakeZeroDurationFiresImmediately(t *testing.T) {
+	fc := NewFake(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	select {
+	case <-fc.After(0):
+	default:
+		t.Fatal("zero-duration timer did not fire")
+	}
+}
@@ -0,0 +1,130 @@
+package clock
+
+import (
+	"sync"
+	"time"
+)
+
+// Fake is a Clock whose time only moves when [Fake.Advance] is called.
+// Timers created from it fire when an Advance carries the clock past their
+// deadline. It is safe for concurrent use.
+type Fake struct {
+	mu     sync.Mutex
+	now    time.Time
+	timers []*fakeTimer
+}
+
+// NewFake returns a Fake clock frozen at the given time.
+func NewFake(now time.Time) *Fake {
+	return &Fake{now: now}
+}
+
+// Now returns the fake clock's current time.
+func (f *Fake) Now() time.Time {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.now
+}
+
+// Since returns the fake time elapsed since t.
+func (f *Fake) Since(t time.Time) time.Duration {
+	return f.Now().Sub(t)
+}
+
+// After returns a channel on which the fake time is sent once the clock has
+// been advanced by at least d.
+func (f *Fake) After(d time.Duration) <-chan time.Time {
+	return f.NewTimer(d).C()
+}
+
+// NewTimer creates a timer that fires when the clock is advanced past its
+// deadline.
+func (f *Fake) NewTimer(d time.Duration) Timer {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	t := &fakeTimer{
+		clk:      f,
+		ch:       make(chan time.Time, 1),
+		deadline: f.now.Add(d),
+		active:   true,
+	}
+	f.timers = append(f.timers, t)
+	f.fireLocked()
+	return t
+}
+
+// Advance moves the clock forward by d, firing every active timer whose
+// deadline has been reached.
+func (f *Fake) Advance(d time.Duration) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.now = f.now.Add(d)
+	f.fireLocked()
+}
+
+// Timers returns the number of timers that have not yet fired or been
+// stopped. Tests can poll it to wait for the code under test to start
+// waiting before advancing the clock.
+func (f *Fake) Timers() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	n := 0
+	for _, t := range f.timers {
+		if t.active {
+			n++
+		}
+	}
+	return n
+}
+
+// fireLocked fires every active timer whose deadline is not after the
+// current time. Callers must hold f.mu.
+func (f *Fake) fireLocked() {
+	remaining := f.timers[:0]
+	for _, t := range f.timers {
+		if t.active && !t.deadline.After(f.now) {
+			t.active = false
+			// Non-blocking, like the runtime timers: if a previous
+			// firing was never drained the new one is coalesced.
+			select {
+			case t.ch <- f.now:
+			default:
+			}
+			continue
+		}
+		remaining = append(remaining, t)
+	}
+	f.timers = remaining
+}
+
+type fakeTimer struct {
+	clk      *Fake
+	ch       chan time.Time
+	deadline time.Time
+	active   bool
+}
+
+func (t *fakeTimer) C() <-chan time.Time {
+	return t.ch
+}
+
+func (t *fakeTimer) Stop() bool {
+	t.clk.mu.Lock()
+	defer t.clk.mu.Unlock()
+	wasActive := t.active
+	t.active = false
+	return wasActive
+}
+
+func (t *fakeTimer) Reset(d time.Duration) bool {
+	t.clk.mu.Lock()
+	defer t.clk.mu.Unlock()
+	wasActive := t.active
+	t.deadline = t.clk.now.Add(d)
+	if !t.active {
+		t.active = true
+		t.clk.timers = append(t.clk.timers, t)
+	}
+	t.clk.fireLocked()
+	return wasActive
+}
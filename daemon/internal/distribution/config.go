@@ -18,6 +18,7 @@ import (
 	"github.com/moby/moby/v2/daemon/internal/layer"
 	refstore "github.com/moby/moby/v2/daemon/internal/refstore"
 	registrypkg "github.com/moby/moby/v2/daemon/pkg/registry"
+	"github.com/moby/moby/v2/daemon/server/backend"
 	"github.com/opencontainers/go-digest"
 	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
 	"github.com/pkg/errors"
@@ -74,6 +75,12 @@ type ImagePushConfig struct {
 	LayerStores PushLayerProvider
 	// UploadManager dispatches uploads.
 	UploadManager *xfer.LayerUploadManager
+	// Compression is the algorithm used for layers that are (re)compressed
+	// during the push; [backend.CompressionDefault] selects gzip.
+	Compression backend.Compression
+	// CompressionLevel is the algorithm-specific compression level. Zero
+	// selects the algorithm's default level.
+	CompressionLevel int
 }
 
 // RegistryResolver is used for TLS configuration and endpoint lookup.
@@ -10,8 +10,10 @@ import (
 
 	"github.com/containerd/log"
 	"github.com/distribution/reference"
+	"github.com/klauspost/compress/zstd"
 	"github.com/moby/moby/api/pkg/progress"
 	"github.com/moby/moby/api/types/events"
+	"github.com/moby/moby/v2/daemon/server/backend"
 )
 
 const compressionBufSize = 32768
@@ -103,13 +105,35 @@ func Push(ctx context.Context, ref reference.Named, config *ImagePushConfig) err
 // is finished. This allows the caller to make sure the goroutine finishes
 // before it releases any resources connected with the reader that was
 // passed in.
-func compress(in io.Reader) (io.ReadCloser, chan struct{}) {
+func compress(in io.Reader, comp backend.Compression, level int) (io.ReadCloser, chan struct{}, error) {
 	compressionDone := make(chan struct{})
 
 	pipeReader, pipeWriter := io.Pipe()
 	// Use a bufio.Writer to avoid excessive chunking in HTTP request.
 	bufWriter := bufio.NewWriterSize(pipeWriter, compressionBufSize)
-	compressor := gzip.NewWriter(bufWriter)
+
+	var compressor io.WriteCloser
+	switch comp {
+	case backend.CompressionZstd:
+		var opts []zstd.EOption
+		if level > 0 {
+			opts = append(opts, zstd.WithEncoderLevel(zstd.EncoderLevelFromZstd(level)))
+		}
+		zw, err := zstd.NewWriter(bufWriter, opts...)
+		if err != nil {
+			return nil, nil, err
+		}
+		compressor = zw
+	default:
+		if level == 0 {
+			level = gzip.DefaultCompression
+		}
+		gw, err := gzip.NewWriterLevel(bufWriter, level)
+		if err != nil {
+			return nil, nil, err
+		}
+		compressor = gw
+	}
 
 	go func() {
 		_, err := io.Copy(compressor, in)
@@ -127,5 +151,5 @@ func compress(in io.Reader) (io.ReadCloser, chan struct{}) {
 		close(compressionDone)
 	}()
 
-	return pipeReader, compressionDone
+	return pipeReader, compressionDone, nil
 }
@@ -1,6 +1,7 @@
 package distribution
 
 import (
+	"compress/gzip"
 	"context"
 	"fmt"
 	"io"
@@ -21,8 +22,10 @@ import (
 	"github.com/moby/moby/v2/daemon/internal/layer"
 	"github.com/moby/moby/v2/daemon/internal/stringid"
 	"github.com/moby/moby/v2/daemon/pkg/registry"
+	"github.com/moby/moby/v2/daemon/server/backend"
 	"github.com/moby/moby/v2/pkg/ioutils"
 	"github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
 	"github.com/pkg/errors"
 )
 
@@ -148,12 +151,14 @@ func (p *pusher) pushTag(ctx context.Context, ref reference.NamedTagged, id dige
 	var descriptors []xfer.UploadDescriptor
 
 	descriptorTemplate := pushDescriptor{
-		metadataService: p.metadataService,
-		hmacKey:         hmacKey,
-		repoName:        p.repoName,
-		ref:             p.ref,
-		repo:            p.repo,
-		pushState:       &p.pushState,
+		metadataService:  p.metadataService,
+		hmacKey:          hmacKey,
+		repoName:         p.repoName,
+		ref:              p.ref,
+		repo:             p.repo,
+		pushState:        &p.pushState,
+		compression:      p.config.Compression,
+		compressionLevel: p.config.CompressionLevel,
 	}
 
 	// Loop bounds condition is to avoid pushing the base layer on Windows.
@@ -238,6 +243,8 @@ type pushDescriptor struct {
 	ref              reference.Named
 	repo             distribution.Repository
 	pushState        *pushState
+	compression      backend.Compression
+	compressionLevel int
 	remoteDescriptor distribution.Descriptor
 	// a set of digests whose presence has been checked in a target repository
 	checkedDigests map[digest.Digest]struct{}
@@ -421,15 +428,46 @@ func (pd *pushDescriptor) uploadUsingSession(
 
 	reader = progress.NewProgressReader(ioutils.NewCancelReadCloser(ctx, contentReader), progressOutput, pd.layer.Size(), pd.ID(), "Pushing")
 
+	mediaType := schema2.MediaTypeLayer
+	if pd.compression == backend.CompressionZstd {
+		// schema2 has no media type for zstd-compressed layers; the OCI
+		// one is used instead, which most registries accept.
+		mediaType = ocispec.MediaTypeImageLayerZstd
+	}
+
 	switch m := pd.layer.MediaType(); m {
 	case schema2.MediaTypeUncompressedLayer:
-		compressedReader, compressionDone := compress(reader)
+		compressedReader, compressionDone, err := compress(reader, pd.compression, pd.compressionLevel)
+		if err != nil {
+			reader.Close()
+			return distribution.Descriptor{}, xfer.DoNotRetry{Err: err}
+		}
 		defer func(closer io.Closer) {
 			closer.Close()
 			<-compressionDone
 		}(reader)
 		reader = compressedReader
 	case schema2.MediaTypeLayer:
+		if pd.compression != backend.CompressionZstd {
+			break
+		}
+		// The layer store holds the layer as a gzipped tar; recompress
+		// it with zstd.
+		decompressed, err := gzip.NewReader(reader)
+		if err != nil {
+			reader.Close()
+			return distribution.Descriptor{}, xfer.DoNotRetry{Err: err}
+		}
+		compressedReader, compressionDone, err := compress(decompressed, pd.compression, pd.compressionLevel)
+		if err != nil {
+			reader.Close()
+			return distribution.Descriptor{}, xfer.DoNotRetry{Err: err}
+		}
+		defer func(closer io.Closer) {
+			closer.Close()
+			<-compressionDone
+		}(reader)
+		reader = compressedReader
 	default:
 		reader.Close()
 		return distribution.Descriptor{}, xfer.DoNotRetry{Err: fmt.Errorf("unsupported layer media type %s", m)}
@@ -462,7 +500,7 @@ func (pd *pushDescriptor) uploadUsingSession(
 
 	desc := distribution.Descriptor{
 		Digest:    pushDigest,
-		MediaType: schema2.MediaTypeLayer,
+		MediaType: mediaType,
 		Size:      nn,
 	}
 
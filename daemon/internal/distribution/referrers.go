@@ -0,0 +1,105 @@
+package distribution
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/distribution/reference"
+	"github.com/docker/distribution/manifest/manifestlist"
+	"github.com/moby/moby/api/types/registry"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+)
+
+// referrersCacheTTL bounds how long a cached artifact listing is served
+// before the registry is queried again.
+const referrersCacheTTL = 1 * time.Minute
+
+// ReferrersCache lazily fetches, and caches for a short time, the
+// descriptors of artifacts that refer to an image manifest (such as
+// signatures, SBOMs and attestations). The zero value is ready for use.
+type ReferrersCache struct {
+	mu      sync.Mutex
+	entries map[string]referrersCacheEntry
+}
+
+type referrersCacheEntry struct {
+	artifacts []ocispec.Descriptor
+	expires   time.Time
+}
+
+// Get returns the descriptors of artifacts referring to the manifest the
+// canonical reference points at, using the OCI referrers tag fallback
+// ("sha256-<hex>"), which also works with registries that do not implement
+// the referrers API. A manifest without referrers yields an empty list.
+func (c *ReferrersCache) Get(ctx context.Context, ref reference.Canonical, registryService RegistryResolver) ([]ocispec.Descriptor, error) {
+	key := ref.String()
+	c.mu.Lock()
+	if e, ok := c.entries[key]; ok && time.Now().Before(e.expires) {
+		c.mu.Unlock()
+		return e.artifacts, nil
+	}
+	c.mu.Unlock()
+
+	artifacts, err := fetchReferrers(ctx, ref, registryService)
+	if err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	if c.entries == nil {
+		c.entries = make(map[string]referrersCacheEntry)
+	}
+	c.entries[key] = referrersCacheEntry{
+		artifacts: artifacts,
+		expires:   time.Now().Add(referrersCacheTTL),
+	}
+	c.mu.Unlock()
+	return artifacts, nil
+}
+
+func fetchReferrers(ctx context.Context, ref reference.Canonical, registryService RegistryResolver) ([]ocispec.Descriptor, error) {
+	repos, err := GetRepositories(ctx, ref, &ImagePullConfig{
+		Config: Config{
+			AuthConfig:      &registry.AuthConfig{},
+			RegistryService: registryService,
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	tag := strings.ReplaceAll(ref.Digest().String(), ":", "-")
+	for _, repo := range repos {
+		// A missing referrers tag means the manifest has no artifacts
+		// associated with it; that is not an error.
+		desc, err := repo.Tags(ctx).Get(ctx, tag)
+		if err != nil {
+			continue
+		}
+		mnfstsrvc, err := repo.Manifests(ctx)
+		if err != nil {
+			continue
+		}
+		mnfst, err := mnfstsrvc.Get(ctx, desc.Digest)
+		if err != nil {
+			continue
+		}
+		index, ok := mnfst.(*manifestlist.DeserializedManifestList)
+		if !ok {
+			continue
+		}
+		var artifacts []ocispec.Descriptor
+		for _, m := range index.Manifests {
+			artifacts = append(artifacts, ocispec.Descriptor{
+				MediaType:   m.MediaType,
+				Digest:      m.Digest,
+				Size:        m.Size,
+				Annotations: m.Annotations,
+			})
+		}
+		return artifacts, nil
+	}
+	return nil, nil
+}
@@ -0,0 +1,64 @@
+package utils
+
+import (
+	"strconv"
+	"sync"
+
+	"github.com/moby/moby/api/pkg/progress"
+	eventtypes "github.com/moby/moby/api/types/events"
+)
+
+// EventLogger publishes an event through the daemon events subsystem. It is
+// implemented by the daemon's events.Events.
+type EventLogger interface {
+	Log(action eventtypes.Action, eventType eventtypes.Type, actor eventtypes.Actor)
+}
+
+// ProgressEventsOutput returns a progress.Output that forwards all progress
+// updates to out, and additionally publishes an image event through logger
+// each time a layer transitions to a new phase of the transfer (for example
+// from "Downloading" to "Download complete"). Byte-count updates within a
+// phase are forwarded but not published as events, so that the events stream
+// is not flooded during large transfers.
+func ProgressEventsOutput(out progress.Output, logger EventLogger, action eventtypes.Action, ref string) progress.Output {
+	return &progressEventsOutput{
+		out:    out,
+		logger: logger,
+		action: action,
+		ref:    ref,
+		phases: make(map[string]string),
+	}
+}
+
+type progressEventsOutput struct {
+	out    progress.Output
+	logger EventLogger
+	action eventtypes.Action
+	ref    string
+
+	mu     sync.Mutex
+	phases map[string]string // last published phase, keyed by layer ID
+}
+
+func (o *progressEventsOutput) WriteProgress(p progress.Progress) error {
+	if p.ID != "" && p.Action != "" {
+		o.mu.Lock()
+		last, seen := o.phases[p.ID]
+		if !seen || last != p.Action {
+			o.phases[p.ID] = p.Action
+			o.mu.Unlock()
+			o.logger.Log(o.action, eventtypes.ImageEventType, eventtypes.Actor{
+				ID: o.ref,
+				Attributes: map[string]string{
+					"layer":   p.ID,
+					"phase":   p.Action,
+					"current": strconv.FormatInt(p.Current, 10),
+					"total":   strconv.FormatInt(p.Total, 10),
+				},
+			})
+		} else {
+			o.mu.Unlock()
+		}
+	}
+	return o.out.WriteProgress(p)
+}
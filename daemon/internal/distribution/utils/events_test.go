@@ -0,0 +1,54 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/moby/moby/api/pkg/progress"
+	eventtypes "github.com/moby/moby/api/types/events"
+	"gotest.tools/v3/assert"
+	is "gotest.tools/v3/assert/cmp"
+)
+
+type recordingLogger struct {
+	actions []eventtypes.Action
+	actors  []eventtypes.Actor
+}
+
+func (l *recordingLogger) Log(action eventtypes.Action, _ eventtypes.Type, actor eventtypes.Actor) {
+	l.actions = append(l.actions, action)
+	l.actors = append(l.actors, actor)
+}
+
+func TestProgressEventsOutput(t *testing.T) {
+	logger := &recordingLogger{}
+	out := ProgressEventsOutput(progress.DiscardOutput(), logger, eventtypes.ActionPullProgress, "busybox:latest")
+
+	updates := []progress.Progress{
+		{ID: "abc123", Action: "Downloading", Current: 0, Total: 100},
+		{ID: "abc123", Action: "Downloading", Current: 50, Total: 100},
+		{ID: "abc123", Action: "Downloading", Current: 100, Total: 100},
+		{ID: "abc123", Action: "Download complete", Current: 100, Total: 100},
+		{ID: "def456", Action: "Downloading", Current: 10, Total: 20},
+		{Message: "Status: Downloaded newer image"},
+	}
+	for _, p := range updates {
+		assert.NilError(t, out.WriteProgress(p))
+	}
+
+	// Only phase transitions produce events; byte-count updates within a
+	// phase and ID-less status messages do not.
+	assert.Assert(t, is.Len(logger.actors, 3))
+	for _, action := range logger.actions {
+		assert.Check(t, is.Equal(action, eventtypes.ActionPullProgress))
+	}
+	first := logger.actors[0]
+	assert.Check(t, is.Equal(first.ID, "busybox:latest"))
+	assert.Check(t, is.DeepEqual(first.Attributes, map[string]string{
+		"layer":   "abc123",
+		"phase":   "Downloading",
+		"current": "0",
+		"total":   "100",
+	}))
+	assert.Check(t, is.Equal(logger.actors[1].Attributes["phase"], "Download complete"))
+	assert.Check(t, is.Equal(logger.actors[2].Attributes["layer"], "def456"))
+}
@@ -25,6 +25,12 @@ type Store interface {
 	GetParent(id ID) (ID, error)
 	SetLastUpdated(id ID) error
 	GetLastUpdated(id ID) (time.Time, error)
+	SetLastUsed(id ID) error
+	GetLastUsed(id ID) (time.Time, error)
+	SetLastPulled(id ID) error
+	GetLastPulled(id ID) (time.Time, error)
+	SetBuildID(id ID, buildID string) error
+	GetBuildID(id ID) (string, error)
 	SetBuiltLocally(id ID) error
 	IsBuiltLocally(id ID) (bool, error)
 	Children(id ID) []ID
@@ -298,6 +304,59 @@ func (is *store) GetLastUpdated(id ID) (time.Time, error) {
 	return time.Parse(time.RFC3339Nano, string(bytes))
 }
 
+// SetLastUsed records the current time as the last time a container was
+// created from the image ID.
+func (is *store) SetLastUsed(id ID) error {
+	lastUsed := []byte(time.Now().Format(time.RFC3339Nano))
+	return is.fs.SetMetadata(id.Digest(), "lastUsed", lastUsed)
+}
+
+// GetLastUsed returns the last time a container was created from the image
+// ID, or the zero time if it was never recorded.
+func (is *store) GetLastUsed(id ID) (time.Time, error) {
+	bytes, err := is.fs.GetMetadata(id.Digest(), "lastUsed")
+	if err != nil || len(bytes) == 0 {
+		// No lastUsed time
+		return time.Time{}, nil
+	}
+	return time.Parse(time.RFC3339Nano, string(bytes))
+}
+
+// SetLastPulled records the current time as the last time the image ID was
+// pulled.
+func (is *store) SetLastPulled(id ID) error {
+	lastPulled := []byte(time.Now().Format(time.RFC3339Nano))
+	return is.fs.SetMetadata(id.Digest(), "lastPulled", lastPulled)
+}
+
+// GetLastPulled returns the last time the image ID was pulled, or the zero
+// time if it was never recorded.
+func (is *store) GetLastPulled(id ID) (time.Time, error) {
+	bytes, err := is.fs.GetMetadata(id.Digest(), "lastPulled")
+	if err != nil || len(bytes) == 0 {
+		// No lastPulled time
+		return time.Time{}, nil
+	}
+	return time.Parse(time.RFC3339Nano, string(bytes))
+}
+
+// SetBuildID records the ID of the build history record that produced the
+// image ID.
+func (is *store) SetBuildID(id ID, buildID string) error {
+	return is.fs.SetMetadata(id.Digest(), "buildID", []byte(buildID))
+}
+
+// GetBuildID returns the ID of the build history record that produced the
+// image ID, or an empty string if no build was recorded.
+func (is *store) GetBuildID(id ID) (string, error) {
+	bytes, err := is.fs.GetMetadata(id.Digest(), "buildID")
+	if err != nil || len(bytes) == 0 {
+		// No build ID
+		return "", nil
+	}
+	return string(bytes), nil
+}
+
 // SetBuiltLocally sets whether image can be used as a builder cache
 func (is *store) SetBuiltLocally(id ID) error {
 	return is.fs.SetMetadata(id.Digest(), "builtLocally", []byte{1})
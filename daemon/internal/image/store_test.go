@@ -183,6 +183,23 @@ func TestGetAndSetLastUpdated(t *testing.T) {
 	assert.Check(t, is.Equal(updated.IsZero(), false))
 }
 
+func TestGetAndSetLastUsed(t *testing.T) {
+	imgStore := defaultImageStore(t)
+
+	id, err := imgStore.Create([]byte(`{"comment": "abc1", "rootfs": {"type": "layers"}}`))
+	assert.NilError(t, err)
+
+	used, err := imgStore.GetLastUsed(id)
+	assert.NilError(t, err)
+	assert.Check(t, is.Equal(used.IsZero(), true))
+
+	assert.Check(t, imgStore.SetLastUsed(id))
+
+	used, err = imgStore.GetLastUsed(id)
+	assert.NilError(t, err)
+	assert.Check(t, is.Equal(used.IsZero(), false))
+}
+
 func TestStoreLen(t *testing.T) {
 	imgStore := defaultImageStore(t)
 
@@ -10,6 +10,7 @@ import (
 	"path/filepath"
 	"reflect"
 	"runtime"
+	"sync"
 
 	"github.com/containerd/containerd/v2/pkg/tracing"
 	"github.com/containerd/log"
@@ -27,6 +28,7 @@ import (
 	"github.com/moby/sys/sequential"
 	"github.com/moby/sys/symlink"
 	"github.com/opencontainers/go-digest"
+	"golang.org/x/sync/errgroup"
 )
 
 func (l *tarexporter) Load(ctx context.Context, inTar io.ReadCloser, outStream io.Writer, quiet bool) (outErr error) {
@@ -77,90 +79,39 @@ func (l *tarexporter) Load(ctx context.Context, inTar io.ReadCloser, outStream i
 		return errors.New("invalid manifest, manifest cannot be null (but can be [])")
 	}
 
-	var parentLinks []parentLink
-	var imageIDsStr string
-	var imageRefCount int
+	// Images in the archive are independent of each other (layers shared
+	// between them are deduplicated by the layer store), so extract them in
+	// parallel, bounded by the configured concurrency. Layers within an
+	// image form a chain and are still registered sequentially.
+	eg, egCtx := errgroup.WithContext(ctx)
+	if l.loadConcurrency > 1 {
+		eg.SetLimit(l.loadConcurrency)
+	} else {
+		eg.SetLimit(1)
+	}
 
+	var (
+		mu            sync.Mutex
+		parentLinks   []parentLink
+		imageIDsStr   string
+		imageRefCount int
+	)
 	for _, m := range manifest {
-		select {
-		case <-ctx.Done():
-			return ctx.Err()
-		default:
-		}
-		configPath, err := safePath(tmpDir, m.Config)
-		if err != nil {
-			return err
-		}
-		config, err := os.ReadFile(configPath)
-		if err != nil {
-			return err
-		}
-		img, err := image.NewFromJSON(config)
-		if err != nil {
-			return err
-		}
-		if err := image.CheckOS(img.OperatingSystem()); err != nil {
-			return fmt.Errorf("cannot load %s image on %s", img.OperatingSystem(), runtime.GOOS)
-		}
-		if l.platformMatcher != nil && !l.platformMatcher.Match(img.Platform()) {
-			continue
-		}
-		rootFS := *img.RootFS
-		rootFS.DiffIDs = nil
-
-		if expected, actual := len(m.Layers), len(img.RootFS.DiffIDs); expected != actual {
-			return fmt.Errorf("invalid manifest, layers length mismatch: expected %d, got %d", expected, actual)
-		}
-
-		for i, diffID := range img.RootFS.DiffIDs {
-			select {
-			case <-ctx.Done():
-				return ctx.Err()
-			default:
-			}
-			layerPath, err := safePath(tmpDir, m.Layers[i])
-			if err != nil {
+		eg.Go(func() error {
+			imgID, refCount, err := l.loadManifestItem(egCtx, tmpDir, m, outStream, progressOutput, &mu)
+			if err != nil || imgID == "" {
 				return err
 			}
-			r := rootFS
-			r.Append(diffID)
-			newLayer, err := l.lss.Get(r.ChainID())
-			if err != nil {
-				newLayer, err = l.loadLayer(ctx, layerPath, rootFS, diffID.String(), m.LayerSources[diffID], progressOutput)
-				if err != nil {
-					return err
-				}
-			}
-			defer layer.ReleaseAndLog(l.lss, newLayer)
-			if expected, actual := diffID, newLayer.DiffID(); expected != actual {
-				return fmt.Errorf("invalid diffID for layer %d: expected %q, got %q", i, expected, actual)
-			}
-			rootFS.Append(diffID)
-		}
-
-		imgID, err := l.is.Create(config)
-		if err != nil {
-			return err
-		}
-		imageIDsStr += fmt.Sprintf("Loaded image ID: %s\n", imgID)
-
-		imageRefCount = 0
-		for _, repoTag := range m.RepoTags {
-			named, err := reference.ParseNormalizedNamed(repoTag)
-			if err != nil {
-				return err
-			}
-			ref, ok := named.(reference.NamedTagged)
-			if !ok {
-				return fmt.Errorf("invalid tag %q", repoTag)
-			}
-			l.setLoadedTag(ref, imgID.Digest(), outStream)
-			fmt.Fprintf(outStream, "Loaded image: %s\n", reference.FamiliarString(ref))
-			imageRefCount++
-		}
-
-		parentLinks = append(parentLinks, parentLink{imgID, m.Parent})
-		l.loggerImgEvent.LogImageEvent(ctx, imgID.String(), imgID.String(), events.ActionLoad)
+			mu.Lock()
+			defer mu.Unlock()
+			imageIDsStr += fmt.Sprintf("Loaded image ID: %s\n", imgID)
+			imageRefCount += refCount
+			parentLinks = append(parentLinks, parentLink{imgID, m.Parent})
+			return nil
+		})
+	}
+	if err := eg.Wait(); err != nil {
+		return err
 	}
 
 	for _, p := range validatedParentLinks(parentLinks) {
@@ -178,6 +129,93 @@ func (l *tarexporter) Load(ctx context.Context, inTar io.ReadCloser, outStream i
 	return nil
 }
 
+// loadManifestItem extracts a single image from the unpacked archive. It
+// returns an empty image ID when the image does not match the requested
+// platform. mu guards writes to outStream, which is shared between images
+// loaded in parallel.
+func (l *tarexporter) loadManifestItem(ctx context.Context, tmpDir string, m manifestItem, outStream io.Writer, progressOutput progress.Output, mu *sync.Mutex) (image.ID, int, error) {
+	select {
+	case <-ctx.Done():
+		return "", 0, ctx.Err()
+	default:
+	}
+	configPath, err := safePath(tmpDir, m.Config)
+	if err != nil {
+		return "", 0, err
+	}
+	config, err := os.ReadFile(configPath)
+	if err != nil {
+		return "", 0, err
+	}
+	img, err := image.NewFromJSON(config)
+	if err != nil {
+		return "", 0, err
+	}
+	if err := image.CheckOS(img.OperatingSystem()); err != nil {
+		return "", 0, fmt.Errorf("cannot load %s image on %s", img.OperatingSystem(), runtime.GOOS)
+	}
+	if l.platformMatcher != nil && !l.platformMatcher.Match(img.Platform()) {
+		return "", 0, nil
+	}
+	rootFS := *img.RootFS
+	rootFS.DiffIDs = nil
+
+	if expected, actual := len(m.Layers), len(img.RootFS.DiffIDs); expected != actual {
+		return "", 0, fmt.Errorf("invalid manifest, layers length mismatch: expected %d, got %d", expected, actual)
+	}
+
+	for i, diffID := range img.RootFS.DiffIDs {
+		select {
+		case <-ctx.Done():
+			return "", 0, ctx.Err()
+		default:
+		}
+		layerPath, err := safePath(tmpDir, m.Layers[i])
+		if err != nil {
+			return "", 0, err
+		}
+		r := rootFS
+		r.Append(diffID)
+		newLayer, err := l.lss.Get(r.ChainID())
+		if err != nil {
+			newLayer, err = l.loadLayer(ctx, layerPath, rootFS, diffID.String(), m.LayerSources[diffID], progressOutput)
+			if err != nil {
+				return "", 0, err
+			}
+		}
+		defer layer.ReleaseAndLog(l.lss, newLayer)
+		if expected, actual := diffID, newLayer.DiffID(); expected != actual {
+			return "", 0, fmt.Errorf("invalid diffID for layer %d: expected %q, got %q", i, expected, actual)
+		}
+		rootFS.Append(diffID)
+	}
+
+	imgID, err := l.is.Create(config)
+	if err != nil {
+		return "", 0, err
+	}
+
+	refCount := 0
+	for _, repoTag := range m.RepoTags {
+		named, err := reference.ParseNormalizedNamed(repoTag)
+		if err != nil {
+			return "", 0, err
+		}
+		ref, ok := named.(reference.NamedTagged)
+		if !ok {
+			return "", 0, fmt.Errorf("invalid tag %q", repoTag)
+		}
+		mu.Lock()
+		l.setLoadedTag(ref, imgID.Digest(), outStream)
+		fmt.Fprintf(outStream, "Loaded image: %s\n", reference.FamiliarString(ref))
+		mu.Unlock()
+		refCount++
+	}
+
+	l.loggerImgEvent.LogImageEvent(ctx, imgID.String(), imgID.String(), events.ActionLoad)
+	return imgID, refCount, nil
+}
+
 func untar(ctx context.Context, inTar io.ReadCloser, tmpDir string) error {
 	_, trace := tracing.StartSpan(ctx, "chrootarchive.Untar")
 	defer trace.End()
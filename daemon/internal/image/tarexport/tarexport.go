@@ -32,6 +32,18 @@ type tarexporter struct {
 	loggerImgEvent  LogImageEvent
 	platform        *platforms.Platform
 	platformMatcher platforms.Matcher
+	loadConcurrency int
+}
+
+// Opt is an option for NewTarExporter.
+type Opt func(*tarexporter)
+
+// WithLoadConcurrency sets the number of images Load extracts from the
+// archive in parallel. Values below 1 select sequential loading.
+func WithLoadConcurrency(n int) Opt {
+	return func(l *tarexporter) {
+		l.loadConcurrency = n
+	}
 }
 
 // LogImageEvent defines interface for event generation related to image tar(load and save) operations
@@ -41,7 +53,7 @@ type LogImageEvent interface {
 }
 
 // NewTarExporter returns new Exporter for tar packages
-func NewTarExporter(is image.Store, lss layer.Store, rs refstore.Store, loggerImgEvent LogImageEvent, platform *ocispec.Platform) image.Exporter {
+func NewTarExporter(is image.Store, lss layer.Store, rs refstore.Store, loggerImgEvent LogImageEvent, platform *ocispec.Platform, opts ...Opt) image.Exporter {
 	l := &tarexporter{
 		is:             is,
 		lss:            lss,
@@ -52,5 +64,8 @@ func NewTarExporter(is image.Store, lss layer.Store, rs refstore.Store, loggerIm
 	if platform != nil {
 		l.platformMatcher = platforms.OnlyStrict(*platform)
 	}
+	for _, opt := range opts {
+		opt(l)
+	}
 	return l
 }
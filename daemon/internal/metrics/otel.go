@@ -0,0 +1,55 @@
+package metrics
+
+import (
+	"context"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+// OpenTelemetry counterparts to a subset of the Prometheus metrics above.
+// They are pushed through the OTLP metrics pipeline when one is configured
+// (see otelutil.NewMeterProvider); with no pipeline configured the global
+// meter provider is a no-op and recording costs nothing. Instruments are
+// created against the global provider at init time and late-bind to the
+// provider installed during daemon startup.
+var (
+	meter = otel.Meter("github.com/moby/moby/v2/daemon")
+
+	otelImagePullDuration, _ = meter.Float64Histogram("engine.daemon.image.pull.duration",
+		metric.WithDescription("Time taken to pull an image"),
+		metric.WithUnit("s"))
+	otelContainerRestarts, _ = meter.Int64Counter("engine.daemon.container.restarts",
+		metric.WithDescription("Number of containers restarted by their restart policy"))
+	otelEventsLogged, _ = meter.Int64Counter("engine.daemon.events",
+		metric.WithDescription("Number of events logged"))
+	otelEventSubscribers, _ = meter.Int64UpDownCounter("engine.daemon.events.subscribers",
+		metric.WithDescription("Current number of subscribers to events"))
+)
+
+// RecordImagePull records the duration of an image pull and whether it
+// succeeded.
+func RecordImagePull(ctx context.Context, d time.Duration, err error) {
+	otelImagePullDuration.Record(ctx, d.Seconds(), metric.WithAttributes(
+		attribute.Bool("error", err != nil),
+	))
+}
+
+// RecordContainerRestart counts a container restart triggered by its
+// restart policy.
+func RecordContainerRestart(ctx context.Context) {
+	otelContainerRestarts.Add(ctx, 1)
+}
+
+// RecordEventLogged counts a logged daemon event.
+func RecordEventLogged(ctx context.Context) {
+	otelEventsLogged.Add(ctx, 1)
+}
+
+// AddEventSubscriber tracks an events subscriber being added (delta 1) or
+// removed (delta -1).
+func AddEventSubscriber(ctx context.Context, delta int64) {
+	otelEventSubscribers.Add(ctx, delta)
+}
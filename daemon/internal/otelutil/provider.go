@@ -7,6 +7,9 @@ import (
 	"github.com/moby/buildkit/util/tracing/detect"
 	"go.opentelemetry.io/contrib/processors/baggagecopy"
 	"go.opentelemetry.io/otel/baggage"
+	"go.opentelemetry.io/otel/metric"
+	metricnoop "go.opentelemetry.io/otel/metric/noop"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
 	"go.opentelemetry.io/otel/sdk/resource"
 	sdktrace "go.opentelemetry.io/otel/sdk/trace"
 	"go.opentelemetry.io/otel/trace"
@@ -37,3 +40,31 @@ func NewTracerProvider(ctx context.Context, allowNoop bool) (trace.TracerProvide
 	)
 	return tp, tp.Shutdown
 }
+
+// NewMeterProvider creates a meter provider pushing metrics to the OTLP
+// endpoint configured through the standard OTEL_* environment variables,
+// detected the same way as the span exporter above. When no endpoint is
+// configured (and allowNoop is set), a no-op provider is returned so that
+// instrumented code paths cost nothing.
+func NewMeterProvider(ctx context.Context, allowNoop bool) (metric.MeterProvider, func(context.Context) error) {
+	noopShutdown := func(ctx context.Context) error { return nil }
+
+	exp, err := detect.NewMetricExporter(ctx)
+	if err != nil {
+		log.G(ctx).WithError(err).Warn("Failed to initialize metrics export, skipping")
+		if allowNoop {
+			return metricnoop.NewMeterProvider(), noopShutdown
+		}
+	}
+
+	if allowNoop && detect.IsNoneMetricExporter(exp) {
+		log.G(ctx).Info("OTEL metrics export is not configured, using no-op meter provider")
+		return metricnoop.NewMeterProvider(), noopShutdown
+	}
+
+	mp := sdkmetric.NewMeterProvider(
+		sdkmetric.WithResource(resource.Default()),
+		sdkmetric.WithReader(sdkmetric.NewPeriodicReader(exp)),
+	)
+	return mp, mp.Shutdown
+}
@@ -0,0 +1,73 @@
+package privhelper
+
+import (
+	"net"
+	"net/rpc"
+	"os"
+	"os/exec"
+	"syscall"
+
+	"github.com/moby/sys/reexec"
+	"github.com/pkg/errors"
+)
+
+// Client is the daemon's handle on a running privileged helper process.
+type Client struct {
+	rpc *rpc.Client
+	cmd *exec.Cmd
+}
+
+// Start spawns the privileged helper process and connects to it. It must be
+// called while the daemon still holds the privileges the helper is meant to
+// retain; the daemon side can drop privileges afterwards.
+func Start() (*Client, error) {
+	fds, err := syscall.Socketpair(syscall.AF_UNIX, syscall.SOCK_STREAM|syscall.SOCK_CLOEXEC, 0)
+	if err != nil {
+		return nil, errors.Wrap(err, "privhelper: failed to create socketpair")
+	}
+	theirs := os.NewFile(uintptr(fds[1]), "privhelper-helper")
+	ours := os.NewFile(uintptr(fds[0]), "privhelper-daemon")
+
+	cmd := reexec.Command(reexecName)
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	cmd.ExtraFiles = []*os.File{theirs}
+	if err := cmd.Start(); err != nil {
+		theirs.Close()
+		ours.Close()
+		return nil, errors.Wrap(err, "privhelper: failed to start helper process")
+	}
+	theirs.Close()
+
+	conn, err := net.FileConn(ours)
+	ours.Close()
+	if err != nil {
+		_ = cmd.Process.Kill()
+		return nil, errors.Wrap(err, "privhelper: failed to connect to helper")
+	}
+	return &Client{rpc: rpc.NewClient(conn), cmd: cmd}, nil
+}
+
+// Mount asks the helper to perform a mount(2).
+func (c *Client) Mount(source, target, fstype string, flags uintptr, data string) error {
+	return c.rpc.Call("Ops.Mount", MountRequest{
+		Source: source,
+		Target: target,
+		FSType: fstype,
+		Flags:  flags,
+		Data:   data,
+	}, &struct{}{})
+}
+
+// Unmount asks the helper to perform an umount2(2).
+func (c *Client) Unmount(target string, flags int) error {
+	return c.rpc.Call("Ops.Unmount", UnmountRequest{Target: target, Flags: flags}, &struct{}{})
+}
+
+// Close shuts down the RPC connection; the helper exits once its end of the
+// socketpair is closed.
+func (c *Client) Close() error {
+	err := c.rpc.Close()
+	_, _ = c.cmd.Process.Wait()
+	return err
+}
@@ -0,0 +1,30 @@
+package privhelper
+
+import (
+	"os"
+	"testing"
+
+	"github.com/moby/sys/reexec"
+)
+
+func TestMain(m *testing.M) {
+	if reexec.Init() {
+		return
+	}
+	os.Exit(m.Run())
+}
+
+// TestClientRoundTrip starts a helper process and exercises the RPC with an
+// operation that is expected to fail, verifying that errors cross the
+// process boundary intact.
+func TestClientRoundTrip(t *testing.T) {
+	c, err := Start()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c.Close()
+
+	if err := c.Unmount("/this/path/does/not/exist", 0); err == nil {
+		t.Fatal("expected unmount of nonexistent path to fail")
+	}
+}
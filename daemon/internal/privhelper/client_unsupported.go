@@ -0,0 +1,26 @@
+//go:build !linux
+
+package privhelper
+
+import "github.com/pkg/errors"
+
+// Client is the daemon's handle on a running privileged helper process.
+type Client struct{}
+
+// Start is not supported on this platform.
+func Start() (*Client, error) {
+	return nil, errors.New("privhelper: split privileges are only supported on Linux")
+}
+
+// Mount is not supported on this platform.
+func (c *Client) Mount(source, target, fstype string, flags uintptr, data string) error {
+	return errors.New("privhelper: not supported on this platform")
+}
+
+// Unmount is not supported on this platform.
+func (c *Client) Unmount(target string, flags int) error {
+	return errors.New("privhelper: not supported on this platform")
+}
+
+// Close is a no-op on this platform.
+func (c *Client) Close() error { return nil }
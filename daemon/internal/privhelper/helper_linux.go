@@ -0,0 +1,57 @@
+package privhelper
+
+import (
+	"context"
+	"net"
+	"net/rpc"
+	"os"
+
+	"github.com/containerd/log"
+	"github.com/moby/sys/reexec"
+	"golang.org/x/sys/unix"
+)
+
+// reexecName is the argv[0] under which the privileged helper runs.
+const reexecName = "docker-priv-helper"
+
+func init() {
+	reexec.Register(reexecName, helperMain)
+}
+
+// helperMain is the entrypoint of the privileged helper process. It serves
+// RPC requests on the socketpair end inherited as fd 3 until the daemon
+// closes its end.
+func helperMain() {
+	f := os.NewFile(3, "privhelper-socket")
+	conn, err := net.FileConn(f)
+	f.Close()
+	if err != nil {
+		log.G(context.TODO()).WithError(err).Fatal("privhelper: failed to use inherited socket")
+	}
+	srv := rpc.NewServer()
+	if err := srv.Register(&Ops{}); err != nil {
+		log.G(context.TODO()).WithError(err).Fatal("privhelper: failed to register RPC service")
+	}
+	srv.ServeConn(conn)
+	os.Exit(0)
+}
+
+// Ops is the RPC service exposed by the privileged helper. Its method set
+// is the complete privileged surface available to the unprivileged daemon.
+type Ops struct{}
+
+// Mount performs a mount(2) on behalf of the daemon.
+func (*Ops) Mount(req MountRequest, _ *struct{}) error {
+	log.G(context.TODO()).WithFields(log.Fields{
+		"source": req.Source,
+		"target": req.Target,
+		"fstype": req.FSType,
+	}).Debug("privhelper: mount")
+	return unix.Mount(req.Source, req.Target, req.FSType, req.Flags, req.Data)
+}
+
+// Unmount performs an umount2(2) on behalf of the daemon.
+func (*Ops) Unmount(req UnmountRequest, _ *struct{}) error {
+	log.G(context.TODO()).WithField("target", req.Target).Debug("privhelper: unmount")
+	return unix.Unmount(req.Target, req.Flags)
+}
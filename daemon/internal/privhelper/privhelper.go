@@ -0,0 +1,30 @@
+// Package privhelper implements a small privileged helper process and the
+// RPC used to reach it. It is the first building block for running the
+// daemon with split privileges: the API server and state management run in
+// an unprivileged process and delegate the few operations which genuinely
+// need elevated privileges (mounting, netlink, cgroup manipulation) to the
+// helper over a deliberately narrow interface.
+//
+// The helper is spawned via reexec while the daemon still holds full
+// privileges and communicates over an inherited socketpair, so no
+// filesystem socket is exposed. The RPC surface is intentionally tiny and
+// every request is logged by the helper; new operations should only be
+// added when a call site is actually migrated.
+//
+// The helper is enabled with the "split-privileges" daemon feature flag.
+package privhelper
+
+// MountRequest describes a mount(2) to be performed by the helper.
+type MountRequest struct {
+	Source string
+	Target string
+	FSType string
+	Flags  uintptr
+	Data   string
+}
+
+// UnmountRequest describes an umount2(2) to be performed by the helper.
+type UnmountRequest struct {
+	Target string
+	Flags  int
+}
@@ -2,16 +2,19 @@ package restartmanager
 
 import (
 	"errors"
+	"math/rand/v2"
 	"sync"
 	"time"
 
 	"github.com/moby/moby/api/types/container"
+	"github.com/moby/moby/v2/daemon/internal/clock"
 )
 
 const (
-	backoffMultiplier = 2
-	defaultTimeout    = 100 * time.Millisecond
-	maxRestartTimeout = 1 * time.Minute
+	backoffMultiplier  = 2
+	defaultTimeout     = 100 * time.Millisecond
+	maxRestartTimeout  = 1 * time.Minute
+	defaultResetWindow = 10 * time.Second
 )
 
 // ErrRestartCanceled is returned when the restart manager has been
@@ -28,11 +31,19 @@ type RestartManager struct {
 	active       bool
 	cancel       chan struct{}
 	canceled     bool
+	clk          clock.Clock
 }
 
 // New returns a new RestartManager based on a policy.
 func New(policy container.RestartPolicy, restartCount int) *RestartManager {
-	return &RestartManager{policy: policy, restartCount: restartCount, cancel: make(chan struct{})}
+	return NewWithClock(policy, restartCount, clock.System())
+}
+
+// NewWithClock is like New, but waits out restart delays against the given
+// clock instead of the system clock, so that tests can drive the backoff
+// deterministically.
+func NewWithClock(policy container.RestartPolicy, restartCount int, clk clock.Clock) *RestartManager {
+	return &RestartManager{policy: policy, restartCount: restartCount, cancel: make(chan struct{}), clk: clk}
 }
 
 // SetPolicy sets the restart-policy for the RestartManager.
@@ -62,19 +73,20 @@ func (rm *RestartManager) ShouldRestart(exitCode uint32, hasBeenManuallyStopped
 	if rm.active {
 		return false, nil, errors.New("invalid call on an active restart manager")
 	}
-	// if the container ran for more than 10s, regardless of status and policy reset
-	// the timeout back to the default.
-	if executionDuration.Seconds() >= 10 {
+	// if the container ran for longer than the reset window, regardless of
+	// status and policy, reset the timeout back to the initial delay.
+	if executionDuration >= rm.resetWindow() {
 		rm.timeout = 0
 	}
+	maxTimeout := rm.maxTimeout()
 	switch {
 	case rm.timeout == 0:
-		rm.timeout = defaultTimeout
-	case rm.timeout < maxRestartTimeout:
+		rm.timeout = rm.initialTimeout()
+	case rm.timeout < maxTimeout:
 		rm.timeout *= backoffMultiplier
 	}
-	if rm.timeout > maxRestartTimeout {
-		rm.timeout = maxRestartTimeout
+	if rm.timeout > maxTimeout {
+		rm.timeout = maxTimeout
 	}
 
 	var restart bool
@@ -97,20 +109,29 @@ func (rm *RestartManager) ShouldRestart(exitCode uint32, hasBeenManuallyStopped
 
 	rm.restartCount++
 
+	// Add a random offset of up to Jitter times the backoff delay, so that
+	// containers crash-looping in lockstep spread out their restarts. The
+	// offset is not recorded in rm.timeout, to keep the backoff progression
+	// itself deterministic.
+	restartTimeout := rm.timeout
+	if rm.policy.Jitter > 0 {
+		restartTimeout += time.Duration(rm.policy.Jitter * rand.Float64() * float64(restartTimeout))
+	}
+
 	unlockOnExit = false
 	rm.active = true
 	rm.Unlock()
 
 	ch := make(chan error)
 	go func() {
-		timeout := time.NewTimer(rm.timeout)
+		timeout := rm.clk.NewTimer(restartTimeout)
 		defer timeout.Stop()
 
 		select {
 		case <-rm.cancel:
 			ch <- ErrRestartCanceled
 			close(ch)
-		case <-timeout.C:
+		case <-timeout.C():
 			rm.Lock()
 			close(ch)
 			rm.active = false
@@ -121,6 +142,86 @@ func (rm *RestartManager) ShouldRestart(exitCode uint32, hasBeenManuallyStopped
 	return true, ch, nil
 }
 
+// Simulate returns the decision ShouldRestart would take for a hypothetical
+// exit with the given exit code, without mutating the manager's state. The
+// returned delay is the backoff that would be applied before the restart; it
+// does not include the random jitter offset applied to real restarts.
+func (rm *RestartManager) Simulate(exitCode uint32, hasBeenManuallyStopped bool, executionDuration time.Duration) (bool, time.Duration) {
+	rm.Lock()
+	defer rm.Unlock()
+
+	if rm.policy.IsNone() || rm.canceled {
+		return false, 0
+	}
+
+	timeout := rm.timeout
+	if executionDuration >= rm.resetWindow() {
+		timeout = 0
+	}
+	maxTimeout := rm.maxTimeout()
+	switch {
+	case timeout == 0:
+		timeout = rm.initialTimeout()
+	case timeout < maxTimeout:
+		timeout *= backoffMultiplier
+	}
+	if timeout > maxTimeout {
+		timeout = maxTimeout
+	}
+
+	var restart bool
+	switch {
+	case rm.policy.IsAlways():
+		restart = true
+	case rm.policy.IsUnlessStopped() && !hasBeenManuallyStopped:
+		restart = true
+	case rm.policy.IsOnFailure():
+		// the default value of 0 for MaximumRetryCount means that we will not enforce a maximum count
+		if maxRetryCount := rm.policy.MaximumRetryCount; maxRetryCount == 0 || rm.restartCount < maxRetryCount {
+			restart = exitCode != 0
+		}
+	}
+	if !restart {
+		return false, 0
+	}
+	return true, timeout
+}
+
+// initialTimeout returns the delay before the first restart, which is also
+// the base for the exponential backoff applied to subsequent restarts.
+func (rm *RestartManager) initialTimeout() time.Duration {
+	if d := rm.policy.InitialDelay; d > 0 {
+		return d
+	}
+	return defaultTimeout
+}
+
+// maxTimeout returns the cap on the exponential backoff between restarts.
+func (rm *RestartManager) maxTimeout() time.Duration {
+	if d := rm.policy.MaximumDelay; d > 0 {
+		return d
+	}
+	return maxRestartTimeout
+}
+
+// resetWindow returns how long the container must have run for the backoff
+// to be reset to the initial delay.
+func (rm *RestartManager) resetWindow() time.Duration {
+	if d := rm.policy.ResetWindow; d > 0 {
+		return d
+	}
+	return defaultResetWindow
+}
+
+// ResetBackoff resets the restart backoff to its initial value. It is called
+// when the daemon detects that the host resumed from suspend, so that a
+// backoff accumulated against a stopped clock does not carry over.
+func (rm *RestartManager) ResetBackoff() {
+	rm.Lock()
+	rm.timeout = 0
+	rm.Unlock()
+}
+
 // Cancel tells the RestartManager to no longer restart the container.
 func (rm *RestartManager) Cancel() {
 	rm.Do(func() {
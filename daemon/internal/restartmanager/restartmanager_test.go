@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"github.com/moby/moby/api/types/container"
+	"github.com/moby/moby/v2/daemon/internal/clock"
 )
 
 func TestRestartManagerTimeout(t *testing.T) {
@@ -22,6 +23,20 @@ func TestRestartManagerTimeout(t *testing.T) {
 	}
 }
 
+func TestRestartManagerResetBackoff(t *testing.T) {
+	rm := New(container.RestartPolicy{Name: "always"}, 0)
+	rm.timeout = 5 * time.Second
+	rm.ResetBackoff()
+	duration := 1 * time.Second
+	_, _, err := rm.ShouldRestart(0, false, duration)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rm.timeout != defaultTimeout {
+		t.Fatalf("restart manager should have a timeout of 100 ms but has %s", rm.timeout)
+	}
+}
+
 func TestRestartManagerTimeoutReset(t *testing.T) {
 	rm := New(container.RestartPolicy{Name: "always"}, 0)
 	rm.timeout = 5 * time.Second
@@ -34,3 +49,100 @@ func TestRestartManagerTimeoutReset(t *testing.T) {
 		t.Fatalf("restart manager should have a timeout of 100 ms but has %s", rm.timeout)
 	}
 }
+
+func TestRestartManagerSimulate(t *testing.T) {
+	rm := New(container.RestartPolicy{Name: "on-failure", MaximumRetryCount: 2}, 1)
+	rm.timeout = time.Second
+
+	should, delay := rm.Simulate(1, false, time.Second)
+	if !should {
+		t.Fatal("container should be restarted")
+	}
+	if delay != 2*time.Second {
+		t.Fatalf("simulated delay should be 2s but is %s", delay)
+	}
+	// Simulation must not mutate the manager's state.
+	if rm.timeout != time.Second {
+		t.Fatalf("restart manager timeout changed to %s", rm.timeout)
+	}
+	if rm.restartCount != 1 {
+		t.Fatalf("restart manager restart count changed to %d", rm.restartCount)
+	}
+
+	if should, _ := rm.Simulate(0, false, time.Second); should {
+		t.Fatal("container should not be restarted on a clean exit")
+	}
+
+	rm.restartCount = 2
+	if should, _ := rm.Simulate(1, false, time.Second); should {
+		t.Fatal("container should not be restarted past the maximum retry count")
+	}
+}
+
+func TestRestartManagerCustomBackoff(t *testing.T) {
+	policy := container.RestartPolicy{
+		Name:         "always",
+		InitialDelay: 500 * time.Millisecond,
+		MaximumDelay: 2 * time.Second,
+		ResetWindow:  30 * time.Second,
+	}
+	steps := []struct {
+		previous, expected time.Duration
+		executionDuration  time.Duration
+	}{
+		{0, 500 * time.Millisecond, time.Second},
+		{500 * time.Millisecond, time.Second, time.Second},
+		{time.Second, 2 * time.Second, time.Second},
+		{2 * time.Second, 2 * time.Second, time.Second},
+		// Ran for longer than the default reset window, but not longer
+		// than the configured one: keep backing off.
+		{time.Second, 2 * time.Second, 15 * time.Second},
+		// Ran for longer than the configured reset window: start over.
+		{2 * time.Second, 500 * time.Millisecond, 40 * time.Second},
+	}
+	for _, step := range steps {
+		rm := New(policy, 0)
+		rm.timeout = step.previous
+		should, _, err := rm.ShouldRestart(0, false, step.executionDuration)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !should {
+			t.Fatal("container should be restarted")
+		}
+		if rm.timeout != step.expected {
+			t.Fatalf("restart manager should have a timeout of %s but has %s", step.expected, rm.timeout)
+		}
+	}
+}
+
+func TestRestartManagerFakeClock(t *testing.T) {
+	fc := clock.NewFake(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	rm := NewWithClock(container.RestartPolicy{Name: "always"}, 0, fc)
+
+	should, ch, err := rm.ShouldRestart(1, false, time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !should {
+		t.Fatal("container should be restarted")
+	}
+
+	// Wait for the restart goroutine to start waiting on the fake clock.
+	for i := 0; fc.Timers() == 0; i++ {
+		if i > 1000 {
+			t.Fatal("restart manager never armed its timer")
+		}
+		time.Sleep(time.Millisecond)
+	}
+	select {
+	case <-ch:
+		t.Fatal("restart delay elapsed before the clock was advanced")
+	default:
+	}
+
+	fc.Advance(defaultTimeout)
+	if _, ok := <-ch; ok {
+		t.Fatal("expected the restart channel to be closed without an error")
+	}
+}
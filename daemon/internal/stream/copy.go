@@ -0,0 +1,23 @@
+package stream
+
+import (
+	"io"
+
+	"github.com/moby/moby/v2/pkg/pools"
+)
+
+// fastPathCopy copies src to dst, using io.Copy when either end implements
+// a kernel-assisted fast path (splice or sendfile, surfaced through
+// io.ReaderFrom/io.WriterTo, e.g. by sockets and pipes on Linux) so that
+// container output does not travel through user-space buffers. When neither
+// end offers a fast path it falls back to a copy through a pooled buffer,
+// which avoids the allocation io.Copy would make.
+func fastPathCopy(dst io.Writer, src io.Reader) (int64, error) {
+	if _, ok := dst.(io.ReaderFrom); ok {
+		return io.Copy(dst, src)
+	}
+	if _, ok := src.(io.WriterTo); ok {
+		return io.Copy(dst, src)
+	}
+	return pools.Copy(dst, src)
+}
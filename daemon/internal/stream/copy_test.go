@@ -0,0 +1,117 @@
+package stream
+
+import (
+	"bytes"
+	"crypto/rand"
+	"io"
+	"os"
+	"testing"
+
+	"github.com/moby/moby/v2/pkg/pools"
+	"gotest.tools/v3/assert"
+	is "gotest.tools/v3/assert/cmp"
+)
+
+func TestFastPathCopy(t *testing.T) {
+	data := make([]byte, 512*1024)
+	_, err := rand.Read(data)
+	assert.NilError(t, err)
+
+	t.Run("pipe to pipe", func(t *testing.T) {
+		srcR, srcW, err := os.Pipe()
+		assert.NilError(t, err)
+		dstR, dstW, err := os.Pipe()
+		assert.NilError(t, err)
+		defer srcR.Close()
+		defer dstR.Close()
+
+		go func() {
+			srcW.Write(data)
+			srcW.Close()
+		}()
+
+		var (
+			got     []byte
+			readErr error
+		)
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			got, readErr = io.ReadAll(dstR)
+		}()
+
+		written, err := fastPathCopy(dstW, srcR)
+		assert.NilError(t, err)
+		assert.Check(t, is.Equal(written, int64(len(data))))
+		dstW.Close()
+
+		<-done
+		assert.NilError(t, readErr)
+		assert.Assert(t, bytes.Equal(got, data))
+	})
+
+	t.Run("buffered fallback", func(t *testing.T) {
+		var dst bytes.Buffer
+		written, err := fastPathCopy(&dst, io.LimitReader(neverEnding('x'), int64(len(data))))
+		assert.NilError(t, err)
+		assert.Check(t, is.Equal(written, int64(len(data))))
+		assert.Check(t, is.Equal(dst.Len(), len(data)))
+	})
+}
+
+// neverEnding is an io.Reader that yields the same byte forever, with no
+// WriterTo fast path.
+type neverEnding byte
+
+func (b neverEnding) Read(p []byte) (int, error) {
+	for i := range p {
+		p[i] = byte(b)
+	}
+	return len(p), nil
+}
+
+func benchmarkCopy(b *testing.B, copyFn func(io.Writer, io.Reader) (int64, error)) {
+	data := make([]byte, 1024*1024)
+	if _, err := rand.Read(data); err != nil {
+		b.Fatal(err)
+	}
+
+	b.SetBytes(int64(len(data)))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		srcR, srcW, err := os.Pipe()
+		if err != nil {
+			b.Fatal(err)
+		}
+		dstR, dstW, err := os.Pipe()
+		if err != nil {
+			b.Fatal(err)
+		}
+
+		go func() {
+			srcW.Write(data)
+			srcW.Close()
+		}()
+		drained := make(chan struct{})
+		go func() {
+			defer close(drained)
+			io.Copy(io.Discard, dstR)
+		}()
+
+		if _, err := copyFn(dstW, srcR); err != nil {
+			b.Fatal(err)
+		}
+		dstW.Close()
+		<-drained
+		dstR.Close()
+		srcR.Close()
+	}
+}
+
+func BenchmarkFastPathCopy(b *testing.B) {
+	benchmarkCopy(b, fastPathCopy)
+}
+
+func BenchmarkBufferedCopy(b *testing.B) {
+	benchmarkCopy(b, pools.Copy)
+}
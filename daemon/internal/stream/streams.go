@@ -11,7 +11,6 @@ import (
 	"github.com/containerd/containerd/v2/pkg/cio"
 	"github.com/containerd/log"
 	"github.com/moby/moby/v2/daemon/internal/stream/bytespipe"
-	"github.com/moby/moby/v2/pkg/pools"
 )
 
 // Config holds information about I/O streams managed together.
@@ -129,7 +128,7 @@ func (c *Config) CopyToPipe(iop *cio.DirectIO) {
 		c.wg.Add(1)
 		go func() {
 			defer c.wg.Done()
-			if _, err := pools.Copy(w, r); err != nil {
+			if _, err := fastPathCopy(w, r); err != nil {
 				if c.closed.Load() {
 					return
 				}
@@ -151,7 +150,7 @@ func (c *Config) CopyToPipe(iop *cio.DirectIO) {
 	if stdin := c.Stdin(); stdin != nil {
 		if iop.Stdin != nil {
 			go func() {
-				_, err := pools.Copy(iop.Stdin, stdin)
+				_, err := fastPathCopy(iop.Stdin, stdin)
 				if err != nil {
 					if c.closed.Load() {
 						return
@@ -0,0 +1,90 @@
+// Package transferops tracks in-flight image distribution operations (pulls
+// and pushes) so that they can be listed and cancelled through the API.
+package transferops
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/moby/moby/api/types/registry"
+	"github.com/moby/moby/v2/daemon/internal/stringid"
+	"github.com/moby/moby/v2/errdefs"
+	"github.com/pkg/errors"
+)
+
+// Tracker records in-flight image distribution operations. The zero value is
+// not usable; use [NewTracker].
+type Tracker struct {
+	mu  sync.Mutex
+	ops map[string]*operation
+}
+
+type operation struct {
+	registry.DistributionOperation
+	cancel context.CancelFunc
+}
+
+// NewTracker returns an empty Tracker.
+func NewTracker() *Tracker {
+	return &Tracker{ops: make(map[string]*operation)}
+}
+
+// Start registers an in-flight operation of the given type (one of the
+// registry.DistributionOperation* constants) for the given image reference.
+// It returns a derived context that is cancelled when the operation is
+// cancelled through [Tracker.Cancel], and a function that deregisters the
+// operation once the transfer has completed.
+func (t *Tracker) Start(ctx context.Context, opType, image string) (context.Context, func()) {
+	ctx, cancel := context.WithCancel(ctx)
+	id := stringid.GenerateRandomID()
+	t.mu.Lock()
+	t.ops[id] = &operation{
+		DistributionOperation: registry.DistributionOperation{
+			ID:        id,
+			Type:      opType,
+			Image:     image,
+			StartedAt: time.Now(),
+		},
+		cancel: cancel,
+	}
+	t.mu.Unlock()
+	return ctx, func() {
+		t.mu.Lock()
+		delete(t.ops, id)
+		t.mu.Unlock()
+		cancel()
+	}
+}
+
+// List returns the in-flight operations, oldest first.
+func (t *Tracker) List() []registry.DistributionOperation {
+	t.mu.Lock()
+	ops := make([]registry.DistributionOperation, 0, len(t.ops))
+	for _, op := range t.ops {
+		ops = append(ops, op.DistributionOperation)
+	}
+	t.mu.Unlock()
+	sort.Slice(ops, func(a, b int) bool {
+		if ops[a].StartedAt.Equal(ops[b].StartedAt) {
+			return ops[a].ID < ops[b].ID
+		}
+		return ops[a].StartedAt.Before(ops[b].StartedAt)
+	})
+	return ops
+}
+
+// Cancel cancels the in-flight operation with the given ID by cancelling the
+// context returned by [Tracker.Start]. It returns a "not found" error if no
+// such operation is in flight.
+func (t *Tracker) Cancel(id string) error {
+	t.mu.Lock()
+	op, ok := t.ops[id]
+	t.mu.Unlock()
+	if !ok {
+		return errdefs.NotFound(errors.Errorf("no such distribution operation: %s", id))
+	}
+	op.cancel()
+	return nil
+}
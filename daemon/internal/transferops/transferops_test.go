@@ -0,0 +1,36 @@
+package transferops
+
+import (
+	"context"
+	"testing"
+
+	cerrdefs "github.com/containerd/errdefs"
+	"github.com/moby/moby/api/types/registry"
+	"gotest.tools/v3/assert"
+	is "gotest.tools/v3/assert/cmp"
+)
+
+func TestTracker(t *testing.T) {
+	tracker := NewTracker()
+	assert.Check(t, is.Len(tracker.List(), 0))
+
+	ctx, done := tracker.Start(context.Background(), registry.DistributionOperationPull, "busybox:latest")
+
+	ops := tracker.List()
+	assert.Assert(t, is.Len(ops, 1))
+	assert.Check(t, is.Equal(ops[0].Type, "pull"))
+	assert.Check(t, is.Equal(ops[0].Image, "busybox:latest"))
+	assert.Check(t, ops[0].ID != "")
+
+	// Cancelling the operation cancels the transfer's context.
+	assert.NilError(t, tracker.Cancel(ops[0].ID))
+	assert.Check(t, is.ErrorIs(ctx.Err(), context.Canceled))
+
+	// The operation stays listed until the transfer deregisters itself.
+	assert.Check(t, is.Len(tracker.List(), 1))
+	done()
+	assert.Check(t, is.Len(tracker.List(), 0))
+
+	err := tracker.Cancel(ops[0].ID)
+	assert.Check(t, cerrdefs.IsNotFound(err))
+}
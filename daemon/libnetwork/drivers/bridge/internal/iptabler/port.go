@@ -95,7 +95,7 @@ func (n *network) setPerPortNAT(ipv iptables.IPVersion, b types.PortBinding, ena
 		"-j", "DNAT",
 		"--to-destination", net.JoinHostPort(b.IP.String(), strconv.Itoa(int(b.Port))),
 	}
-	if !n.ipt.config.Hairpin {
+	if !n.ipt.config.Hairpin && !b.DisableProxy {
 		args = append(args, "!", "-i", n.config.IfName)
 	}
 	if ipv == iptables.IPv6 {
@@ -113,7 +113,7 @@ func (n *network) setPerPortNAT(ipv iptables.IPVersion, b types.PortBinding, ena
 		"--dport", strconv.Itoa(int(b.Port)),
 		"-j", "MASQUERADE",
 	}}
-	if err := appendOrDelChainRule(rule, "MASQUERADE", n.ipt.config.Hairpin && enable); err != nil {
+	if err := appendOrDelChainRule(rule, "MASQUERADE", (n.ipt.config.Hairpin || b.DisableProxy) && enable); err != nil {
 		return err
 	}
 
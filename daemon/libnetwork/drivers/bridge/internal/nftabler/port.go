@@ -113,10 +113,6 @@ func (n *network) setPerPortForwarding(ctx context.Context, pbs []types.PortBind
 
 func (n *network) setPerPortDNAT(ctx context.Context, pbs []types.PortBinding, pbc pbContext, enable bool) error {
 	updater := pbc.table.ChainUpdateFunc(ctx, natChain, enable)
-	var proxySkip string
-	if !n.fw.config.Hairpin {
-		proxySkip = fmt.Sprintf("iifname != %s ", n.config.IfName)
-	}
 	var v6LLSkip string
 	if pbc.ipv == firewaller.IPv6 {
 		v6LLSkip = "ip6 saddr != fe80::/10 "
@@ -131,6 +127,10 @@ func (n *network) setPerPortDNAT(ctx context.Context, pbs []types.PortBinding, p
 		if (pb.IP.To4() != nil) != (pb.HostIP.To4() != nil) {
 			continue
 		}
+		var proxySkip string
+		if !n.fw.config.Hairpin && !pb.DisableProxy {
+			proxySkip = fmt.Sprintf("iifname != %s ", n.config.IfName)
+		}
 		var daddrMatch string
 		if !pb.HostIP.IsUnspecified() {
 			daddrMatch = fmt.Sprintf("%s daddr %s ", pbc.table.Family(), pb.HostIP)
@@ -149,11 +149,12 @@ func (n *network) setPerPortDNAT(ctx context.Context, pbs []types.PortBinding, p
 // setPerPortHairpinMasq allows containers to access their own published ports on the host
 // when hairpin is enabled (no docker-proxy), by masquerading.
 func (n *network) setPerPortHairpinMasq(ctx context.Context, pbs []types.PortBinding, pbc pbContext, enable bool) error {
-	if !n.fw.config.Hairpin {
-		return nil
-	}
 	updater := pbc.table.ChainUpdateFunc(ctx, chainNatPostRtIn(n.config.IfName), enable)
 	for _, pb := range pbs {
+		// Nothing to do if the proxy handles hairpin traffic for this binding.
+		if !n.fw.config.Hairpin && !pb.DisableProxy {
+			continue
+		}
 		// Nothing to do if NAT is disabled.
 		if pb.HostPort == 0 {
 			continue
@@ -121,6 +121,22 @@ var builtinLocalDrivers = map[string]struct{}{
 	"internal":    {},
 	"private":     {},
 	"ics":         {},
+	// "ipvlan" is not an HNS network type; it is backed by an L2Bridge
+	// network (see [hnsNetworkType]), which gives endpoints the same
+	// semantics as the Linux ipvlan driver: they share the host
+	// interface's MAC address and get directly routable IPs, optionally
+	// on a VLAN-tagged interface.
+	"ipvlan": {},
+}
+
+// hnsNetworkType maps a Docker network-type to the HNS network type that
+// backs it. Most of the builtin local drivers are named after the HNS
+// network type they represent and map to themselves.
+func hnsNetworkType(networkType string) string {
+	if networkType == "ipvlan" {
+		return "l2bridge"
+	}
+	return networkType
 }
 
 // IsBuiltinLocalDriver validates if network-type is a builtin local-scoped driver
@@ -371,7 +387,7 @@ func (d *driver) CreateNetwork(ctx context.Context, id string, option map[string
 
 		network := &hcsshim.HNSNetwork{
 			Name:               config.Name,
-			Type:               d.name,
+			Type:               hnsNetworkType(d.name),
 			Subnets:            subnets,
 			DNSServerList:      config.DNSServers,
 			DNSSuffix:          config.DNSSuffix,
@@ -699,7 +715,7 @@ func (d *driver) CreateEndpoint(ctx context.Context, nid, eid string, ifInfo dri
 
 	portMapping := epConnectivity.PortBindings
 
-	if n.config.Type == "l2bridge" || n.config.Type == "l2tunnel" {
+	if n.config.Type == "l2bridge" || n.config.Type == "l2tunnel" || n.config.Type == "ipvlan" {
 		ip := net.IPv4(0, 0, 0, 0)
 		if ifInfo.Address() != nil {
 			ip = ifInfo.Address().IP
@@ -826,7 +842,7 @@ func (d *driver) DeleteEndpoint(nid, eid string) error {
 		return err
 	}
 
-	if n.config.Type == "l2bridge" || n.config.Type == "l2tunnel" {
+	if n.config.Type == "l2bridge" || n.config.Type == "l2tunnel" || n.config.Type == "ipvlan" {
 		ReleasePorts(n.portMapper, ep.portMapping)
 	}
 
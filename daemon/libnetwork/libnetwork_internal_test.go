@@ -831,3 +831,38 @@ func (b *badDriver) NetworkAllocate(id string, option map[string]string, ipV4Dat
 func (b *badDriver) NetworkFree(id string) error {
 	return types.NotImplementedErrorf("not implemented")
 }
+
+func TestNetworkDNSOptions(t *testing.T) {
+	nw := func(opts map[string]string) *Network {
+		return &Network{
+			generic: map[string]any{netlabel.GenericData: opts},
+		}
+	}
+
+	n := nw(map[string]string{
+		netlabel.NetworkDNSServers: "10.0.0.1, 10.0.0.2,fd00::53",
+		netlabel.NetworkDNSSearch:  "example.com,example.org",
+		netlabel.NetworkDNSOptions: "ndots:2, edns0",
+	})
+	servers, err := n.validatedDNSServers()
+	assert.NilError(t, err)
+	assert.Check(t, is.DeepEqual(servers, []netip.Addr{
+		netip.MustParseAddr("10.0.0.1"),
+		netip.MustParseAddr("10.0.0.2"),
+		netip.MustParseAddr("fd00::53"),
+	}, cmpopts.EquateComparable(netip.Addr{})))
+	assert.Check(t, is.DeepEqual(n.dnsSearchDomains(), []string{"example.com", "example.org"}))
+	assert.Check(t, is.DeepEqual(n.dnsOptions(), []string{"ndots:2", "edns0"}))
+
+	n = nw(map[string]string{})
+	servers, err = n.validatedDNSServers()
+	assert.NilError(t, err)
+	assert.Check(t, is.Nil(servers))
+	assert.Check(t, is.Nil(n.dnsSearchDomains()))
+	assert.Check(t, is.Nil(n.dnsOptions()))
+
+	n = nw(map[string]string{netlabel.NetworkDNSServers: "10.0.0.1,notanaddress"})
+	_, err = n.validatedDNSServers()
+	assert.Check(t, is.ErrorContains(err, `invalid nameserver address "notanaddress"`))
+	assert.Check(t, is.Nil(n.dnsServers()))
+}
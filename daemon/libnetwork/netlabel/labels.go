@@ -26,6 +26,18 @@ const (
 	// DNSServers A list of DNS servers associated with the endpoint
 	DNSServers = Prefix + ".endpoint.dnsservers"
 
+	// NetworkDNSServers is a comma separated list of upstream DNS servers
+	// inherited by containers attached to the network
+	NetworkDNSServers = Prefix + ".dns.servers"
+
+	// NetworkDNSSearch is a comma separated list of DNS search domains
+	// inherited by containers attached to the network
+	NetworkDNSSearch = Prefix + ".dns.search"
+
+	// NetworkDNSOptions is a comma separated list of resolver options
+	// inherited by containers attached to the network
+	NetworkDNSOptions = Prefix + ".dns.options"
+
 	// EndpointSysctls is a comma separated list interface-specific sysctls
 	// where the interface name is represented by the string "IFNAME".
 	EndpointSysctls = Prefix + ".endpoint.sysctls"
@@ -39,6 +51,10 @@ const (
 	// EnableIPv6 constant represents enabling IPV6 at network level
 	EnableIPv6 = Prefix + ".enable_ipv6"
 
+	// IPv6Only constant represents an IPv6-only network; shorthand for
+	// disabling IPv4 and enabling IPv6, with no IPv4 pool allocated
+	IPv6Only = Prefix + ".ipv6only"
+
 	// DriverMTU constant represents the MTU size for the network driver
 	DriverMTU = DriverPrefix + ".mtu"
 
@@ -366,6 +366,9 @@ func (n *Network) validateConfiguration() error {
 		if err := n.validateAdvertiseAddrConfig(); err != nil {
 			return err
 		}
+		if err := n.validateDNSConfig(); err != nil {
+			return err
+		}
 	} else {
 		if n.configOnly {
 			return types.ForbiddenErrorf("a configuration network cannot depend on another configuration network")
@@ -568,6 +571,67 @@ func (n *Network) advertiseAddrInterval() (time.Duration, bool) {
 	return *v, true
 }
 
+// validatedDNSServers parses the network-level DNS servers option. It returns
+// nil if the option is not set on the network.
+func (n *Network) validatedDNSServers() ([]netip.Addr, error) {
+	serversStr, ok := n.DriverOptions()[netlabel.NetworkDNSServers]
+	if !ok {
+		return nil, nil
+	}
+	var servers []netip.Addr
+	for _, s := range strings.Split(serversStr, ",") {
+		s = strings.TrimSpace(s)
+		if s == "" {
+			continue
+		}
+		addr, err := netip.ParseAddr(s)
+		if err != nil {
+			return nil, fmt.Errorf("value for option "+netlabel.NetworkDNSServers+" contains invalid nameserver address %q", s)
+		}
+		servers = append(servers, addr)
+	}
+	return servers, nil
+}
+
+func (n *Network) validateDNSConfig() error {
+	_, err := n.validatedDNSServers()
+	return err
+}
+
+// dnsServers returns the upstream DNS servers configured as a network-level
+// option, to be inherited by containers attached to the network.
+func (n *Network) dnsServers() []netip.Addr {
+	v, err := n.validatedDNSServers()
+	if err != nil {
+		// Config was validated before network creation, this path is only
+		// taken if an invalid value made it into the store.
+		return nil
+	}
+	return v
+}
+
+// dnsSearchDomains returns the DNS search domains configured as a
+// network-level option.
+func (n *Network) dnsSearchDomains() []string {
+	return splitCSVOption(n.DriverOptions()[netlabel.NetworkDNSSearch])
+}
+
+// dnsOptions returns the resolver options configured as a network-level
+// option.
+func (n *Network) dnsOptions() []string {
+	return splitCSVOption(n.DriverOptions()[netlabel.NetworkDNSOptions])
+}
+
+func splitCSVOption(val string) []string {
+	var res []string
+	for _, s := range strings.Split(val, ",") {
+		if s = strings.TrimSpace(s); s != "" {
+			res = append(res, s)
+		}
+	}
+	return res
+}
+
 func (n *Network) MarshalJSON() ([]byte, error) {
 	// TODO: Can be made much more generic with the help of reflection (but has some golang limitations)
 	netMap := make(map[string]any)
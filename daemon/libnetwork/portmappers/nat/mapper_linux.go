@@ -105,6 +105,11 @@ func (pm PortMapper) MapPorts(ctx context.Context, cfg []portmapperapi.PortBindi
 			if bindings[i].BoundSocket == nil || bindings[i].RootlesskitUnsupported || bindings[i].StopProxy != nil {
 				continue
 			}
+			if bindings[i].DisableProxy {
+				// The proxy is disabled for this binding, hairpin NAT
+				// rules deal with container access to the mapped port.
+				continue
+			}
 			var err error
 			bindings[i].StopProxy, err = pm.startProxy(
 				bindings[i].ChildPortBinding(), bindings[i].BoundSocket,
@@ -310,7 +315,7 @@ func listenBoundPorts(pbs []portmapperapi.PortBinding, proxyEnabled bool) error
 		if errC := rc.Control(func(fd uintptr) {
 			somaxconn := 0
 			// SCTP sockets do not support somaxconn=0
-			if proxyEnabled || pbs[i].Proto == types.SCTP {
+			if (proxyEnabled && !pbs[i].DisableProxy) || pbs[i].Proto == types.SCTP {
 				somaxconn = -1 // silently capped to "/proc/sys/net/core/somaxconn"
 			}
 			err = syscall.Listen(int(fd), somaxconn)
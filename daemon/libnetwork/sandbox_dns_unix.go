@@ -12,6 +12,7 @@ import (
 	"strings"
 
 	"github.com/containerd/log"
+	"github.com/moby/moby/v2/daemon/internal/sliceutil"
 	"github.com/moby/moby/v2/daemon/libnetwork/etchosts"
 	"github.com/moby/moby/v2/daemon/libnetwork/internal/resolvconf"
 	"github.com/moby/moby/v2/daemon/libnetwork/types"
@@ -253,6 +254,9 @@ func (sb *Sandbox) loadResolvConf(path string) (*resolvconf.ResolvConf, error) {
 	rc.SetHeader(`# Generated by Docker Engine.
 # This file can be edited; Docker Engine will not make further changes once it
 # has been modified.`)
+	// DNS configuration set as network-level options is inherited by the
+	// container, unless overridden by container-level configuration.
+	nwServers, nwSearch, nwOptions := sb.networkDNSConfig()
 	if len(sb.config.dnsList) > 0 {
 		var dnsAddrs []netip.Addr
 		for _, ns := range sb.config.dnsList {
@@ -263,16 +267,34 @@ func (sb *Sandbox) loadResolvConf(path string) (*resolvconf.ResolvConf, error) {
 			dnsAddrs = append(dnsAddrs, addr)
 		}
 		rc.OverrideNameServers(dnsAddrs)
+	} else if len(nwServers) > 0 {
+		rc.OverrideNameServers(nwServers)
 	}
 	if len(sb.config.dnsSearchList) > 0 {
 		rc.OverrideSearch(sb.config.dnsSearchList)
+	} else if len(nwSearch) > 0 {
+		rc.OverrideSearch(nwSearch)
 	}
 	if len(sb.config.dnsOptionsList) > 0 {
 		rc.OverrideOptions(sb.config.dnsOptionsList)
+	} else if len(nwOptions) > 0 {
+		rc.OverrideOptions(nwOptions)
 	}
 	return &rc, nil
 }
 
+// networkDNSConfig aggregates the DNS configuration set as network-level
+// options on the networks the sandbox's endpoints are attached to.
+func (sb *Sandbox) networkDNSConfig() (servers []netip.Addr, search, options []string) {
+	for _, ep := range sb.Endpoints() {
+		n := ep.getNetwork()
+		servers = append(servers, n.dnsServers()...)
+		search = append(search, n.dnsSearchDomains()...)
+		options = append(options, n.dnsOptions()...)
+	}
+	return sliceutil.Dedup(servers), sliceutil.Dedup(search), sliceutil.Dedup(options)
+}
+
 // For a new sandbox, write an initial version of the container's resolv.conf. It'll
 // be a copy of the host's file, with overrides for nameservers, options and search
 // domains applied.
@@ -74,6 +74,10 @@ type PortBinding struct {
 	HostIP      net.IP
 	HostPort    uint16
 	HostPortEnd uint16
+	// DisableProxy disables the userland proxy for this binding, even when
+	// the proxy is enabled daemon-wide. The port is then mapped by NAT
+	// rules alone (hairpin NAT for container access to the port).
+	DisableProxy bool
 }
 
 // HostAddr returns the host side transport address
@@ -107,12 +111,13 @@ func (p PortBinding) ContainerAddr() (net.Addr, error) {
 // GetCopy returns a copy of this PortBinding structure instance
 func (p *PortBinding) GetCopy() PortBinding {
 	return PortBinding{
-		Proto:       p.Proto,
-		IP:          GetIPCopy(p.IP),
-		Port:        p.Port,
-		HostIP:      GetIPCopy(p.HostIP),
-		HostPort:    p.HostPort,
-		HostPortEnd: p.HostPortEnd,
+		Proto:        p.Proto,
+		IP:           GetIPCopy(p.IP),
+		Port:         p.Port,
+		HostIP:       GetIPCopy(p.HostIP),
+		HostPort:     p.HostPort,
+		HostPortEnd:  p.HostPortEnd,
+		DisableProxy: p.DisableProxy,
 	}
 }
 
@@ -123,7 +128,8 @@ func (p *PortBinding) Equal(o *PortBinding) bool {
 		p.Port == o.Port &&
 		p.HostIP.Equal(o.HostIP) &&
 		p.HostPort == o.HostPort &&
-		p.HostPortEnd == o.HostPortEnd
+		p.HostPortEnd == o.HostPortEnd &&
+		p.DisableProxy == o.DisableProxy
 }
 
 // String returns the PortBinding structure in the form "HostIP:HostPort:IP:Port/Proto",
@@ -23,6 +23,7 @@ import (
 	smithymiddleware "github.com/aws/smithy-go/middleware"
 	smithyhttp "github.com/aws/smithy-go/transport/http"
 	"github.com/containerd/log"
+	"github.com/docker/go-units"
 	"github.com/moby/moby/v2/daemon/internal/lazyregexp"
 	"github.com/moby/moby/v2/daemon/logger"
 	"github.com/moby/moby/v2/daemon/logger/loggerutils"
@@ -46,9 +47,12 @@ const (
 	forceFlushIntervalKey  = "awslogs-force-flush-interval-seconds"
 	maxBufferedEventsKey   = "awslogs-max-buffered-events"
 	logFormatKey           = "awslogs-format"
+	offlineBufferDirKey    = "awslogs-offline-buffer-dir"
+	offlineBufferSizeKey   = "awslogs-offline-buffer-max-size"
 
 	defaultForceFlushInterval = 5 * time.Second
 	defaultMaxBufferedEvents  = 4096
+	defaultOfflineBufferSize  = 64 * 1024 * 1024
 
 	// See: http://docs.aws.amazon.com/AmazonCloudWatchLogs/latest/APIReference/API_PutLogEvents.html
 	perEventBytes          = 26
@@ -194,6 +198,16 @@ func New(info logger.Info) (logger.Logger, error) {
 	}
 	go containerStream.collectBatch(creationDone)
 
+	if dir := info.Config[offlineBufferDirKey]; dir != "" {
+		maxSize := int64(defaultOfflineBufferSize)
+		if v := info.Config[offlineBufferSizeKey]; v != "" {
+			if maxSize, err = units.RAMInBytes(v); err != nil {
+				return nil, err
+			}
+		}
+		return loggerutils.NewDiskBuffer(containerStream, dir, info.ContainerID, maxSize)
+	}
+
 	return containerStream, nil
 }
 
@@ -752,6 +766,8 @@ func ValidateLogOpt(cfg map[string]string) error {
 		case forceFlushIntervalKey:
 		case maxBufferedEventsKey:
 		case logFormatKey:
+		case offlineBufferDirKey:
+		case offlineBufferSizeKey:
 		default:
 			return fmt.Errorf("unknown log opt '%s' for %s log driver", key, name)
 		}
@@ -779,6 +795,14 @@ func ValidateLogOpt(cfg map[string]string) error {
 			return fmt.Errorf("must specify a positive integer for log opt '%s': %v", maxBufferedEventsKey, cfg[maxBufferedEventsKey])
 		}
 	}
+	if cfg[offlineBufferSizeKey] != "" {
+		if cfg[offlineBufferDirKey] == "" {
+			return fmt.Errorf("log opt '%s' requires '%s' to be set", offlineBufferSizeKey, offlineBufferDirKey)
+		}
+		if value, err := units.RAMInBytes(cfg[offlineBufferSizeKey]); err != nil || value <= 0 {
+			return fmt.Errorf("must specify a positive size for log opt '%s': %v", offlineBufferSizeKey, cfg[offlineBufferSizeKey])
+		}
+	}
 	_, datetimeFormatKeyExists := cfg[datetimeFormatKey]
 	_, multilinePatternKeyExists := cfg[multilinePatternKey]
 	if datetimeFormatKeyExists && multilinePatternKeyExists {
@@ -5,6 +5,7 @@ import (
 	"context"
 	"io"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/containerd/log"
@@ -31,6 +32,15 @@ type Copier struct {
 	copyJobs  sync.WaitGroup
 	closeOnce sync.Once
 	closed    chan struct{}
+
+	// sampleRate is the fraction of complete log lines forwarded to the
+	// log driver, in (0, 1). Zero (or >= 1) disables sampling.
+	sampleRate float64
+	// sampleBurst is the number of initial lines that are always
+	// forwarded before sampling starts.
+	sampleBurst   uint64
+	sampleSeen    atomic.Uint64
+	sampleDropped atomic.Uint64
 }
 
 // NewCopier creates a new Copier
@@ -42,6 +52,48 @@ func NewCopier(srcs map[string]io.Reader, dst Logger) *Copier {
 	}
 }
 
+// ConfigureSampling enables statistical line sampling on the copier. Only
+// rate*100 percent of complete log lines are forwarded to the log driver;
+// the first burst lines are always forwarded. Lines dropped by sampling are
+// counted and can be retrieved with SamplingStats. It must be called before
+// Run.
+func (c *Copier) ConfigureSampling(rate float64, burst int) {
+	c.sampleRate = rate
+	if burst > 0 {
+		c.sampleBurst = uint64(burst)
+	}
+}
+
+// SamplingStats returns the number of log lines dropped by sampling so far.
+// The second return value is false when sampling is not enabled.
+func (c *Copier) SamplingStats() (dropped uint64, enabled bool) {
+	if c.sampleRate <= 0 || c.sampleRate >= 1 {
+		return 0, false
+	}
+	return c.sampleDropped.Load(), true
+}
+
+// keepLine reports whether the next complete log line should be forwarded to
+// the log driver, applying the configured sampling policy. The sampling is
+// deterministic: a line is kept whenever the scaled line counter crosses an
+// integer boundary, which converges on the configured rate without requiring
+// randomness. Lines dropped by sampling are counted.
+func (c *Copier) keepLine() bool {
+	if c.sampleRate <= 0 || c.sampleRate >= 1 {
+		return true
+	}
+	n := c.sampleSeen.Add(1)
+	if n <= c.sampleBurst {
+		return true
+	}
+	n -= c.sampleBurst
+	if uint64(float64(n)*c.sampleRate) > uint64(float64(n-1)*c.sampleRate) {
+		return true
+	}
+	c.sampleDropped.Add(1)
+	return false
+}
+
 // Run starts logs copying
 func (c *Copier) Run() {
 	for src, w := range c.srcs {
@@ -71,6 +123,10 @@ func (c *Copier) copySrc(name string, src io.Reader) {
 	var ordinal int
 	firstPartial := true
 	hasMorePartial := false
+	// keepLine is the sampling decision for the logical line currently
+	// being emitted; it is made once per line so that all partials of a
+	// line are kept or dropped together.
+	keepLine := true
 
 	for {
 		select {
@@ -107,6 +163,9 @@ func (c *Copier) copySrc(name string, src io.Reader) {
 				case <-c.closed:
 					return
 				default:
+					if !hasMorePartial {
+						keepLine = c.keepLine()
+					}
 					msg := NewMessage()
 					msg.Source = name
 					msg.Line = append(msg.Line, buf[p:p+q]...)
@@ -126,7 +185,9 @@ func (c *Copier) copySrc(name string, src io.Reader) {
 						msg.Timestamp = partialTS
 					}
 
-					if logErr := c.dst.Log(msg); logErr != nil {
+					if !keepLine {
+						PutMessage(msg)
+					} else if logErr := c.dst.Log(msg); logErr != nil {
 						logDriverError(c.dst.Name(), string(msg.Line), logErr)
 					}
 				}
@@ -137,6 +198,9 @@ func (c *Copier) copySrc(name string, src io.Reader) {
 			// noting that it's a partial log line.
 			if eof || (p == 0 && n == len(buf)) {
 				if p < n {
+					if firstPartial {
+						keepLine = c.keepLine()
+					}
 					msg := NewMessage()
 					msg.Source = name
 					msg.Line = append(msg.Line, buf[p:n]...)
@@ -158,7 +222,9 @@ func (c *Copier) copySrc(name string, src io.Reader) {
 					ordinal++
 					hasMorePartial = true
 
-					if logErr := c.dst.Log(msg); logErr != nil {
+					if !keepLine {
+						PutMessage(msg)
+					} else if logErr := c.dst.Log(msg); logErr != nil {
 						logDriverError(c.dst.Name(), string(msg.Line), logErr)
 					}
 					p = 0
@@ -120,6 +120,63 @@ func TestCopier(t *testing.T) {
 	}
 }
 
+func TestCopierSampling(t *testing.T) {
+	const (
+		total = 1000
+		burst = 100
+		rate  = 0.1
+	)
+
+	var stdout bytes.Buffer
+	for i := 0; i < total; i++ {
+		if _, err := stdout.WriteString("sampled log line\n"); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var jsonBuf bytes.Buffer
+	jsonLog := &TestLoggerJSON{Encoder: json.NewEncoder(&jsonBuf)}
+
+	c := NewCopier(map[string]io.Reader{"stdout": &stdout}, jsonLog)
+	c.ConfigureSampling(rate, burst)
+	c.Run()
+	wait := make(chan struct{})
+	go func() {
+		c.Wait()
+		close(wait)
+	}()
+	select {
+	case <-time.After(1 * time.Second):
+		t.Fatal("Copier failed to do its work in 1 second")
+	case <-wait:
+	}
+
+	var logged int
+	dec := json.NewDecoder(&jsonBuf)
+	for {
+		var msg Message
+		if err := dec.Decode(&msg); err != nil {
+			if err == io.EOF {
+				break
+			}
+			t.Fatal(err)
+		}
+		logged++
+	}
+
+	expected := burst + int(float64(total-burst)*rate)
+	if logged != expected {
+		t.Fatalf("expected %d lines to be logged, got %d", expected, logged)
+	}
+	dropped, enabled := c.SamplingStats()
+	if !enabled {
+		t.Fatal("expected sampling to be reported as enabled")
+	}
+	if int(dropped) != total-expected {
+		t.Fatalf("expected %d dropped lines, got %d", total-expected, dropped)
+	}
+}
+
 // TestCopierLongLines tests long lines without line breaks
 func TestCopierLongLines(t *testing.T) {
 	// Long lines (should be split at "defaultBufSize")
@@ -3,6 +3,7 @@ package logger
 import (
 	"fmt"
 	"sort"
+	"strconv"
 	"sync"
 
 	"github.com/docker/go-units"
@@ -127,6 +128,8 @@ func GetLogDriver(name string) (Creator, error) {
 var builtInLogOpts = map[string]bool{
 	"mode":            true,
 	"max-buffer-size": true,
+	"sample-rate":     true,
+	"sample-burst":    true,
 }
 
 // ValidateLogOpts checks the options for the given log driver. The
@@ -151,6 +154,24 @@ func ValidateLogOpts(name string, cfg map[string]string) error {
 		}
 	}
 
+	if s, ok := cfg["sample-rate"]; ok {
+		rate, err := strconv.ParseFloat(s, 64)
+		if err != nil {
+			return errors.Wrap(err, "error parsing option sample-rate")
+		}
+		if rate <= 0 || rate > 1 {
+			return fmt.Errorf("logger: sample-rate must be greater than 0 and at most 1: %s", s)
+		}
+	}
+	if s, ok := cfg["sample-burst"]; ok {
+		if _, ok := cfg["sample-rate"]; !ok {
+			return fmt.Errorf("logger: sample-burst option is only supported with sample-rate")
+		}
+		if v, err := strconv.Atoi(s); err != nil || v < 0 {
+			return fmt.Errorf("logger: sample-burst must be a non-negative integer: %s", s)
+		}
+	}
+
 	if err := validateExternal(cfg); err != nil {
 		return err
 	}
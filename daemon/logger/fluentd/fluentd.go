@@ -4,13 +4,18 @@ package fluentd
 
 import (
 	"context"
+	"crypto/tls"
+	"crypto/x509"
 	"math"
 	"net/url"
+	"os"
 	"strconv"
 	"strings"
+	"sync/atomic"
 	"time"
 
 	"github.com/containerd/log"
+	gometrics "github.com/docker/go-metrics"
 	"github.com/docker/go-units"
 	"github.com/fluent/fluent-logger-golang/fluent"
 	"github.com/moby/moby/v2/daemon/logger"
@@ -25,6 +30,27 @@ type fluentd struct {
 	containerName string
 	writer        *fluent.Fluent
 	extra         map[string]string
+
+	// tunnel is the local forwarder presenting a client certificate to
+	// the fluentd endpoint. It is nil unless mutual TLS is configured.
+	tunnel *tlsTunnel
+
+	// queue decouples Log callers from the fluent writer so that the
+	// on-full behavior is controlled by the driver. It is nil in the
+	// default "block" mode, in which writes go directly to the writer.
+	queue   chan *queuedMessage
+	onFull  string
+	done    chan struct{}
+	flushed chan struct{}
+	dropped atomic.Uint64
+}
+
+// queuedMessage is a log entry waiting in the driver queue for delivery to
+// the fluent writer.
+type queuedMessage struct {
+	tag  string
+	ts   time.Time
+	data map[string]string
 }
 
 type location struct {
@@ -63,8 +89,39 @@ const (
 	// This allows fluentd to give up unhealthy connections and not be blocked forever
 	// when downstream connections get unhealthy.
 	writeTimeoutKey = "fluentd-write-timeout"
+
+	// TLS options for tls:// addresses. Setting both tlsCertKey and
+	// tlsKeyKey enables mutual TLS.
+	tlsCAKey         = "fluentd-ca"
+	tlsCertKey       = "fluentd-cert"
+	tlsKeyKey        = "fluentd-key"
+	tlsSkipVerifyKey = "fluentd-insecure-skip-verify"
+
+	// onFullKey selects what happens when the driver queue is full:
+	// "block" (default), "drop-oldest", or "drop-new". The queue is only
+	// used for the two dropping modes.
+	onFullKey    = "fluentd-on-full"
+	queueSizeKey = "fluentd-queue-size"
+
+	onFullBlock      = "block"
+	onFullDropOldest = "drop-oldest"
+	onFullDropNew    = "drop-new"
+
+	defaultQueueSize = 1000
+)
+
+var (
+	queueDepth  gometrics.Gauge
+	droppedLogs gometrics.Counter
 )
 
+func init() {
+	ns := gometrics.NewNamespace("logger", "fluentd", nil)
+	queueDepth = ns.NewGauge("queue_depth", "Number of log messages waiting in the fluentd log driver queue", gometrics.Unit("messages"))
+	droppedLogs = ns.NewCounter("dropped_logs", "Number of log messages dropped by the fluentd log driver because its queue was full")
+	gometrics.Register(ns)
+}
+
 func init() {
 	if err := logger.RegisterLogDriver(name, New); err != nil {
 		panic(err)
@@ -98,17 +155,84 @@ func New(info logger.Info) (logger.Logger, error) {
 		"config":    fluentConfig,
 	}).Debug("logging driver fluentd configured")
 
+	var tunnel *tlsTunnel
+	if info.Config[tlsCertKey] != "" || info.Config[tlsKeyKey] != "" {
+		tlsConfig, err := parseTLSConfig(info.Config)
+		if err != nil {
+			return nil, errdefs.InvalidParameter(err)
+		}
+		// The fluent client library dials TLS itself without support for
+		// client certificates, so route it through a local forwarder
+		// which presents the certificate.
+		tunnel, err = newTLSTunnel(fluentConfig.FluentHost+":"+strconv.Itoa(fluentConfig.FluentPort), tlsConfig)
+		if err != nil {
+			return nil, err
+		}
+		fluentConfig.FluentNetwork = "unix"
+		fluentConfig.FluentSocketPath = tunnel.socketPath()
+		fluentConfig.FluentHost = ""
+		fluentConfig.FluentPort = 0
+	}
+
 	writer, err := fluent.New(fluentConfig)
 	if err != nil {
+		if tunnel != nil {
+			tunnel.Close()
+		}
 		return nil, err
 	}
-	return &fluentd{
+	f := &fluentd{
 		tag:           tag,
 		containerID:   info.ContainerID,
 		containerName: info.ContainerName,
 		writer:        writer,
 		extra:         extraAttrs,
-	}, nil
+		tunnel:        tunnel,
+		onFull:        onFullBlock,
+	}
+	if mode := info.Config[onFullKey]; mode != "" && mode != onFullBlock {
+		queueSize := defaultQueueSize
+		if qs := info.Config[queueSizeKey]; qs != "" {
+			queueSize, err = strconv.Atoi(qs)
+			if err != nil {
+				writer.Close()
+				return nil, errdefs.InvalidParameter(err)
+			}
+		}
+		f.onFull = mode
+		f.queue = make(chan *queuedMessage, queueSize)
+		f.done = make(chan struct{})
+		f.flushed = make(chan struct{})
+		go f.run()
+	}
+	return f, nil
+}
+
+// run drains the driver queue into the fluent writer. On close it delivers
+// whatever is still queued before signalling that the queue is flushed.
+func (f *fluentd) run() {
+	defer close(f.flushed)
+	post := func(m *queuedMessage) {
+		queueDepth.Dec(1)
+		if err := f.writer.PostWithTime(m.tag, m.ts, m.data); err != nil {
+			log.G(context.TODO()).WithError(err).WithField("container", f.containerID).Error("fluentd: failed to post queued log message")
+		}
+	}
+	for {
+		select {
+		case m := <-f.queue:
+			post(m)
+		case <-f.done:
+			for {
+				select {
+				case m := <-f.queue:
+					post(m)
+				default:
+					return
+				}
+			}
+		}
+	}
 }
 
 func (f *fluentd) Log(msg *logger.Message) error {
@@ -130,13 +254,49 @@ func (f *fluentd) Log(msg *logger.Message) error {
 
 	ts := msg.Timestamp
 	logger.PutMessage(msg)
-	// fluent-logger-golang buffers logs from failures and disconnections,
-	// and these are transferred again automatically.
-	return f.writer.PostWithTime(f.tag, ts, data)
+	if f.queue == nil {
+		// fluent-logger-golang buffers logs from failures and disconnections,
+		// and these are transferred again automatically.
+		return f.writer.PostWithTime(f.tag, ts, data)
+	}
+
+	m := &queuedMessage{tag: f.tag, ts: ts, data: data}
+	for {
+		select {
+		case f.queue <- m:
+			queueDepth.Inc(1)
+			return nil
+		default:
+		}
+		if f.onFull == onFullDropNew {
+			f.dropped.Add(1)
+			droppedLogs.Inc(1)
+			return nil
+		}
+		// drop-oldest: make room and try again.
+		select {
+		case <-f.queue:
+			queueDepth.Dec(1)
+			f.dropped.Add(1)
+			droppedLogs.Inc(1)
+		default:
+		}
+	}
 }
 
 func (f *fluentd) Close() error {
-	return f.writer.Close()
+	if f.queue != nil {
+		close(f.done)
+		<-f.flushed
+	}
+	if dropped := f.dropped.Load(); dropped > 0 {
+		log.G(context.TODO()).WithField("container", f.containerID).Warnf("fluentd: %d log messages dropped because the queue was full", dropped)
+	}
+	err := f.writer.Close()
+	if f.tunnel != nil {
+		f.tunnel.Close()
+	}
+	return err
 }
 
 func (f *fluentd) Name() string {
@@ -162,16 +322,71 @@ func ValidateLogOpt(cfg map[string]string) error {
 		case retryWaitKey:
 		case subSecondPrecisionKey:
 		case writeTimeoutKey:
+		case tlsCAKey:
+		case tlsCertKey:
+		case tlsKeyKey:
+		case tlsSkipVerifyKey:
+		case onFullKey:
+		case queueSizeKey:
 			// Accepted
 		default:
 			return errors.Errorf("unknown log opt '%s' for fluentd log driver", key)
 		}
 	}
 
+	switch cfg[onFullKey] {
+	case "", onFullBlock, onFullDropOldest, onFullDropNew:
+	default:
+		return errors.Errorf("invalid value for %s: must be one of %s, %s, %s", onFullKey, onFullBlock, onFullDropOldest, onFullDropNew)
+	}
+	if qs := cfg[queueSizeKey]; qs != "" {
+		if cfg[onFullKey] == "" || cfg[onFullKey] == onFullBlock {
+			return errors.Errorf("%s is only supported with %s=%s or %s=%s", queueSizeKey, onFullKey, onFullDropOldest, onFullKey, onFullDropNew)
+		}
+		if v, err := strconv.Atoi(qs); err != nil || v <= 0 {
+			return errors.Errorf("invalid value for %s: must be a positive integer", queueSizeKey)
+		}
+	}
+	if (cfg[tlsCertKey] == "") != (cfg[tlsKeyKey] == "") {
+		return errors.Errorf("%s and %s must be set together", tlsCertKey, tlsKeyKey)
+	}
+
 	_, err := parseConfig(cfg)
 	return err
 }
 
+// parseTLSConfig builds the TLS client configuration for mutual TLS from the
+// fluentd-ca, fluentd-cert, fluentd-key, and fluentd-insecure-skip-verify
+// options.
+func parseTLSConfig(cfg map[string]string) (*tls.Config, error) {
+	cert, err := tls.LoadX509KeyPair(cfg[tlsCertKey], cfg[tlsKeyKey])
+	if err != nil {
+		return nil, errors.Wrap(err, "error loading fluentd client certificate")
+	}
+	tlsConfig := &tls.Config{
+		Certificates: []tls.Certificate{cert},
+	}
+	if ca := cfg[tlsCAKey]; ca != "" {
+		pem, err := os.ReadFile(ca)
+		if err != nil {
+			return nil, errors.Wrap(err, "error reading fluentd CA certificate")
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, errors.Errorf("invalid CA certificate in %s", ca)
+		}
+		tlsConfig.RootCAs = pool
+	}
+	if sv := cfg[tlsSkipVerifyKey]; sv != "" {
+		skipVerify, err := strconv.ParseBool(sv)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid value for %s", tlsSkipVerifyKey)
+		}
+		tlsConfig.InsecureSkipVerify = skipVerify
+	}
+	return tlsConfig, nil
+}
+
 func parseConfig(cfg map[string]string) (fluent.Config, error) {
 	var config fluent.Config
 
@@ -260,6 +475,16 @@ func parseConfig(cfg map[string]string) (fluent.Config, error) {
 		}
 	}
 
+	tlsSkipVerify := false
+	if cfg[tlsSkipVerifyKey] != "" {
+		if tlsSkipVerify, err = strconv.ParseBool(cfg[tlsSkipVerifyKey]); err != nil {
+			return config, errors.Wrapf(err, "invalid value for %s", tlsSkipVerifyKey)
+		}
+	}
+	if (cfg[tlsCAKey] != "" || cfg[tlsCertKey] != "") && loc.protocol != "tls" {
+		return config, errors.New("TLS options are only supported with a tls:// fluentd-address")
+	}
+
 	config = fluent.Config{
 		FluentPort:             loc.port,
 		FluentHost:             loc.host,
@@ -274,6 +499,7 @@ func parseConfig(cfg map[string]string) (fluent.Config, error) {
 		RequestAck:             requestAck,
 		ForceStopAsyncSend:     async,
 		WriteTimeout:           writeTimeout,
+		TlsInsecureSkipVerify:  tlsSkipVerify,
 	}
 
 	return config, nil
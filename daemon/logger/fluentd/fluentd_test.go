@@ -235,6 +235,32 @@ func TestValidateWriteTimeoutDuration(t *testing.T) {
 	}
 }
 
+func TestValidateLogOptOnFull(t *testing.T) {
+	for _, mode := range []string{"", onFullBlock, onFullDropOldest, onFullDropNew} {
+		t.Run("valid "+mode, func(t *testing.T) {
+			assert.NilError(t, ValidateLogOpt(map[string]string{onFullKey: mode}))
+		})
+	}
+	assert.ErrorContains(t, ValidateLogOpt(map[string]string{onFullKey: "reject"}), "invalid value for fluentd-on-full")
+	assert.ErrorContains(t, ValidateLogOpt(map[string]string{queueSizeKey: "100"}), "only supported with")
+	assert.ErrorContains(t, ValidateLogOpt(map[string]string{onFullKey: onFullDropNew, queueSizeKey: "0"}), "positive integer")
+	assert.NilError(t, ValidateLogOpt(map[string]string{onFullKey: onFullDropNew, queueSizeKey: "100"}))
+}
+
+func TestValidateLogOptTLS(t *testing.T) {
+	assert.ErrorContains(t, ValidateLogOpt(map[string]string{tlsCertKey: "/cert.pem"}), "must be set together")
+	assert.ErrorContains(t, ValidateLogOpt(map[string]string{
+		addressKey: "tcp://localhost:24224",
+		tlsCAKey:   "/ca.pem",
+	}), "only supported with a tls://")
+	assert.NilError(t, ValidateLogOpt(map[string]string{
+		addressKey: "tls://localhost:24224",
+		tlsCertKey: "/cert.pem",
+		tlsKeyKey:  "/key.pem",
+		tlsCAKey:   "/ca.pem",
+	}))
+}
+
 // TestWriteTimeoutIsEffective tests that writes timeout when the server is unresponsive.
 // The test ensures that instead of hanging forever, the fluentd write operation returns
 // an error when writes cannot be completed within the specified duration.
@@ -0,0 +1,89 @@
+package fluentd
+
+import (
+	"context"
+	"crypto/tls"
+	"io"
+	"net"
+	"runtime"
+
+	"github.com/containerd/log"
+	"github.com/moby/moby/v2/daemon/internal/stringid"
+	"github.com/pkg/errors"
+)
+
+// tlsTunnel forwards connections from a local listener to a remote TLS
+// endpoint, presenting a client certificate. It exists because the fluent
+// client library dials TLS itself with no support for client certificates,
+// so for mutual TLS the library is pointed at the tunnel's local socket
+// instead of the remote address.
+type tlsTunnel struct {
+	listener net.Listener
+	addr     string
+	config   *tls.Config
+	done     chan struct{}
+}
+
+// newTLSTunnel starts a forwarder to addr using the given TLS client
+// configuration, listening on an abstract unix socket so that no socket file
+// is left behind if the daemon exits uncleanly.
+func newTLSTunnel(addr string, config *tls.Config) (*tlsTunnel, error) {
+	if runtime.GOOS != "linux" {
+		return nil, errors.New("fluentd client certificates are only supported on Linux")
+	}
+	l, err := net.Listen("unix", "@docker-fluentd-"+stringid.GenerateRandomID()[:12])
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to listen for fluentd TLS tunnel")
+	}
+	t := &tlsTunnel{
+		listener: l,
+		addr:     addr,
+		config:   config,
+		done:     make(chan struct{}),
+	}
+	go t.accept()
+	return t, nil
+}
+
+// socketPath returns the abstract socket address for the fluent library to
+// dial.
+func (t *tlsTunnel) socketPath() string {
+	return t.listener.Addr().String()
+}
+
+func (t *tlsTunnel) accept() {
+	for {
+		conn, err := t.listener.Accept()
+		if err != nil {
+			select {
+			case <-t.done:
+				return
+			default:
+				log.G(context.TODO()).WithError(err).Error("fluentd TLS tunnel accept failed")
+				continue
+			}
+		}
+		go t.forward(conn)
+	}
+}
+
+// forward proxies a single local connection to the remote TLS endpoint.
+func (t *tlsTunnel) forward(local net.Conn) {
+	remote, err := tls.Dial("tcp", t.addr, t.config)
+	if err != nil {
+		log.G(context.TODO()).WithError(err).WithField("address", t.addr).Error("fluentd TLS tunnel failed to connect")
+		local.Close()
+		return
+	}
+	go func() {
+		_, _ = io.Copy(remote, local)
+		remote.Close()
+	}()
+	_, _ = io.Copy(local, remote)
+	local.Close()
+}
+
+func (t *tlsTunnel) Close() error {
+	close(t.done)
+	return t.listener.Close()
+}
@@ -9,10 +9,12 @@ import (
 	"time"
 
 	"github.com/moby/moby/v2/daemon/logger"
+	"github.com/moby/moby/v2/daemon/logger/loggerutils"
 
 	"cloud.google.com/go/compute/metadata"
 	"cloud.google.com/go/logging"
 	"github.com/containerd/log"
+	"github.com/docker/go-units"
 	mrpb "google.golang.org/genproto/googleapis/api/monitoredres"
 )
 
@@ -28,6 +30,11 @@ const (
 	logZoneKey        = "gcp-meta-zone"
 	logNameKey        = "gcp-meta-name"
 	logIDKey          = "gcp-meta-id"
+
+	offlineBufferDirKey  = "gcp-offline-buffer-dir"
+	offlineBufferSizeKey = "gcp-offline-buffer-max-size"
+
+	defaultOfflineBufferSize = 64 * 1024 * 1024
 )
 
 var (
@@ -197,6 +204,16 @@ func New(info logger.Info) (logger.Logger, error) {
 		}
 	}
 
+	if dir := info.Config[offlineBufferDirKey]; dir != "" {
+		maxSize := int64(defaultOfflineBufferSize)
+		if v := info.Config[offlineBufferSizeKey]; v != "" {
+			if maxSize, err = units.RAMInBytes(v); err != nil {
+				return nil, err
+			}
+		}
+		return loggerutils.NewDiskBuffer(l, dir, info.ContainerID, maxSize)
+	}
+
 	return l, nil
 }
 
@@ -206,10 +223,19 @@ func ValidateLogOpts(cfg map[string]string) error {
 	for k := range cfg {
 		switch k {
 		case projectOptKey, logLabelsKey, logLabelsRegexKey, logEnvKey, logEnvRegexKey, logCmdKey, logZoneKey, logNameKey, logIDKey:
+		case offlineBufferDirKey, offlineBufferSizeKey:
 		default:
 			return fmt.Errorf("%q is not a valid option for the gcplogs driver", k)
 		}
 	}
+	if cfg[offlineBufferSizeKey] != "" {
+		if cfg[offlineBufferDirKey] == "" {
+			return fmt.Errorf("log opt %q requires %q to be set", offlineBufferSizeKey, offlineBufferDirKey)
+		}
+		if v, err := units.RAMInBytes(cfg[offlineBufferSizeKey]); err != nil || v <= 0 {
+			return fmt.Errorf("must specify a positive size for log opt %q: %v", offlineBufferSizeKey, cfg[offlineBufferSizeKey])
+		}
+	}
 	return nil
 }
 
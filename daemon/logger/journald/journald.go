@@ -3,9 +3,11 @@
 package journald
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
 	"strconv"
+	"strings"
 	"sync/atomic"
 	"time"
 	"unicode"
@@ -58,6 +60,11 @@ type journald struct {
 
 	vars map[string]string // additional variables and values to send to the journal along with the log message
 
+	// jsonFields maps top-level keys of JSON-formatted log lines to the
+	// journal field each should be recorded as. It is empty unless the
+	// "journal-fields" log option was set.
+	jsonFields map[string]string
+
 	closed chan struct{}
 
 	// Overrides for unit tests.
@@ -132,9 +139,21 @@ func newJournald(info logger.Info) (*journald, error) {
 	for k, v := range extraAttrs {
 		vars[k] = v
 	}
+	var jsonFields map[string]string
+	if fields := info.Config["journal-fields"]; fields != "" {
+		jsonFields = make(map[string]string)
+		for _, key := range strings.Split(fields, ",") {
+			key = strings.TrimSpace(key)
+			if key == "" {
+				continue
+			}
+			jsonFields[key] = sanitizeKeyMod(key)
+		}
+	}
 	return &journald{
 		epoch:         epoch,
 		vars:          vars,
+		jsonFields:    jsonFields,
 		closed:        make(chan struct{}),
 		sendToJournal: journal.Send,
 	}, nil
@@ -150,6 +169,7 @@ func validateLogOpt(cfg map[string]string) error {
 		case "env":
 		case "env-regex":
 		case "tag":
+		case "journal-fields":
 		default:
 			return fmt.Errorf("unknown log opt '%s' for journald log driver", key)
 		}
@@ -178,6 +198,10 @@ func (s *journald) Log(msg *logger.Message) error {
 	source := msg.Source
 	logger.PutMessage(msg)
 
+	if len(s.jsonFields) > 0 {
+		s.mapJSONFields(vars, line)
+	}
+
 	seq := s.ordinal.Add(1)
 	vars[fieldLogOrdinal] = strconv.FormatUint(seq, 10)
 
@@ -187,6 +211,41 @@ func (s *journald) Log(msg *logger.Message) error {
 	return s.sendToJournal(line, journal.PriInfo, vars)
 }
 
+// mapJSONFields parses line as a JSON object and records the configured
+// top-level keys as journal fields in vars, so that journalctl can filter on
+// application fields directly. Lines that are not JSON objects are sent
+// unchanged.
+func (s *journald) mapJSONFields(vars map[string]string, line string) {
+	trimmed := strings.TrimLeftFunc(line, unicode.IsSpace)
+	if !strings.HasPrefix(trimmed, "{") {
+		return
+	}
+	var obj map[string]any
+	if err := json.Unmarshal([]byte(trimmed), &obj); err != nil {
+		return
+	}
+	for key, field := range s.jsonFields {
+		v, ok := obj[key]
+		if !ok {
+			continue
+		}
+		switch v := v.(type) {
+		case string:
+			vars[field] = v
+		case json.Number:
+			vars[field] = v.String()
+		default:
+			// Numbers, booleans, and nested values are recorded in
+			// their JSON representation.
+			b, err := json.Marshal(v)
+			if err != nil {
+				continue
+			}
+			vars[field] = string(b)
+		}
+	}
+}
+
 func (s *journald) Name() string {
 	return name
 }
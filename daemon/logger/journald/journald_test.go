@@ -6,6 +6,36 @@ import (
 	"testing"
 )
 
+func TestMapJSONFields(t *testing.T) {
+	s := &journald{
+		jsonFields: map[string]string{
+			"level":      "LEVEL",
+			"request.id": "REQUEST_ID",
+			"count":      "COUNT",
+		},
+	}
+
+	for line, expected := range map[string]map[string]string{
+		`{"level":"info","request.id":"abc123","ignored":"x"}`: {"LEVEL": "info", "REQUEST_ID": "abc123"},
+		`  {"count":42}`:    {"COUNT": "42"},
+		`{"level":true}`:    {"LEVEL": "true"},
+		`not json at all`:   {},
+		`{"truncated":`:     {},
+		`["level","array"]`: {},
+	} {
+		vars := map[string]string{}
+		s.mapJSONFields(vars, line)
+		if len(vars) != len(expected) {
+			t.Fatalf("line %q: expected %v, got %v", line, expected, vars)
+		}
+		for k, v := range expected {
+			if vars[k] != v {
+				t.Fatalf("line %q: expected %s=%q, got %q", line, k, v, vars[k])
+			}
+		}
+	}
+}
+
 func TestSanitizeKeyMod(t *testing.T) {
 	entries := map[string]string{
 		"io.kubernetes.pod.name":      "IO_KUBERNETES_POD_NAME",
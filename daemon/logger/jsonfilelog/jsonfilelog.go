@@ -81,6 +81,18 @@ func New(info logger.Info) (logger.Logger, error) {
 		}
 	}
 
+	var encryptKey []byte
+	if keyFile, ok := info.Config["encrypt-key-file"]; ok {
+		if maxFiles == 1 || capval == -1 {
+			return nil, errors.New("encrypt-key-file cannot be set when max-file is less than 2 or max-size is not set")
+		}
+		var err error
+		encryptKey, err = loggerutils.ReadEncryptKey(keyFile)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	extraAttrs, err := info.ExtraAttributes(nil)
 	if err != nil {
 		return nil, err
@@ -104,7 +116,7 @@ func New(info logger.Info) (logger.Logger, error) {
 		}
 	}
 
-	writer, err := loggerutils.NewLogFile(info.LogPath, capval, maxFiles, compress, decodeFunc, 0o640, getTailReader)
+	writer, err := loggerutils.NewLogFile(info.LogPath, capval, maxFiles, compress, encryptKey, decodeFunc, 0o640, getTailReader)
 	if err != nil {
 		return nil, err
 	}
@@ -158,6 +170,7 @@ func ValidateLogOpt(cfg map[string]string) error {
 		case "max-file":
 		case "max-size":
 		case "compress":
+		case "encrypt-key-file":
 		case "labels":
 		case "labels-regex":
 		case "env":
@@ -180,3 +193,13 @@ func (l *JSONFileLogger) Close() error {
 func (l *JSONFileLogger) Name() string {
 	return Name
 }
+
+// SetRotationPolicy updates the rotation limits applied to future writes.
+func (l *JSONFileLogger) SetRotationPolicy(maxSize int64, maxFiles int) {
+	l.writer.SetLimits(maxSize, maxFiles)
+}
+
+// Rotate forces an immediate rotation of the current log file.
+func (l *JSONFileLogger) Rotate() error {
+	return l.writer.Rotate()
+}
@@ -1,8 +1,10 @@
 package jsonfilelog
 
 import (
+	"bufio"
 	"context"
 	"encoding/json"
+	"errors"
 	"io"
 
 	"github.com/moby/moby/v2/daemon/logger"
@@ -81,6 +83,44 @@ func decodeFunc(rdr io.Reader) loggerutils.Decoder {
 	}
 }
 
-func getTailReader(ctx context.Context, r loggerutils.SizeReaderAt, req int) (loggerutils.SizeReaderAt, int, error) {
+func getTailReader(ctx context.Context, r loggerutils.SizeReaderAt, req int, maxBytes int64) (loggerutils.SizeReaderAt, int, error) {
+	if maxBytes > 0 && maxBytes < r.Size() {
+		truncated, err := truncateToLastBytes(r, maxBytes)
+		if err != nil {
+			return nil, 0, err
+		}
+		r = truncated
+	}
+	if req <= 0 {
+		return r, 0, nil
+	}
 	return tailfile.NewTailReader(ctx, r, req)
 }
+
+// truncateToLastBytes returns a reader for approximately the last maxBytes
+// bytes of r, aligned to the start of a log line so that decoding does not
+// begin in the middle of a message.
+func truncateToLastBytes(r loggerutils.SizeReaderAt, maxBytes int64) (loggerutils.SizeReaderAt, error) {
+	offset := r.Size() - maxBytes
+
+	// If the byte immediately before the window is a newline, the window
+	// already starts at the beginning of a line.
+	buf := make([]byte, 1)
+	if _, err := r.ReadAt(buf, offset-1); err != nil {
+		return nil, err
+	}
+	if buf[0] != '\n' {
+		// Skip the first (partial) line of the window.
+		br := bufio.NewReader(io.NewSectionReader(r, offset, maxBytes))
+		skipped, err := br.ReadBytes('\n')
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				// No complete line within the window.
+				return io.NewSectionReader(r, r.Size(), 0), nil
+			}
+			return nil, err
+		}
+		offset += int64(len(skipped))
+	}
+	return io.NewSectionReader(r, offset, r.Size()-offset), nil
+}
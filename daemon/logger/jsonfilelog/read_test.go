@@ -10,13 +10,16 @@ import (
 	"os"
 	"path/filepath"
 	"strconv"
+	"strings"
 	"testing"
 	"text/tabwriter"
 	"time"
 
 	"github.com/moby/moby/v2/daemon/logger"
 	"github.com/moby/moby/v2/daemon/logger/loggertest"
+	"github.com/moby/moby/v2/daemon/logger/loggerutils"
 	"gotest.tools/v3/assert"
+	is "gotest.tools/v3/assert/cmp"
 )
 
 func BenchmarkJSONFileLoggerReadLogs(b *testing.B) {
@@ -216,3 +219,41 @@ func (d dirStringer) String() string {
 	tw.Flush()
 	return buf.String()
 }
+
+func TestGetTailReaderMaxBytes(t *testing.T) {
+	lines := []string{
+		`{"log":"one\n","stream":"stdout","time":"2024-01-01T00:00:01Z"}`,
+		`{"log":"two\n","stream":"stdout","time":"2024-01-01T00:00:02Z"}`,
+		`{"log":"three\n","stream":"stdout","time":"2024-01-01T00:00:03Z"}`,
+	}
+	data := strings.Join(lines, "\n") + "\n"
+	lastTwo := strings.Join(lines[1:], "\n") + "\n"
+	last := lines[2] + "\n"
+
+	readAll := func(t *testing.T, r loggerutils.SizeReaderAt) string {
+		t.Helper()
+		out, err := io.ReadAll(io.NewSectionReader(r, 0, r.Size()))
+		assert.NilError(t, err)
+		return string(out)
+	}
+
+	for _, tc := range []struct {
+		name     string
+		req      int
+		maxBytes int64
+		expected string
+	}{
+		{name: "no byte limit", req: 3, maxBytes: 0, expected: data},
+		{name: "limit larger than file", req: 0, maxBytes: int64(len(data)) + 100, expected: data},
+		{name: "limit cuts into a line", req: 0, maxBytes: int64(len(lastTwo)) + 10, expected: lastTwo},
+		{name: "limit on line boundary", req: 0, maxBytes: int64(len(lastTwo)), expected: lastTwo},
+		{name: "limit smaller than last line", req: 0, maxBytes: 5, expected: ""},
+		{name: "combined with line limit", req: 1, maxBytes: int64(len(lastTwo)), expected: last},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			rdr, _, err := getTailReader(context.Background(), strings.NewReader(data), tc.req, tc.maxBytes)
+			assert.NilError(t, err)
+			assert.Check(t, is.Equal(readAll(t, rdr), tc.expected))
+		})
+	}
+}
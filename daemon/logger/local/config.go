@@ -9,6 +9,7 @@ type CreateConfig struct {
 	DisableCompression bool
 	MaxFileSize        int64
 	MaxFileCount       int
+	EncryptKey         []byte
 }
 
 func newDefaultConfig() *CreateConfig {
@@ -32,5 +33,9 @@ func validateConfig(cfg *CreateConfig) error {
 			return errors.New("compression cannot be enabled when max file count is 1")
 		}
 	}
+
+	if len(cfg.EncryptKey) > 0 && cfg.MaxFileCount <= 1 {
+		return errors.New("encryption cannot be enabled when max file count is 1")
+	}
 	return nil
 }
@@ -37,9 +37,10 @@ var buffersPool = sync.Pool{New: func() interface{} {
 
 // LogOptKeys are the keys names used for log opts passed in to initialize the driver.
 var LogOptKeys = map[string]bool{
-	"max-file": true,
-	"max-size": true,
-	"compress": true,
+	"max-file":         true,
+	"max-size":         true,
+	"compress":         true,
+	"encrypt-key-file": true,
 }
 
 // ValidateLogOpt looks for log driver specific options.
@@ -96,6 +97,14 @@ func New(info logger.Info) (logger.Logger, error) {
 		}
 		cfg.DisableCompression = !compressLogs
 	}
+
+	if keyFile, ok := info.Config["encrypt-key-file"]; ok {
+		key, err := loggerutils.ReadEncryptKey(keyFile)
+		if err != nil {
+			return nil, errdefs.InvalidParameter(err)
+		}
+		cfg.EncryptKey = key
+	}
 	return newDriver(info.LogPath, cfg)
 }
 
@@ -138,7 +147,7 @@ func newDriver(logPath string, cfg *CreateConfig) (logger.Logger, error) {
 		return nil, errdefs.InvalidParameter(err)
 	}
 
-	lf, err := loggerutils.NewLogFile(logPath, cfg.MaxFileSize, cfg.MaxFileCount, !cfg.DisableCompression, decodeFunc, 0o640, getTailReader)
+	lf, err := loggerutils.NewLogFile(logPath, cfg.MaxFileSize, cfg.MaxFileCount, !cfg.DisableCompression, cfg.EncryptKey, decodeFunc, 0o640, getTailReader)
 	if err != nil {
 		return nil, err
 	}
@@ -169,6 +178,16 @@ func (d *driver) Close() error {
 	return d.logfile.Close()
 }
 
+// SetRotationPolicy updates the rotation limits applied to future writes.
+func (d *driver) SetRotationPolicy(maxSize int64, maxFiles int) {
+	d.logfile.SetLimits(maxSize, maxFiles)
+}
+
+// Rotate forces an immediate rotation of the current log file.
+func (d *driver) Rotate() error {
+	return d.logfile.Rotate()
+}
+
 func messageToProto(msg *logger.Message, proto *logdriver.LogEntry, partial *logdriver.PartialLogEntryMetadata) {
 	proto.Source = msg.Source
 	proto.TimeNano = msg.Timestamp.UnixNano()
@@ -22,7 +22,7 @@ func (d *driver) ReadLogs(ctx context.Context, config logger.ReadConfig) *logger
 	return d.logfile.ReadLogs(ctx, config)
 }
 
-func getTailReader(ctx context.Context, r loggerutils.SizeReaderAt, req int) (loggerutils.SizeReaderAt, int, error) {
+func getTailReader(ctx context.Context, r loggerutils.SizeReaderAt, req int, maxBytes int64) (loggerutils.SizeReaderAt, int, error) {
 	size := r.Size()
 	if req < 0 {
 		return nil, 0, errdefs.InvalidParameter(errors.Errorf("invalid number of lines to tail: %d", req))
@@ -69,10 +69,14 @@ func getTailReader(ctx context.Context, r loggerutils.SizeReaderAt, req int) (lo
 			return nil, 0, errdefs.DataLoss(errors.New("log message header and footer indicate different message sizes"))
 		}
 
+		next := offset - int64(msgLen) - encodeBinaryLen64*2
+		if maxBytes > 0 && size-next > maxBytes {
+			// Including this message would exceed the byte limit.
+			break
+		}
 		found++
-		offset -= int64(msgLen)
-		offset -= encodeBinaryLen64 * 2
-		if found == req {
+		offset = next
+		if req > 0 && found == req {
 			break
 		}
 		if offset <= 0 {
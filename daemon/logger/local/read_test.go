@@ -1,12 +1,18 @@
 package local
 
 import (
+	"bytes"
+	"context"
+	"errors"
 	"io"
 	"os"
+	"strings"
 	"testing"
 
 	"github.com/moby/moby/v2/daemon/logger"
+	"github.com/moby/moby/v2/daemon/logger/loggerutils"
 	"gotest.tools/v3/assert"
+	is "gotest.tools/v3/assert/cmp"
 )
 
 func TestDecode(t *testing.T) {
@@ -48,3 +54,51 @@ func testDecode(t *testing.T, buf []byte, split int) {
 	assert.NilError(t, err)
 	assert.Equal(t, "hello\n", string(message.Line))
 }
+
+func TestGetTailReaderMaxBytes(t *testing.T) {
+	var data []byte
+	var sizes []int
+	for _, line := range []string{"one", "two", "three"} {
+		buf := make([]byte, 0)
+		err := marshal(&logger.Message{Line: []byte(line)}, &buf)
+		assert.NilError(t, err)
+		data = append(data, buf...)
+		sizes = append(sizes, len(buf))
+	}
+
+	decodeAll := func(t *testing.T, r loggerutils.SizeReaderAt) []string {
+		t.Helper()
+		d := &decoder{rdr: io.NewSectionReader(r, 0, r.Size())}
+		var lines []string
+		for {
+			msg, err := d.Decode()
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			assert.NilError(t, err)
+			lines = append(lines, strings.TrimSuffix(string(msg.Line), "\n"))
+		}
+		return lines
+	}
+
+	for _, tc := range []struct {
+		name     string
+		req      int
+		maxBytes int64
+		expected []string
+	}{
+		{name: "no byte limit", req: 3, maxBytes: 0, expected: []string{"one", "two", "three"}},
+		{name: "limit larger than file", req: 0, maxBytes: int64(len(data)) + 100, expected: []string{"one", "two", "three"}},
+		{name: "limit cuts into a message", req: 0, maxBytes: int64(sizes[1]+sizes[2]) - 1, expected: []string{"three"}},
+		{name: "limit on message boundary", req: 0, maxBytes: int64(sizes[1] + sizes[2]), expected: []string{"two", "three"}},
+		{name: "limit smaller than last message", req: 0, maxBytes: 1, expected: nil},
+		{name: "combined with line limit", req: 1, maxBytes: int64(sizes[1] + sizes[2]), expected: []string{"three"}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			rdr, n, err := getTailReader(context.Background(), bytes.NewReader(data), tc.req, tc.maxBytes)
+			assert.NilError(t, err)
+			assert.Check(t, is.Equal(n, len(tc.expected)))
+			assert.Check(t, is.DeepEqual(decodeAll(t, rdr), tc.expected))
+		})
+	}
+}
@@ -78,12 +78,34 @@ type SizedLogger interface {
 	BufSize() int
 }
 
+// Rotator is the interface for logging drivers that write to rotatable
+// local log files, allowing their rotation policy to be changed and a
+// rotation to be forced while the container is running.
+type Rotator interface {
+	Logger
+	// SetRotationPolicy updates the rotation limits applied to future
+	// writes. A maxSize of 0 leaves the size limit unchanged and -1
+	// removes it; a maxFiles of 0 leaves the number of retained files
+	// unchanged.
+	SetRotationPolicy(maxSize int64, maxFiles int)
+	// Rotate forces an immediate rotation of the current log file.
+	Rotate() error
+}
+
 // ReadConfig is the configuration passed into ReadLogs.
 type ReadConfig struct {
-	Since  time.Time
-	Until  time.Time
-	Tail   int
-	Follow bool
+	Since time.Time
+	Until time.Time
+	Tail  int
+	// TailBytes limits reading to approximately the last TailBytes bytes
+	// of log data. A value <= 0 means no byte limit. Both Tail and
+	// TailBytes may be set, in which case the more restrictive of the two
+	// applies.
+	TailBytes int64
+	// Streams selects the log streams ("stdout", "stderr") to read
+	// messages from. An empty list means all streams.
+	Streams []string
+	Follow  bool
 }
 
 // LogReader is the interface for reading log messages for loggers that support reading.
@@ -0,0 +1,275 @@
+package loggerutils
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/containerd/log"
+	"github.com/moby/moby/v2/daemon/logger"
+	"github.com/pkg/errors"
+)
+
+const (
+	// replayMaxBackoff caps the retry interval while replaying spooled
+	// messages to a remote logging backend that is still unreachable.
+	replayMaxBackoff = 30 * time.Second
+	replayMinBackoff = time.Second
+)
+
+// spooledMessage is the on-disk representation of a buffered log message.
+// Messages are stored as one JSON document per line.
+type spooledMessage struct {
+	Line      []byte    `json:"line"`
+	Source    string    `json:"source"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// DiskBuffer wraps a remote logger with an on-disk spool. When the wrapped
+// logger returns an error, messages are appended to a spool file instead of
+// being lost, and are replayed in order once the backend accepts writes
+// again. A spool left behind by a previous daemon run is replayed on
+// creation.
+type DiskBuffer struct {
+	inner   logger.Logger
+	path    string
+	maxSize int64
+
+	mu        sync.Mutex
+	spool     *os.File
+	spoolSize int64
+	replaying bool
+	dropped   uint64
+	closed    bool
+
+	replayDone chan struct{}
+}
+
+// NewDiskBuffer wraps l with an on-disk spool at dir/<name>.spool, bounded
+// to maxSize bytes. Messages that would grow the spool beyond maxSize are
+// dropped and counted.
+func NewDiskBuffer(l logger.Logger, dir, name string, maxSize int64) (*DiskBuffer, error) {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, errors.Wrap(err, "error creating offline buffer directory")
+	}
+	path := filepath.Join(dir, name+".spool")
+	if err := recoverReplayFile(path); err != nil {
+		return nil, err
+	}
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_RDWR|os.O_APPEND, 0o600)
+	if err != nil {
+		return nil, errors.Wrap(err, "error opening offline buffer")
+	}
+	fi, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return nil, err
+	}
+	b := &DiskBuffer{
+		inner:     l,
+		path:      path,
+		maxSize:   maxSize,
+		spool:     f,
+		spoolSize: fi.Size(),
+	}
+	if b.spoolSize > 0 {
+		// Replay what a previous daemon run left behind.
+		b.startReplayLocked()
+	}
+	return b, nil
+}
+
+// recoverReplayFile merges a replay file left behind by an interrupted
+// replay back into the spool, preserving order: the replay file holds the
+// older messages.
+func recoverReplayFile(path string) error {
+	replayPath := path + ".replay"
+	replay, err := os.OpenFile(replayPath, os.O_RDWR|os.O_APPEND, 0o600)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+	defer replay.Close()
+	if spool, err := os.Open(path); err == nil {
+		_, err = io.Copy(replay, spool)
+		spool.Close()
+		if err != nil {
+			return err
+		}
+	} else if !os.IsNotExist(err) {
+		return err
+	}
+	return os.Rename(replayPath, path)
+}
+
+// Log sends the message to the wrapped logger, spooling it to disk when the
+// backend is unavailable or when earlier messages are still queued (so that
+// ordering is preserved).
+func (b *DiskBuffer) Log(msg *logger.Message) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.spoolSize > 0 || b.replaying {
+		return b.appendLocked(msg)
+	}
+	if err := b.inner.Log(msg); err != nil {
+		log.G(context.TODO()).WithError(err).WithField("driver", b.inner.Name()).Warn("logging backend unavailable; spooling log messages to disk")
+		if err := b.appendLocked(msg); err != nil {
+			return err
+		}
+		b.startReplayLocked()
+	}
+	return nil
+}
+
+// appendLocked writes msg to the spool file. The caller must hold b.mu.
+func (b *DiskBuffer) appendLocked(msg *logger.Message) error {
+	entry, err := json.Marshal(spooledMessage{
+		Line:      msg.Line,
+		Source:    msg.Source,
+		Timestamp: msg.Timestamp,
+	})
+	if err != nil {
+		return err
+	}
+	logger.PutMessage(msg)
+	if b.maxSize > 0 && b.spoolSize+int64(len(entry))+1 > b.maxSize {
+		b.dropped++
+		return nil
+	}
+	if _, err := b.spool.Write(append(entry, '\n')); err != nil {
+		return err
+	}
+	b.spoolSize += int64(len(entry)) + 1
+	return nil
+}
+
+// startReplayLocked launches the replay goroutine if it is not already
+// running. The caller must hold b.mu.
+func (b *DiskBuffer) startReplayLocked() {
+	if b.replaying {
+		return
+	}
+	b.replaying = true
+	b.replayDone = make(chan struct{})
+	go b.replay(b.replayDone)
+}
+
+// replay drains the spool into the wrapped logger in order, retrying with
+// backoff while the backend stays unavailable. New messages arriving during
+// a replay are appended to a fresh spool and drained in a later pass, so
+// ordering is preserved.
+func (b *DiskBuffer) replay(done chan<- struct{}) {
+	defer close(done)
+	for {
+		b.mu.Lock()
+		if b.closed || b.spoolSize == 0 {
+			b.replaying = false
+			b.mu.Unlock()
+			return
+		}
+		replayPath := b.path + ".replay"
+		if err := b.rotateLocked(replayPath); err != nil {
+			log.G(context.TODO()).WithError(err).Error("error rotating offline log buffer; abandoning replay")
+			b.replaying = false
+			b.mu.Unlock()
+			return
+		}
+		b.mu.Unlock()
+
+		if !b.replayFile(replayPath) {
+			return
+		}
+		_ = os.Remove(replayPath)
+	}
+}
+
+// rotateLocked moves the current spool to replayPath and starts a fresh
+// spool file. The caller must hold b.mu.
+func (b *DiskBuffer) rotateLocked(replayPath string) error {
+	b.spool.Close()
+	if err := os.Rename(b.path, replayPath); err != nil {
+		return err
+	}
+	f, err := os.OpenFile(b.path, os.O_CREATE|os.O_RDWR|os.O_APPEND, 0o600)
+	if err != nil {
+		return err
+	}
+	b.spool = f
+	b.spoolSize = 0
+	return nil
+}
+
+// replayFile sends every message in the file at path to the wrapped logger,
+// in order. It returns false when the buffer was closed before the file was
+// fully replayed.
+func (b *DiskBuffer) replayFile(path string) bool {
+	f, err := os.Open(path)
+	if err != nil {
+		log.G(context.TODO()).WithError(err).Error("error opening offline log buffer for replay")
+		return true
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(nil, 1024*1024)
+	backoff := replayMinBackoff
+	for scanner.Scan() {
+		var entry spooledMessage
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			// A torn write from an unclean shutdown; skip it.
+			continue
+		}
+		for {
+			msg := logger.NewMessage()
+			msg.Line = append(msg.Line, entry.Line...)
+			msg.Source = entry.Source
+			msg.Timestamp = entry.Timestamp
+			if err := b.inner.Log(msg); err == nil {
+				backoff = replayMinBackoff
+				break
+			}
+			b.mu.Lock()
+			closed := b.closed
+			b.mu.Unlock()
+			if closed {
+				return false
+			}
+			time.Sleep(backoff)
+			if backoff *= 2; backoff > replayMaxBackoff {
+				backoff = replayMaxBackoff
+			}
+		}
+	}
+	return true
+}
+
+func (b *DiskBuffer) Name() string {
+	return b.inner.Name()
+}
+
+// Close stops any replay in progress and closes the wrapped logger. A spool
+// that could not be fully replayed is left on disk so a later daemon run can
+// pick it up.
+func (b *DiskBuffer) Close() error {
+	b.mu.Lock()
+	b.closed = true
+	replayDone := b.replayDone
+	if b.dropped > 0 {
+		log.G(context.TODO()).WithField("driver", b.inner.Name()).Warnf("%d log messages dropped because the offline buffer was full", b.dropped)
+	}
+	b.mu.Unlock()
+	if replayDone != nil {
+		<-replayDone
+	}
+	b.mu.Lock()
+	b.spool.Close()
+	b.mu.Unlock()
+	return b.inner.Close()
+}
@@ -0,0 +1,103 @@
+package loggerutils
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/moby/moby/v2/daemon/logger"
+	"github.com/pkg/errors"
+	"gotest.tools/v3/assert"
+	"gotest.tools/v3/poll"
+)
+
+// flakyLogger fails Log calls while failing is set, and records delivered
+// lines otherwise.
+type flakyLogger struct {
+	mu      sync.Mutex
+	failing bool
+	lines   []string
+}
+
+func (l *flakyLogger) Log(msg *logger.Message) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.failing {
+		return errors.New("backend unavailable")
+	}
+	l.lines = append(l.lines, string(msg.Line))
+	logger.PutMessage(msg)
+	return nil
+}
+
+func (l *flakyLogger) Name() string { return "flaky" }
+
+func (l *flakyLogger) Close() error { return nil }
+
+func (l *flakyLogger) setFailing(failing bool) {
+	l.mu.Lock()
+	l.failing = failing
+	l.mu.Unlock()
+}
+
+func (l *flakyLogger) delivered() []string {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return append([]string(nil), l.lines...)
+}
+
+func message(line string) *logger.Message {
+	msg := logger.NewMessage()
+	msg.Line = append(msg.Line, line...)
+	msg.Source = "stdout"
+	msg.Timestamp = time.Now().UTC()
+	return msg
+}
+
+func TestDiskBufferReplay(t *testing.T) {
+	dir := t.TempDir()
+	inner := &flakyLogger{}
+
+	b, err := NewDiskBuffer(inner, dir, "ctr", 1024*1024)
+	assert.NilError(t, err)
+
+	assert.NilError(t, b.Log(message("one")))
+
+	inner.setFailing(true)
+	assert.NilError(t, b.Log(message("two")))
+	assert.NilError(t, b.Log(message("three")))
+
+	inner.setFailing(false)
+	poll.WaitOn(t, func(poll.LogT) poll.Result {
+		if len(inner.delivered()) == 3 {
+			return poll.Success()
+		}
+		return poll.Continue("waiting for replay, got %v", inner.delivered())
+	}, poll.WithTimeout(10*time.Second))
+
+	assert.DeepEqual(t, inner.delivered(), []string{"one", "two", "three"})
+	assert.NilError(t, b.Close())
+}
+
+func TestDiskBufferRecoversSpoolOnStart(t *testing.T) {
+	dir := t.TempDir()
+	inner := &flakyLogger{failing: true}
+
+	b, err := NewDiskBuffer(inner, dir, "ctr", 1024*1024)
+	assert.NilError(t, err)
+	assert.NilError(t, b.Log(message("spooled")))
+	assert.NilError(t, b.Close())
+
+	// A second buffer over the same spool replays what was left behind.
+	inner2 := &flakyLogger{}
+	b2, err := NewDiskBuffer(inner2, dir, "ctr", 1024*1024)
+	assert.NilError(t, err)
+	poll.WaitOn(t, func(poll.LogT) poll.Result {
+		if len(inner2.delivered()) == 1 {
+			return poll.Success()
+		}
+		return poll.Continue("waiting for replay")
+	}, poll.WithTimeout(10*time.Second))
+	assert.DeepEqual(t, inner2.delivered(), []string{"spooled"})
+	assert.NilError(t, b2.Close())
+}
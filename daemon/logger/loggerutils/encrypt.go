@@ -0,0 +1,390 @@
+package loggerutils
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"io/fs"
+	"os"
+	"time"
+
+	"github.com/containerd/containerd/v2/pkg/tracing"
+	"github.com/containerd/log"
+	"github.com/moby/moby/v2/pkg/pools"
+	"github.com/pkg/errors"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// Rotated log files can optionally be encrypted at rest with AES-GCM. The
+// on-disk format is a cleartext header followed by a sequence of
+// independently sealed chunks so that files larger than memory can be
+// written and read back in a streaming fashion:
+//
+//	magic (8 bytes) | flags (1 byte) | metadata length (uint16 BE) | metadata JSON
+//	repeated: chunk length (uint32 BE) | nonce | ciphertext
+//
+// The metadata is the same rotateFileMetadata stored in the gzip header of
+// compressed rotated files and is deliberately left in the clear so that
+// readers can skip files without the key material ever touching files they
+// do not need. Each chunk is sealed with its index as additional data, and
+// the stream ends with an empty sentinel chunk, so chunks cannot be
+// reordered and truncation — even at a chunk boundary — is detected.
+var encryptMagic = []byte("DLOGENC\x01")
+
+const (
+	// encryptFlagCompressed indicates that the plaintext is gzip-compressed.
+	encryptFlagCompressed byte = 1 << 0
+
+	// encryptChunkSize is the amount of plaintext sealed per chunk.
+	encryptChunkSize = 64 * 1024
+)
+
+// ReadEncryptKey reads an AES key from the file at path. The file may
+// contain either the raw key bytes or their hex encoding; the decoded key
+// must be 16, 24 or 32 bytes long.
+func ReadEncryptKey(path string) ([]byte, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "error reading log encryption key")
+	}
+	key := bytes.TrimSpace(raw)
+	if decoded, err := hex.DecodeString(string(key)); err == nil {
+		key = decoded
+	}
+	switch len(key) {
+	case 16, 24, 32:
+		return key, nil
+	default:
+		return nil, errors.Errorf("invalid log encryption key in %s: decoded key must be 16, 24 or 32 bytes, got %d", path, len(key))
+	}
+}
+
+func newLogAEAD(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid log encryption key")
+	}
+	return cipher.NewGCM(block)
+}
+
+// encryptWriter seals written data into length-prefixed AES-GCM chunks.
+type encryptWriter struct {
+	w     io.Writer
+	aead  cipher.AEAD
+	buf   []byte
+	chunk uint64
+}
+
+func (ew *encryptWriter) Write(p []byte) (int, error) {
+	written := len(p)
+	for len(p) > 0 {
+		n := encryptChunkSize - len(ew.buf)
+		if n > len(p) {
+			n = len(p)
+		}
+		ew.buf = append(ew.buf, p[:n]...)
+		p = p[n:]
+		if len(ew.buf) == encryptChunkSize {
+			if err := ew.flush(); err != nil {
+				return 0, err
+			}
+		}
+	}
+	return written, nil
+}
+
+func (ew *encryptWriter) flush() error {
+	if len(ew.buf) == 0 {
+		return nil
+	}
+	err := ew.seal(ew.buf)
+	ew.buf = ew.buf[:0]
+	return err
+}
+
+func (ew *encryptWriter) seal(plain []byte) error {
+	nonce := make([]byte, ew.aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return err
+	}
+	var aad [8]byte
+	binary.BigEndian.PutUint64(aad[:], ew.chunk)
+	sealed := ew.aead.Seal(nonce, nonce, plain, aad[:])
+
+	var hdr [4]byte
+	binary.BigEndian.PutUint32(hdr[:], uint32(len(sealed)))
+	if _, err := ew.w.Write(hdr[:]); err != nil {
+		return err
+	}
+	if _, err := ew.w.Write(sealed); err != nil {
+		return err
+	}
+	ew.chunk++
+	return nil
+}
+
+// Close flushes any buffered plaintext as a final, possibly short, chunk and
+// terminates the stream with an empty sentinel chunk, so that truncation at
+// a chunk boundary does not pass for a clean end of stream on read.
+func (ew *encryptWriter) Close() error {
+	if err := ew.flush(); err != nil {
+		return err
+	}
+	return ew.seal(nil)
+}
+
+// encryptReader is the inverse of encryptWriter: an io.Reader yielding the
+// plaintext of a chunked AES-GCM stream.
+type encryptReader struct {
+	r     io.Reader
+	aead  cipher.AEAD
+	plain []byte
+	chunk uint64
+	eof   bool
+}
+
+func (er *encryptReader) Read(p []byte) (int, error) {
+	for len(er.plain) == 0 {
+		if er.eof {
+			return 0, io.EOF
+		}
+		var hdr [4]byte
+		if _, err := io.ReadFull(er.r, hdr[:]); err != nil {
+			if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+				// The stream ended without the sentinel chunk: the file
+				// was cut off, possibly cleanly at a chunk boundary.
+				return 0, errors.New("encrypted log file is truncated")
+			}
+			return 0, err
+		}
+		n := binary.BigEndian.Uint32(hdr[:])
+		if n > encryptChunkSize+uint32(er.aead.NonceSize()+er.aead.Overhead()) {
+			return 0, errors.New("encrypted log file is corrupted: chunk too large")
+		}
+		sealed := make([]byte, n)
+		if _, err := io.ReadFull(er.r, sealed); err != nil {
+			return 0, errors.New("encrypted log file is truncated")
+		}
+		if len(sealed) < er.aead.NonceSize() {
+			return 0, errors.New("encrypted log file is corrupted: short chunk")
+		}
+		var aad [8]byte
+		binary.BigEndian.PutUint64(aad[:], er.chunk)
+		plain, err := er.aead.Open(nil, sealed[:er.aead.NonceSize()], sealed[er.aead.NonceSize():], aad[:])
+		if err != nil {
+			return 0, errors.Wrap(err, "error decrypting log file chunk")
+		}
+		er.chunk++
+		if len(plain) == 0 {
+			// The empty sentinel chunk marks the end of the stream.
+			er.eof = true
+			return 0, io.EOF
+		}
+		er.plain = plain
+	}
+	n := copy(p, er.plain)
+	er.plain = er.plain[n:]
+	return n, nil
+}
+
+func writeEncryptHeader(w io.Writer, flags byte, meta rotateFileMetadata) error {
+	metaJSON, err := json.Marshal(&meta)
+	if err != nil {
+		return err
+	}
+	if _, err := w.Write(encryptMagic); err != nil {
+		return err
+	}
+	var hdr [3]byte
+	hdr[0] = flags
+	binary.BigEndian.PutUint16(hdr[1:], uint16(len(metaJSON)))
+	if _, err := w.Write(hdr[:]); err != nil {
+		return err
+	}
+	_, err = w.Write(metaJSON)
+	return err
+}
+
+func readEncryptHeader(r io.Reader) (flags byte, meta rotateFileMetadata, _ error) {
+	magic := make([]byte, len(encryptMagic))
+	if _, err := io.ReadFull(r, magic); err != nil {
+		return 0, meta, errors.Wrap(err, "error reading encrypted log file header")
+	}
+	if !bytes.Equal(magic, encryptMagic) {
+		return 0, meta, errors.New("not an encrypted log file")
+	}
+	var hdr [3]byte
+	if _, err := io.ReadFull(r, hdr[:]); err != nil {
+		return 0, meta, errors.Wrap(err, "error reading encrypted log file header")
+	}
+	metaJSON := make([]byte, binary.BigEndian.Uint16(hdr[1:]))
+	if _, err := io.ReadFull(r, metaJSON); err != nil {
+		return 0, meta, errors.Wrap(err, "error reading encrypted log file metadata")
+	}
+	if err := json.Unmarshal(metaJSON, &meta); err != nil {
+		// Only an optimization is lost; the file can still be read.
+		log.G(context.TODO()).WithError(err).Warn("Failed to unmarshal encrypted log file metadata")
+	}
+	return hdr[0], meta, nil
+}
+
+// encryptFile encrypts the log file at fileName to fileName+".enc",
+// gzip-compressing the plaintext first when compress is set, and removes the
+// source file once the encrypted copy is fully written out. It is the
+// encrypting counterpart of compressFile and has the same crash-safety
+// properties for concurrent readers.
+func encryptFile(fileName string, key []byte, compress bool, lastTimestamp time.Time) (retErr error) {
+	file, err := open(fileName)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			log.G(context.TODO()).WithField("file", fileName).WithError(err).Debug("Could not open log file to encrypt")
+			return nil
+		}
+		return errors.Wrap(err, "failed to open log file")
+	}
+	defer func() {
+		file.Close()
+		if retErr == nil {
+			err := unlink(fileName)
+			if err != nil && !errors.Is(err, fs.ErrNotExist) {
+				retErr = errors.Wrap(err, "failed to remove source log file")
+			}
+		}
+	}()
+
+	aead, err := newLogAEAD(key)
+	if err != nil {
+		return err
+	}
+
+	outFile, err := openFile(fileName+".enc", os.O_CREATE|os.O_TRUNC|os.O_RDWR, 0o600)
+	if err != nil {
+		return errors.Wrap(err, "failed to open or create encrypted log file")
+	}
+	defer func() {
+		outFile.Close()
+		if retErr != nil {
+			if err := unlink(fileName + ".enc"); err != nil && !errors.Is(err, fs.ErrNotExist) {
+				log.G(context.TODO()).WithError(err).Error("Error cleaning up after failed log encryption")
+			}
+		}
+	}()
+
+	var flags byte
+	if compress {
+		flags |= encryptFlagCompressed
+	}
+	if err := writeEncryptHeader(outFile, flags, rotateFileMetadata{LastTime: lastTimestamp}); err != nil {
+		return errors.Wrap(err, "failed to write encrypted log file header")
+	}
+
+	ew := &encryptWriter{w: outFile, aead: aead}
+	var dst io.Writer = ew
+	var gzw *gzip.Writer
+	if compress {
+		gzw = gzip.NewWriter(ew)
+		dst = gzw
+	}
+	if _, err := pools.Copy(dst, file); err != nil {
+		return errors.Wrapf(err, "error encrypting log file %s", fileName)
+	}
+	if gzw != nil {
+		if err := gzw.Close(); err != nil {
+			return err
+		}
+	}
+	return ew.Close()
+}
+
+// decryptFile returns a converter function for sharedTempFileConverter which
+// decrypts (and, if applicable, decompresses) an encrypted rotated log file.
+func decryptFile(key []byte) fileConvertFn {
+	return func(dst io.WriteSeeker, src io.ReadSeeker) error {
+		if _, err := src.Seek(0, io.SeekStart); err != nil {
+			return err
+		}
+		aead, err := newLogAEAD(key)
+		if err != nil {
+			return err
+		}
+		flags, _, err := readEncryptHeader(src)
+		if err != nil {
+			return err
+		}
+		var rdr io.Reader = &encryptReader{r: src, aead: aead}
+		if flags&encryptFlagCompressed != 0 {
+			gzr, err := gzip.NewReader(rdr)
+			if err != nil {
+				return err
+			}
+			defer gzr.Close()
+			rdr = gzr
+		}
+		_, err = pools.Copy(dst, rdr)
+		return err
+	}
+}
+
+// encryptedFileOpener holds a reference to an encrypted log file and will
+// lazily open a decrypted version of the file.
+type encryptedFileOpener struct {
+	closed bool
+
+	f *os.File
+
+	lf       *LogFile
+	ifBefore time.Time
+}
+
+func (efo *encryptedFileOpener) ReaderAt(ctx context.Context) (_ sizeReaderAtCloser, retErr error) {
+	_, span := tracing.StartSpan(ctx, "logger.Logfile.Encrypted.ReaderAt")
+	defer func() {
+		if retErr != nil {
+			span.SetStatus(retErr)
+		}
+		span.End()
+	}()
+
+	span.SetAttributes(attribute.String("file", efo.f.Name()))
+
+	if efo.closed {
+		return nil, errors.New("encrypted file closed")
+	}
+	if efo.lf.decrypt == nil {
+		return nil, errors.Errorf("log file %s is encrypted and no encryption key is configured", efo.f.Name())
+	}
+
+	if _, err := efo.f.Seek(0, io.SeekStart); err != nil {
+		return nil, err
+	}
+	// Use the cleartext metadata to determine if we even need to read this
+	// file based on inputs, like the compressed-file opener does with the
+	// gzip header.
+	_, meta, err := readEncryptHeader(efo.f)
+	if err == nil && !meta.LastTime.IsZero() && meta.LastTime.Before(efo.ifBefore) {
+		span.SetAttributes(attribute.Bool("skip", true))
+		return &sizeReaderAtWithCloser{}, nil
+	}
+	if err == nil {
+		span.SetAttributes(attribute.Stringer("lastLogTime", meta.LastTime))
+	}
+
+	span.AddEvent("Start decrypt")
+	return efo.lf.decrypt.Do(efo.f)
+}
+
+func (efo *encryptedFileOpener) Close() {
+	efo.closed = true
+	efo.f.Close()
+}
+
+func (efo *encryptedFileOpener) Ref() string {
+	return efo.f.Name()
+}
@@ -0,0 +1,142 @@
+package loggerutils
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/moby/moby/v2/daemon/logger"
+	"github.com/moby/moby/v2/pkg/tailfile"
+	"gotest.tools/v3/assert"
+	"gotest.tools/v3/poll"
+)
+
+func testEncryptKey() []byte {
+	key := make([]byte, 32)
+	for i := range key {
+		key[i] = byte(i)
+	}
+	return key
+}
+
+func TestEncryptFileRoundTrip(t *testing.T) {
+	key := testEncryptKey()
+
+	for _, compress := range []bool{false, true} {
+		name := "uncompressed"
+		if compress {
+			name = "compressed"
+		}
+		t.Run(name, func(t *testing.T) {
+			dir := t.TempDir()
+			fileName := filepath.Join(dir, "container.log.1")
+			// More than one chunk's worth of data to exercise the
+			// chunked format.
+			content := bytes.Repeat([]byte("hello world\n"), 3*encryptChunkSize/12)
+			assert.NilError(t, os.WriteFile(fileName, content, 0o600))
+
+			assert.NilError(t, encryptFile(fileName, key, compress, time.Now()))
+			_, err := os.Stat(fileName)
+			assert.Assert(t, os.IsNotExist(err), "source file should be removed after encryption")
+
+			src, err := os.Open(fileName + ".enc")
+			assert.NilError(t, err)
+			defer src.Close()
+
+			dst, err := os.CreateTemp(dir, "decrypted")
+			assert.NilError(t, err)
+			defer dst.Close()
+
+			assert.NilError(t, decryptFile(key)(dst, src))
+			decrypted, err := os.ReadFile(dst.Name())
+			assert.NilError(t, err)
+			assert.Assert(t, bytes.Equal(decrypted, content), "decrypted content does not match original")
+
+			wrongKey := testEncryptKey()
+			wrongKey[0] ^= 0xff
+			dst2, err := os.CreateTemp(dir, "decrypted")
+			assert.NilError(t, err)
+			defer dst2.Close()
+			assert.ErrorContains(t, decryptFile(wrongKey)(dst2, src), "error decrypting log file chunk")
+		})
+	}
+}
+
+func TestEncryptFileDetectsTruncation(t *testing.T) {
+	key := testEncryptKey()
+	dir := t.TempDir()
+	fileName := filepath.Join(dir, "container.log.1")
+	content := bytes.Repeat([]byte("hello world\n"), encryptChunkSize/12)
+	assert.NilError(t, os.WriteFile(fileName, content, 0o600))
+	assert.NilError(t, encryptFile(fileName, key, false, time.Now()))
+
+	enc, err := os.ReadFile(fileName + ".enc")
+	assert.NilError(t, err)
+
+	// Cut the stream at the final chunk boundary by stripping the empty
+	// sentinel chunk; without the sentinel this would read back as a clean
+	// end of stream.
+	aead, err := newLogAEAD(key)
+	assert.NilError(t, err)
+	sentinelLen := 4 + aead.NonceSize() + aead.Overhead()
+	assert.NilError(t, os.WriteFile(fileName+".enc", enc[:len(enc)-sentinelLen], 0o600))
+
+	src, err := os.Open(fileName + ".enc")
+	assert.NilError(t, err)
+	defer src.Close()
+	dst, err := os.CreateTemp(dir, "decrypted")
+	assert.NilError(t, err)
+	defer dst.Close()
+	assert.ErrorContains(t, decryptFile(key)(dst, src), "encrypted log file is truncated")
+}
+
+func TestEncryptedRotateAndRead(t *testing.T) {
+	dir := t.TempDir()
+	logPath := filepath.Join(dir, "log")
+
+	getTailReader := func(ctx context.Context, r SizeReaderAt, lines int, maxBytes int64) (SizeReaderAt, int, error) {
+		return tailfile.NewTailReader(ctx, r, lines)
+	}
+	createDecoder := func(rdr io.Reader) Decoder {
+		return &testDecoder{scanner: bufio.NewScanner(rdr)}
+	}
+	l, err := NewLogFile(
+		logPath,
+		5,                // capacity
+		3,                // maxFiles
+		false,            // compress
+		testEncryptKey(), // encryptKey
+		createDecoder,
+		0o600, // perms
+		getTailReader,
+	)
+	assert.NilError(t, err)
+	defer l.Close()
+
+	ls := dirStringer{dir}
+	timestamp := time.Time{}
+
+	assert.NilError(t, l.WriteLogEntry(timestamp, []byte("line 1\n")), ls)
+	assert.NilError(t, l.WriteLogEntry(timestamp, []byte("line 2\n")), ls)
+	poll.WaitOn(t, checkFileExists(logPath+".1.enc"), poll.WithDelay(time.Millisecond), poll.WithTimeout(30*time.Second))
+
+	assert.NilError(t, l.WriteLogEntry(timestamp, []byte("line 3\n")), ls)
+	poll.WaitOn(t, checkFileExists(logPath+".2.enc"), poll.WithDelay(time.Millisecond), poll.WithTimeout(30*time.Second))
+
+	// The rotated files on disk must not contain the plaintext.
+	enc, err := os.ReadFile(logPath + ".2.enc")
+	assert.NilError(t, err)
+	assert.Assert(t, !bytes.Contains(enc, []byte("line 1")), "rotated log file contains plaintext")
+
+	// Reading back decrypts transparently.
+	lw := l.ReadLogs(context.TODO(), logger.ReadConfig{Tail: 1000})
+	defer lw.ConsumerGone()
+	waitForMsg(t, lw, "line 1", 30*time.Second)
+	waitForMsg(t, lw, "line 2", 30*time.Second)
+	waitForMsg(t, lw, "line 3", 30*time.Second)
+}
@@ -40,10 +40,11 @@ type LogFile struct {
 
 	// Logger configuration
 
-	capacity int64 // maximum size of each file
-	maxFiles int   // maximum number of files
-	compress bool  // whether old versions of log files are compressed
-	perms    os.FileMode
+	capacity   int64  // maximum size of each file
+	maxFiles   int    // maximum number of files
+	compress   bool   // whether old versions of log files are compressed
+	encryptKey []byte // when set, old versions of log files are encrypted at rest
+	perms      os.FileMode
 
 	// Log file codec
 
@@ -67,6 +68,7 @@ type LogFile struct {
 	read chan logReadState
 
 	decompress *sharedTempFileConverter
+	decrypt    *sharedTempFileConverter // nil unless encryptKey is set
 
 	pos           logPos    // Current log file write position.
 	f             *os.File  // Current log file for writing.
@@ -111,13 +113,22 @@ type SizeReaderAt interface {
 }
 
 // GetTailReaderFunc is used to truncate a reader to only read as much as is required
-// in order to get the passed in number of log lines.
+// in order to get the passed in number of log lines, limited to at most the last
+// maxBytes bytes of the reader. A nLogLines of 0 means no line limit and a
+// maxBytes <= 0 means no byte limit.
 // It returns the sectioned reader, the number of lines that the section reader
 // contains, and any error that occurs.
-type GetTailReaderFunc func(ctx context.Context, f SizeReaderAt, nLogLines int) (rdr SizeReaderAt, nLines int, err error)
-
-// NewLogFile creates new LogFile
-func NewLogFile(logPath string, capacity int64, maxFiles int, compress bool, decodeFunc MakeDecoderFn, perms os.FileMode, getTailReader GetTailReaderFunc) (*LogFile, error) {
+type GetTailReaderFunc func(ctx context.Context, f SizeReaderAt, nLogLines int, maxBytes int64) (rdr SizeReaderAt, nLines int, err error)
+
+// NewLogFile creates new LogFile. When encryptKey is non-nil, rotated log
+// files are encrypted at rest with AES-GCM using the given key.
+func NewLogFile(logPath string, capacity int64, maxFiles int, compress bool, encryptKey []byte, decodeFunc MakeDecoderFn, perms os.FileMode, getTailReader GetTailReaderFunc) (*LogFile, error) {
+	if encryptKey != nil {
+		// Catch malformed keys up front rather than at first rotation.
+		if _, err := newLogAEAD(encryptKey); err != nil {
+			return nil, err
+		}
+	}
 	logFile, err := openFile(logPath, os.O_WRONLY|os.O_APPEND|os.O_CREATE, perms)
 	if err != nil {
 		return nil, err
@@ -137,7 +148,7 @@ func NewLogFile(logPath string, capacity int64, maxFiles int, compress bool, dec
 	st := make(chan logReadState, 1)
 	st <- logReadState{pos: pos}
 
-	return &LogFile{
+	lf := &LogFile{
 		f:             logFile,
 		read:          st,
 		pos:           pos,
@@ -145,11 +156,16 @@ func NewLogFile(logPath string, capacity int64, maxFiles int, compress bool, dec
 		capacity:      capacity,
 		maxFiles:      maxFiles,
 		compress:      compress,
+		encryptKey:    encryptKey,
 		decompress:    newSharedTempFileConverter(decompress),
 		createDecoder: decodeFunc,
 		perms:         perms,
 		getTailReader: getTailReader,
-	}, nil
+	}
+	if encryptKey != nil {
+		lf.decrypt = newSharedTempFileConverter(decryptFile(encryptKey))
+	}
+	return lf, nil
 }
 
 // WriteLogEntry writes the provided log message to the current log file.
@@ -193,13 +209,46 @@ func (w *LogFile) WriteLogEntry(timestamp time.Time, marshalled []byte) error {
 	return nil
 }
 
+// Rotate forces the current log file to be rotated out, regardless of its
+// size. Processing of the rotated file (compression or encryption) happens
+// asynchronously, as for size-triggered rotations.
+func (w *LogFile) Rotate() error {
+	select {
+	case <-w.closed:
+		return errors.New("cannot rotate because the output file was closed")
+	default:
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.rotate()
+}
+
+// SetLimits updates the rotation limits applied to future writes. A maxSize
+// of 0 leaves the size limit unchanged and -1 removes it; a maxFiles of 0
+// leaves the number of retained files unchanged.
+func (w *LogFile) SetLimits(maxSize int64, maxFiles int) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if maxSize != 0 {
+		w.capacity = maxSize
+	}
+	if maxFiles > 0 {
+		// maxFiles is read during the asynchronous part of a rotation;
+		// take rotateMu so an in-flight rotation completes with a
+		// consistent value.
+		w.rotateMu.Lock()
+		w.maxFiles = maxFiles
+		w.rotateMu.Unlock()
+	}
+}
+
 func (w *LogFile) rotate() (retErr error) {
 	w.rotateMu.Lock()
-	noCompress := w.maxFiles <= 1 || !w.compress
+	noProcess := w.maxFiles <= 1 || (!w.compress && w.encryptKey == nil)
 	defer func() {
-		// If we aren't going to run the goroutine to compress the log file, then we need to unlock in this function.
-		// Otherwise the lock will be released in the goroutine that handles compression.
-		if retErr != nil || noCompress {
+		// If we aren't going to run the goroutine to compress or encrypt the log file, then we need to unlock in this function.
+		// Otherwise the lock will be released in the goroutine that handles the processing.
+		if retErr != nil || noProcess {
 			w.rotateMu.Unlock()
 		}
 	}()
@@ -216,7 +265,7 @@ func (w *LogFile) rotate() (retErr error) {
 		w.fsopMu.Lock()
 		defer w.fsopMu.Unlock()
 
-		if err := rotate(fname, w.maxFiles, w.compress); err != nil {
+		if err := rotate(fname, w.maxFiles, w.rotatedFileExt()); err != nil {
 			log.G(context.TODO()).WithError(err).Warn("Error rotating log file, log data may have been lost")
 		} else {
 			// We may have readers working their way through the
@@ -245,7 +294,7 @@ func (w *LogFile) rotate() (retErr error) {
 	w.f = file
 	w.pos = logPos{rotation: w.pos.rotation + 1}
 
-	if noCompress {
+	if noProcess {
 		return nil
 	}
 
@@ -254,12 +303,16 @@ func (w *LogFile) rotate() (retErr error) {
 		defer w.rotateMu.Unlock()
 		// No need to hold fsopMu as at no point will the filesystem be
 		// in a state which would cause problems for readers. Opening
-		// the uncompressed file is tried first, falling back to the
-		// compressed one. compressFile only deletes the uncompressed
-		// file once the compressed one is fully written out, so at no
-		// point during the compression process will a reader fail to
-		// open a complete copy of the file.
-		if err := compressFile(fname+".1", ts); err != nil {
+		// the unprocessed file is tried first, falling back to the
+		// compressed or encrypted one. compressFile and encryptFile
+		// only delete the source file once the processed one is fully
+		// written out, so at no point will a reader fail to open a
+		// complete copy of the file.
+		if w.encryptKey != nil {
+			if err := encryptFile(fname+".1", w.encryptKey, w.compress, ts); err != nil {
+				log.G(context.TODO()).WithError(err).Error("Error encrypting log file after rotation")
+			}
+		} else if err := compressFile(fname+".1", ts); err != nil {
 			log.G(context.TODO()).WithError(err).Error("Error compressing log file after rotation")
 		}
 	}()
@@ -267,14 +320,21 @@ func (w *LogFile) rotate() (retErr error) {
 	return nil
 }
 
-func rotate(name string, maxFiles int, compress bool) error {
-	if maxFiles < 2 {
-		return nil
+// rotatedFileExt returns the filename extension which fully rotated log
+// files carry, depending on how they are post-processed.
+func (w *LogFile) rotatedFileExt() string {
+	if w.encryptKey != nil {
+		return ".enc"
+	}
+	if w.compress {
+		return ".gz"
 	}
+	return ""
+}
 
-	var extension string
-	if compress {
-		extension = ".gz"
+func rotate(name string, maxFiles int, extension string) error {
+	if maxFiles < 2 {
+		return nil
 	}
 
 	lastFile := fmt.Sprintf("%s.%d%s", name, maxFiles-1, extension)
@@ -425,7 +485,7 @@ func (w *LogFile) tailFiles(ctx context.Context, config logger.ReadConfig, watch
 		files = append(files, &sizeReaderAtOpener{current, "current"})
 	}
 
-	return tailFiles(ctx, files, watcher, dec, w.getTailReader, config.Tail, fwd)
+	return tailFiles(ctx, files, watcher, dec, w.getTailReader, config.Tail, config.TailBytes, fwd)
 }
 
 type sizeReaderAtOpener struct {
@@ -679,6 +739,18 @@ func (w *LogFile) openRotatedFile(ctx context.Context, i int, config logger.Read
 		return nil, errors.Wrap(err, "error opening rotated log file")
 	}
 
+	f, err = open(fmt.Sprintf("%s.%d.enc", w.f.Name(), i))
+	if err == nil {
+		return &encryptedFileOpener{
+			f:        f,
+			lf:       w,
+			ifBefore: config.Since,
+		}, nil
+	}
+	if !errors.Is(err, fs.ErrNotExist) {
+		return nil, errors.Wrap(err, "error opening file for decryption")
+	}
+
 	f, err = open(fmt.Sprintf("%s.%d.gz", w.f.Name(), i))
 	if err != nil {
 		if !errors.Is(err, fs.ErrNotExist) {
@@ -703,9 +775,9 @@ type sizeReaderAtCloser interface {
 	io.Closer
 }
 
-func getTailFiles(ctx context.Context, files []fileOpener, nLines int, getTailReader GetTailReaderFunc) (_ []sizeReaderAtCloser, retErr error) {
+func getTailFiles(ctx context.Context, files []fileOpener, nLines int, nBytes int64, getTailReader GetTailReaderFunc) (_ []sizeReaderAtCloser, retErr error) {
 	ctx, span := tracing.StartSpan(ctx, "logger.Logfile.CollectTailFiles")
-	span.SetAttributes(attribute.Int("requested_lines", nLines))
+	span.SetAttributes(attribute.Int("requested_lines", nLines), attribute.Int64("requested_bytes", nBytes))
 
 	defer func() {
 		if retErr != nil {
@@ -725,7 +797,7 @@ func getTailFiles(ctx context.Context, files []fileOpener, nLines int, getTailRe
 		}
 	}()
 
-	if nLines <= 0 {
+	if nLines <= 0 && nBytes <= 0 {
 		for _, fo := range files {
 			span.AddEvent("Open file", attribute.String("file", fo.Ref()))
 
@@ -739,7 +811,14 @@ func getTailFiles(ctx context.Context, files []fileOpener, nLines int, getTailRe
 		return out, nil
 	}
 
-	for i := len(files) - 1; i >= 0 && nLines > 0; i-- {
+	limitLines, limitBytes := nLines > 0, nBytes > 0
+	for i := len(files) - 1; i >= 0; i-- {
+		if limitLines && nLines <= 0 {
+			break
+		}
+		if limitBytes && nBytes <= 0 {
+			break
+		}
 		if err := ctx.Err(); err != nil {
 			return nil, errors.Wrap(err, "stopping parsing files to tail due to error")
 		}
@@ -754,15 +833,24 @@ func getTailFiles(ctx context.Context, files []fileOpener, nLines int, getTailRe
 			return nil, err
 		}
 
-		span.AddEvent("Scan file to tail", fileAttr, attribute.Int("remaining_lines", nLines))
+		span.AddEvent("Scan file to tail", fileAttr, attribute.Int("remaining_lines", nLines), attribute.Int64("remaining_bytes", nBytes))
 
-		tail, n, err := getTailReader(ctx, ra, nLines)
+		reqLines := 0
+		if limitLines {
+			reqLines = nLines
+		}
+		var reqBytes int64
+		if limitBytes {
+			reqBytes = nBytes
+		}
+		tail, n, err := getTailReader(ctx, ra, reqLines, reqBytes)
 		if err != nil {
 			ra.Close()
 			log.G(ctx).WithError(err).Warn("Error scanning log file for tail file request, skipping")
 			continue
 		}
 		nLines -= n
+		nBytes -= tail.Size()
 		out = append(out, &sizeReaderAtWithCloser{tail, ra.Close})
 	}
 
@@ -771,7 +859,7 @@ func getTailFiles(ctx context.Context, files []fileOpener, nLines int, getTailRe
 	return out, nil
 }
 
-func tailFiles(ctx context.Context, files []fileOpener, watcher *logger.LogWatcher, dec Decoder, getTailReader GetTailReaderFunc, nLines int, fwd *forwarder) (cont bool) {
+func tailFiles(ctx context.Context, files []fileOpener, watcher *logger.LogWatcher, dec Decoder, getTailReader GetTailReaderFunc, nLines int, nBytes int64, fwd *forwarder) (cont bool) {
 	ctx, cancel := context.WithCancel(ctx)
 	defer cancel()
 
@@ -783,7 +871,7 @@ func tailFiles(ctx context.Context, files []fileOpener, watcher *logger.LogWatch
 		}
 	}()
 
-	readers, err := getTailFiles(ctx, files, nLines, getTailReader)
+	readers, err := getTailFiles(ctx, files, nLines, nBytes, getTailReader)
 	if err != nil {
 		watcher.Err <- err
 		return false
@@ -844,10 +932,19 @@ func tailFiles(ctx context.Context, files []fileOpener, watcher *logger.LogWatch
 
 type forwarder struct {
 	since, until time.Time
+	// streams filters messages by their Source; nil means all streams.
+	streams map[string]struct{}
 }
 
 func newForwarder(config logger.ReadConfig) *forwarder {
-	return &forwarder{since: config.Since, until: config.Until}
+	fwd := &forwarder{since: config.Since, until: config.Until}
+	if len(config.Streams) > 0 {
+		fwd.streams = make(map[string]struct{}, len(config.Streams))
+		for _, s := range config.Streams {
+			fwd.streams[s] = struct{}{}
+		}
+	}
+	return fwd
 }
 
 // Do reads log messages from dec and sends the messages matching the filter
@@ -897,6 +994,11 @@ func (fwd *forwarder) Do(ctx context.Context, watcher *logger.LogWatcher, next f
 			log.G(ctx).Debug("Log is newer than requested window, skipping remaining logs")
 			return false
 		}
+		if fwd.streams != nil {
+			if _, ok := fwd.streams[msg.Source]; !ok {
+				continue
+			}
+		}
 
 		select {
 		case <-ctx.Done():
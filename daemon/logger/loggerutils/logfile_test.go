@@ -89,7 +89,7 @@ func TestTailFiles(t *testing.T) {
 	watcher := logger.NewLogWatcher()
 	defer watcher.ConsumerGone()
 
-	tailReader := func(ctx context.Context, r SizeReaderAt, lines int) (SizeReaderAt, int, error) {
+	tailReader := func(ctx context.Context, r SizeReaderAt, lines int, maxBytes int64) (SizeReaderAt, int, error) {
 		return tailfile.NewTailReader(ctx, r, lines)
 	}
 	dec := &testDecoder{}
@@ -99,7 +99,7 @@ func TestTailFiles(t *testing.T) {
 	started := make(chan struct{})
 	go func() {
 		close(started)
-		tailFiles(context.TODO(), files, watcher, dec, tailReader, config.Tail, fwd)
+		tailFiles(context.TODO(), files, watcher, dec, tailReader, config.Tail, config.TailBytes, fwd)
 	}()
 	<-started
 
@@ -167,7 +167,7 @@ func TestTailFiles(t *testing.T) {
 		done := make(chan struct{})
 		go func() {
 			close(started)
-			tailFiles(context.TODO(), files, watcher, &testJSONStreamDecoder{}, tailReader, config.Tail, fwd)
+			tailFiles(context.TODO(), files, watcher, &testJSONStreamDecoder{}, tailReader, config.Tail, config.TailBytes, fwd)
 			close(done)
 		}()
 
@@ -206,7 +206,7 @@ func TestCheckCapacityAndRotate(t *testing.T) {
 	dir := t.TempDir()
 
 	logPath := filepath.Join(dir, "log")
-	getTailReader := func(ctx context.Context, r SizeReaderAt, lines int) (SizeReaderAt, int, error) {
+	getTailReader := func(ctx context.Context, r SizeReaderAt, lines int, maxBytes int64) (SizeReaderAt, int, error) {
 		return tailfile.NewTailReader(ctx, r, lines)
 	}
 	createDecoder := func(io.Reader) Decoder {
@@ -217,6 +217,7 @@ func TestCheckCapacityAndRotate(t *testing.T) {
 		5,    // capacity
 		3,    // maxFiles
 		true, // compress
+		nil,  // encryptKey
 		createDecoder,
 		0o600, // perms
 		getTailReader,
@@ -265,6 +266,53 @@ func TestCheckCapacityAndRotate(t *testing.T) {
 	})
 }
 
+func TestForceRotate(t *testing.T) {
+	dir := t.TempDir()
+
+	logPath := filepath.Join(dir, "log")
+	getTailReader := func(ctx context.Context, r SizeReaderAt, lines int, maxBytes int64) (SizeReaderAt, int, error) {
+		return tailfile.NewTailReader(ctx, r, lines)
+	}
+	createDecoder := func(io.Reader) Decoder {
+		return dummyDecoder{}
+	}
+	l, err := NewLogFile(
+		logPath,
+		-1,    // capacity
+		2,     // maxFiles
+		false, // compress
+		nil,   // encryptKey
+		createDecoder,
+		0o600, // perms
+		getTailReader,
+	)
+	assert.NilError(t, err)
+	defer l.Close()
+
+	ls := dirStringer{dir}
+
+	timestamp := time.Time{}
+
+	// No capacity limit is set, so writes should never trigger a rotation
+	// on their own.
+	assert.NilError(t, l.WriteLogEntry(timestamp, []byte("hello world!")))
+	_, err = os.Stat(logPath + ".1")
+	assert.Assert(t, os.IsNotExist(err), ls)
+
+	assert.NilError(t, l.Rotate())
+	_, err = os.Stat(logPath + ".1")
+	assert.NilError(t, err, ls)
+
+	// Lowering the size limit should make the next write rotate again.
+	l.SetLimits(5, 0)
+	assert.NilError(t, l.WriteLogEntry(timestamp, []byte("hello world!")))
+	assert.NilError(t, l.WriteLogEntry(timestamp, []byte("hello world!")))
+	poll.WaitOn(t, checkFileExists(logPath+".1"), poll.WithDelay(time.Millisecond), poll.WithTimeout(30*time.Second))
+
+	assert.NilError(t, l.Close())
+	assert.ErrorContains(t, l.Rotate(), "closed")
+}
+
 func waitForMsg(t *testing.T, lw *logger.LogWatcher, expected string, timeout time.Duration) {
 	t.Helper()
 
@@ -40,6 +40,10 @@ func (daemon *Daemon) ContainerLogs(ctx context.Context, containerName string, c
 	if !config.ShowStdout && !config.ShowStderr {
 		return nil, false, errdefs.InvalidParameter(errors.New("You must choose at least one stream"))
 	}
+	filt, err := newLogsFilter(config.Grep, config.Fields)
+	if err != nil {
+		return nil, false, err
+	}
 	ctr, err := daemon.GetContainer(containerName)
 	if err != nil {
 		return nil, false, err
@@ -77,6 +81,27 @@ func (daemon *Daemon) ContainerLogs(ctx context.Context, containerName string, c
 		tailLines = -1
 	}
 
+	var tailBytes int64
+	if config.TailBytes != "" {
+		tailBytes, err = strconv.ParseInt(config.TailBytes, 10, 64)
+		if err != nil || tailBytes < 0 {
+			return nil, false, errdefs.InvalidParameter(errors.Errorf("invalid value for tail-bytes: %s", config.TailBytes))
+		}
+	}
+
+	// Select the requested streams in the log reader so that drivers
+	// supporting it do not have to decode and ship messages which would be
+	// discarded anyway.
+	var streams []string
+	if !config.ShowStdout || !config.ShowStderr {
+		if config.ShowStdout {
+			streams = append(streams, "stdout")
+		}
+		if config.ShowStderr {
+			streams = append(streams, "stderr")
+		}
+	}
+
 	var since time.Time
 	if config.Since != "" {
 		s, n, err := timetypes.ParseTimestamps(config.Since, 0)
@@ -97,10 +122,12 @@ func (daemon *Daemon) ContainerLogs(ctx context.Context, containerName string, c
 
 	follow := config.Follow && !cLogCreated
 	logs := logReader.ReadLogs(ctx, logger.ReadConfig{
-		Since:  since,
-		Until:  until,
-		Tail:   tailLines,
-		Follow: follow,
+		Since:     since,
+		Until:     until,
+		Tail:      tailLines,
+		TailBytes: tailBytes,
+		Streams:   streams,
+		Follow:    follow,
 	})
 
 	// past this point, we can't possibly return any errors, so we can just
@@ -150,6 +177,19 @@ func (daemon *Daemon) ContainerLogs(ctx context.Context, containerName string, c
 				}
 				m := msg.AsLogMessage() // just a pointer conversion, does not copy data
 
+				// Not all log drivers select streams in their reader;
+				// drop messages from unselected streams here.
+				if (m.Source == "stdout" && !config.ShowStdout) || (m.Source == "stderr" && !config.ShowStderr) {
+					continue
+				}
+
+				if filt != nil {
+					var keep bool
+					if m, keep = filt.filter(m); !keep {
+						continue
+					}
+				}
+
 				// there could be a case where the reader stops accepting
 				// messages and the context is canceled. we need to check that
 				// here, or otherwise we risk blocking forever on the message
@@ -165,6 +205,51 @@ func (daemon *Daemon) ContainerLogs(ctx context.Context, containerName string, c
 	return messageChan, ctr.Config.Tty, nil
 }
 
+// ContainerLogsRotate updates the log rotation limits of a running container
+// and forces an immediate rotation of its current log file. Updated limits are
+// persisted to the container's log configuration so they survive a restart.
+func (daemon *Daemon) ContainerLogsRotate(name string, config backend.ContainerLogsRotateConfig) error {
+	ctr, err := daemon.GetContainer(name)
+	if err != nil {
+		return err
+	}
+
+	ctr.Lock()
+	defer ctr.Unlock()
+
+	if !ctr.State.Running || ctr.LogDriver == nil {
+		return errdefs.Conflict(errors.New("container is not running"))
+	}
+
+	rotator, ok := ctr.LogDriver.(logger.Rotator)
+	if !ok {
+		return errdefs.NotImplemented(errors.Errorf("log driver %s does not support rotation", ctr.HostConfig.LogConfig.Type))
+	}
+
+	if config.MaxSize != 0 || config.MaxFiles != 0 {
+		rotator.SetRotationPolicy(config.MaxSize, config.MaxFiles)
+		if ctr.HostConfig.LogConfig.Config == nil {
+			ctr.HostConfig.LogConfig.Config = make(map[string]string)
+		}
+		if config.MaxSize == -1 {
+			delete(ctr.HostConfig.LogConfig.Config, "max-size")
+		} else if config.MaxSize > 0 {
+			ctr.HostConfig.LogConfig.Config["max-size"] = strconv.FormatInt(config.MaxSize, 10)
+		}
+		if config.MaxFiles > 0 {
+			ctr.HostConfig.LogConfig.Config["max-file"] = strconv.Itoa(config.MaxFiles)
+		}
+		if err := ctr.CheckpointTo(context.TODO(), daemon.containersReplica); err != nil {
+			return errdefs.System(err)
+		}
+	}
+
+	if err := rotator.Rotate(); err != nil {
+		return errdefs.System(errors.Wrap(err, "error rotating log file"))
+	}
+	return nil
+}
+
 func (daemon *Daemon) getLogger(container *container.Container) (_ logger.Logger, created bool, _ error) {
 	var logDriver logger.Logger
 	container.Lock()
@@ -0,0 +1,124 @@
+package daemon
+
+import (
+	"bytes"
+	"encoding/json"
+	"regexp"
+	"strings"
+
+	"github.com/moby/moby/v2/daemon/server/backend"
+	"github.com/moby/moby/v2/errdefs"
+	"github.com/pkg/errors"
+)
+
+// logsFilter performs server-side filtering and field extraction on log
+// messages before they are sent to the client, so that retrieving a few
+// matching lines from a large log does not require transferring all of it.
+type logsFilter struct {
+	grep   *regexp.Regexp
+	fields []logField
+}
+
+// logField is a parsed dotted field path, e.g. ".request.id".
+type logField struct {
+	name string
+	path []string
+}
+
+// newLogsFilter builds a logsFilter from the grep and fields parameters of a
+// logs request. It returns nil when neither is set.
+func newLogsFilter(grep, fields string) (*logsFilter, error) {
+	if grep == "" && fields == "" {
+		return nil, nil
+	}
+	f := &logsFilter{}
+	if grep != "" {
+		re, err := regexp.Compile(grep)
+		if err != nil {
+			return nil, errdefs.InvalidParameter(errors.Wrap(err, "invalid regular expression for grep"))
+		}
+		f.grep = re
+	}
+	for _, field := range strings.Split(fields, ",") {
+		field = strings.TrimSpace(field)
+		if field == "" {
+			continue
+		}
+		name := strings.TrimPrefix(field, ".")
+		if name == "" {
+			return nil, errdefs.InvalidParameter(errors.Errorf("invalid field selector %q", field))
+		}
+		f.fields = append(f.fields, logField{name: name, path: strings.Split(name, ".")})
+	}
+	return f, nil
+}
+
+// filter applies the configured grep expression and field selection to m. It
+// returns the message to send to the client, or false when the message should
+// be dropped. The returned message may be m itself or a copy; m is never
+// modified.
+func (f *logsFilter) filter(m *backend.LogMessage) (*backend.LogMessage, bool) {
+	if f.grep != nil && !f.grep.Match(m.Line) {
+		return nil, false
+	}
+	if len(f.fields) == 0 {
+		return m, true
+	}
+	line, ok := f.extract(m.Line)
+	if !ok {
+		return nil, false
+	}
+	out := *m
+	out.Line = line
+	return &out, true
+}
+
+// extract builds a JSON object containing only the selected fields of line.
+// It returns false when line is not a JSON object or none of the selected
+// fields are present.
+func (f *logsFilter) extract(line []byte) ([]byte, bool) {
+	var doc map[string]any
+	if err := json.Unmarshal(bytes.TrimSpace(line), &doc); err != nil {
+		return nil, false
+	}
+	buf := bytes.NewBuffer(nil)
+	buf.WriteByte('{')
+	found := false
+	for _, field := range f.fields {
+		v, ok := lookupField(doc, field.path)
+		if !ok {
+			continue
+		}
+		val, err := json.Marshal(v)
+		if err != nil {
+			continue
+		}
+		if found {
+			buf.WriteByte(',')
+		}
+		key, _ := json.Marshal(field.name)
+		buf.Write(key)
+		buf.WriteByte(':')
+		buf.Write(val)
+		found = true
+	}
+	if !found {
+		return nil, false
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), true
+}
+
+func lookupField(doc map[string]any, path []string) (any, bool) {
+	var v any = doc
+	for _, key := range path {
+		m, ok := v.(map[string]any)
+		if !ok {
+			return nil, false
+		}
+		if v, ok = m[key]; !ok {
+			return nil, false
+		}
+	}
+	return v, true
+}
@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	containertypes "github.com/moby/moby/api/types/container"
+	"github.com/moby/moby/v2/daemon/server/backend"
 )
 
 func TestMergeAndVerifyLogConfigNilConfig(t *testing.T) {
@@ -13,3 +14,49 @@ func TestMergeAndVerifyLogConfigNilConfig(t *testing.T) {
 		t.Fatal(err)
 	}
 }
+
+func TestLogsFilter(t *testing.T) {
+	t.Run("nil when unset", func(t *testing.T) {
+		f, err := newLogsFilter("", "")
+		if err != nil || f != nil {
+			t.Fatalf("expected nil filter, got %v, %v", f, err)
+		}
+	})
+
+	t.Run("invalid regex", func(t *testing.T) {
+		if _, err := newLogsFilter("(", ""); err == nil {
+			t.Fatal("expected error for invalid regex")
+		}
+	})
+
+	t.Run("grep", func(t *testing.T) {
+		f, err := newLogsFilter("err(or)?", "")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, keep := f.filter(&backend.LogMessage{Line: []byte("an error occurred")}); !keep {
+			t.Fatal("expected matching line to be kept")
+		}
+		if _, keep := f.filter(&backend.LogMessage{Line: []byte("all good")}); keep {
+			t.Fatal("expected non-matching line to be dropped")
+		}
+	})
+
+	t.Run("fields", func(t *testing.T) {
+		f, err := newLogsFilter("", ".level,.request.id,.missing")
+		if err != nil {
+			t.Fatal(err)
+		}
+		m, keep := f.filter(&backend.LogMessage{Line: []byte(`{"level":"info","request":{"id":"abc"},"extra":true}`)})
+		if !keep {
+			t.Fatal("expected JSON line to be kept")
+		}
+		const expected = `{"level":"info","request.id":"abc"}`
+		if string(m.Line) != expected {
+			t.Fatalf("expected %s, got %s", expected, m.Line)
+		}
+		if _, keep := f.filter(&backend.LogMessage{Line: []byte("not json")}); keep {
+			t.Fatal("expected non-JSON line to be dropped")
+		}
+	})
+}
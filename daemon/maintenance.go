@@ -0,0 +1,81 @@
+package daemon
+
+import (
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/moby/moby/api/types/events"
+	"github.com/moby/moby/api/types/system"
+	"github.com/moby/moby/v2/errdefs"
+	"github.com/pkg/errors"
+)
+
+// maintenanceState tracks whether the daemon is refusing new workloads so a
+// node can be drained before planned downtime. It is in-memory only; a
+// daemon restart leaves maintenance mode.
+type maintenanceState struct {
+	mu      sync.Mutex
+	enabled bool
+	message string
+	since   time.Time
+}
+
+// MaintenanceStatus returns the daemon's current maintenance-mode state.
+func (daemon *Daemon) MaintenanceStatus() system.Maintenance {
+	daemon.maintenance.mu.Lock()
+	defer daemon.maintenance.mu.Unlock()
+	st := system.Maintenance{
+		Enabled: daemon.maintenance.enabled,
+		Message: daemon.maintenance.message,
+	}
+	if daemon.maintenance.enabled {
+		st.Since = daemon.maintenance.since.Format(time.RFC3339Nano)
+	}
+	return st
+}
+
+// SetMaintenance enables or disables maintenance mode. While enabled, new
+// container creates and image pulls are rejected with an unavailable error
+// carrying the configured message; operations that wind work down (stops,
+// removes) and read-only operations are unaffected. A daemon event is
+// emitted when the mode changes.
+func (daemon *Daemon) SetMaintenance(cfg system.Maintenance) error {
+	daemon.maintenance.mu.Lock()
+	changed := daemon.maintenance.enabled != cfg.Enabled
+	daemon.maintenance.enabled = cfg.Enabled
+	if cfg.Enabled {
+		daemon.maintenance.message = cfg.Message
+		if changed {
+			daemon.maintenance.since = time.Now()
+		}
+	} else {
+		daemon.maintenance.message = ""
+		daemon.maintenance.since = time.Time{}
+	}
+	daemon.maintenance.mu.Unlock()
+
+	if changed {
+		attributes := map[string]string{"enabled": strconv.FormatBool(cfg.Enabled)}
+		if cfg.Enabled && cfg.Message != "" {
+			attributes["message"] = cfg.Message
+		}
+		daemon.LogDaemonEventWithAttributes(events.ActionMaintenance, attributes)
+	}
+	return nil
+}
+
+// maintenanceError returns an [errdefs.Unavailable] error when the daemon is
+// in maintenance mode, and nil otherwise.
+func (daemon *Daemon) maintenanceError() error {
+	daemon.maintenance.mu.Lock()
+	defer daemon.maintenance.mu.Unlock()
+	if !daemon.maintenance.enabled {
+		return nil
+	}
+	msg := "daemon is in maintenance mode"
+	if daemon.maintenance.message != "" {
+		msg += ": " + daemon.maintenance.message
+	}
+	return errdefs.Unavailable(errors.New(msg))
+}
@@ -0,0 +1,40 @@
+package daemon
+
+import (
+	"testing"
+
+	cerrdefs "github.com/containerd/errdefs"
+	"github.com/moby/moby/api/types/system"
+	"gotest.tools/v3/assert"
+	is "gotest.tools/v3/assert/cmp"
+)
+
+func TestMaintenanceMode(t *testing.T) {
+	d := &Daemon{}
+
+	assert.NilError(t, d.maintenanceError())
+	st := d.MaintenanceStatus()
+	assert.Check(t, !st.Enabled)
+	assert.Check(t, is.Equal(st.Since, ""))
+
+	assert.NilError(t, d.SetMaintenance(system.Maintenance{Enabled: true, Message: "kernel upgrade"}))
+	err := d.maintenanceError()
+	assert.Assert(t, cerrdefs.IsUnavailable(err), err)
+	assert.Check(t, is.ErrorContains(err, "kernel upgrade"))
+	st = d.MaintenanceStatus()
+	assert.Check(t, st.Enabled)
+	assert.Check(t, is.Equal(st.Message, "kernel upgrade"))
+	assert.Check(t, st.Since != "")
+
+	// Re-applying the same state must not reset the entry time.
+	since := st.Since
+	assert.NilError(t, d.SetMaintenance(system.Maintenance{Enabled: true, Message: "kernel upgrade"}))
+	assert.Check(t, is.Equal(d.MaintenanceStatus().Since, since))
+
+	assert.NilError(t, d.SetMaintenance(system.Maintenance{}))
+	assert.NilError(t, d.maintenanceError())
+	st = d.MaintenanceStatus()
+	assert.Check(t, !st.Enabled)
+	assert.Check(t, is.Equal(st.Message, ""))
+	assert.Check(t, is.Equal(st.Since, ""))
+}
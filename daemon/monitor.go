@@ -8,6 +8,7 @@ import (
 
 	cerrdefs "github.com/containerd/errdefs"
 	"github.com/containerd/log"
+	containertypes "github.com/moby/moby/api/types/container"
 	"github.com/moby/moby/api/types/events"
 	"github.com/moby/moby/v2/daemon/config"
 	"github.com/moby/moby/v2/daemon/container"
@@ -53,8 +54,13 @@ func (daemon *Daemon) handleContainerExit(c *container.Container, e *libcontaine
 	// container is started again.
 	daemon.stopHealthchecks(c)
 
+	var usage *containertypes.ResourceUsage
 	tsk, ok := c.Task()
 	if ok {
+		// Capture a final resource usage summary while the task's cgroup
+		// still exists, before the task is deleted.
+		usage = taskResourceUsage(tsk)
+
 		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 		es, err := tsk.Delete(ctx)
 		cancel()
@@ -86,7 +92,7 @@ func (daemon *Daemon) handleContainerExit(c *container.Container, e *libcontaine
 	}
 
 	daemonShutdown := daemon.IsShuttingDown()
-	execDuration := time.Since(c.StartedAt)
+	execDuration := c.ExecutionDuration()
 	restart, wait, err := c.RestartManager().ShouldRestart(uint32(ctrExitStatus.ExitCode), daemonShutdown || c.HasBeenManuallyStopped, execDuration)
 	if err != nil {
 		log.G(ctx).WithFields(log.Fields{
@@ -105,10 +111,16 @@ func (daemon *Daemon) handleContainerExit(c *container.Container, e *libcontaine
 		"exitCode":     strconv.Itoa(ctrExitStatus.ExitCode),
 		"execDuration": strconv.Itoa(int(execDuration.Seconds())),
 	}
+	if usage != nil {
+		c.ResourceUsage = usage
+		attributes["maxMemory"] = strconv.FormatUint(usage.MaxMemoryBytes, 10)
+		attributes["cpuTotalNs"] = strconv.FormatUint(usage.CPUTotalNs, 10)
+	}
 	daemon.Cleanup(context.TODO(), c)
 
 	if restart {
 		c.RestartCount++
+		metrics.RecordContainerRestart(ctx)
 		log.G(ctx).WithFields(log.Fields{
 			"container":     c.ID,
 			"restartCount":  c.RestartCount,
@@ -163,6 +175,29 @@ func (daemon *Daemon) handleContainerExit(c *container.Container, e *libcontaine
 	return checkpointErr
 }
 
+// handleOOMAction applies the container's configured OOM action after an OOM
+// event. The default action ("kill") leaves handling to the kernel OOM killer
+// and needs nothing from the daemon. Pausing and restarting call back into
+// containerd, so they run in a goroutine to not block event processing.
+func (daemon *Daemon) handleOOMAction(c *container.Container, action containertypes.OOMAction) {
+	switch action {
+	case containertypes.OOMActionPause:
+		go func() {
+			if err := daemon.containerPause(c); err != nil {
+				log.G(context.TODO()).WithFields(log.Fields{"container": c.ID, "error": err}).Warn("Failed to pause container after OOM event")
+				return
+			}
+			daemon.LogContainerEventWithAttributes(c, events.ActionPause, map[string]string{"reason": "oom"})
+		}()
+	case containertypes.OOMActionRestart:
+		go func() {
+			if err := daemon.ContainerRestart(context.Background(), c.ID, containertypes.StopOptions{}); err != nil {
+				log.G(context.TODO()).WithFields(log.Fields{"container": c.ID, "error": err}).Warn("Failed to restart container after OOM event")
+			}
+		}()
+	}
+}
+
 // ProcessEvent is called by libcontainerd whenever an event occurs
 func (daemon *Daemon) ProcessEvent(id string, e libcontainerdtypes.EventType, ei libcontainerdtypes.EventInfo) error {
 	c, err := daemon.GetContainer(id)
@@ -178,14 +213,17 @@ func (daemon *Daemon) ProcessEvent(id string, e libcontainerdtypes.EventType, ei
 		}
 
 		c.Lock()
-		defer c.Unlock()
 		c.OOMKilled = true
 		daemon.updateHealthMonitor(c)
-		if err := c.CheckpointTo(context.TODO(), daemon.containersReplica); err != nil {
+		err := c.CheckpointTo(context.TODO(), daemon.containersReplica)
+		action := c.HostConfig.OOMAction
+		c.Unlock()
+		if err != nil {
 			return err
 		}
 
 		daemon.LogContainerEvent(c, events.ActionOOM)
+		daemon.handleOOMAction(c, action)
 		return nil
 	case libcontainerdtypes.EventExit:
 		if ei.ProcessID == ei.ContainerID {
@@ -234,13 +272,24 @@ func (daemon *Daemon) ProcessEvent(id string, e libcontainerdtypes.EventType, ei
 							"process":   ei.ProcessID,
 						}).Warn("failed to delete process")
 					}
+					daemon.removeExecCgroup(execConfig)
 				}()
 			}
 		}
-		daemon.LogContainerEventWithAttributes(c, events.ActionExecDie, map[string]string{
+		attributes := map[string]string{
 			"execID":   ei.ProcessID,
 			"exitCode": strconv.Itoa(exitCode),
-		})
+		}
+		// Exec processes run in the container's cgroup, so the best
+		// available accounting at exec exit is a snapshot of the
+		// container-wide usage.
+		if tsk, ok := c.Task(); ok {
+			if usage := taskResourceUsage(tsk); usage != nil {
+				attributes["maxMemory"] = strconv.FormatUint(usage.MaxMemoryBytes, 10)
+				attributes["cpuTotalNs"] = strconv.FormatUint(usage.CPUTotalNs, 10)
+			}
+		}
+		daemon.LogContainerEventWithAttributes(c, events.ActionExecDie, attributes)
 		return nil
 	case libcontainerdtypes.EventStart:
 		c.Lock()
@@ -248,7 +297,11 @@ func (daemon *Daemon) ProcessEvent(id string, e libcontainerdtypes.EventType, ei
 
 		// This is here to handle start not generated by docker
 		if !c.Running {
-			ctr, err := daemon.containerd.LoadContainer(context.Background(), c.ID)
+			tenantClient, err := daemon.containerdFor(c)
+			if err != nil {
+				return err
+			}
+			ctr, err := tenantClient.LoadContainer(context.Background(), c.ID)
 			if err != nil {
 				if cerrdefs.IsNotFound(err) {
 					// The container was started by not-docker and so could have been deleted by
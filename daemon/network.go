@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"math"
 	"net"
 	"sort"
 	"strconv"
@@ -337,6 +338,35 @@ func (daemon *Daemon) createNetwork(ctx context.Context, cfg *config.Config, cre
 		}
 	}
 
+	// The ipv6only option is shorthand for an IPv6-only network; it disables
+	// IPv4 so no IPv4 pool is allocated, and rejects conflicting settings
+	// rather than silently overriding them.
+	if v, ok := networkOptions[netlabel.IPv6Only]; ok {
+		ipv6Only, err := strconv.ParseBool(v)
+		if err != nil {
+			return nil, errdefs.InvalidParameter(fmt.Errorf("driver-opt %q is not a valid bool", netlabel.IPv6Only))
+		}
+		if ipv6Only {
+			if create.EnableIPv4 != nil && *create.EnableIPv4 {
+				return nil, errdefs.InvalidParameter(errors.New("IPv4 cannot be enabled on an IPv6-only network"))
+			}
+			if create.EnableIPv6 != nil && !*create.EnableIPv6 {
+				return nil, errdefs.InvalidParameter(errors.New("IPv6 cannot be disabled on an IPv6-only network"))
+			}
+			if create.IPAM != nil {
+				for _, ipamCfg := range create.IPAM.Config {
+					if ipamCfg.Subnet == "" {
+						continue
+					}
+					if _, ipNet, err := net.ParseCIDR(ipamCfg.Subnet); err == nil && ipNet.IP.To4() != nil {
+						return nil, errdefs.InvalidParameter(fmt.Errorf("IPv6-only network cannot have IPv4 subnet %s", ipamCfg.Subnet))
+					}
+				}
+			}
+			enableIPv4, enableIPv6 = false, true
+		}
+	}
+
 	nwOptions := []libnetwork.NetworkOption{
 		libnetwork.NetworkOptionEnableIPv4(enableIPv4),
 		libnetwork.NetworkOptionEnableIPv6(enableIPv6),
@@ -616,6 +646,9 @@ func (daemon *Daemon) GetNetworks(filter filters.Args, config backend.NetworkLis
 			if config.Verbose {
 				networks[i].Services = buildServiceAttachments(idx[nw.ID])
 			}
+			if config.Size {
+				networks[i].Usage = buildNetworkUsage(idx[nw.ID])
+			}
 		}
 	}
 
@@ -669,6 +702,43 @@ func buildContainerAttachments(nw *libnetwork.Network) map[string]networktypes.E
 	return containers
 }
 
+// buildNetworkUsage counts the endpoints attached to the network and the
+// allocated and free addresses in its IPv4 pools. It is used when inspecting
+// a network with size reporting requested.
+func buildNetworkUsage(nw *libnetwork.Network) *networktypes.Usage {
+	endpoints := nw.Endpoints()
+	u := &networktypes.Usage{Endpoints: len(endpoints)}
+
+	var capacity int64
+	v4Info, _ := nw.IpamInfo()
+	for _, info := range v4Info {
+		if info.Pool == nil {
+			continue
+		}
+		ones, bits := info.Pool.Mask.Size()
+		if hostBits := bits - ones; hostBits >= 63 {
+			capacity = math.MaxInt64
+		} else if capacity != math.MaxInt64 {
+			capacity += int64(1) << hostBits
+		}
+		if info.Gateway != nil {
+			u.AllocatedIPs++
+		}
+		u.AllocatedIPs += int64(len(info.AuxAddresses))
+	}
+	for _, ep := range endpoints {
+		if ei := ep.Info(); ei != nil {
+			if iface := ei.Iface(); iface != nil && iface.Address() != nil {
+				u.AllocatedIPs++
+			}
+		}
+	}
+	if capacity > u.AllocatedIPs {
+		u.FreeIPs = capacity - u.AllocatedIPs
+	}
+	return u
+}
+
 // buildServiceAttachments creates a [network.ServiceInfo] map of all services
 // attached to the network. It is used when listing networks in "verbose" mode.
 func buildServiceAttachments(nw *libnetwork.Network) map[string]networktypes.ServiceInfo {
@@ -974,20 +1044,25 @@ func buildPortsRelatedCreateEndpointOptions(c *container.Container, n *libnetwor
 		})
 
 		for _, binding := range bindings[port] {
-			newP, err := nat.NewPort(nat.SplitProtoPort(binding.HostPort))
+			hostPort, disableProxy, err := splitPortBindingOptions(binding.HostPort)
 			var portStart, portEnd int
 			if err == nil {
-				portStart, portEnd, err = newP.Range()
+				var newP nat.Port
+				newP, err = nat.NewPort(nat.SplitProtoPort(hostPort))
+				if err == nil {
+					portStart, portEnd, err = newP.Range()
+				}
 			}
 			if err != nil {
 				return nil, fmt.Errorf("error parsing HostPort value (%s): %w", binding.HostPort, err)
 			}
 			publishedPorts = append(publishedPorts, lntypes.PortBinding{
-				Proto:       portProto,
-				Port:        portNum,
-				HostIP:      net.ParseIP(binding.HostIP),
-				HostPort:    uint16(portStart),
-				HostPortEnd: uint16(portEnd),
+				Proto:        portProto,
+				Port:         portNum,
+				HostIP:       net.ParseIP(binding.HostIP),
+				HostPort:     uint16(portStart),
+				HostPortEnd:  uint16(portEnd),
+				DisableProxy: disableProxy,
 			})
 		}
 
@@ -1005,6 +1080,32 @@ func buildPortsRelatedCreateEndpointOptions(c *container.Container, n *libnetwor
 	}, nil
 }
 
+// splitPortBindingOptions splits a port binding's HostPort value into the
+// host port (or port range) itself, and comma-separated options appended to
+// it - for example, "8080,proxy=false". The only option currently recognised
+// is "proxy", which defaults to true; "proxy=false" disables the userland
+// proxy for the binding.
+func splitPortBindingOptions(hostPort string) (port string, disableProxy bool, _ error) {
+	port, opts, ok := strings.Cut(hostPort, ",")
+	if !ok {
+		return port, false, nil
+	}
+	for _, opt := range strings.Split(opts, ",") {
+		k, v, _ := strings.Cut(opt, "=")
+		switch k {
+		case "proxy":
+			enable, err := strconv.ParseBool(v)
+			if err != nil {
+				return "", false, fmt.Errorf("invalid value %q for port binding option %q", v, k)
+			}
+			disableProxy = !enable
+		default:
+			return "", false, fmt.Errorf("unknown port binding option %q", k)
+		}
+	}
+	return port, disableProxy, nil
+}
+
 // getPortMapInfo retrieves the current port-mapping programmed for the given sandbox
 func getPortMapInfo(sb *libnetwork.Sandbox) containertypes.PortMap {
 	pm := containertypes.PortMap{}
@@ -0,0 +1,141 @@
+package daemon
+
+import (
+	containertypes "github.com/moby/moby/api/types/container"
+	"github.com/moby/moby/v2/daemon/container"
+	"github.com/moby/moby/v2/errdefs"
+	"github.com/pkg/errors"
+	"github.com/vishvananda/netlink"
+	"golang.org/x/sys/unix"
+)
+
+const (
+	// networkRateMinBurst is the smallest burst buffer programmed for a
+	// rate limit; smaller values would make the kernel drop even single
+	// full-sized packets.
+	networkRateMinBurst = 32 * 1024
+	// networkRateLatencyDivisor sizes the TBF backlog to 50ms at the
+	// configured rate (rate / 20), on top of the burst buffer.
+	networkRateLatencyDivisor = 20
+)
+
+// applyNetworkRate (re)programs the bandwidth limits of the container's
+// sandbox interfaces from its HostConfig. A zeroed NetworkRate clears
+// previously applied limits, so the function is also used for live updates;
+// a nil NetworkRate is a no-op.
+func (daemon *Daemon) applyNetworkRate(ctr *container.Container) error {
+	rate := ctr.HostConfig.NetworkRate
+	if rate == nil {
+		return nil
+	}
+	sb, err := daemon.netController.GetSandbox(ctr.ID)
+	if err != nil {
+		return errdefs.System(errors.Wrap(err, "no network sandbox for container"))
+	}
+
+	var applyErr error
+	err = sb.ExecFunc(func() {
+		for _, ep := range sb.Endpoints() {
+			iface := ep.Iface()
+			if iface == nil || iface.SrcName() == "" {
+				continue
+			}
+			if err := setInterfaceRate(sb.ID(), iface.SrcName(), rate); err != nil {
+				applyErr = errors.Wrapf(err, "failed to set network rate on %s", iface.SrcName())
+				return
+			}
+		}
+	})
+	if err != nil {
+		return errdefs.System(err)
+	}
+	if applyErr != nil {
+		return errdefs.System(applyErr)
+	}
+	return nil
+}
+
+// setInterfaceRate programs the egress and ingress limits of a single
+// interface inside the container's network namespace. Egress is shaped with
+// a TBF root qdisc; ingress is policed with a matchall filter on the clsact
+// qdisc shared with traffic mirroring (see clsact_linux.go). Zero rates
+// remove the corresponding qdisc or filter.
+func setInterfaceRate(sandboxID, name string, rate *containertypes.NetworkRate) error {
+	link, err := netlink.LinkByName(name)
+	if err != nil {
+		return errors.Wrapf(err, "interface %s not found in container", name)
+	}
+	index := link.Attrs().Index
+
+	egress := &netlink.Tbf{
+		QdiscAttrs: netlink.QdiscAttrs{
+			LinkIndex: index,
+			Parent:    netlink.HANDLE_ROOT,
+			Handle:    netlink.MakeHandle(1, 0),
+		},
+	}
+	if rate.EgressBps > 0 {
+		burst := networkRateBurst(rate.EgressBps, rate.Burst)
+		egress.Rate = uint64(rate.EgressBps)
+		egress.Buffer = netlink.Xmittime(egress.Rate, uint32(burst))
+		egress.Limit = uint32(burst + rate.EgressBps/networkRateLatencyDivisor)
+		if err := netlink.QdiscReplace(egress); err != nil {
+			return errors.Wrap(err, "failed to set egress rate")
+		}
+	} else if err := netlink.QdiscDel(egress); err != nil && !errors.Is(err, unix.ENOENT) && !errors.Is(err, unix.EINVAL) {
+		return errors.Wrap(err, "failed to clear egress rate")
+	}
+
+	// The police filter lives on the clsact qdisc shared with traffic
+	// mirroring; only the rate filter (identified by its priority) is
+	// touched, so the other feature's filters survive reprogramming.
+	filterAttrs := netlink.FilterAttrs{
+		LinkIndex: index,
+		Parent:    netlink.HANDLE_MIN_INGRESS,
+		Priority:  clsactRatePriority,
+		Protocol:  unix.ETH_P_ALL,
+		Handle:    1,
+	}
+	if err := netlink.FilterDel(&netlink.MatchAll{FilterAttrs: filterAttrs}); err != nil && !errors.Is(err, unix.ENOENT) && !errors.Is(err, unix.EINVAL) {
+		return errors.Wrap(err, "failed to clear ingress rate")
+	}
+	if rate.IngressBps > 0 {
+		if err := acquireClsact(sandboxID, index, name, clsactRateUser); err != nil {
+			return err
+		}
+		police := netlink.NewPoliceAction()
+		police.Rate = uint32(rate.IngressBps)
+		police.Burst = uint32(networkRateBurst(rate.IngressBps, rate.Burst))
+		police.Mtu = 65535
+		police.ExceedAction = netlink.TC_POLICE_SHOT
+		filter := &netlink.MatchAll{
+			FilterAttrs: filterAttrs,
+			Actions:     []netlink.Action{police},
+		}
+		if err := netlink.FilterAdd(filter); err != nil {
+			releaseClsact(sandboxID, index, name, clsactRateUser)
+			return errors.Wrap(err, "failed to set ingress rate")
+		}
+	} else {
+		releaseClsact(sandboxID, index, name, clsactRateUser)
+	}
+	return nil
+}
+
+// clsactRateUser is the fixed clsact user token of the ingress policer; the
+// policer holds at most one reference per interface, so acquire and release
+// pair on the token regardless of how often the rate is reprogrammed.
+const clsactRateUser = "network-rate"
+
+// networkRateBurst returns the burst buffer size to program for the given
+// rate: the configured burst when set, otherwise 10 milliseconds worth of
+// traffic, but never less than networkRateMinBurst.
+func networkRateBurst(rateBps, burst int64) int64 {
+	if burst <= 0 {
+		burst = rateBps / 100
+	}
+	if burst < networkRateMinBurst {
+		burst = networkRateMinBurst
+	}
+	return burst
+}
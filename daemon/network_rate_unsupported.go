@@ -0,0 +1,18 @@
+//go:build !linux
+
+package daemon
+
+import (
+	"errors"
+
+	"github.com/moby/moby/v2/daemon/container"
+	"github.com/moby/moby/v2/errdefs"
+)
+
+// applyNetworkRate is not supported on this platform.
+func (daemon *Daemon) applyNetworkRate(ctr *container.Container) error {
+	if ctr.HostConfig.NetworkRate == nil {
+		return nil
+	}
+	return errdefs.NotImplemented(errors.New("network rate limits are only supported on Linux"))
+}
@@ -0,0 +1,39 @@
+package daemon
+
+import (
+	"testing"
+
+	"gotest.tools/v3/assert"
+	is "gotest.tools/v3/assert/cmp"
+)
+
+func TestSplitPortBindingOptions(t *testing.T) {
+	tests := []struct {
+		hostPort        string
+		expPort         string
+		expDisableProxy bool
+		expErr          string
+	}{
+		{hostPort: "", expPort: ""},
+		{hostPort: "8080", expPort: "8080"},
+		{hostPort: "8080-8090", expPort: "8080-8090"},
+		{hostPort: "8080,proxy=true", expPort: "8080"},
+		{hostPort: "8080,proxy=false", expPort: "8080", expDisableProxy: true},
+		{hostPort: ",proxy=false", expPort: "", expDisableProxy: true},
+		{hostPort: "8080,proxy=nope", expErr: `invalid value "nope" for port binding option "proxy"`},
+		{hostPort: "8080,proxy", expErr: `invalid value "" for port binding option "proxy"`},
+		{hostPort: "8080,nonsense=1", expErr: `unknown port binding option "nonsense"`},
+	}
+	for _, tc := range tests {
+		t.Run(tc.hostPort, func(t *testing.T) {
+			port, disableProxy, err := splitPortBindingOptions(tc.hostPort)
+			if tc.expErr != "" {
+				assert.Check(t, is.Error(err, tc.expErr))
+				return
+			}
+			assert.NilError(t, err)
+			assert.Check(t, is.Equal(port, tc.expPort))
+			assert.Check(t, is.Equal(disableProxy, tc.expDisableProxy))
+		})
+	}
+}
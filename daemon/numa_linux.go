@@ -0,0 +1,122 @@
+package daemon
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	containertypes "github.com/moby/moby/api/types/container"
+	"github.com/moby/moby/v2/errdefs"
+	"github.com/pkg/errors"
+)
+
+// numaSysfsRoot is where the kernel exposes the host's NUMA topology. It is
+// a variable so tests can point it at a fake topology.
+var numaSysfsRoot = "/sys/devices/system/node"
+
+// numaNodes returns the NUMA nodes available on the host, in ascending order.
+func numaNodes() ([]int64, error) {
+	entries, err := os.ReadDir(numaSysfsRoot)
+	if err != nil {
+		return nil, err
+	}
+	var nodes []int64
+	for _, entry := range entries {
+		if !strings.HasPrefix(entry.Name(), "node") {
+			continue
+		}
+		id, err := strconv.ParseInt(strings.TrimPrefix(entry.Name(), "node"), 10, 64)
+		if err != nil {
+			continue
+		}
+		nodes = append(nodes, id)
+	}
+	sort.Slice(nodes, func(i, j int) bool { return nodes[i] < nodes[j] })
+	return nodes, nil
+}
+
+// numaNodeCPUs returns the cpuset list (e.g. "0-3,8-11") of the CPUs
+// belonging to the given NUMA node.
+func numaNodeCPUs(node int64) (string, error) {
+	cpus, err := os.ReadFile(filepath.Join(numaSysfsRoot, fmt.Sprintf("node%d", node), "cpulist"))
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(cpus)), nil
+}
+
+// setNUMAPlacement translates the container's NUMA placement options into an
+// explicit cpuset, pinning its CPUs and memory to a single NUMA node. With
+// CpusetAutoBalance set, the daemon picks the node with the fewest containers
+// already pinned to it.
+func (daemon *Daemon) setNUMAPlacement(hostConfig *containertypes.HostConfig) error {
+	if hostConfig.NUMANode == nil && !hostConfig.CpusetAutoBalance {
+		return nil
+	}
+	if hostConfig.NUMANode != nil && hostConfig.CpusetAutoBalance {
+		return errdefs.InvalidParameter(errors.New("conflicting options: NUMA node and cpuset auto-balancing are mutually exclusive"))
+	}
+	if hostConfig.CpusetCpus != "" || hostConfig.CpusetMems != "" {
+		return errdefs.InvalidParameter(errors.New("conflicting options: NUMA placement cannot be combined with an explicit cpuset"))
+	}
+
+	nodes, err := numaNodes()
+	if err != nil || len(nodes) == 0 {
+		return errdefs.InvalidParameter(errors.New("NUMA placement requested, but no NUMA topology was found on this host"))
+	}
+
+	var node int64
+	if hostConfig.NUMANode != nil {
+		node = *hostConfig.NUMANode
+		if !numaNodeExists(nodes, node) {
+			return errdefs.InvalidParameter(errors.Errorf("NUMA node %d does not exist, available nodes: %v", node, nodes))
+		}
+	} else {
+		node = pickLeastLoadedNUMANode(nodes, daemon.numaNodeLoad())
+		hostConfig.NUMANode = &node
+	}
+
+	cpus, err := numaNodeCPUs(node)
+	if err != nil {
+		return errdefs.System(errors.Wrapf(err, "error discovering CPUs of NUMA node %d", node))
+	}
+	hostConfig.CpusetCpus = cpus
+	hostConfig.CpusetMems = strconv.FormatInt(node, 10)
+	return nil
+}
+
+// numaNodeLoad counts, per NUMA node, the containers that are pinned to it.
+func (daemon *Daemon) numaNodeLoad() map[int64]int {
+	load := make(map[int64]int)
+	for _, c := range daemon.List() {
+		if c.HostConfig == nil || c.HostConfig.NUMANode == nil {
+			continue
+		}
+		load[*c.HostConfig.NUMANode]++
+	}
+	return load
+}
+
+func numaNodeExists(nodes []int64, node int64) bool {
+	for _, n := range nodes {
+		if n == node {
+			return true
+		}
+	}
+	return false
+}
+
+// pickLeastLoadedNUMANode returns the node with the fewest containers pinned
+// to it, preferring lower-numbered nodes on a tie.
+func pickLeastLoadedNUMANode(nodes []int64, load map[int64]int) int64 {
+	best := nodes[0]
+	for _, n := range nodes[1:] {
+		if load[n] < load[best] {
+			best = n
+		}
+	}
+	return best
+}
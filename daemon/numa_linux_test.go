@@ -0,0 +1,71 @@
+package daemon
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	containertypes "github.com/moby/moby/api/types/container"
+	"github.com/moby/moby/v2/daemon/container"
+	"gotest.tools/v3/assert"
+	is "gotest.tools/v3/assert/cmp"
+)
+
+// fakeNUMATopology points numaSysfsRoot at a fake two-node topology for the
+// duration of the test.
+func fakeNUMATopology(t *testing.T) {
+	t.Helper()
+	root := t.TempDir()
+	for node, cpus := range map[string]string{"node0": "0-3", "node1": "4-7"} {
+		assert.NilError(t, os.MkdirAll(filepath.Join(root, node), 0o755))
+		assert.NilError(t, os.WriteFile(filepath.Join(root, node, "cpulist"), []byte(cpus+"\n"), 0o644))
+	}
+	oldRoot := numaSysfsRoot
+	numaSysfsRoot = root
+	t.Cleanup(func() { numaSysfsRoot = oldRoot })
+}
+
+func TestSetNUMAPlacement(t *testing.T) {
+	fakeNUMATopology(t)
+	d := &Daemon{containers: container.NewMemoryStore()}
+
+	t.Run("explicit node", func(t *testing.T) {
+		node := int64(1)
+		hostConfig := &containertypes.HostConfig{Resources: containertypes.Resources{NUMANode: &node}}
+		assert.NilError(t, d.setNUMAPlacement(hostConfig))
+		assert.Check(t, is.Equal(hostConfig.CpusetCpus, "4-7"))
+		assert.Check(t, is.Equal(hostConfig.CpusetMems, "1"))
+	})
+
+	t.Run("nonexistent node", func(t *testing.T) {
+		node := int64(7)
+		hostConfig := &containertypes.HostConfig{Resources: containertypes.Resources{NUMANode: &node}}
+		err := d.setNUMAPlacement(hostConfig)
+		assert.Check(t, is.ErrorContains(err, "NUMA node 7 does not exist"))
+	})
+
+	t.Run("conflicts with explicit cpuset", func(t *testing.T) {
+		node := int64(0)
+		hostConfig := &containertypes.HostConfig{Resources: containertypes.Resources{NUMANode: &node, CpusetCpus: "0,1"}}
+		err := d.setNUMAPlacement(hostConfig)
+		assert.Check(t, is.ErrorContains(err, "explicit cpuset"))
+	})
+
+	t.Run("auto-balance picks least loaded node", func(t *testing.T) {
+		node := int64(0)
+		ctr := &container.Container{
+			ID:         "pinned",
+			State:      container.NewState(),
+			HostConfig: &containertypes.HostConfig{Resources: containertypes.Resources{NUMANode: &node}},
+		}
+		d.containers.Add(ctr.ID, ctr)
+		defer d.containers.Delete(ctr.ID)
+
+		hostConfig := &containertypes.HostConfig{Resources: containertypes.Resources{CpusetAutoBalance: true}}
+		assert.NilError(t, d.setNUMAPlacement(hostConfig))
+		assert.Assert(t, hostConfig.NUMANode != nil)
+		assert.Check(t, is.Equal(*hostConfig.NUMANode, int64(1)))
+		assert.Check(t, is.Equal(hostConfig.CpusetCpus, "4-7"))
+		assert.Check(t, is.Equal(hostConfig.CpusetMems, "1"))
+	})
+}
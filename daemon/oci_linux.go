@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"slices"
 	"strconv"
 	"strings"
 
@@ -29,6 +30,8 @@ import (
 	"github.com/opencontainers/cgroups"
 	"github.com/opencontainers/runtime-spec/specs-go"
 	"github.com/pkg/errors"
+	"golang.org/x/sys/unix"
+	cdiparser "tags.cncf.io/container-device-interface/pkg/parser"
 )
 
 const inContainerInitPath = "/sbin/" + dconfig.DefaultInitBinary
@@ -50,6 +53,23 @@ func withRlimits(daemon *Daemon, daemonCfg *dconfig.Config, c *container.Contain
 			})
 		}
 
+		if cd := hostConfig.CoreDump; cd != nil {
+			core := specs.POSIXRlimit{Type: "RLIMIT_CORE"}
+			if cd.Enabled {
+				core.Soft = unix.RLIM_INFINITY
+				core.Hard = unix.RLIM_INFINITY
+				if cd.SizeLimit > 0 {
+					core.Soft = uint64(cd.SizeLimit)
+					core.Hard = uint64(cd.SizeLimit)
+				}
+			}
+			// The core dump policy takes precedence over a "core" ulimit.
+			rlimits = slices.DeleteFunc(rlimits, func(rl specs.POSIXRlimit) bool {
+				return rl.Type == "RLIMIT_CORE"
+			})
+			rlimits = append(rlimits, core)
+		}
+
 		if s.Process == nil {
 			s.Process = &specs.Process{}
 		}
@@ -712,7 +732,7 @@ func withCommonOptions(daemon *Daemon, daemonCfg *dconfig.Config, c *container.C
 			Path:     c.BaseFS,
 			Readonly: c.HostConfig.ReadonlyRootfs,
 		}
-		if err := c.SetupWorkingDirectory(daemon.idMapping.RootPair()); err != nil {
+		if err := daemon.setupWorkingDirectory(daemonCfg, c); err != nil {
 			return err
 		}
 		cwd := c.Config.WorkingDir
@@ -849,6 +869,19 @@ func WithDevices(daemon *Daemon, c *container.Container) coci.SpecOpts {
 		var devs []specs.LinuxDevice
 		devPermissions := s.Linux.Resources.Devices
 
+		// Split fully-qualified CDI device names (e.g. "vendor.com/gpu=0")
+		// out of the device mappings; these are resolved through the CDI
+		// registry instead of being treated as host paths.
+		var cdiDeviceNames []string
+		var deviceMappings []containertypes.DeviceMapping
+		for _, deviceMapping := range c.HostConfig.Devices {
+			if cdiparser.IsQualifiedName(deviceMapping.PathOnHost) {
+				cdiDeviceNames = append(cdiDeviceNames, deviceMapping.PathOnHost)
+				continue
+			}
+			deviceMappings = append(deviceMappings, deviceMapping)
+		}
+
 		if c.HostConfig.Privileged {
 			hostDevices, err := coci.HostDevices()
 			if err != nil {
@@ -857,7 +890,7 @@ func WithDevices(daemon *Daemon, c *container.Container) coci.SpecOpts {
 			devs = append(devs, hostDevices...)
 
 			// adding device mappings in privileged containers
-			for _, deviceMapping := range c.HostConfig.Devices {
+			for _, deviceMapping := range deviceMappings {
 				// issue a warning that custom cgroup permissions are ignored in privileged mode
 				if deviceMapping.CgroupPermissions != "rwm" {
 					log.G(ctx).WithField("container", c.ID).Warnf("custom %s permissions for device %s are ignored in privileged mode", deviceMapping.CgroupPermissions, deviceMapping.PathOnHost)
@@ -881,7 +914,7 @@ func WithDevices(daemon *Daemon, c *container.Container) coci.SpecOpts {
 				},
 			}
 		} else {
-			for _, deviceMapping := range c.HostConfig.Devices {
+			for _, deviceMapping := range deviceMappings {
 				d, dPermissions, err := oci.DevicesFromPath(deviceMapping.PathOnHost, deviceMapping.PathInContainer, deviceMapping.CgroupPermissions)
 				if err != nil {
 					return err
@@ -911,6 +944,12 @@ func WithDevices(daemon *Daemon, c *container.Container) coci.SpecOpts {
 				return err
 			}
 		}
+
+		if len(cdiDeviceNames) > 0 {
+			if err := daemon.handleDevice(containertypes.DeviceRequest{Driver: "cdi", DeviceIDs: cdiDeviceNames}, s); err != nil {
+				return err
+			}
+		}
 		return nil
 	}
 }
@@ -964,6 +1003,26 @@ func WithResources(c *container.Container) coci.SpecOpts {
 		if r.BlkioWeight != 0 {
 			w := r.BlkioWeight
 			s.Linux.Resources.BlockIO.Weight = &w
+			// On cgroup v2 the runtime translates the legacy blkio weight
+			// to the io.weight interface file. Kernels that only provide
+			// the BFQ scheduler expose io.bfq.weight instead, which takes
+			// the legacy value as-is.
+			if cdcgroups.Mode() == cdcgroups.Unified && !cgroupv2IOFileExists("io.weight") && cgroupv2IOFileExists("io.bfq.weight") {
+				if s.Linux.Resources.Unified == nil {
+					s.Linux.Resources.Unified = make(map[string]string)
+				}
+				s.Linux.Resources.Unified["io.bfq.weight"] = strconv.FormatUint(uint64(w), 10)
+			}
+		}
+		if len(r.IOLatencyTarget) > 0 {
+			ioLatency, err := getIOLatencyUnified(r)
+			if err != nil {
+				return err
+			}
+			if s.Linux.Resources.Unified == nil {
+				s.Linux.Resources.Unified = make(map[string]string)
+			}
+			s.Linux.Resources.Unified["io.latency"] = ioLatency
 		}
 		s.Linux.Resources.Pids = getPidsLimit(r)
 
@@ -971,6 +1030,18 @@ func WithResources(c *container.Container) coci.SpecOpts {
 	}
 }
 
+// cgroupv2IOFileExists reports whether the given io controller interface file
+// exists in the daemon's own cgroup. The io.weight and io.bfq.weight files
+// are scheduler dependent, so their presence has to be probed at runtime.
+func cgroupv2IOFileExists(file string) bool {
+	_, g, err := cdcgroups.ParseCgroupFileUnified("/proc/self/cgroup")
+	if err != nil || g == "" {
+		return false
+	}
+	_, err = os.Stat(filepath.Join("/sys/fs/cgroup", g, file))
+	return err == nil
+}
+
 // WithSysctls sets the container's sysctls
 func WithSysctls(c *container.Container) coci.SpecOpts {
 	return func(ctx context.Context, _ coci.Client, _ *containers.Container, s *coci.Spec) error {
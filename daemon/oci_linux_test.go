@@ -250,3 +250,98 @@ func checkResourcesAreUnset(t *testing.T, r *specs.LinuxResources) {
 		}
 	}
 }
+
+// TestWithRlimitsCoreDumpPolicy checks that HostConfig.CoreDump is mapped to
+// RLIMIT_CORE, overriding any "core" ulimit.
+func TestWithRlimitsCoreDumpPolicy(t *testing.T) {
+	d := &Daemon{}
+	cfg := &config.Config{}
+
+	tests := []struct {
+		name     string
+		ulimits  []*containertypes.Ulimit
+		coreDump *containertypes.CoreDumpConfig
+		expected specs.POSIXRlimit
+	}{
+		{
+			name:     "disabled",
+			coreDump: &containertypes.CoreDumpConfig{},
+			expected: specs.POSIXRlimit{Type: "RLIMIT_CORE"},
+		},
+		{
+			name:     "enabled unlimited",
+			coreDump: &containertypes.CoreDumpConfig{Enabled: true},
+			expected: specs.POSIXRlimit{Type: "RLIMIT_CORE", Soft: unix.RLIM_INFINITY, Hard: unix.RLIM_INFINITY},
+		},
+		{
+			name:     "enabled with size limit",
+			coreDump: &containertypes.CoreDumpConfig{Enabled: true, SizeLimit: 1 << 20},
+			expected: specs.POSIXRlimit{Type: "RLIMIT_CORE", Soft: 1 << 20, Hard: 1 << 20},
+		},
+		{
+			name:     "overrides core ulimit",
+			ulimits:  []*containertypes.Ulimit{{Name: "core", Soft: 42, Hard: 42}},
+			coreDump: &containertypes.CoreDumpConfig{},
+			expected: specs.POSIXRlimit{Type: "RLIMIT_CORE"},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			c := &container.Container{
+				HostConfig: &containertypes.HostConfig{
+					Resources: containertypes.Resources{Ulimits: tc.ulimits},
+					CoreDump:  tc.coreDump,
+				},
+			}
+			s := &specs.Spec{}
+			err := withRlimits(d, cfg, c)(context.Background(), nil, nil, s)
+			assert.NilError(t, err)
+
+			var core []specs.POSIXRlimit
+			for _, rl := range s.Process.Rlimits {
+				if rl.Type == "RLIMIT_CORE" {
+					core = append(core, rl)
+				}
+			}
+			assert.Check(t, is.DeepEqual(core, []specs.POSIXRlimit{tc.expected}))
+		})
+	}
+}
+
+// TestWithDevicesCDIQualifiedNames checks that fully-qualified CDI device
+// names passed via HostConfig.Devices (as in "docker run --device
+// vendor.com/gpu=0") are routed through the CDI device driver instead of
+// being treated as host paths.
+func TestWithDevicesCDIQualifiedNames(t *testing.T) {
+	var requested []string
+	registerDeviceDriver("cdi", &deviceDriver{
+		updateSpec: func(_ *specs.Spec, dev *deviceInstance) error {
+			requested = dev.req.DeviceIDs
+			return nil
+		},
+	})
+	defer delete(deviceDrivers, "cdi")
+
+	c := &container.Container{
+		HostConfig: &containertypes.HostConfig{
+			Resources: containertypes.Resources{
+				Devices: []containertypes.DeviceMapping{
+					{PathOnHost: "vendor.com/gpu=0", PathInContainer: "vendor.com/gpu=0", CgroupPermissions: "rwm"},
+					{PathOnHost: "/dev/null", PathInContainer: "/dev/fake", CgroupPermissions: "rwm"},
+				},
+			},
+		},
+	}
+	d := &Daemon{}
+
+	s := &specs.Spec{Linux: &specs.Linux{Resources: &specs.LinuxResources{}}}
+	err := WithDevices(d, c)(context.Background(), nil, nil, s)
+	assert.NilError(t, err)
+
+	assert.Check(t, is.DeepEqual(requested, []string{"vendor.com/gpu=0"}))
+	var paths []string
+	for _, dev := range s.Linux.Devices {
+		paths = append(paths, dev.Path)
+	}
+	assert.Check(t, is.DeepEqual(paths, []string{"/dev/fake"}))
+}
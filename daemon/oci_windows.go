@@ -19,6 +19,7 @@ import (
 	"github.com/moby/moby/v2/daemon/pkg/oci"
 	"github.com/moby/moby/v2/daemon/server/backend"
 	"github.com/moby/moby/v2/errdefs"
+	"github.com/opencontainers/go-digest"
 	"github.com/opencontainers/runtime-spec/specs-go"
 	"github.com/pkg/errors"
 	"golang.org/x/sys/windows"
@@ -348,10 +349,31 @@ func getBackingDeviceForContainerdMount(mountPoint string) (string, error) {
 var errInvalidCredentialSpecSecOpt = errdefs.InvalidParameter(fmt.Errorf("invalid credential spec security option - value must be prefixed by 'file://', 'registry://', or 'raw://' followed by a non-empty value"))
 
 // setWindowsCredentialSpec sets the spec's `Windows.CredentialSpec`
-// field if relevant
+// field if relevant, and records a digest of the applied credential spec on
+// the container so inspect can report when the source has since been updated.
 func (daemon *Daemon) setWindowsCredentialSpec(c *container.Container, s *specs.Spec) error {
+	credentialSpec, err := daemon.resolveCredentialSpec(c)
+	if err != nil {
+		return err
+	}
+
+	if credentialSpec != "" {
+		if s.Windows == nil {
+			s.Windows = &specs.Windows{}
+		}
+		s.Windows.CredentialSpec = credentialSpec
+		c.CredentialSpecDigest = digest.FromString(credentialSpec).String()
+	}
+
+	return nil
+}
+
+// resolveCredentialSpec resolves the credential spec referenced by the
+// container's "credentialspec" security option. It returns an empty string
+// when the container has no credential spec configured.
+func (daemon *Daemon) resolveCredentialSpec(c *container.Container) (string, error) {
 	if c.HostConfig == nil || c.HostConfig.SecurityOpt == nil {
-		return nil
+		return "", nil
 	}
 
 	// TODO (jrouge/wk8): if provided with several security options, we silently ignore
@@ -363,28 +385,28 @@ func (daemon *Daemon) setWindowsCredentialSpec(c *container.Container, s *specs.
 	for _, secOpt := range c.HostConfig.SecurityOpt {
 		k, v, ok := strings.Cut(secOpt, "=")
 		if !ok {
-			return errdefs.InvalidParameter(fmt.Errorf("invalid security option: no equals sign in supplied value %s", secOpt))
+			return "", errdefs.InvalidParameter(fmt.Errorf("invalid security option: no equals sign in supplied value %s", secOpt))
 		}
 		// FIXME(thaJeztah): options should not be case-insensitive
 		if !strings.EqualFold(k, "credentialspec") {
-			return errdefs.InvalidParameter(fmt.Errorf("security option not supported: %s", k))
+			return "", errdefs.InvalidParameter(fmt.Errorf("security option not supported: %s", k))
 		}
 
 		scheme, value, ok := strings.Cut(v, "://")
 		if !ok || value == "" {
-			return errInvalidCredentialSpecSecOpt
+			return "", errInvalidCredentialSpecSecOpt
 		}
 		var err error
 		switch strings.ToLower(scheme) {
 		case "file":
 			credentialSpec, err = readCredentialSpecFile(c.ID, daemon.root, filepath.Clean(value))
 			if err != nil {
-				return errdefs.InvalidParameter(err)
+				return "", errdefs.InvalidParameter(err)
 			}
 		case "registry":
 			credentialSpec, err = readCredentialSpecRegistry(c.ID, value)
 			if err != nil {
-				return errdefs.InvalidParameter(err)
+				return "", errdefs.InvalidParameter(err)
 			}
 		case "config":
 			// if the container does not have a DependencyStore, then it
@@ -392,12 +414,12 @@ func (daemon *Daemon) setWindowsCredentialSpec(c *container.Container, s *specs.
 			// impression that `config://` is a valid API, return the same
 			// error as if you'd passed any other random word.
 			if c.DependencyStore == nil {
-				return errInvalidCredentialSpecSecOpt
+				return "", errInvalidCredentialSpecSecOpt
 			}
 
 			csConfig, err := c.DependencyStore.Configs().Get(value)
 			if err != nil {
-				return errdefs.System(errors.Wrap(err, "error getting value from config store"))
+				return "", errdefs.System(errors.Wrap(err, "error getting value from config store"))
 			}
 			// stuff the resulting secret data into a string to use as the
 			// CredentialSpec
@@ -405,18 +427,11 @@ func (daemon *Daemon) setWindowsCredentialSpec(c *container.Container, s *specs.
 		case "raw":
 			credentialSpec = value
 		default:
-			return errInvalidCredentialSpecSecOpt
-		}
-	}
-
-	if credentialSpec != "" {
-		if s.Windows == nil {
-			s.Windows = &specs.Windows{}
+			return "", errInvalidCredentialSpecSecOpt
 		}
-		s.Windows.CredentialSpec = credentialSpec
 	}
 
-	return nil
+	return credentialSpec, nil
 }
 
 func setResourcesInSpec(c *container.Container, s *specs.Spec, isHyperV bool) {
@@ -456,6 +471,21 @@ func setResourcesInSpec(c *container.Container, s *specs.Spec, isHyperV bool) {
 		}
 	}
 
+	if len(c.HostConfig.CPUGroupAffinity) > 0 {
+		if s.Windows.Resources == nil {
+			s.Windows.Resources = &specs.WindowsResources{}
+		}
+		if s.Windows.Resources.CPU == nil {
+			s.Windows.Resources.CPU = &specs.WindowsCPUResources{}
+		}
+		for _, affinity := range c.HostConfig.CPUGroupAffinity {
+			s.Windows.Resources.CPU.Affinity = append(s.Windows.Resources.CPU.Affinity, specs.WindowsCPUGroupAffinity{
+				Mask:  affinity.Mask,
+				Group: affinity.Group,
+			})
+		}
+	}
+
 	memoryLimit := uint64(c.HostConfig.Memory)
 	if memoryLimit != 0 {
 		if s.Windows.Resources == nil {
@@ -388,3 +388,29 @@ func TestSetupWindowsDevices(t *testing.T) {
 		}
 	})
 }
+
+func TestSetResourcesInSpecStorageQoS(t *testing.T) {
+	c := &container.Container{
+		HostConfig: &containertypes.HostConfig{
+			Resources: containertypes.Resources{
+				IOMaximumIOps:      1000,
+				IOMaximumBandwidth: 32 * 1024 * 1024,
+			},
+		},
+	}
+
+	for _, isHyperV := range []bool{false, true} {
+		s := &specs.Spec{Windows: &specs.Windows{}}
+		setResourcesInSpec(c, s, isHyperV)
+
+		assert.Assert(t, s.Windows.Resources != nil)
+		assert.Assert(t, s.Windows.Resources.Storage != nil)
+		assert.Check(t, is.Equal(*s.Windows.Resources.Storage.Iops, uint64(1000)))
+		assert.Check(t, is.Equal(*s.Windows.Resources.Storage.Bps, uint64(32*1024*1024)))
+	}
+
+	// No storage QoS is set when no limits are requested.
+	s := &specs.Spec{Windows: &specs.Windows{}}
+	setResourcesInSpec(&container.Container{HostConfig: &containertypes.HostConfig{}}, s, false)
+	assert.Check(t, s.Windows.Resources == nil || s.Windows.Resources.Storage == nil)
+}
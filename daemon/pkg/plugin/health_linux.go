@@ -0,0 +1,165 @@
+package plugin
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/containerd/log"
+	"github.com/moby/moby/api/types"
+	v2 "github.com/moby/moby/v2/daemon/pkg/plugin/v2"
+	"github.com/pkg/errors"
+)
+
+const (
+	defaultHealthInterval = 30 * time.Second
+	defaultHealthRetries  = 3
+	healthProbeTimeout    = 10 * time.Second
+
+	// Exponential backoff applied between health-triggered restarts, so a
+	// plugin that keeps failing its probes does not restart in a tight loop.
+	healthBackoffInitial = 1 * time.Second
+	healthBackoffMax     = 1 * time.Minute
+)
+
+// startHealthMonitor begins periodic liveness probing of a started plugin,
+// replacing any monitor left over from a previous start. When the plugin
+// fails enough consecutive probes it is restarted.
+func (pm *Manager) startHealthMonitor(p *v2.Plugin, c *controller) {
+	interval := defaultHealthInterval
+	retries := defaultHealthRetries
+	path := ""
+	if hc := p.PluginObj.Config.Healthcheck; hc != nil {
+		if hc.Interval > 0 {
+			interval = time.Duration(hc.Interval)
+		}
+		if hc.Retries > 0 {
+			retries = hc.Retries
+		}
+		path = hc.Path
+	}
+
+	pm.mu.Lock()
+	if c.healthStop != nil {
+		close(c.healthStop)
+	}
+	stop := make(chan struct{})
+	c.healthStop = stop
+	pm.mu.Unlock()
+
+	go pm.monitorPluginHealth(p, c, stop, interval, retries, path)
+}
+
+// stopHealthMonitor stops the plugin's health monitor, if one is running.
+func (pm *Manager) stopHealthMonitor(c *controller) {
+	pm.mu.Lock()
+	if c.healthStop != nil {
+		close(c.healthStop)
+		c.healthStop = nil
+	}
+	pm.mu.Unlock()
+}
+
+func (pm *Manager) monitorPluginHealth(p *v2.Plugin, c *controller, stop chan struct{}, interval time.Duration, retries int, path string) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	logger := log.G(context.TODO()).WithField("plugin", p.Name())
+
+	var failures int
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+		}
+
+		if err := probePlugin(p, path); err != nil {
+			failures++
+			logger.WithError(err).Debugf("Plugin healthcheck failed (%d/%d)", failures, retries)
+			if failures < retries {
+				continue
+			}
+
+			p.SetStatus(types.PluginStatusUnhealthy)
+
+			pm.mu.Lock()
+			restart := c.restart
+			backoff := c.restartBackoff
+			if backoff == 0 {
+				backoff = healthBackoffInitial
+			}
+			if next := backoff * 2; next <= healthBackoffMax {
+				c.restartBackoff = next
+			} else {
+				c.restartBackoff = healthBackoffMax
+			}
+			pm.mu.Unlock()
+
+			if !restart {
+				// The plugin is being disabled; leave it alone.
+				return
+			}
+
+			logger.WithField("backoff", backoff).Warn("Plugin is unhealthy, restarting")
+			p.SetStatus(types.PluginStatusRestarting)
+			select {
+			case <-time.After(backoff):
+			case <-stop:
+				return
+			}
+			// Killing the plugin makes the executor deliver an exit event;
+			// HandleExitEvent then re-enables the plugin because the
+			// controller still has restart set, which also starts a fresh
+			// health monitor.
+			shutdownPlugin(p, c.exitChan, pm.executor)
+			return
+		}
+
+		failures = 0
+		p.SetStatus(types.PluginStatusHealthy)
+		pm.mu.Lock()
+		c.restartBackoff = 0
+		pm.mu.Unlock()
+	}
+}
+
+// probePlugin checks that the plugin is alive: it dials the plugin's socket
+// and, when the plugin's config declares a healthcheck path, requests it
+// over HTTP and requires a status code below 400.
+func probePlugin(p *v2.Plugin, path string) error {
+	addr := p.Addr()
+	if addr == nil {
+		return errors.New("plugin has no address")
+	}
+	if path == "" {
+		conn, err := net.DialTimeout(addr.Network(), addr.String(), healthProbeTimeout)
+		if err != nil {
+			return err
+		}
+		return conn.Close()
+	}
+
+	client := &http.Client{
+		Timeout: healthProbeTimeout,
+		Transport: &http.Transport{
+			DialContext: func(_ context.Context, _, _ string) (net.Conn, error) {
+				return net.DialTimeout(addr.Network(), addr.String(), healthProbeTimeout)
+			},
+		},
+	}
+	if !strings.HasPrefix(path, "/") {
+		path = "/" + path
+	}
+	resp, err := client.Get("http://plugin" + path)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	if resp.StatusCode >= http.StatusBadRequest {
+		return errors.Errorf("plugin healthcheck returned status %s", resp.Status)
+	}
+	return nil
+}
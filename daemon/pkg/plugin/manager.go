@@ -11,6 +11,7 @@ import (
 	"strings"
 	"sync"
 	"syscall"
+	"time"
 
 	"github.com/containerd/containerd/v2/core/content"
 	"github.com/containerd/containerd/v2/plugins/content/local"
@@ -90,6 +91,12 @@ type controller struct {
 	restart       bool
 	exitChan      chan bool
 	timeoutInSecs int
+
+	// healthStop stops the health monitor of the running plugin, if any.
+	healthStop chan struct{}
+	// restartBackoff is the delay before the next health-triggered
+	// restart; it grows exponentially while the plugin keeps failing.
+	restartBackoff time.Duration
 }
 
 // NewManager returns a new plugin manager.
@@ -113,6 +113,7 @@ func (pm *Manager) pluginPostStart(p *v2.Plugin, c *controller) error {
 	}
 	pm.config.Store.SetState(p, true)
 	pm.config.Store.CallHandler(p)
+	pm.startHealthMonitor(p, c)
 
 	return pm.save(p)
 }
@@ -185,7 +186,9 @@ func (pm *Manager) disable(p *v2.Plugin, c *controller) error {
 	}
 
 	c.restart = false
+	pm.stopHealthMonitor(c)
 	shutdownPlugin(p, c.exitChan, pm.executor)
+	p.SetStatus("")
 	pm.config.Store.SetState(p, false)
 	return pm.save(p)
 }
@@ -204,6 +207,7 @@ func (pm *Manager) Shutdown() {
 		}
 		if pm.executor != nil && p.IsEnabled() {
 			c.restart = false
+			pm.stopHealthMonitor(c)
 			shutdownPlugin(p, c.exitChan, pm.executor)
 		}
 	}
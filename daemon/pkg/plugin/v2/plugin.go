@@ -309,6 +309,21 @@ func (p *Plugin) SetAddr(addr net.Addr) {
 	p.mu.Unlock()
 }
 
+// Status returns the plugin's current health status.
+func (p *Plugin) Status() string {
+	p.mu.RLock()
+	s := p.PluginObj.Status
+	p.mu.RUnlock()
+	return s
+}
+
+// SetStatus sets the plugin's current health status.
+func (p *Plugin) SetStatus(s string) {
+	p.mu.Lock()
+	p.PluginObj.Status = s
+	p.mu.Unlock()
+}
+
 // Protocol is the protocol that should be used for interacting with the plugin.
 func (p *Plugin) Protocol() string {
 	if p.PluginObj.Config.Interface.ProtocolScheme != "" {
@@ -0,0 +1,135 @@
+package daemon
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/containerd/log"
+	"github.com/moby/go-archive"
+	systemtype "github.com/moby/moby/api/types/system"
+	"github.com/moby/moby/v2/daemon/config"
+	"github.com/opencontainers/go-digest"
+)
+
+// preloadStateFilename is the file under the daemon root that records the
+// digests of preload entries already imported by earlier daemon starts.
+const preloadStateFilename = "preloaded-images.json"
+
+// preloadImages imports image tarballs and OCI layout directories staged in
+// the configured preload-images-dir. Entries whose digest was already
+// imported by an earlier daemon start are skipped, making the import
+// idempotent. Results are logged and exposed via GET /system/preload-images.
+func (daemon *Daemon) preloadImages(ctx context.Context, cfg *config.Config) {
+	dir := cfg.PreloadImagesDir
+	if dir == "" {
+		return
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		log.G(ctx).WithError(err).WithField("dir", dir).Error("failed to read preload-images-dir")
+		return
+	}
+
+	statePath := filepath.Join(daemon.root, preloadStateFilename)
+	imported := map[string]bool{}
+	if data, err := os.ReadFile(statePath); err == nil {
+		if err := json.Unmarshal(data, &imported); err != nil {
+			log.G(ctx).WithError(err).WithField("file", statePath).Warn("ignoring corrupt preload state file")
+			imported = map[string]bool{}
+		}
+	}
+
+	var results []systemtype.PreloadedImage
+	for _, entry := range entries {
+		path := filepath.Join(dir, entry.Name())
+		res := systemtype.PreloadedImage{Path: entry.Name()}
+		logger := log.G(ctx).WithField("path", path)
+
+		dgst, open, err := preloadEntrySource(path, entry)
+		if err != nil {
+			logger.WithError(err).Error("failed to read preload entry")
+			res.Error = err.Error()
+			results = append(results, res)
+			continue
+		}
+		if open == nil {
+			// Not an image tarball or OCI layout; ignore.
+			continue
+		}
+		res.Digest = dgst.String()
+
+		if imported[res.Digest] {
+			logger.WithField("digest", res.Digest).Debug("preload entry already imported, skipping")
+			results = append(results, res)
+			continue
+		}
+
+		logger.WithField("digest", res.Digest).Info("importing preloaded image")
+		rc, err := open()
+		if err == nil {
+			err = daemon.imageService.LoadImage(ctx, rc, nil, io.Discard, true)
+		}
+		if err != nil {
+			logger.WithError(err).Error("failed to import preloaded image")
+			res.Error = err.Error()
+		} else {
+			logger.Info("preloaded image imported")
+			res.Loaded = true
+			imported[res.Digest] = true
+		}
+		results = append(results, res)
+	}
+
+	if data, err := json.Marshal(imported); err == nil {
+		if err := os.WriteFile(statePath, data, 0o600); err != nil {
+			log.G(ctx).WithError(err).WithField("file", statePath).Warn("failed to save preload state")
+		}
+	}
+	daemon.preloadedImages = results
+}
+
+// preloadEntrySource identifies a preload directory entry. For image
+// tarballs the digest covers the file contents; for OCI layout directories
+// it covers the layout's index.json, which references all content by digest.
+// A nil open function means the entry is not a preloadable image.
+func preloadEntrySource(path string, entry os.DirEntry) (digest.Digest, func() (io.ReadCloser, error), error) {
+	if entry.IsDir() {
+		if _, err := os.Stat(filepath.Join(path, "oci-layout")); err != nil {
+			return "", nil, nil
+		}
+		index, err := os.ReadFile(filepath.Join(path, "index.json"))
+		if err != nil {
+			return "", nil, err
+		}
+		return digest.FromBytes(index), func() (io.ReadCloser, error) {
+			return archive.Tar(path, archive.Uncompressed)
+		}, nil
+	}
+
+	name := entry.Name()
+	if !strings.HasSuffix(name, ".tar") && !strings.HasSuffix(name, ".tar.gz") && !strings.HasSuffix(name, ".tgz") {
+		return "", nil, nil
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return "", nil, err
+	}
+	defer f.Close()
+	dgst, err := digest.FromReader(f)
+	if err != nil {
+		return "", nil, err
+	}
+	return dgst, func() (io.ReadCloser, error) {
+		return os.Open(path)
+	}, nil
+}
+
+// PreloadedImages returns the results of the image preload performed at
+// daemon startup. Called from the system router.
+func (daemon *Daemon) PreloadedImages() []systemtype.PreloadedImage {
+	return daemon.preloadedImages
+}
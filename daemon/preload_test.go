@@ -0,0 +1,59 @@
+package daemon
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/opencontainers/go-digest"
+	"gotest.tools/v3/assert"
+	is "gotest.tools/v3/assert/cmp"
+)
+
+func TestPreloadEntrySource(t *testing.T) {
+	dir := t.TempDir()
+
+	tarball := filepath.Join(dir, "busybox.tar")
+	assert.NilError(t, os.WriteFile(tarball, []byte("tarball content"), 0o600))
+
+	layout := filepath.Join(dir, "alpine")
+	assert.NilError(t, os.Mkdir(layout, 0o755))
+	assert.NilError(t, os.WriteFile(filepath.Join(layout, "oci-layout"), []byte(`{"imageLayoutVersion": "1.0.0"}`), 0o600))
+	assert.NilError(t, os.WriteFile(filepath.Join(layout, "index.json"), []byte(`{"manifests": []}`), 0o600))
+
+	plainDir := filepath.Join(dir, "not-a-layout")
+	assert.NilError(t, os.Mkdir(plainDir, 0o755))
+
+	notes := filepath.Join(dir, "README.md")
+	assert.NilError(t, os.WriteFile(notes, []byte("notes"), 0o600))
+
+	entries, err := os.ReadDir(dir)
+	assert.NilError(t, err)
+
+	byName := map[string]os.DirEntry{}
+	for _, e := range entries {
+		byName[e.Name()] = e
+	}
+
+	dgst, open, err := preloadEntrySource(tarball, byName["busybox.tar"])
+	assert.NilError(t, err)
+	assert.Assert(t, open != nil)
+	assert.Check(t, is.Equal(dgst, digest.FromBytes([]byte("tarball content"))))
+	rc, err := open()
+	assert.NilError(t, err)
+	assert.NilError(t, rc.Close())
+
+	dgst, open, err = preloadEntrySource(layout, byName["alpine"])
+	assert.NilError(t, err)
+	assert.Assert(t, open != nil)
+	assert.Check(t, is.Equal(dgst, digest.FromBytes([]byte(`{"manifests": []}`))))
+
+	// Directories without an oci-layout file and unrelated files are ignored.
+	_, open, err = preloadEntrySource(plainDir, byName["not-a-layout"])
+	assert.NilError(t, err)
+	assert.Check(t, is.Nil(open))
+
+	_, open, err = preloadEntrySource(notes, byName["README.md"])
+	assert.NilError(t, err)
+	assert.Check(t, is.Nil(open))
+}
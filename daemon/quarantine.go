@@ -0,0 +1,139 @@
+package daemon
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/containerd/log"
+	containertypes "github.com/moby/moby/api/types/container"
+	"github.com/moby/moby/v2/errdefs"
+	"github.com/moby/sys/atomicwriter"
+	"github.com/pkg/errors"
+)
+
+const (
+	// quarantineDirName is the directory (under the daemon root) holding
+	// container directories that could not be parsed at startup.
+	quarantineDirName = "containers-quarantine"
+	// quarantineMetaFile is the metadata file written inside a quarantined
+	// container directory, recording why and when it was quarantined.
+	quarantineMetaFile = "quarantine.json"
+)
+
+func (daemon *Daemon) quarantineDir() string {
+	return filepath.Join(daemon.root, quarantineDirName)
+}
+
+// isUnparseableContainerError returns true when loading a container failed
+// because its on-disk configuration could not be parsed, as opposed to a
+// transient error.
+func isUnparseableContainerError(err error) bool {
+	var syntaxErr *json.SyntaxError
+	var typeErr *json.UnmarshalTypeError
+	return errors.As(err, &syntaxErr) || errors.As(err, &typeErr)
+}
+
+// quarantineContainer moves the on-disk directory of an unparseable
+// container out of the daemon repository into the quarantine area, so that
+// it no longer produces errors on every startup. The directory can be
+// inspected with QuarantinedContainers and moved back (after repair) with
+// RestoreQuarantinedContainer.
+func (daemon *Daemon) quarantineContainer(id string, loadErr error) error {
+	if err := os.MkdirAll(daemon.quarantineDir(), 0o700); err != nil {
+		return err
+	}
+	dst := filepath.Join(daemon.quarantineDir(), id)
+	if err := os.Rename(filepath.Join(daemon.repository, id), dst); err != nil {
+		return err
+	}
+	meta, err := json.Marshal(containertypes.QuarantineEntry{
+		ID:            id,
+		Reason:        loadErr.Error(),
+		QuarantinedAt: time.Now().UTC(),
+	})
+	if err != nil {
+		return err
+	}
+	return atomicwriter.WriteFile(filepath.Join(dst, quarantineMetaFile), meta, 0o600)
+}
+
+// QuarantinedContainers lists the containers that were moved to the
+// quarantine area because their configuration could not be parsed.
+func (daemon *Daemon) QuarantinedContainers() ([]containertypes.QuarantineEntry, error) {
+	dir, err := os.ReadDir(daemon.quarantineDir())
+	if err != nil {
+		if os.IsNotExist(err) {
+			return []containertypes.QuarantineEntry{}, nil
+		}
+		return nil, err
+	}
+
+	entries := make([]containertypes.QuarantineEntry, 0, len(dir))
+	for _, v := range dir {
+		if !v.IsDir() {
+			continue
+		}
+		entry := containertypes.QuarantineEntry{ID: v.Name()}
+		data, err := os.ReadFile(filepath.Join(daemon.quarantineDir(), v.Name(), quarantineMetaFile))
+		if err == nil {
+			if err := json.Unmarshal(data, &entry); err != nil {
+				log.G(context.TODO()).WithError(err).WithField("container", v.Name()).Warn("Error parsing quarantine metadata")
+			}
+			entry.ID = v.Name()
+		}
+		entries = append(entries, entry)
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].ID < entries[j].ID })
+	return entries, nil
+}
+
+// RestoreQuarantinedContainer moves a quarantined container directory back
+// into the daemon repository and attempts to load and register the
+// container. The configuration must have been repaired out-of-band; if it
+// still cannot be parsed, the container is quarantined again.
+func (daemon *Daemon) RestoreQuarantinedContainer(id string) error {
+	src := filepath.Join(daemon.quarantineDir(), id)
+	if _, err := os.Stat(src); err != nil {
+		if os.IsNotExist(err) {
+			return errdefs.NotFound(errors.Errorf("no such quarantined container: %s", id))
+		}
+		return err
+	}
+	if ctr, _ := daemon.GetContainer(id); ctr != nil {
+		return errdefs.Conflict(errors.Errorf("container %s already exists", id))
+	}
+
+	if err := os.Remove(filepath.Join(src, quarantineMetaFile)); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	if err := os.Rename(src, filepath.Join(daemon.repository, id)); err != nil {
+		return err
+	}
+
+	c, err := daemon.load(id)
+	if err != nil {
+		if isUnparseableContainerError(err) {
+			if qErr := daemon.quarantineContainer(id, err); qErr != nil {
+				log.G(context.TODO()).WithError(qErr).WithField("container", id).Error("failed to re-quarantine container")
+			}
+		}
+		return errdefs.InvalidParameter(errors.Wrap(err, "container configuration is still unparseable"))
+	}
+	if c.Driver != daemon.imageService.StorageDriver() {
+		return errdefs.Conflict(errors.Errorf("container %s was created with storage driver %s", id, c.Driver))
+	}
+	rwLayer, err := daemon.imageService.GetLayerByID(c.ID)
+	if err != nil {
+		return errors.Wrap(err, "failed to load container mount")
+	}
+	c.RWLayer = rwLayer
+
+	if err := daemon.registerName(c); err != nil {
+		return err
+	}
+	return daemon.register(context.TODO(), c)
+}
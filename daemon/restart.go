@@ -3,7 +3,10 @@ package daemon
 import (
 	"context"
 	"fmt"
+	"strings"
+	"time"
 
+	"github.com/containerd/log"
 	containertypes "github.com/moby/moby/api/types/container"
 	"github.com/moby/moby/api/types/events"
 	"github.com/moby/moby/v2/daemon/container"
@@ -27,6 +30,29 @@ func (daemon *Daemon) ContainerRestart(ctx context.Context, name string, options
 	return nil
 }
 
+// ContainerRestartSimulate returns the decision the container's restart
+// manager would take for a hypothetical exit with the given exit code, based
+// on the container's current restart policy, restart count and backoff state.
+// No state is modified; the simulated restart is not counted against the
+// policy's maximum retry count and does not advance the backoff.
+func (daemon *Daemon) ContainerRestartSimulate(name string, exitCode int) (*containertypes.RestartSimulation, error) {
+	ctr, err := daemon.GetContainer(name)
+	if err != nil {
+		return nil, err
+	}
+
+	ctr.Lock()
+	defer ctr.Unlock()
+
+	wouldRestart, delay := ctr.RestartManager().Simulate(uint32(exitCode), ctr.HasBeenManuallyStopped, ctr.State.ExecutionDuration())
+	return &containertypes.RestartSimulation{
+		WouldRestart: wouldRestart,
+		Delay:        delay,
+		RestartCount: ctr.RestartCount,
+		Policy:       ctr.HostConfig.RestartPolicy,
+	}, nil
+}
+
 // containerRestart attempts to gracefully stop and then start the
 // container. When stopping, wait for the given duration in seconds to
 // gracefully stop, before forcefully terminating the container. If
@@ -72,3 +98,87 @@ func (daemon *Daemon) containerRestart(ctx context.Context, daemonCfg *configSto
 	daemon.LogContainerEvent(container, events.ActionRestart)
 	return nil
 }
+
+// restartDependencyTimeout bounds how long a container restarted at daemon
+// boot waits for the containers in its DependsOn list.
+const restartDependencyTimeout = 60 * time.Second
+
+// resolveRestartDependencies maps each container restarted at daemon boot to
+// the containers named in its HostConfig.DependsOn list. Names that don't
+// resolve to a container are logged and ignored.
+func (daemon *Daemon) resolveRestartDependencies(restartContainers map[*container.Container]chan struct{}) map[*container.Container][]*container.Container {
+	graph := make(map[*container.Container][]*container.Container)
+	for c := range restartContainers {
+		for _, name := range c.HostConfig.DependsOn {
+			dep, err := daemon.GetContainer(name)
+			if err != nil {
+				log.G(context.TODO()).WithFields(log.Fields{
+					"container":  c.ID,
+					"dependency": name,
+				}).Warn("ignoring unknown container dependency")
+				continue
+			}
+			graph[c] = append(graph[c], dep)
+		}
+	}
+	return graph
+}
+
+// restartDependencyCycles returns the containers that participate in a
+// DependsOn cycle. Containers in a cycle are started in arbitrary order
+// instead of stalling the boot sequence until the dependency timeout.
+func restartDependencyCycles(graph map[*container.Container][]*container.Container) map[*container.Container]bool {
+	inCycle := make(map[*container.Container]bool)
+	for c := range graph {
+		stack := append([]*container.Container(nil), graph[c]...)
+		visited := make(map[*container.Container]bool)
+		for len(stack) > 0 {
+			cur := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			if cur == c {
+				inCycle[c] = true
+				break
+			}
+			if visited[cur] {
+				continue
+			}
+			visited[cur] = true
+			stack = append(stack, graph[cur]...)
+		}
+	}
+	return inCycle
+}
+
+// waitForRestartDependencies blocks until every dependency has been started
+// (and reports healthy, if it defines a health check) or the timeout
+// expires. Dependencies that are neither running nor part of the restart set
+// are skipped; the ordering is best effort, like the legacy link wait.
+func waitForRestartDependencies(deps []*container.Container, started map[*container.Container]chan struct{}, timeout time.Duration) error {
+	deadline := time.After(timeout)
+	for _, dep := range deps {
+		if ch, ok := started[dep]; ok {
+			select {
+			case <-ch:
+			case <-deadline:
+				return fmt.Errorf("timed out waiting for dependency %s to start", strings.TrimPrefix(dep.Name, "/"))
+			}
+		}
+		for {
+			dep.Lock()
+			running := dep.Running
+			healthy := dep.Health == nil || dep.Health.Status() == containertypes.Healthy
+			dep.Unlock()
+			if !running || healthy {
+				// A dependency that failed to start, or is not running at
+				// all, is not worth waiting out the timeout for.
+				break
+			}
+			select {
+			case <-time.After(500 * time.Millisecond):
+			case <-deadline:
+				return fmt.Errorf("timed out waiting for dependency %s to become healthy", strings.TrimPrefix(dep.Name, "/"))
+			}
+		}
+	}
+	return nil
+}
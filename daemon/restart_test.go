@@ -0,0 +1,57 @@
+package daemon
+
+import (
+	"testing"
+	"time"
+
+	"github.com/moby/moby/v2/daemon/container"
+	"gotest.tools/v3/assert"
+	is "gotest.tools/v3/assert/cmp"
+)
+
+func TestRestartDependencyCycles(t *testing.T) {
+	a := &container.Container{ID: "a"}
+	b := &container.Container{ID: "b"}
+	c := &container.Container{ID: "c"}
+
+	// a <-> b form a cycle, c depends on a but is not part of it.
+	graph := map[*container.Container][]*container.Container{
+		a: {b},
+		b: {a},
+		c: {a},
+	}
+
+	inCycle := restartDependencyCycles(graph)
+	assert.Check(t, inCycle[a])
+	assert.Check(t, inCycle[b])
+	assert.Check(t, !inCycle[c])
+}
+
+func TestWaitForRestartDependencies(t *testing.T) {
+	newDep := func(name string) (*container.Container, chan struct{}) {
+		return &container.Container{Name: name, State: container.NewState()}, make(chan struct{})
+	}
+
+	t.Run("started dependency", func(t *testing.T) {
+		dep, ch := newDep("/dep")
+		dep.SetRunning(nil, nil, time.Now())
+		close(ch)
+
+		started := map[*container.Container]chan struct{}{dep: ch}
+		err := waitForRestartDependencies([]*container.Container{dep}, started, time.Second)
+		assert.NilError(t, err)
+	})
+
+	t.Run("timeout waiting for start", func(t *testing.T) {
+		dep, ch := newDep("/slow")
+		started := map[*container.Container]chan struct{}{dep: ch}
+		err := waitForRestartDependencies([]*container.Container{dep}, started, 50*time.Millisecond)
+		assert.Check(t, is.ErrorContains(err, "timed out waiting for dependency slow to start"))
+	})
+
+	t.Run("dependency outside the restart set", func(t *testing.T) {
+		dep, _ := newDep("/stopped")
+		err := waitForRestartDependencies([]*container.Container{dep}, nil, 50*time.Millisecond)
+		assert.NilError(t, err)
+	})
+}
@@ -0,0 +1,62 @@
+package daemon
+
+import (
+	"context"
+	"time"
+
+	"github.com/containerd/log"
+	"github.com/moby/moby/api/types/events"
+)
+
+const (
+	// clockJumpCheckInterval is how often the daemon samples the wall and
+	// monotonic clocks to detect a suspend/resume cycle.
+	clockJumpCheckInterval = 10 * time.Second
+	// clockJumpThreshold is the minimum divergence between wall-clock and
+	// monotonic elapsed time that is treated as a host resume rather than
+	// scheduler noise.
+	clockJumpThreshold = 30 * time.Second
+)
+
+// monitorClockJumps runs a ticker comparing wall-clock and monotonic elapsed
+// time. The monotonic clock does not advance while the host is suspended, so
+// a large divergence between the two indicates the host resumed from suspend
+// (or the wall clock was stepped, e.g. by NTP). The loop runs until the
+// daemon shuts down.
+func (daemon *Daemon) monitorClockJumps() {
+	ticker := time.NewTicker(clockJumpCheckInterval)
+	defer ticker.Stop()
+
+	prev := time.Now()
+	for {
+		select {
+		case <-daemon.shutdownStarted:
+			return
+		case <-ticker.C:
+		}
+		now := time.Now()
+		// Round(0) strips the monotonic reading so that the subtraction
+		// measures wall-clock time.
+		wall := now.Round(0).Sub(prev.Round(0))
+		mono := now.Sub(prev)
+		prev = now
+		if jump := wall - mono; jump >= clockJumpThreshold {
+			daemon.handleResume(jump)
+		}
+	}
+}
+
+// handleResume re-evaluates time-based container state after the host wall
+// clock jumped forward, and emits a "daemon resume" event so that clients
+// can do the same. Restart backoffs are reset: the backoff was accumulated
+// against a clock that was not advancing, so carrying it over would delay
+// restarts for no reason.
+func (daemon *Daemon) handleResume(jump time.Duration) {
+	log.G(context.TODO()).WithField("clock-jump", jump).Info("host resume detected; re-evaluating container timers")
+	for _, c := range daemon.List() {
+		c.RestartManager().ResetBackoff()
+	}
+	daemon.LogDaemonEventWithAttributes(events.ActionResume, map[string]string{
+		"clock-jump": jump.String(),
+	})
+}
@@ -38,6 +38,14 @@ type shimConfig struct {
 	Opts     interface{}
 	Features *features.Features
 
+	// Version is the version string reported by a path-based runtime
+	// binary, probed at daemon start and on config reload.
+	Version string
+	// ProbeError records why probing the runtime binary failed, so that a
+	// broken runtime is surfaced in "docker info" rather than at container
+	// start.
+	ProbeError string
+
 	// Check if the ShimConfig is valid given the current state of the system.
 	PreflightCheck func() error
 }
@@ -65,6 +73,16 @@ func defaultV2ShimConfig(conf *config.Config, runtimePath string) *shimConfig {
 		},
 	}
 
+	var versionStderr bytes.Buffer
+	versionCmd := exec.Command(runtimePath, "--version")
+	versionCmd.Stderr = &versionStderr
+	if versionB, err := versionCmd.Output(); err != nil {
+		shim.ProbeError = err.Error()
+		log.G(context.TODO()).WithError(err).Warnf("Failed to run %v: %q", versionCmd.Args, versionStderr.String())
+	} else {
+		shim.Version = strings.TrimSpace(strings.SplitN(string(versionB), "\n", 2)[0])
+	}
+
 	var featuresStderr bytes.Buffer
 	featuresCmd := exec.Command(runtimePath, "features")
 	featuresCmd.Stderr = &featuresStderr
@@ -233,6 +251,32 @@ func (r *runtimes) Features(name string) *features.Features {
 	return nil
 }
 
+// Version returns the version string reported by the runtime binary, or an
+// empty string if the runtime was not probed.
+func (r *runtimes) Version(name string) string {
+	if name == "" {
+		name = r.Default
+	}
+
+	if rt := r.configured[name]; rt != nil {
+		return rt.Version
+	}
+	return ""
+}
+
+// ProbeError returns the error from probing the runtime binary, or an empty
+// string if the probe succeeded or the runtime was not probed.
+func (r *runtimes) ProbeError(name string) string {
+	if name == "" {
+		name = r.Default
+	}
+
+	if rt := r.configured[name]; rt != nil {
+		return rt.ProbeError
+	}
+	return ""
+}
+
 // isPermissibleC8dRuntimeName tests whether name is safe to pass into
 // containerd as a runtime name, and whether the name is well-formed.
 // It does not check if the runtime is installed.
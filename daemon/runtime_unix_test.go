@@ -241,7 +241,11 @@ func TestGetRuntime(t *testing.T) {
 
 	stockRuntime, ok := runtimes.configured[config.StockRuntimeName]
 	assert.Assert(t, ok, "stock runtime could not be found (test needs to be updated)")
+	// The probed runtime information depends on the environment the test
+	// runs in, and is not part of what Get returns.
 	stockRuntime.Features = nil
+	stockRuntime.Version = ""
+	stockRuntime.ProbeError = ""
 
 	configdOpts := proto.Clone(stockRuntime.Opts.(*runcoptions.Options)).(*runcoptions.Options)
 	configdOpts.BinaryName = configuredRuntime.Path
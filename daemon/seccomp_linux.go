@@ -2,13 +2,17 @@ package daemon
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
+	"fmt"
+	"strings"
 
 	"github.com/containerd/containerd/v2/core/containers"
 	coci "github.com/containerd/containerd/v2/pkg/oci"
 	"github.com/containerd/log"
 	dconfig "github.com/moby/moby/v2/daemon/config"
 	"github.com/moby/moby/v2/daemon/container"
+	"github.com/moby/moby/v2/errdefs"
 	"github.com/moby/profiles/seccomp"
 	"github.com/opencontainers/runtime-spec/specs-go"
 )
@@ -24,7 +28,7 @@ func WithSeccomp(daemon *Daemon, c *container.Container) coci.SpecOpts {
 		if c.HostConfig.Privileged {
 			var err error
 			if c.SeccompProfile != "" {
-				s.Linux.Seccomp, err = seccomp.LoadProfile(c.SeccompProfile, s)
+				s.Linux.Seccomp, err = loadSeccompProfile(c.SeccompProfile, s)
 			}
 			return err
 		}
@@ -39,12 +43,23 @@ func WithSeccomp(daemon *Daemon, c *container.Container) coci.SpecOpts {
 		if s.Linux == nil {
 			s.Linux = &specs.Linux{}
 		}
+		// The default profile configured for the container's runtime, if
+		// any, takes precedence over the daemon-wide default but not over
+		// a profile set on the container itself.
+		rtProfile, hasRTProfile := daemon.runtimeSeccomp[c.HostConfig.Runtime]
+
 		var err error
 		switch {
 		case c.SeccompProfile == dconfig.SeccompProfileDefault:
 			s.Linux.Seccomp, err = seccomp.GetDefaultProfile(s)
 		case c.SeccompProfile != "":
-			s.Linux.Seccomp, err = seccomp.LoadProfile(c.SeccompProfile, s)
+			s.Linux.Seccomp, err = loadSeccompProfile(c.SeccompProfile, s)
+		case hasRTProfile && rtProfile.content != nil:
+			s.Linux.Seccomp, err = seccomp.LoadProfile(string(rtProfile.content), s)
+		case hasRTProfile && rtProfile.path == dconfig.SeccompProfileUnconfined:
+			c.SeccompProfile = dconfig.SeccompProfileUnconfined
+		case hasRTProfile:
+			s.Linux.Seccomp, err = seccomp.GetDefaultProfile(s)
 		case daemon.seccompProfile != nil:
 			s.Linux.Seccomp, err = seccomp.LoadProfile(string(daemon.seccompProfile), s)
 		case daemon.seccompProfilePath == dconfig.SeccompProfileUnconfined:
@@ -52,6 +67,105 @@ func WithSeccomp(daemon *Daemon, c *container.Container) coci.SpecOpts {
 		default:
 			s.Linux.Seccomp, err = seccomp.GetDefaultProfile(s)
 		}
-		return err
+		if err != nil {
+			return err
+		}
+		return daemon.validateSeccompActions(c, s.Linux.Seccomp)
+	}
+}
+
+// loadSeccompProfile resolves a container-supplied seccomp profile. A
+// profile body prefixed with "+" is an overlay that is merged into the
+// built-in default profile instead of replacing it, so a profile can allow
+// or remove individual syscalls without copying the whole default profile.
+func loadSeccompProfile(body string, s *coci.Spec) (*specs.LinuxSeccomp, error) {
+	if overlay, ok := strings.CutPrefix(strings.TrimSpace(body), "+"); ok {
+		merged, err := mergeWithDefaultProfile(overlay)
+		if err != nil {
+			return nil, errdefs.InvalidParameter(err)
+		}
+		return seccomp.LoadProfile(string(merged), s)
+	}
+	return seccomp.LoadProfile(body, s)
+}
+
+// mergeWithDefaultProfile merges an overlay profile (in the regular seccomp
+// profile format) into the built-in default profile and returns the result
+// as JSON. Syscall names mentioned by the overlay are first removed from the
+// default profile's rules and the overlay rules are then appended, so an
+// overlay rule both overrides the action for a syscall and, when its action
+// is the profile's default action, effectively removes the syscall from the
+// default profile. A non-empty defaultAction in the overlay replaces the
+// default profile's.
+func mergeWithDefaultProfile(overlay string) ([]byte, error) {
+	var cfg seccomp.Seccomp
+	if err := json.Unmarshal([]byte(overlay), &cfg); err != nil {
+		return nil, fmt.Errorf("decoding seccomp profile overlay failed: %v", err)
+	}
+
+	overridden := make(map[string]bool)
+	for _, sc := range cfg.Syscalls {
+		if sc.Name != "" {
+			overridden[sc.Name] = true
+		}
+		for _, name := range sc.Names {
+			overridden[name] = true
+		}
+	}
+
+	merged := seccomp.DefaultProfile()
+	rules := merged.Syscalls[:0]
+	for _, sc := range merged.Syscalls {
+		names := sc.Names[:0]
+		for _, name := range sc.Names {
+			if !overridden[name] {
+				names = append(names, name)
+			}
+		}
+		sc.Names = names
+		if len(sc.Names) > 0 || sc.Name != "" {
+			rules = append(rules, sc)
+		}
+	}
+	merged.Syscalls = append(rules, cfg.Syscalls...)
+
+	if cfg.DefaultAction != "" {
+		merged.DefaultAction = cfg.DefaultAction
+		merged.DefaultErrnoRet = cfg.DefaultErrnoRet
+	}
+	return json.Marshal(merged)
+}
+
+// validateSeccompActions checks the actions used by the resolved seccomp
+// profile against those advertised in the runtime's OCI features document, so
+// that a profile using e.g. SCMP_ACT_NOTIFY with a runtime that does not
+// support it fails with a precise error instead of a generic start failure.
+// Runtimes which do not advertise their supported actions are not validated.
+func (daemon *Daemon) validateSeccompActions(c *container.Container, profile *specs.LinuxSeccomp) error {
+	if profile == nil {
+		return nil
+	}
+	rt := c.HostConfig.Runtime
+	cfg := daemon.config()
+	feat := cfg.Runtimes.Features(rt)
+	if feat == nil || feat.Linux == nil || feat.Linux.Seccomp == nil || feat.Linux.Seccomp.Actions == nil {
+		return nil
+	}
+	supported := make(map[string]bool, len(feat.Linux.Seccomp.Actions))
+	for _, a := range feat.Linux.Seccomp.Actions {
+		supported[a] = true
+	}
+	runtimeDesc := rt
+	if v := cfg.Runtimes.Version(rt); v != "" {
+		runtimeDesc += " (" + v + ")"
+	}
+	if !supported[string(profile.DefaultAction)] {
+		return errdefs.InvalidParameter(fmt.Errorf("seccomp default action %q is not supported by runtime %s", profile.DefaultAction, runtimeDesc))
+	}
+	for _, sc := range profile.Syscalls {
+		if !supported[string(sc.Action)] {
+			return errdefs.InvalidParameter(fmt.Errorf("seccomp action %q is not supported by runtime %s", sc.Action, runtimeDesc))
+		}
 	}
+	return nil
 }
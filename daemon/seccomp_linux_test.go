@@ -204,3 +204,37 @@ func TestWithSeccomp(t *testing.T) {
 		})
 	}
 }
+
+func TestLoadSeccompProfileOverlay(t *testing.T) {
+	spec := oci.DefaultLinuxSpec()
+	overlay := `+{
+		"syscalls": [
+			{"names": ["perf_event_open"], "action": "SCMP_ACT_ALLOW"},
+			{"names": ["chown"], "action": "SCMP_ACT_ERRNO"}
+		]
+	}`
+
+	profile, err := loadSeccompProfile(overlay, &spec)
+	assert.NilError(t, err)
+	assert.Assert(t, profile != nil)
+
+	actions := make(map[string]specs.LinuxSeccompAction)
+	for _, sc := range profile.Syscalls {
+		for _, name := range sc.Names {
+			actions[name] = sc.Action
+		}
+	}
+
+	// The overlay's rules take effect on top of the default profile.
+	assert.Equal(t, actions["perf_event_open"], specs.ActAllow)
+	assert.Equal(t, actions["chown"], specs.ActErrno)
+	// Unrelated rules from the default profile are preserved.
+	assert.Equal(t, actions["open"], specs.ActAllow)
+	assert.Equal(t, profile.DefaultAction, seccomp.DefaultProfile().DefaultAction)
+}
+
+func TestLoadSeccompProfileOverlayInvalid(t *testing.T) {
+	spec := oci.DefaultLinuxSpec()
+	_, err := loadSeccompProfile(`+{not json`, &spec)
+	assert.ErrorContains(t, err, "decoding seccomp profile overlay failed")
+}
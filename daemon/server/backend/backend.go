@@ -7,6 +7,7 @@ import (
 
 	"github.com/distribution/reference"
 	"github.com/moby/moby/api/types/container"
+	"github.com/moby/moby/api/types/filters"
 	"github.com/moby/moby/api/types/network"
 	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
 )
@@ -19,6 +20,37 @@ type ContainerCreateConfig struct {
 	NetworkingConfig            *network.NetworkingConfig
 	Platform                    *ocispec.Platform
 	DefaultReadOnlyNonRecursive bool
+	// LabelFiles and AnnotationFiles are files on the daemon host with
+	// KEY=VALUE lines to merge into the container's labels and annotations.
+	LabelFiles      []string
+	AnnotationFiles []string
+	// PinDigest makes the daemon resolve the image reference to a digest
+	// at create time and record the digested reference on the container.
+	PinDigest bool
+}
+
+// Compression selects the algorithm the daemon uses to (re)compress image
+// layers when pushing or exporting images.
+type Compression string
+
+const (
+	// CompressionDefault leaves the choice of algorithm to the daemon,
+	// which currently compresses with gzip.
+	CompressionDefault Compression = ""
+	// CompressionGzip compresses layers with gzip.
+	CompressionGzip Compression = "gzip"
+	// CompressionZstd compresses layers with zstd.
+	CompressionZstd Compression = "zstd"
+)
+
+// PushOptions holds daemon-side options for an image push.
+type PushOptions struct {
+	// Compression is the algorithm used for layers that are (re)compressed
+	// during the push.
+	Compression Compression
+	// CompressionLevel is the algorithm-specific compression level. Zero
+	// selects the algorithm's default level.
+	CompressionLevel int
 }
 
 // ContainerRmConfig holds arguments for the container remove
@@ -89,9 +121,52 @@ type LogSelector struct {
 type ContainerStatsConfig struct {
 	Stream    bool
 	OneShot   bool
+	NetDetail bool
+	OutStream func() io.Writer
+}
+
+// ContainersStatsConfig holds information for configuring the runtime
+// behavior of a backend.ContainersStats() call.
+type ContainersStatsConfig struct {
+	Stream    bool
+	Interval  time.Duration
+	Filters   filters.Args
+	OutStream func() io.Writer
+}
+
+// ContainerMirrorConfig holds information for configuring the runtime
+// behavior of a backend.ContainerMirrorTraffic() call.
+type ContainerMirrorConfig struct {
+	// Interface is the name of the interface inside the container's
+	// network namespace to capture from. If empty, the first interface
+	// of the container's sandbox is used.
+	Interface string
+	// Target is the name of an interface inside the container's network
+	// namespace to mirror traffic to. If empty, captured packets are
+	// written to OutStream in pcap format instead.
+	Target string
+	// Filter is an optional classic BPF program in the numeric format
+	// produced by "tcpdump -ddd", restricting which packets are captured.
+	Filter string
+	// Duration bounds how long traffic is mirrored.
+	Duration time.Duration
+	// OutStream returns the stream to write the pcap capture to. It is
+	// only called once the capture has been set up successfully.
 	OutStream func() io.Writer
 }
 
+// ContainerLogsRotateConfig holds the parameters for the backend.ContainerLogsRotate
+// call.
+type ContainerLogsRotateConfig struct {
+	// MaxSize is the new maximum size in bytes of each log file before it
+	// is rotated. A value of 0 leaves the current limit unchanged, and -1
+	// removes the limit.
+	MaxSize int64
+	// MaxFiles is the new maximum number of log files to retain. A value
+	// of 0 leaves the current limit unchanged.
+	MaxFiles int
+}
+
 // ContainerInspectOptions defines options for the backend.ContainerInspect
 // call.
 type ContainerInspectOptions struct {
@@ -136,6 +211,9 @@ type GetImageOpts struct {
 type ImageInspectOpts struct {
 	Manifests bool
 	Platform  *ocispec.Platform
+	// Artifacts makes the inspect response include the artifacts
+	// associated with the image through the OCI referrers mechanism.
+	Artifacts bool
 }
 
 // CommitConfig is the configuration for creating an image as part of a build.
@@ -175,4 +253,7 @@ type NetworkListConfig struct {
 	// TODO(@cpuguy83): naming is hard, this is pulled from what was being used in the router before moving here
 	Detailed bool
 	Verbose  bool
+	// Size indicates that per-network usage counters (endpoints, allocated
+	// and free addresses) should be included in the response.
+	Size bool
 }
@@ -4,6 +4,7 @@ import (
 	"github.com/moby/moby/api/types/build"
 	"github.com/moby/moby/api/types/container"
 	"github.com/moby/moby/api/types/image"
+	"github.com/moby/moby/api/types/system"
 	"github.com/moby/moby/api/types/volume"
 )
 
@@ -26,4 +27,5 @@ type DiskUsage struct {
 	Containers *container.DiskUsage
 	Volumes    *volume.DiskUsage
 	BuildCache *build.CacheDiskUsage
+	Storage    *system.StorageUsage
 }
@@ -15,6 +15,9 @@ import (
 // APIVersionKey is the client's requested API version.
 type APIVersionKey struct{}
 
+// ClientIdentityKey is the identity derived for the requesting client.
+type ClientIdentityKey struct{}
+
 // APIFunc is an adapter to allow the use of ordinary functions as Docker API endpoints.
 // Any function that has the appropriate signature can be registered as an API endpoint (e.g. getVersion).
 type APIFunc func(ctx context.Context, w http.ResponseWriter, r *http.Request, vars map[string]string) error
@@ -121,6 +124,20 @@ func VersionFromContext(ctx context.Context) string {
 	return ""
 }
 
+// ClientIdentityFromContext returns the client identity set by the client
+// identity middleware, or an empty string when none is set.
+func ClientIdentityFromContext(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+
+	if val := ctx.Value(ClientIdentityKey{}); val != nil {
+		return val.(string)
+	}
+
+	return ""
+}
+
 // matchesContentType validates the content type against the expected one
 func matchesContentType(contentType, expectedType string) error {
 	mimetype, _, err := mime.ParseMediaType(contentType)
@@ -0,0 +1,34 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/moby/moby/v2/daemon/server/httputils"
+)
+
+// DefaultClientIdentity is the identity assigned to clients that do not
+// present a TLS client certificate, such as clients connecting over the
+// unix socket.
+const DefaultClientIdentity = "default"
+
+// ClientIdentityMiddleware attaches an identity for the requesting client
+// to the request context. The identity is the Common Name of the client's
+// verified TLS certificate when one was presented; all other clients share
+// [DefaultClientIdentity]. The identity is used to attribute daemon-enforced
+// resource quotas.
+type ClientIdentityMiddleware struct{}
+
+// WrapHandler returns a new handler function wrapping the previous one in the request chain.
+func (ClientIdentityMiddleware) WrapHandler(handler func(ctx context.Context, w http.ResponseWriter, r *http.Request, vars map[string]string) error) func(ctx context.Context, w http.ResponseWriter, r *http.Request, vars map[string]string) error {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request, vars map[string]string) error {
+		identity := DefaultClientIdentity
+		if r.TLS != nil && len(r.TLS.PeerCertificates) > 0 {
+			if cn := r.TLS.PeerCertificates[0].Subject.CommonName; cn != "" {
+				identity = cn
+			}
+		}
+		ctx = context.WithValue(ctx, httputils.ClientIdentityKey{}, identity)
+		return handler(ctx, w, r, vars)
+	}
+}
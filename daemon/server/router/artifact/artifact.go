@@ -0,0 +1,32 @@
+package artifact
+
+import "github.com/moby/moby/v2/daemon/server/router"
+
+// artifactRouter is a router to exchange OCI artifacts with a registry
+type artifactRouter struct {
+	backend Backend
+	routes  []router.Route
+}
+
+// NewRouter initializes a new artifact router
+func NewRouter(backend Backend) router.Router {
+	r := &artifactRouter{
+		backend: backend,
+	}
+	r.initRoutes()
+	return r
+}
+
+// Routes returns the available routes
+func (ar *artifactRouter) Routes() []router.Route {
+	return ar.routes
+}
+
+// initRoutes initializes the routes in the artifact router
+func (ar *artifactRouter) initRoutes() {
+	ar.routes = []router.Route{
+		// POST
+		router.NewPostRoute("/artifacts/{name:.*}/pull", ar.postArtifactPull),
+		router.NewPostRoute("/artifacts/{name:.*}/push", ar.postArtifactPush),
+	}
+}
@@ -0,0 +1,61 @@
+package artifact
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/distribution/reference"
+	"github.com/moby/moby/api/types/artifact"
+	"github.com/moby/moby/api/types/registry"
+	"github.com/moby/moby/v2/daemon/server/httputils"
+	"github.com/moby/moby/v2/errdefs"
+)
+
+func (ar *artifactRouter) postArtifactPull(ctx context.Context, w http.ResponseWriter, r *http.Request, vars map[string]string) error {
+	ref, err := parseArtifactReference(vars["name"])
+	if err != nil {
+		return err
+	}
+
+	authConfig, _ := registry.DecodeAuthConfig(r.Header.Get(registry.AuthHeader))
+	desc, err := ar.backend.ArtifactPull(ctx, ref, authConfig)
+	if err != nil {
+		return err
+	}
+
+	return httputils.WriteJSON(w, http.StatusOK, artifact.PullResponse{
+		Reference:  reference.FamiliarString(ref),
+		Descriptor: desc,
+	})
+}
+
+func (ar *artifactRouter) postArtifactPush(ctx context.Context, w http.ResponseWriter, r *http.Request, vars map[string]string) error {
+	ref, err := parseArtifactReference(vars["name"])
+	if err != nil {
+		return err
+	}
+
+	authConfig, _ := registry.DecodeAuthConfig(r.Header.Get(registry.AuthHeader))
+	desc, err := ar.backend.ArtifactPush(ctx, ref, authConfig)
+	if err != nil {
+		return err
+	}
+
+	return httputils.WriteJSON(w, http.StatusOK, artifact.PushResponse{
+		Reference:  reference.FamiliarString(ref),
+		Descriptor: desc,
+	})
+}
+
+// parseArtifactReference parses name into a normalized reference,
+// defaulting to the "latest" tag when neither a tag nor a digest is given.
+func parseArtifactReference(name string) (reference.Named, error) {
+	ref, err := reference.ParseNormalizedNamed(name)
+	if err != nil {
+		return nil, errdefs.InvalidParameter(err)
+	}
+	if _, isCanonical := ref.(reference.Canonical); !isCanonical {
+		ref = reference.TagNameOnly(ref)
+	}
+	return ref, nil
+}
@@ -0,0 +1,62 @@
+package artifact
+
+import (
+	"testing"
+
+	cerrdefs "github.com/containerd/errdefs"
+	"gotest.tools/v3/assert"
+	is "gotest.tools/v3/assert/cmp"
+)
+
+func TestParseArtifactReference(t *testing.T) {
+	tests := []struct {
+		doc         string
+		name        string
+		expected    string
+		expectedErr string
+	}{
+		{
+			doc:      "bare name gets the latest tag",
+			name:     "example.com/my-artifact",
+			expected: "example.com/my-artifact:latest",
+		},
+		{
+			doc:      "short name is normalized",
+			name:     "my-artifact",
+			expected: "docker.io/library/my-artifact:latest",
+		},
+		{
+			doc:      "tag is preserved",
+			name:     "example.com/my-artifact:v1",
+			expected: "example.com/my-artifact:v1",
+		},
+		{
+			doc:      "digest reference gets no tag",
+			name:     "example.com/my-artifact@sha256:e2af53b8e2f1ebb9a978f3a0ca8e3ea2a4111b3bba9f84c0986da5ec85b298bb",
+			expected: "example.com/my-artifact@sha256:e2af53b8e2f1ebb9a978f3a0ca8e3ea2a4111b3bba9f84c0986da5ec85b298bb",
+		},
+		{
+			doc:         "invalid reference",
+			name:        "in valid",
+			expectedErr: "invalid reference format",
+		},
+		{
+			doc:         "empty reference",
+			name:        "",
+			expectedErr: "invalid reference format",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.doc, func(t *testing.T) {
+			ref, err := parseArtifactReference(tc.name)
+			if tc.expectedErr != "" {
+				assert.Check(t, is.ErrorContains(err, tc.expectedErr))
+				assert.Check(t, cerrdefs.IsInvalidArgument(err))
+				return
+			}
+			assert.NilError(t, err)
+			assert.Check(t, is.Equal(ref.String(), tc.expected))
+		})
+	}
+}
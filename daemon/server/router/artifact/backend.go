@@ -0,0 +1,16 @@
+package artifact
+
+import (
+	"context"
+
+	"github.com/distribution/reference"
+	"github.com/moby/moby/api/types/registry"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+)
+
+// Backend is all the methods that need to be implemented
+// to provide artifact specific functionality.
+type Backend interface {
+	ArtifactPull(ctx context.Context, ref reference.Named, authConfig *registry.AuthConfig) (ocispec.Descriptor, error)
+	ArtifactPush(ctx context.Context, ref reference.Named, authConfig *registry.AuthConfig) (ocispec.Descriptor, error)
+}
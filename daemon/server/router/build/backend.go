@@ -16,6 +16,17 @@ type Backend interface {
 	// PruneCache prunes the build cache.
 	PruneCache(context.Context, build.CachePruneOptions) (*build.CachePruneReport, error)
 	Cancel(context.Context, string) error
+
+	// BuildHistory returns recorded build history records, optionally
+	// scoped to a single build ref.
+	BuildHistory(context.Context, string) ([]*build.HistoryRecord, error)
+	// PruneBuildHistory removes completed, unpinned build history records.
+	PruneBuildHistory(context.Context) (*build.HistoryPruneReport, error)
+
+	// RegisterBuildSecrets registers build secrets and SSH agent sockets
+	// ahead of a build, for clients that cannot serve them over the
+	// session gRPC protocol.
+	RegisterBuildSecrets(context.Context, build.SecretsRequest) error
 }
 
 type experimentalProvider interface {
@@ -34,6 +34,9 @@ func (br *buildRouter) initRoutes() {
 		router.NewPostRoute("/build", br.postBuild),
 		router.NewPostRoute("/build/prune", br.postPrune),
 		router.NewPostRoute("/build/cancel", br.postCancel),
+		router.NewPostRoute("/build/secrets", br.postBuildSecrets),
+		router.NewGetRoute("/build/history", br.getBuildHistory),
+		router.NewPostRoute("/build/history/prune", br.postHistoryPrune),
 	}
 }
 
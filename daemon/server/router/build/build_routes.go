@@ -156,9 +156,57 @@ func newImageBuildOptions(ctx context.Context, r *http.Request) (*build.ImageBui
 		options.Version = v
 	}
 
+	secrets, err := parseBuildSecrets(r.Header)
+	if err != nil {
+		return nil, err
+	}
+	options.Secrets = secrets
+	options.SecretIDs = r.Form["secretid"]
+	options.SSHSources, err = parseSSHSources(r.Form["ssh"])
+	if err != nil {
+		return nil, err
+	}
+	if (len(options.Secrets) > 0 || len(options.SecretIDs) > 0 || len(options.SSHSources) > 0) && options.Version != build.BuilderBuildKit {
+		return nil, invalidParam{errors.New("build secrets and SSH forwarding are only supported when using BuildKit")}
+	}
+
 	return options, nil
 }
 
+// parseBuildSecrets reads inline build secrets from the X-Build-Secrets
+// header. Secrets are passed in a header rather than a query parameter so
+// their values never end up in request logs.
+func parseBuildSecrets(header http.Header) ([]build.BuildSecret, error) {
+	encoded := header.Get("X-Build-Secrets")
+	if encoded == "" {
+		return nil, nil
+	}
+	var secrets []build.BuildSecret
+	if err := json.NewDecoder(base64.NewDecoder(base64.URLEncoding, strings.NewReader(encoded))).Decode(&secrets); err != nil {
+		return nil, invalidParam{errors.Wrap(err, "error reading build secrets")}
+	}
+	for _, s := range secrets {
+		if s.ID == "" {
+			return nil, invalidParam{errors.New("build secret is missing an ID")}
+		}
+	}
+	return secrets, nil
+}
+
+// parseSSHSources parses the repeatable "ssh" parameter, accepting entries
+// of the form "id" or "id=agent-socket-path".
+func parseSSHSources(values []string) ([]build.SSHSource, error) {
+	var sources []build.SSHSource
+	for _, v := range values {
+		id, sock, _ := strings.Cut(v, "=")
+		if id == "" {
+			return nil, invalidParam{errors.Errorf("invalid ssh source %q", v)}
+		}
+		sources = append(sources, build.SSHSource{ID: id, AgentSocket: sock})
+	}
+	return sources, nil
+}
+
 func parseVersion(s string) (build.BuilderVersion, error) {
 	switch build.BuilderVersion(s) {
 	case build.BuilderV1:
@@ -248,6 +296,46 @@ func (br *buildRouter) postCancel(ctx context.Context, w http.ResponseWriter, r
 	return br.backend.Cancel(ctx, id)
 }
 
+func (br *buildRouter) postBuildSecrets(ctx context.Context, w http.ResponseWriter, r *http.Request, vars map[string]string) error {
+	var req build.SecretsRequest
+	if err := httputils.ReadJSON(r, &req); err != nil {
+		return err
+	}
+
+	if err := br.backend.RegisterBuildSecrets(ctx, req); err != nil {
+		return err
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+	return nil
+}
+
+func (br *buildRouter) getBuildHistory(ctx context.Context, w http.ResponseWriter, r *http.Request, vars map[string]string) error {
+	if err := httputils.ParseForm(r); err != nil {
+		return err
+	}
+
+	records, err := br.backend.BuildHistory(ctx, r.Form.Get("ref"))
+	if err != nil {
+		return err
+	}
+
+	return httputils.WriteJSON(w, http.StatusOK, records)
+}
+
+func (br *buildRouter) postHistoryPrune(ctx context.Context, w http.ResponseWriter, r *http.Request, vars map[string]string) error {
+	if err := httputils.ParseForm(r); err != nil {
+		return err
+	}
+
+	report, err := br.backend.PruneBuildHistory(ctx)
+	if err != nil {
+		return err
+	}
+
+	return httputils.WriteJSON(w, http.StatusOK, report)
+}
+
 func (br *buildRouter) postBuild(ctx context.Context, w http.ResponseWriter, r *http.Request, vars map[string]string) error {
 	var (
 		notVerboseBuffer = bytes.NewBuffer(nil)
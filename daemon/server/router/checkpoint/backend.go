@@ -1,10 +1,16 @@
 package checkpoint
 
-import "github.com/moby/moby/api/types/checkpoint"
+import (
+	"io"
+
+	"github.com/moby/moby/api/types/checkpoint"
+)
 
 // Backend for Checkpoint
 type Backend interface {
 	CheckpointCreate(container string, config checkpoint.CreateOptions) error
 	CheckpointDelete(container string, config checkpoint.DeleteOptions) error
+	CheckpointExport(container string, config checkpoint.ExportOptions, out io.Writer) error
+	CheckpointImport(container string, config checkpoint.ImportOptions, in io.Reader) error
 	CheckpointList(container string, config checkpoint.ListOptions) ([]checkpoint.Summary, error)
 }
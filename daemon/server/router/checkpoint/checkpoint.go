@@ -29,5 +29,7 @@ func (cr *checkpointRouter) initRoutes() {
 		router.NewGetRoute("/containers/{name:.*}/checkpoints", cr.getContainerCheckpoints, router.Experimental),
 		router.NewPostRoute("/containers/{name:.*}/checkpoints", cr.postContainerCheckpoint, router.Experimental),
 		router.NewDeleteRoute("/containers/{name}/checkpoints/{checkpoint}", cr.deleteContainerCheckpoint, router.Experimental),
+		router.NewGetRoute("/containers/{name}/checkpoints/{checkpoint}/export", cr.getContainerCheckpointExport, router.Experimental),
+		router.NewPostRoute("/containers/{name}/checkpoints/{checkpoint}/import", cr.postContainerCheckpointImport, router.Experimental),
 	}
 }
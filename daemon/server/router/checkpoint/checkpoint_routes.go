@@ -42,6 +42,36 @@ func (cr *checkpointRouter) getContainerCheckpoints(ctx context.Context, w http.
 	return httputils.WriteJSON(w, http.StatusOK, checkpoints)
 }
 
+func (cr *checkpointRouter) getContainerCheckpointExport(ctx context.Context, w http.ResponseWriter, r *http.Request, vars map[string]string) error {
+	if err := httputils.ParseForm(r); err != nil {
+		return err
+	}
+
+	w.Header().Set("Content-Type", "application/x-tar")
+
+	return cr.backend.CheckpointExport(vars["name"], checkpoint.ExportOptions{
+		CheckpointDir: r.Form.Get("dir"),
+		CheckpointID:  vars["checkpoint"],
+	}, w)
+}
+
+func (cr *checkpointRouter) postContainerCheckpointImport(ctx context.Context, w http.ResponseWriter, r *http.Request, vars map[string]string) error {
+	if err := httputils.ParseForm(r); err != nil {
+		return err
+	}
+
+	err := cr.backend.CheckpointImport(vars["name"], checkpoint.ImportOptions{
+		CheckpointDir: r.Form.Get("dir"),
+		CheckpointID:  vars["checkpoint"],
+	}, r.Body)
+	if err != nil {
+		return err
+	}
+
+	w.WriteHeader(http.StatusCreated)
+	return nil
+}
+
 func (cr *checkpointRouter) deleteContainerCheckpoint(ctx context.Context, w http.ResponseWriter, r *http.Request, vars map[string]string) error {
 	if err := httputils.ParseForm(r); err != nil {
 		return err
@@ -3,6 +3,7 @@ package container
 import (
 	"context"
 	"io"
+	"time"
 
 	"github.com/moby/go-archive"
 	"github.com/moby/moby/api/types/container"
@@ -31,7 +32,10 @@ type copyBackend interface {
 
 // stateBackend includes functions to implement to provide container state lifecycle functionality.
 type stateBackend interface {
+	ContainerClone(ctx context.Context, name string, options container.CloneOptions) (container.CreateResponse, error)
 	ContainerCreate(ctx context.Context, config backend.ContainerCreateConfig) (container.CreateResponse, error)
+	ContainerFSFreeze(name string, timeout time.Duration) error
+	ContainerFSThaw(name string) error
 	ContainerKill(name string, signal string) error
 	ContainerPause(name string) error
 	ContainerRename(oldName, newName string) error
@@ -48,11 +52,17 @@ type stateBackend interface {
 // monitorBackend includes functions to implement to provide containers monitoring functionality.
 type monitorBackend interface {
 	ContainerChanges(ctx context.Context, name string) ([]archive.Change, error)
+	ContainerEnvDrift(name string) (*container.EnvDriftResponse, error)
 	ContainerInspect(ctx context.Context, name string, options backend.ContainerInspectOptions) (*container.InspectResponse, error)
 	ContainerLogs(ctx context.Context, name string, config *container.LogsOptions) (msgs <-chan *backend.LogMessage, tty bool, err error)
+	ContainerLogsRotate(name string, config backend.ContainerLogsRotateConfig) error
+	ContainerMirrorTraffic(ctx context.Context, name string, config *backend.ContainerMirrorConfig) error
+	ContainerRestartSimulate(name string, exitCode int) (*container.RestartSimulation, error)
 	ContainerStats(ctx context.Context, name string, config *backend.ContainerStatsConfig) error
+	ContainersStats(ctx context.Context, config *backend.ContainersStatsConfig) error
 	ContainerTop(name string, psArgs string) (*container.TopResponse, error)
 	Containers(ctx context.Context, config *container.ListOptions) ([]*container.Summary, error)
+	ContainersWatch(ctx context.Context, sinceVersion uint64) ([]container.WatchEvent, <-chan container.WatchEvent, func(), error)
 }
 
 // attachBackend includes function to implement to provide container attaching functionality.
@@ -63,6 +73,8 @@ type attachBackend interface {
 // systemBackend includes functions to implement to provide system wide containers functionality
 type systemBackend interface {
 	ContainersPrune(ctx context.Context, pruneFilters filters.Args) (*container.PruneReport, error)
+	QuarantinedContainers() ([]container.QuarantineEntry, error)
+	RestoreQuarantinedContainer(id string) error
 }
 
 type commitBackend interface {
@@ -29,25 +29,36 @@ func (c *containerRouter) initRoutes() {
 		router.NewHeadRoute("/containers/{name:.*}/archive", c.headContainersArchive),
 		// GET
 		router.NewGetRoute("/containers/json", c.getContainersJSON),
+		router.NewGetRoute("/containers/quarantine", c.getContainersQuarantine),
+		router.NewGetRoute("/containers/watch", c.getContainersWatch),
+		router.NewGetRoute("/containers/stats", c.getContainersStatsAll),
 		router.NewGetRoute("/containers/{name:.*}/export", c.getContainersExport),
 		router.NewGetRoute("/containers/{name:.*}/changes", c.getContainersChanges),
 		router.NewGetRoute("/containers/{name:.*}/json", c.getContainersByName),
 		router.NewGetRoute("/containers/{name:.*}/top", c.getContainersTop),
 		router.NewGetRoute("/containers/{name:.*}/logs", c.getContainersLogs),
 		router.NewGetRoute("/containers/{name:.*}/stats", c.getContainersStats),
+		router.NewGetRoute("/containers/{name:.*}/restart/simulate", c.getContainersRestartSimulate),
+		router.NewGetRoute("/containers/{name:.*}/env-drift", c.getContainersEnvDrift),
 		router.NewGetRoute("/containers/{name:.*}/attach/ws", c.wsContainersAttach),
 		router.NewGetRoute("/exec/{id:.*}/json", c.getExecByID),
 		router.NewGetRoute("/containers/{name:.*}/archive", c.getContainersArchive),
 		// POST
 		router.NewPostRoute("/containers/create", c.postContainersCreate),
+		router.NewPostRoute("/containers/quarantine/{name:.*}/restore", c.postContainersQuarantineRestore),
+		router.NewPostRoute("/containers/{name:.*}/clone", c.postContainerClone),
 		router.NewPostRoute("/containers/{name:.*}/kill", c.postContainersKill),
 		router.NewPostRoute("/containers/{name:.*}/pause", c.postContainersPause),
 		router.NewPostRoute("/containers/{name:.*}/unpause", c.postContainersUnpause),
+		router.NewPostRoute("/containers/{name:.*}/fsfreeze", c.postContainersFSFreeze),
+		router.NewPostRoute("/containers/{name:.*}/fsthaw", c.postContainersFSThaw),
+		router.NewPostRoute("/containers/{name:.*}/logs/rotate", c.postContainersLogsRotate),
 		router.NewPostRoute("/containers/{name:.*}/restart", c.postContainersRestart),
 		router.NewPostRoute("/containers/{name:.*}/start", c.postContainersStart),
 		router.NewPostRoute("/containers/{name:.*}/stop", c.postContainersStop),
 		router.NewPostRoute("/containers/{name:.*}/wait", c.postContainersWait),
 		router.NewPostRoute("/containers/{name:.*}/resize", c.postContainersResize),
+		router.NewPostRoute("/containers/{name:.*}/mirror", c.postContainersMirror),
 		router.NewPostRoute("/containers/{name:.*}/attach", c.postContainersAttach),
 		router.NewPostRoute("/containers/{name:.*}/exec", c.postContainerExecCreate),
 		router.NewPostRoute("/exec/{name:.*}/start", c.postContainerExecStart),
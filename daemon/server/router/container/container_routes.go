@@ -9,9 +9,11 @@ import (
 	"runtime"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/containerd/log"
 	"github.com/containerd/platforms"
+	"github.com/docker/go-units"
 	"github.com/moby/moby/api/types"
 	"github.com/moby/moby/api/types/container"
 	"github.com/moby/moby/api/types/filters"
@@ -155,8 +157,9 @@ func (c *containerRouter) getContainersStats(ctx context.Context, w http.Respons
 	}
 
 	return c.backend.ContainerStats(ctx, vars["name"], &backend.ContainerStatsConfig{
-		Stream:  stream,
-		OneShot: oneShot,
+		Stream:    stream,
+		OneShot:   oneShot,
+		NetDetail: httputils.BoolValue(r, "net-detail"),
 		OutStream: func() io.Writer {
 			// Assume that when this is called the request is OK.
 			w.WriteHeader(http.StatusOK)
@@ -170,6 +173,82 @@ func (c *containerRouter) getContainersStats(ctx context.Context, w http.Respons
 	})
 }
 
+// getContainersStatsAll streams stats for all containers matching the given
+// filters over a single connection.
+func (c *containerRouter) getContainersStatsAll(ctx context.Context, w http.ResponseWriter, r *http.Request, vars map[string]string) error {
+	if err := httputils.ParseForm(r); err != nil {
+		return err
+	}
+
+	statsFilters, err := filters.FromJSON(r.Form.Get("filters"))
+	if err != nil {
+		return err
+	}
+
+	stream := httputils.BoolValueOrDefault(r, "stream", true)
+	if !stream {
+		w.Header().Set("Content-Type", "application/json")
+	}
+
+	var interval time.Duration
+	if v := r.Form.Get("interval"); v != "" {
+		interval, err = time.ParseDuration(v)
+		if err != nil {
+			return errdefs.InvalidParameter(errors.Wrap(err, "invalid interval"))
+		}
+	}
+
+	return c.backend.ContainersStats(ctx, &backend.ContainersStatsConfig{
+		Stream:   stream,
+		Interval: interval,
+		Filters:  statsFilters,
+		OutStream: func() io.Writer {
+			// Assume that when this is called the request is OK.
+			w.WriteHeader(http.StatusOK)
+			if !stream {
+				return w
+			}
+			wf := ioutils.NewWriteFlusher(w)
+			wf.Flush()
+			return wf
+		},
+	})
+}
+
+func (c *containerRouter) postContainersMirror(ctx context.Context, w http.ResponseWriter, r *http.Request, vars map[string]string) error {
+	if err := httputils.ParseForm(r); err != nil {
+		return err
+	}
+
+	duration := 30 * time.Second
+	if v := r.Form.Get("duration"); v != "" {
+		var err error
+		duration, err = time.ParseDuration(v)
+		if err != nil {
+			return errdefs.InvalidParameter(errors.Wrap(err, "invalid duration"))
+		}
+		if duration <= 0 {
+			return errdefs.InvalidParameter(errors.New("duration must be positive"))
+		}
+	}
+
+	return c.backend.ContainerMirrorTraffic(ctx, vars["name"], &backend.ContainerMirrorConfig{
+		Interface: r.Form.Get("interface"),
+		Target:    r.Form.Get("target"),
+		Filter:    r.Form.Get("filter"),
+		Duration:  duration,
+		OutStream: func() io.Writer {
+			// Assume that when this is called the capture is set up and
+			// the request is OK.
+			w.Header().Set("Content-Type", "application/vnd.tcpdump.pcap")
+			w.WriteHeader(http.StatusOK)
+			wf := ioutils.NewWriteFlusher(w)
+			wf.Flush()
+			return wf
+		},
+	})
+}
+
 func (c *containerRouter) getContainersLogs(ctx context.Context, w http.ResponseWriter, r *http.Request, vars map[string]string) error {
 	if err := httputils.ParseForm(r); err != nil {
 		return err
@@ -192,9 +271,12 @@ func (c *containerRouter) getContainersLogs(ctx context.Context, w http.Response
 		Since:      r.Form.Get("since"),
 		Until:      r.Form.Get("until"),
 		Tail:       r.Form.Get("tail"),
+		TailBytes:  r.Form.Get("tail-bytes"),
 		ShowStdout: stdout,
 		ShowStderr: stderr,
 		Details:    httputils.BoolValue(r, "details"),
+		Grep:       r.Form.Get("grep"),
+		Fields:     r.Form.Get("fields"),
 	}
 
 	msgs, tty, err := c.backend.ContainerLogs(ctx, containerName, logsConfig)
@@ -318,6 +400,37 @@ func (c *containerRouter) postContainersRestart(ctx context.Context, w http.Resp
 	return nil
 }
 
+func (c *containerRouter) getContainersEnvDrift(ctx context.Context, w http.ResponseWriter, r *http.Request, vars map[string]string) error {
+	drift, err := c.backend.ContainerEnvDrift(vars["name"])
+	if err != nil {
+		return err
+	}
+
+	return httputils.WriteJSON(w, http.StatusOK, drift)
+}
+
+func (c *containerRouter) getContainersRestartSimulate(ctx context.Context, w http.ResponseWriter, r *http.Request, vars map[string]string) error {
+	if err := httputils.ParseForm(r); err != nil {
+		return err
+	}
+
+	var exitCode int
+	if v := r.Form.Get("exit-code"); v != "" {
+		var err error
+		exitCode, err = strconv.Atoi(v)
+		if err != nil {
+			return errdefs.InvalidParameter(errors.Wrapf(err, "invalid value for exit-code: %s", v))
+		}
+	}
+
+	sim, err := c.backend.ContainerRestartSimulate(vars["name"], exitCode)
+	if err != nil {
+		return err
+	}
+
+	return httputils.WriteJSON(w, http.StatusOK, sim)
+}
+
 func (c *containerRouter) postContainersPause(ctx context.Context, w http.ResponseWriter, r *http.Request, vars map[string]string) error {
 	if err := httputils.ParseForm(r); err != nil {
 		return err
@@ -332,6 +445,77 @@ func (c *containerRouter) postContainersPause(ctx context.Context, w http.Respon
 	return nil
 }
 
+func (c *containerRouter) postContainersLogsRotate(ctx context.Context, w http.ResponseWriter, r *http.Request, vars map[string]string) error {
+	if err := httputils.ParseForm(r); err != nil {
+		return err
+	}
+
+	var config backend.ContainerLogsRotateConfig
+	if v := r.Form.Get("max-size"); v != "" {
+		if v == "-1" {
+			config.MaxSize = -1
+		} else {
+			maxSize, err := units.RAMInBytes(v)
+			if err != nil || maxSize <= 0 {
+				return errdefs.InvalidParameter(errors.Errorf("invalid value for max-size: %s", v))
+			}
+			config.MaxSize = maxSize
+		}
+	}
+	if v := r.Form.Get("max-file"); v != "" {
+		maxFiles, err := strconv.Atoi(v)
+		if err != nil || maxFiles < 1 {
+			return errdefs.InvalidParameter(errors.Errorf("invalid value for max-file: %s", v))
+		}
+		config.MaxFiles = maxFiles
+	}
+
+	if err := c.backend.ContainerLogsRotate(vars["name"], config); err != nil {
+		return err
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+
+	return nil
+}
+
+func (c *containerRouter) postContainersFSFreeze(ctx context.Context, w http.ResponseWriter, r *http.Request, vars map[string]string) error {
+	if err := httputils.ParseForm(r); err != nil {
+		return err
+	}
+
+	var timeout time.Duration
+	if v := r.Form.Get("timeout"); v != "" {
+		seconds, err := strconv.Atoi(v)
+		if err != nil {
+			return errdefs.InvalidParameter(errors.Wrapf(err, "invalid value for timeout: %s", v))
+		}
+		timeout = time.Duration(seconds) * time.Second
+	}
+
+	if err := c.backend.ContainerFSFreeze(vars["name"], timeout); err != nil {
+		return err
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+
+	return nil
+}
+
+func (c *containerRouter) postContainersFSThaw(ctx context.Context, w http.ResponseWriter, r *http.Request, vars map[string]string) error {
+	if err := httputils.ParseForm(r); err != nil {
+		return err
+	}
+
+	if err := c.backend.ContainerFSThaw(vars["name"]); err != nil {
+		return err
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+
+	return nil
+}
+
 func (c *containerRouter) postContainersUnpause(ctx context.Context, w http.ResponseWriter, r *http.Request, vars map[string]string) error {
 	if err := httputils.ParseForm(r); err != nil {
 		return err
@@ -447,6 +631,23 @@ func (c *containerRouter) postContainerRename(ctx context.Context, w http.Respon
 	return nil
 }
 
+func (c *containerRouter) postContainerClone(ctx context.Context, w http.ResponseWriter, r *http.Request, vars map[string]string) error {
+	if err := httputils.ParseForm(r); err != nil {
+		return err
+	}
+
+	var options container.CloneOptions
+	if err := httputils.ReadJSON(r, &options); err != nil {
+		return err
+	}
+
+	resp, err := c.backend.ContainerClone(ctx, vars["name"], options)
+	if err != nil {
+		return err
+	}
+	return httputils.WriteJSON(w, http.StatusCreated, resp)
+}
+
 func (c *containerRouter) postContainerUpdate(ctx context.Context, w http.ResponseWriter, r *http.Request, vars map[string]string) error {
 	if err := httputils.ParseForm(r); err != nil {
 		return err
@@ -476,6 +677,7 @@ func (c *containerRouter) postContainerUpdate(ctx context.Context, w http.Respon
 	hostConfig := &container.HostConfig{
 		Resources:     updateConfig.Resources,
 		RestartPolicy: updateConfig.RestartPolicy,
+		CgroupnsMode:  updateConfig.CgroupnsMode,
 	}
 
 	name := vars["name"]
@@ -699,6 +901,9 @@ func (c *containerRouter) postContainersCreate(ctx context.Context, w http.Respo
 		NetworkingConfig:            networkingConfig,
 		Platform:                    platform,
 		DefaultReadOnlyNonRecursive: defaultReadOnlyNonRecursive,
+		LabelFiles:                  req.LabelFiles,
+		AnnotationFiles:             req.AnnotationFiles,
+		PinDigest:                   req.PinDigest,
 	})
 
 	// Log warnings for debugging, regardless if the request was successful or not.
@@ -1112,3 +1317,81 @@ func (c *containerRouter) postContainersPrune(ctx context.Context, w http.Respon
 	}
 	return httputils.WriteJSON(w, http.StatusOK, pruneReport)
 }
+
+func (c *containerRouter) getContainersQuarantine(ctx context.Context, w http.ResponseWriter, r *http.Request, vars map[string]string) error {
+	entries, err := c.backend.QuarantinedContainers()
+	if err != nil {
+		return err
+	}
+	return httputils.WriteJSON(w, http.StatusOK, entries)
+}
+
+// getContainersWatch streams the current state of all containers followed by
+// incremental change events, etcd-style. Passing a previously observed
+// resourceVersion resumes the watch instead of relisting; when the daemon no
+// longer retains the changes since that version, the request fails and the
+// watch must be restarted without a resume point.
+func (c *containerRouter) getContainersWatch(ctx context.Context, w http.ResponseWriter, r *http.Request, vars map[string]string) error {
+	if err := httputils.ParseForm(r); err != nil {
+		return err
+	}
+
+	var sinceVersion uint64
+	if v := r.Form.Get("resourceVersion"); v != "" {
+		var err error
+		sinceVersion, err = strconv.ParseUint(v, 10, 64)
+		if err != nil {
+			return errdefs.InvalidParameter(errors.Wrap(err, "invalid resourceVersion"))
+		}
+	}
+
+	initial, l, cancel, err := c.backend.ContainersWatch(ctx, sinceVersion)
+	if err != nil {
+		return err
+	}
+	defer cancel()
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	output := ioutils.NewWriteFlusher(w)
+	defer output.Close()
+	output.Flush()
+
+	enc := json.NewEncoder(output)
+	for _, ev := range initial {
+		if err := enc.Encode(ev); err != nil {
+			return err
+		}
+	}
+
+	for {
+		select {
+		case ev, ok := <-l:
+			if !ok {
+				// The watcher was evicted for not keeping up with the
+				// stream; the client must reconnect and resume from the
+				// last resourceVersion it processed.
+				return nil
+			}
+			if err := enc.Encode(ev); err != nil {
+				return err
+			}
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
+
+func (c *containerRouter) postContainersQuarantineRestore(ctx context.Context, w http.ResponseWriter, r *http.Request, vars map[string]string) error {
+	if err := httputils.ParseForm(r); err != nil {
+		return err
+	}
+
+	if err := c.backend.RestoreQuarantinedContainer(vars["name"]); err != nil {
+		return err
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+
+	return nil
+}
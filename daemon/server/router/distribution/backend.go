@@ -12,4 +12,6 @@ import (
 // to provide image specific functionality.
 type Backend interface {
 	GetRepositories(context.Context, reference.Named, *registry.AuthConfig) ([]distribution.Repository, error)
+	DistributionOperations() []registry.DistributionOperation
+	CancelDistributionOperation(id string) error
 }
@@ -26,6 +26,9 @@ func (dr *distributionRouter) Routes() []router.Route {
 func (dr *distributionRouter) initRoutes() {
 	dr.routes = []router.Route{
 		// GET
+		router.NewGetRoute("/distribution/operations", dr.getOperations),
 		router.NewGetRoute("/distribution/{name:.*}/json", dr.getDistributionInfo),
+		// POST
+		router.NewPostRoute("/distribution/operations/{id}/cancel", dr.cancelOperation),
 	}
 }
@@ -4,15 +4,18 @@ import (
 	"context"
 	"encoding/json"
 	"net/http"
+	"strings"
 
 	"github.com/distribution/reference"
 	"github.com/docker/distribution"
 	"github.com/docker/distribution/manifest/manifestlist"
+	"github.com/docker/distribution/manifest/ocischema"
 	"github.com/docker/distribution/manifest/schema2"
 	"github.com/moby/moby/api/types/registry"
 	distributionpkg "github.com/moby/moby/v2/daemon/internal/distribution"
 	"github.com/moby/moby/v2/daemon/server/httputils"
 	"github.com/moby/moby/v2/errdefs"
+	"github.com/opencontainers/go-digest"
 	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
 	"github.com/pkg/errors"
 )
@@ -74,6 +77,28 @@ func (dr *distributionRouter) getDistributionInfo(ctx context.Context, w http.Re
 	return lastErr
 }
 
+func (dr *distributionRouter) getOperations(ctx context.Context, w http.ResponseWriter, r *http.Request, vars map[string]string) error {
+	if err := httputils.ParseForm(r); err != nil {
+		return err
+	}
+	ops := dr.backend.DistributionOperations()
+	if ops == nil {
+		ops = []registry.DistributionOperation{}
+	}
+	return httputils.WriteJSON(w, http.StatusOK, ops)
+}
+
+func (dr *distributionRouter) cancelOperation(ctx context.Context, w http.ResponseWriter, r *http.Request, vars map[string]string) error {
+	if err := httputils.ParseForm(r); err != nil {
+		return err
+	}
+	if err := dr.backend.CancelDistributionOperation(vars["id"]); err != nil {
+		return err
+	}
+	w.WriteHeader(http.StatusNoContent)
+	return nil
+}
+
 func (dr *distributionRouter) fetchManifest(ctx context.Context, distrepo distribution.Repository, namedRef reference.Named) (registry.DistributionInspect, error) {
 	var distributionInspect registry.DistributionInspect
 	if canonicalRef, ok := namedRef.(reference.Canonical); !ok {
@@ -138,25 +163,97 @@ func (dr *distributionRouter) fetchManifest(ctx context.Context, distrepo distri
 	// retrieve platform information depending on the type of manifest
 	switch mnfstObj := mnfst.(type) {
 	case *manifestlist.DeserializedManifestList:
+		// Surface the full contents of the index / manifest list, not just
+		// a platform summary. The vendored manifest-list type does not
+		// carry index annotations, so take those from the raw payload.
+		var index struct {
+			Annotations map[string]string `json:"annotations"`
+		}
+		if err := json.Unmarshal(payload, &index); err == nil {
+			distributionInspect.Annotations = index.Annotations
+		}
 		for _, m := range mnfstObj.Manifests {
-			distributionInspect.Platforms = append(distributionInspect.Platforms, ocispec.Platform{
+			platform := ocispec.Platform{
 				Architecture: m.Platform.Architecture,
 				OS:           m.Platform.OS,
 				OSVersion:    m.Platform.OSVersion,
 				OSFeatures:   m.Platform.OSFeatures,
 				Variant:      m.Platform.Variant,
+			}
+			distributionInspect.Platforms = append(distributionInspect.Platforms, platform)
+			distributionInspect.Manifests = append(distributionInspect.Manifests, ocispec.Descriptor{
+				MediaType:   m.MediaType,
+				Digest:      m.Digest,
+				Size:        m.Size,
+				Annotations: m.Annotations,
+				Platform:    &platform,
 			})
 		}
+	case *ocischema.DeserializedManifest:
+		distributionInspect.Annotations = mnfstObj.Annotations
+		if platform := platformFromConfig(ctx, distrepo, mnfstObj.Config.Digest); platform != nil {
+			distributionInspect.Platforms = append(distributionInspect.Platforms, *platform)
+		}
 	case *schema2.DeserializedManifest:
-		blobStore := distrepo.Blobs(ctx)
-		configJSON, err := blobStore.Get(ctx, mnfstObj.Config.Digest)
-		var platform ocispec.Platform
-		if err == nil {
-			err := json.Unmarshal(configJSON, &platform)
-			if err == nil && (platform.OS != "" || platform.Architecture != "") {
-				distributionInspect.Platforms = append(distributionInspect.Platforms, platform)
-			}
+		if platform := platformFromConfig(ctx, distrepo, mnfstObj.Config.Digest); platform != nil {
+			distributionInspect.Platforms = append(distributionInspect.Platforms, *platform)
 		}
 	}
+
+	distributionInspect.Referrers = fetchReferrers(ctx, distrepo, distributionInspect.Descriptor.Digest)
 	return distributionInspect, nil
 }
+
+// platformFromConfig extracts the platform from an image configuration
+// blob. It returns nil if the blob could not be retrieved or does not
+// describe a platform.
+func platformFromConfig(ctx context.Context, distrepo distribution.Repository, configDigest digest.Digest) *ocispec.Platform {
+	configJSON, err := distrepo.Blobs(ctx).Get(ctx, configDigest)
+	if err != nil {
+		return nil
+	}
+	var platform ocispec.Platform
+	if err := json.Unmarshal(configJSON, &platform); err != nil {
+		return nil
+	}
+	if platform.OS == "" && platform.Architecture == "" {
+		return nil
+	}
+	return &platform
+}
+
+// fetchReferrers returns the descriptors of artifacts referring to the
+// given manifest, using the OCI referrers tag fallback ("sha256-<hex>"),
+// which also works with registries that do not implement the referrers
+// API. Registries without referrers for the manifest yield an empty list.
+func fetchReferrers(ctx context.Context, distrepo distribution.Repository, dgst digest.Digest) []ocispec.Descriptor {
+	if dgst == "" {
+		return nil
+	}
+	desc, err := distrepo.Tags(ctx).Get(ctx, strings.ReplaceAll(dgst.String(), ":", "-"))
+	if err != nil {
+		return nil
+	}
+	mnfstsrvc, err := distrepo.Manifests(ctx)
+	if err != nil {
+		return nil
+	}
+	mnfst, err := mnfstsrvc.Get(ctx, desc.Digest)
+	if err != nil {
+		return nil
+	}
+	index, ok := mnfst.(*manifestlist.DeserializedManifestList)
+	if !ok {
+		return nil
+	}
+	var referrers []ocispec.Descriptor
+	for _, m := range index.Manifests {
+		referrers = append(referrers, ocispec.Descriptor{
+			MediaType:   m.MediaType,
+			Digest:      m.Digest,
+			Size:        m.Size,
+			Annotations: m.Annotations,
+		})
+	}
+	return referrers
+}
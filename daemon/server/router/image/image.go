@@ -1,6 +1,7 @@
 package image
 
 import (
+	"github.com/moby/moby/v2/daemon/server/backend"
 	"github.com/moby/moby/v2/daemon/server/router"
 )
 
@@ -8,14 +9,18 @@ import (
 type imageRouter struct {
 	backend  Backend
 	searcher Searcher
-	routes   []router.Route
+	// defaultCompression is the daemon-configured compression used for
+	// image push and export when a request has no "compression" parameter.
+	defaultCompression backend.Compression
+	routes             []router.Route
 }
 
 // NewRouter initializes a new image router
-func NewRouter(backend Backend, searcher Searcher) router.Router {
+func NewRouter(b Backend, searcher Searcher, defaultCompression backend.Compression) router.Router {
 	ir := &imageRouter{
-		backend:  backend,
-		searcher: searcher,
+		backend:            b,
+		searcher:           searcher,
+		defaultCompression: defaultCompression,
 	}
 	ir.initRoutes()
 	return ir
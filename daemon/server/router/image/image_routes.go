@@ -1,6 +1,7 @@
 package image
 
 import (
+	"compress/gzip"
 	"context"
 	"fmt"
 	"io"
@@ -12,6 +13,7 @@ import (
 
 	"github.com/containerd/platforms"
 	"github.com/distribution/reference"
+	"github.com/klauspost/compress/zstd"
 	"github.com/moby/moby/api/pkg/progress"
 	"github.com/moby/moby/api/pkg/streamformatter"
 	"github.com/moby/moby/api/types/filters"
@@ -30,6 +32,32 @@ import (
 	"github.com/pkg/errors"
 )
 
+// compressionOptions returns the compression algorithm and level requested
+// through the "compression" and "compression-level" form values, falling back
+// to the daemon's configured default algorithm.
+func (ir *imageRouter) compressionOptions(r *http.Request) (backend.Compression, int, error) {
+	comp := backend.Compression(r.Form.Get("compression"))
+	if comp == backend.CompressionDefault {
+		comp = ir.defaultCompression
+	}
+	switch comp {
+	case backend.CompressionDefault, backend.CompressionGzip, backend.CompressionZstd:
+		// These are valid.
+	default:
+		return "", 0, errdefs.InvalidParameter(errors.Errorf("invalid compression algorithm: %q", comp))
+	}
+
+	var level int
+	if v := r.Form.Get("compression-level"); v != "" {
+		var err error
+		level, err = strconv.Atoi(v)
+		if err != nil || level < 0 {
+			return "", 0, errdefs.InvalidParameter(errors.Errorf("invalid compression level: %q", v))
+		}
+	}
+	return comp, level, nil
+}
+
 // Creates an image from Pull or from Import
 func (ir *imageRouter) postImagesCreate(ctx context.Context, w http.ResponseWriter, r *http.Request, vars map[string]string) error {
 	if err := httputils.ParseForm(r); err != nil {
@@ -217,7 +245,12 @@ func (ir *imageRouter) postImagesPush(ctx context.Context, w http.ResponseWriter
 		}
 	}
 
-	if err := ir.backend.PushImage(ctx, ref, platform, metaHeaders, authConfig, output); err != nil {
+	comp, level, err := ir.compressionOptions(r)
+	if err != nil {
+		return err
+	}
+
+	if err := ir.backend.PushImage(ctx, ref, platform, metaHeaders, authConfig, output, backend.PushOptions{Compression: comp, CompressionLevel: level}); err != nil {
 		if !output.Flushed() {
 			return err
 		}
@@ -231,11 +264,31 @@ func (ir *imageRouter) getImagesGet(ctx context.Context, w http.ResponseWriter,
 		return err
 	}
 
-	w.Header().Set("Content-Type", "application/x-tar")
+	comp, level, err := ir.compressionOptions(r)
+	if err != nil {
+		return err
+	}
+
+	switch comp {
+	case backend.CompressionGzip:
+		w.Header().Set("Content-Type", "application/x-gzip")
+	case backend.CompressionZstd:
+		w.Header().Set("Content-Type", "application/zstd")
+	default:
+		w.Header().Set("Content-Type", "application/x-tar")
+	}
 
 	output := ioutils.NewWriteFlusher(w)
 	defer output.Close()
 
+	// The export is written through the compressor, but errors are written
+	// to the raw stream; a partially-written compressed stream cannot
+	// carry a trailing error message anyway.
+	exportOut, closeCompressor, err := compressExportStream(output, comp, level)
+	if err != nil {
+		return err
+	}
+
 	var names []string
 	if name, ok := vars["name"]; ok {
 		names = []string{name}
@@ -258,14 +311,44 @@ func (ir *imageRouter) getImagesGet(ctx context.Context, w http.ResponseWriter,
 		}
 	}
 
-	if err := ir.backend.ExportImage(ctx, names, platformList, output); err != nil {
+	if err := ir.backend.ExportImage(ctx, names, platformList, exportOut); err != nil {
 		if !output.Flushed() {
 			return err
 		}
 		_, _ = output.Write(streamformatter.FormatError(err))
+		return nil
 	}
 
-	return nil
+	return closeCompressor()
+}
+
+// compressExportStream wraps out with the requested compression algorithm.
+// The returned function must be called after a successful export to flush
+// the compressor's remaining buffered data.
+func compressExportStream(out io.Writer, comp backend.Compression, level int) (io.Writer, func() error, error) {
+	switch comp {
+	case backend.CompressionGzip:
+		if level == 0 {
+			level = gzip.DefaultCompression
+		}
+		gw, err := gzip.NewWriterLevel(out, level)
+		if err != nil {
+			return nil, nil, errdefs.InvalidParameter(err)
+		}
+		return gw, gw.Close, nil
+	case backend.CompressionZstd:
+		var opts []zstd.EOption
+		if level > 0 {
+			opts = append(opts, zstd.WithEncoderLevel(zstd.EncoderLevelFromZstd(level)))
+		}
+		zw, err := zstd.NewWriter(out, opts...)
+		if err != nil {
+			return nil, nil, errdefs.InvalidParameter(err)
+		}
+		return zw, zw.Close, nil
+	default:
+		return out, func() error { return nil }, nil
+	}
 }
 
 func (ir *imageRouter) postImagesLoad(ctx context.Context, w http.ResponseWriter, r *http.Request, vars map[string]string) error {
@@ -369,6 +452,7 @@ func (ir *imageRouter) getImagesByName(ctx context.Context, w http.ResponseWrite
 	resp, err := ir.backend.ImageInspect(ctx, vars["name"], backend.ImageInspectOpts{
 		Manifests: manifests,
 		Platform:  platform,
+		Artifacts: httputils.BoolValue(r, "artifacts"),
 	})
 	if err != nil {
 		return err
@@ -0,0 +1,131 @@
+package image
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io"
+	"net/http"
+	"net/url"
+	"testing"
+
+	cerrdefs "github.com/containerd/errdefs"
+	"github.com/klauspost/compress/zstd"
+	"github.com/moby/moby/v2/daemon/server/backend"
+	"gotest.tools/v3/assert"
+	is "gotest.tools/v3/assert/cmp"
+)
+
+func TestCompressionOptions(t *testing.T) {
+	tests := []struct {
+		doc           string
+		defaultComp   backend.Compression
+		form          url.Values
+		expectedComp  backend.Compression
+		expectedLevel int
+		expectedErr   string
+	}{
+		{
+			doc:          "defaults to uncompressed",
+			expectedComp: backend.CompressionDefault,
+		},
+		{
+			doc:          "falls back to the daemon default",
+			defaultComp:  backend.CompressionZstd,
+			expectedComp: backend.CompressionZstd,
+		},
+		{
+			doc:          "request overrides the daemon default",
+			defaultComp:  backend.CompressionZstd,
+			form:         url.Values{"compression": {"gzip"}},
+			expectedComp: backend.CompressionGzip,
+		},
+		{
+			doc:           "compression level",
+			form:          url.Values{"compression": {"zstd"}, "compression-level": {"3"}},
+			expectedComp:  backend.CompressionZstd,
+			expectedLevel: 3,
+		},
+		{
+			doc:         "unknown algorithm",
+			form:        url.Values{"compression": {"lz4"}},
+			expectedErr: `invalid compression algorithm: "lz4"`,
+		},
+		{
+			doc:         "non-numeric level",
+			form:        url.Values{"compression": {"gzip"}, "compression-level": {"fast"}},
+			expectedErr: `invalid compression level: "fast"`,
+		},
+		{
+			doc:         "negative level",
+			form:        url.Values{"compression": {"gzip"}, "compression-level": {"-1"}},
+			expectedErr: `invalid compression level: "-1"`,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.doc, func(t *testing.T) {
+			ir := &imageRouter{defaultCompression: tc.defaultComp}
+			comp, level, err := ir.compressionOptions(&http.Request{Form: tc.form})
+			if tc.expectedErr != "" {
+				assert.Check(t, is.ErrorContains(err, tc.expectedErr))
+				assert.Check(t, cerrdefs.IsInvalidArgument(err))
+				return
+			}
+			assert.NilError(t, err)
+			assert.Check(t, is.Equal(comp, tc.expectedComp))
+			assert.Check(t, is.Equal(level, tc.expectedLevel))
+		})
+	}
+}
+
+func TestCompressExportStream(t *testing.T) {
+	const payload = "fake tar stream"
+
+	t.Run("default is a passthrough", func(t *testing.T) {
+		var buf bytes.Buffer
+		w, closeCompressor, err := compressExportStream(&buf, backend.CompressionDefault, 0)
+		assert.NilError(t, err)
+		_, err = io.WriteString(w, payload)
+		assert.NilError(t, err)
+		assert.NilError(t, closeCompressor())
+		assert.Check(t, is.Equal(buf.String(), payload))
+	})
+
+	t.Run("gzip round-trips", func(t *testing.T) {
+		var buf bytes.Buffer
+		w, closeCompressor, err := compressExportStream(&buf, backend.CompressionGzip, 1)
+		assert.NilError(t, err)
+		_, err = io.WriteString(w, payload)
+		assert.NilError(t, err)
+		assert.NilError(t, closeCompressor())
+
+		gr, err := gzip.NewReader(&buf)
+		assert.NilError(t, err)
+		defer gr.Close()
+		plain, err := io.ReadAll(gr)
+		assert.NilError(t, err)
+		assert.Check(t, is.Equal(string(plain), payload))
+	})
+
+	t.Run("zstd round-trips", func(t *testing.T) {
+		var buf bytes.Buffer
+		w, closeCompressor, err := compressExportStream(&buf, backend.CompressionZstd, 3)
+		assert.NilError(t, err)
+		_, err = io.WriteString(w, payload)
+		assert.NilError(t, err)
+		assert.NilError(t, closeCompressor())
+
+		zr, err := zstd.NewReader(&buf)
+		assert.NilError(t, err)
+		defer zr.Close()
+		plain, err := io.ReadAll(zr)
+		assert.NilError(t, err)
+		assert.Check(t, is.Equal(string(plain), payload))
+	})
+
+	t.Run("invalid gzip level", func(t *testing.T) {
+		_, _, err := compressExportStream(io.Discard, backend.CompressionGzip, 99)
+		assert.Check(t, is.ErrorContains(err, "invalid compression level"))
+		assert.Check(t, cerrdefs.IsInvalidArgument(err))
+	})
+}
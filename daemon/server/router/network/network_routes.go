@@ -91,6 +91,7 @@ func (n *networkRouter) getNetwork(ctx context.Context, w http.ResponseWriter, r
 	term := vars["id"]
 	var (
 		verbose bool
+		size    bool
 		err     error
 	)
 	if v := r.URL.Query().Get("verbose"); v != "" {
@@ -98,6 +99,11 @@ func (n *networkRouter) getNetwork(ctx context.Context, w http.ResponseWriter, r
 			return errors.Wrapf(invalidRequestError{err}, "invalid value for verbose: %s", v)
 		}
 	}
+	if v := r.URL.Query().Get("size"); v != "" {
+		if size, err = strconv.ParseBool(v); err != nil {
+			return errors.Wrapf(invalidRequestError{err}, "invalid value for size: %s", v)
+		}
+	}
 	networkScope := r.URL.Query().Get("scope")
 
 	// In case multiple networks have duplicate names, return error.
@@ -117,7 +123,7 @@ func (n *networkRouter) getNetwork(ctx context.Context, w http.ResponseWriter, r
 	if networkScope != "" {
 		filter.Add("scope", networkScope)
 	}
-	networks, _ := n.backend.GetNetworks(filter, backend.NetworkListConfig{Detailed: true, Verbose: verbose})
+	networks, _ := n.backend.GetNetworks(filter, backend.NetworkListConfig{Detailed: true, Verbose: verbose, Size: size})
 	for _, nw := range networks {
 		if nw.ID == term {
 			return httputils.WriteJSON(w, http.StatusOK, nw)
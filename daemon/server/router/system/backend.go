@@ -2,6 +2,7 @@ package system
 
 import (
 	"context"
+	"io"
 	"time"
 
 	"github.com/moby/moby/api/types"
@@ -23,6 +24,11 @@ type Backend interface {
 	SubscribeToEvents(since, until time.Time, ef filters.Args) ([]events.Message, chan interface{})
 	UnsubscribeFromEvents(chan interface{})
 	AuthenticateToRegistry(ctx context.Context, authConfig *registry.AuthConfig) (string, error)
+	SystemExportBundle(ctx context.Context, w io.Writer) error
+	SystemImportBundle(ctx context.Context, r io.Reader) (*system.BundleRestoreReport, error)
+	MaintenanceStatus() system.Maintenance
+	SetMaintenance(cfg system.Maintenance) error
+	PreloadedImages() []system.PreloadedImage
 }
 
 // ClusterBackend is all the methods that need to be implemented
@@ -37,6 +37,11 @@ func NewRouter(b Backend, c ClusterBackend, builder BuildBackend, features func(
 		router.NewGetRoute("/info", r.getInfo),
 		router.NewGetRoute("/version", r.getVersion),
 		router.NewGetRoute("/system/df", r.getDiskUsage),
+		router.NewGetRoute("/system/dr-bundle", r.getDRBundle),
+		router.NewPostRoute("/system/dr-bundle", r.postDRBundle),
+		router.NewGetRoute("/system/maintenance", r.getMaintenance),
+		router.NewPostRoute("/system/maintenance", r.postMaintenance),
+		router.NewGetRoute("/system/preload-images", r.getPreloadedImages),
 		router.NewPostRoute("/auth", r.postAuth),
 	}
 
@@ -246,6 +246,7 @@ func (s *systemRouter) getDiskUsage(ctx context.Context, w http.ResponseWriter,
 		du.Images = systemDiskUsage.Images
 		du.Containers = systemDiskUsage.Containers
 		du.Volumes = systemDiskUsage.Volumes
+		du.Storage = systemDiskUsage.Storage
 	}
 
 	// Use the old struct for the API return value.
@@ -264,6 +265,7 @@ func (s *systemRouter) getDiskUsage(ctx context.Context, w http.ResponseWriter,
 		v.BuildCache = du.BuildCache.Items
 	}
 	v.BuilderSize = builderSize
+	v.Storage = du.Storage
 	return httputils.WriteJSON(w, http.StatusOK, v)
 }
 
@@ -351,7 +353,13 @@ func (s *systemRouter) getEvents(ctx context.Context, w http.ResponseWriter, r *
 
 	for {
 		select {
-		case ev := <-l:
+		case ev, ok := <-l:
+			if !ok {
+				// The daemon closed the subscription, e.g. because this
+				// consumer was too slow to keep up with the event stream.
+				log.G(ctx).Debug("Event subscription closed, stop sending events")
+				return nil
+			}
 			jev, ok := ev.(events.Message)
 			if !ok {
 				log.G(ctx).Warnf("unexpected event message: %q", ev)
@@ -399,3 +407,40 @@ func eventTime(formTime string) (time.Time, error) {
 	}
 	return time.Unix(t, tNano), nil
 }
+
+func (s *systemRouter) getDRBundle(ctx context.Context, w http.ResponseWriter, r *http.Request, vars map[string]string) error {
+	w.Header().Set("Content-Type", "application/x-tar")
+	return s.backend.SystemExportBundle(ctx, w)
+}
+
+func (s *systemRouter) postDRBundle(ctx context.Context, w http.ResponseWriter, r *http.Request, vars map[string]string) error {
+	report, err := s.backend.SystemImportBundle(ctx, r.Body)
+	if err != nil {
+		return err
+	}
+	return httputils.WriteJSON(w, http.StatusOK, report)
+}
+
+func (s *systemRouter) getMaintenance(ctx context.Context, w http.ResponseWriter, r *http.Request, vars map[string]string) error {
+	return httputils.WriteJSON(w, http.StatusOK, s.backend.MaintenanceStatus())
+}
+
+func (s *systemRouter) getPreloadedImages(ctx context.Context, w http.ResponseWriter, r *http.Request, vars map[string]string) error {
+	imgs := s.backend.PreloadedImages()
+	if imgs == nil {
+		imgs = []system.PreloadedImage{}
+	}
+	return httputils.WriteJSON(w, http.StatusOK, imgs)
+}
+
+func (s *systemRouter) postMaintenance(ctx context.Context, w http.ResponseWriter, r *http.Request, vars map[string]string) error {
+	var cfg system.Maintenance
+	if err := httputils.ReadJSON(r, &cfg); err != nil {
+		return err
+	}
+	if err := s.backend.SetMaintenance(cfg); err != nil {
+		return err
+	}
+	w.WriteHeader(http.StatusNoContent)
+	return nil
+}
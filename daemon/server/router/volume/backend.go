@@ -11,9 +11,13 @@ import (
 // Backend is the methods that need to be implemented to provide
 // volume specific functionality
 type Backend interface {
-	List(ctx context.Context, filter filters.Args) ([]*volume.Volume, []string, error)
+	List(ctx context.Context, filter filters.Args, opts ...opts.ListOption) ([]*volume.Volume, []string, error)
 	Get(ctx context.Context, name string, opts ...opts.GetOption) (*volume.Volume, error)
 	Create(ctx context.Context, name, driverName string, opts ...opts.CreateOption) (*volume.Volume, error)
+	Clone(ctx context.Context, name string, options volume.CloneOptions) (*volume.Volume, error)
+	CreateSnapshot(ctx context.Context, name string, options volume.SnapshotCreateOptions) (*volume.Snapshot, error)
+	Snapshots(ctx context.Context, name string) ([]*volume.Snapshot, error)
+	RestoreSnapshot(ctx context.Context, name string, options volume.SnapshotRestoreOptions) error
 	Remove(ctx context.Context, name string, opts ...opts.RemoveOption) error
 	Prune(ctx context.Context, pruneFilters filters.Args) (*volume.PruneReport, error)
 }
@@ -28,10 +28,14 @@ func (v *volumeRouter) initRoutes() {
 	v.routes = []router.Route{
 		// GET
 		router.NewGetRoute("/volumes", v.getVolumesList),
+		router.NewGetRoute("/volumes/{name:.*}/snapshots", v.getVolumeSnapshots),
 		router.NewGetRoute("/volumes/{name:.*}", v.getVolumeByName),
 		// POST
 		router.NewPostRoute("/volumes/create", v.postVolumesCreate),
 		router.NewPostRoute("/volumes/prune", v.postVolumesPrune),
+		router.NewPostRoute("/volumes/{name:.*}/clone", v.postVolumesClone),
+		router.NewPostRoute("/volumes/{name:.*}/snapshots", v.postVolumeSnapshotCreate),
+		router.NewPostRoute("/volumes/{name:.*}/restore", v.postVolumeSnapshotRestore),
 		// PUT
 		router.NewPutRoute("/volumes/{name:.*}", v.putVolumesUpdate),
 		// DELETE
@@ -32,7 +32,13 @@ func (v *volumeRouter) getVolumesList(ctx context.Context, w http.ResponseWriter
 	if err != nil {
 		return errors.Wrap(err, "error reading volume filters")
 	}
-	volumes, warnings, err := v.backend.List(ctx, f)
+
+	var listOpts []opts.ListOption
+	if httputils.BoolValue(r, "size") {
+		listOpts = append(listOpts, opts.WithListSize(true))
+	}
+
+	volumes, warnings, err := v.backend.List(ctx, f, listOpts...)
 	if err != nil {
 		return err
 	}
@@ -65,7 +71,11 @@ func (v *volumeRouter) getVolumeByName(ctx context.Context, w http.ResponseWrite
 	// we prefer to get volumes locally before attempting to get them from the
 	// cluster. Local volumes can only be looked up by name, but cluster
 	// volumes can also be looked up by ID.
-	vol, err := v.backend.Get(ctx, vars["name"], opts.WithGetResolveStatus)
+	getOpts := []opts.GetOption{opts.WithGetResolveStatus}
+	if httputils.BoolValue(r, "size") {
+		getOpts = append(getOpts, opts.WithGetResolveSize)
+	}
+	vol, err := v.backend.Get(ctx, vars["name"], getOpts...)
 
 	// if the volume is not found in the regular volume backend, and the client
 	// is using an API version greater than 1.42 (when cluster volumes were
@@ -130,6 +140,72 @@ func (v *volumeRouter) postVolumesCreate(ctx context.Context, w http.ResponseWri
 	return httputils.WriteJSON(w, http.StatusCreated, vol)
 }
 
+func (v *volumeRouter) postVolumesClone(ctx context.Context, w http.ResponseWriter, r *http.Request, vars map[string]string) error {
+	if err := httputils.ParseForm(r); err != nil {
+		return err
+	}
+
+	var req volume.CloneOptions
+	if err := httputils.ReadJSON(r, &req); err != nil {
+		return err
+	}
+
+	vol, err := v.backend.Clone(ctx, vars["name"], req)
+	if err != nil {
+		return err
+	}
+	return httputils.WriteJSON(w, http.StatusCreated, vol)
+}
+
+func (v *volumeRouter) postVolumeSnapshotCreate(ctx context.Context, w http.ResponseWriter, r *http.Request, vars map[string]string) error {
+	if err := httputils.ParseForm(r); err != nil {
+		return err
+	}
+
+	var req volume.SnapshotCreateOptions
+	if err := httputils.ReadJSON(r, &req); err != nil {
+		return err
+	}
+
+	snapshot, err := v.backend.CreateSnapshot(ctx, vars["name"], req)
+	if err != nil {
+		return err
+	}
+	return httputils.WriteJSON(w, http.StatusCreated, snapshot)
+}
+
+func (v *volumeRouter) postVolumeSnapshotRestore(ctx context.Context, w http.ResponseWriter, r *http.Request, vars map[string]string) error {
+	if err := httputils.ParseForm(r); err != nil {
+		return err
+	}
+
+	var req volume.SnapshotRestoreOptions
+	if err := httputils.ReadJSON(r, &req); err != nil {
+		return err
+	}
+
+	if err := v.backend.RestoreSnapshot(ctx, vars["name"], req); err != nil {
+		return err
+	}
+	w.WriteHeader(http.StatusNoContent)
+	return nil
+}
+
+func (v *volumeRouter) getVolumeSnapshots(ctx context.Context, w http.ResponseWriter, r *http.Request, vars map[string]string) error {
+	if err := httputils.ParseForm(r); err != nil {
+		return err
+	}
+
+	snapshots, err := v.backend.Snapshots(ctx, vars["name"])
+	if err != nil {
+		return err
+	}
+	if snapshots == nil {
+		snapshots = []*volume.Snapshot{}
+	}
+	return httputils.WriteJSON(w, http.StatusOK, &volume.SnapshotListResponse{Snapshots: snapshots})
+}
+
 func (v *volumeRouter) putVolumesUpdate(ctx context.Context, w http.ResponseWriter, r *http.Request, vars map[string]string) error {
 	if !v.cluster.IsManager() {
 		return errdefs.Unavailable(errors.New("volume update only valid for cluster volumes, but swarm is unavailable"))
@@ -588,7 +588,7 @@ type fakeVolumeBackend struct {
 	volumes map[string]*volume.Volume
 }
 
-func (b *fakeVolumeBackend) List(_ context.Context, _ filters.Args) ([]*volume.Volume, []string, error) {
+func (b *fakeVolumeBackend) List(_ context.Context, _ filters.Args, _ ...opts.ListOption) ([]*volume.Volume, []string, error) {
 	var volumes []*volume.Volume
 	for _, v := range b.volumes {
 		volumes = append(volumes, v)
@@ -624,6 +624,39 @@ func (b *fakeVolumeBackend) Create(_ context.Context, name, driverName string, _
 	return v, nil
 }
 
+func (b *fakeVolumeBackend) Clone(ctx context.Context, name string, options volume.CloneOptions) (*volume.Volume, error) {
+	src, ok := b.volumes[name]
+	if !ok {
+		return nil, errdefs.NotFound(fmt.Errorf("volume %s not found", name))
+	}
+	driver := options.Driver
+	if driver == "" {
+		driver = src.Driver
+	}
+	return b.Create(ctx, options.Name, driver)
+}
+
+func (b *fakeVolumeBackend) CreateSnapshot(_ context.Context, name string, options volume.SnapshotCreateOptions) (*volume.Snapshot, error) {
+	if _, ok := b.volumes[name]; !ok {
+		return nil, errdefs.NotFound(fmt.Errorf("volume %s not found", name))
+	}
+	return &volume.Snapshot{Name: options.Name}, nil
+}
+
+func (b *fakeVolumeBackend) Snapshots(_ context.Context, name string) ([]*volume.Snapshot, error) {
+	if _, ok := b.volumes[name]; !ok {
+		return nil, errdefs.NotFound(fmt.Errorf("volume %s not found", name))
+	}
+	return nil, nil
+}
+
+func (b *fakeVolumeBackend) RestoreSnapshot(_ context.Context, name string, _ volume.SnapshotRestoreOptions) error {
+	if _, ok := b.volumes[name]; !ok {
+		return errdefs.NotFound(fmt.Errorf("volume %s not found", name))
+	}
+	return nil
+}
+
 func (b *fakeVolumeBackend) Remove(_ context.Context, name string, o ...opts.RemoveOption) error {
 	removeOpts := &opts.RemoveConfig{}
 	for _, opt := range o {
@@ -101,6 +101,13 @@ func (daemon *Daemon) containerStart(ctx context.Context, daemonCfg *configStore
 		return errdefs.Forbidden(errors.New("custom checkpointdir is not supported"))
 	}
 
+	// Starting a container needs a live containerd connection; fail fast
+	// (or queue briefly, depending on containerd-unavailable-action) when
+	// containerd is being restarted.
+	if err := daemon.waitContainerdAvailable(ctx, &daemonCfg.Config); err != nil {
+		return err
+	}
+
 	// if we encounter an error during start we need to ensure that any other
 	// setup has been cleaned up properly
 	defer func() {
@@ -146,6 +153,10 @@ func (daemon *Daemon) containerStart(ctx context.Context, daemonCfg *configStore
 		}
 	}()
 
+	if err := daemon.applyNetworkRate(container); err != nil {
+		return err
+	}
+
 	mnts, err := daemon.setupContainerDirs(container)
 	if err != nil {
 		return err
@@ -194,7 +205,12 @@ func (daemon *Daemon) containerStart(ctx context.Context, daemonCfg *configStore
 		return err
 	}
 
-	ctr, err := libcontainerd.ReplaceContainer(ctx, daemon.containerd, container.ID, spec, shim, createOptions, func(ctx context.Context, client *containerd.Client, c *containers.Container) error {
+	tenantClient, err := daemon.containerdFor(container)
+	if err != nil {
+		return err
+	}
+
+	ctr, err := libcontainerd.ReplaceContainer(ctx, tenantClient, container.ID, spec, shim, createOptions, func(ctx context.Context, client *containerd.Client, c *containers.Container) error {
 		// Only set the image if we are using containerd for image storage.
 		// This is for metadata purposes only.
 		// Other lower-level components may make use of this information.
@@ -253,6 +269,7 @@ func (daemon *Daemon) containerStart(ctx context.Context, daemonCfg *configStore
 	daemon.setStateCounter(container)
 
 	daemon.initHealthMonitor(container)
+	daemon.enforceStartDeadline(container)
 
 	if err := container.CheckpointTo(context.WithoutCancel(ctx), daemon.containersReplica); err != nil {
 		log.G(ctx).WithError(err).WithField("container", container.ID).
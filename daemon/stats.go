@@ -5,10 +5,12 @@ import (
 	"encoding/json"
 	"errors"
 	"runtime"
+	"strings"
 	"time"
 
 	"github.com/containerd/log"
 	containertypes "github.com/moby/moby/api/types/container"
+	"github.com/moby/moby/api/types/filters"
 	"github.com/moby/moby/v2/daemon/container"
 	"github.com/moby/moby/v2/daemon/server/backend"
 	"github.com/moby/moby/v2/errdefs"
@@ -25,6 +27,21 @@ func (daemon *Daemon) ContainerStats(ctx context.Context, prefixOrName string, c
 	if config.Stream && config.OneShot {
 		return errdefs.InvalidParameter(errors.New("cannot have stream=true and one-shot=true"))
 	}
+	if config.NetDetail && runtime.GOOS != "linux" {
+		return errdefs.InvalidParameter(errors.New("detailed network stats are only supported on Linux"))
+	}
+
+	maybeAddNetDetail := func(s *containertypes.StatsResponse) {
+		if !config.NetDetail {
+			return
+		}
+		netDetail, err := daemon.netDetailStats(ctr)
+		if err != nil {
+			log.G(ctx).WithError(err).WithField("container", ctr.ID).Debug("Could not collect detailed network stats")
+			return
+		}
+		s.NetDetail = netDetail
+	}
 
 	enc := json.NewEncoder(config.OutStream())
 
@@ -42,6 +59,7 @@ func (daemon *Daemon) ContainerStats(ctx context.Context, prefixOrName string, c
 		if err != nil {
 			return err
 		}
+		maybeAddNetDetail(stats)
 		return enc.Encode(stats)
 	}
 
@@ -77,6 +95,7 @@ func (daemon *Daemon) ContainerStats(ctx context.Context, prefixOrName string, c
 				continue
 			}
 
+			maybeAddNetDetail(statsJSON)
 			if err := enc.Encode(statsJSON); err != nil {
 				return err
 			}
@@ -133,3 +152,124 @@ done:
 		return nil, err
 	}
 }
+
+// GetContainersStats collects stats for all the given containers. The only
+// work shared across the batch is the system-wide CPU usage sample, which
+// repeated GetContainerStats calls would read once per container; each
+// container's own cgroup and network stats are still collected individually.
+// Collection failures for individual containers yield an empty response
+// carrying only the container name and ID, so the result always has one
+// entry per container, in order.
+func (daemon *Daemon) GetContainersStats(containers []*container.Container) []*containertypes.StatsResponse {
+	systemUsage, onlineCPUs, sysErr := getSystemCPUUsage()
+
+	resp := make([]*containertypes.StatsResponse, 0, len(containers))
+	for _, ctr := range containers {
+		stats, err := daemon.stats(ctr)
+		if err == nil {
+			err = sysErr
+		}
+		if err == nil {
+			stats.CPUStats.SystemUsage = systemUsage
+			stats.CPUStats.OnlineCPUs = onlineCPUs
+			if !ctr.Config.NetworkDisabled && runtime.GOOS != "windows" {
+				stats.Networks, err = daemon.getNetworkStats(ctr)
+			}
+		}
+		if err != nil {
+			switch err.(type) {
+			case errdefs.ErrConflict, errdefs.ErrNotFound:
+				// not running or not found; return empty stats below
+			default:
+				log.G(context.TODO()).Errorf("collecting stats for container %s: %v", ctr.Name, err)
+			}
+			stats = &containertypes.StatsResponse{}
+		}
+		stats.Name = ctr.Name
+		stats.ID = ctr.ID
+		resp = append(resp, stats)
+	}
+	return resp
+}
+
+// acceptedStatsFilters are the filters accepted by ContainersStats.
+var acceptedStatsFilters = map[string]bool{
+	"id":    true,
+	"name":  true,
+	"label": true,
+}
+
+// ContainersStats writes a multiplexed stream of stats for all containers
+// matching the given filters to the stream in the config object. Each sample
+// is a regular stats response identifying the container it belongs to, so a
+// single connection can monitor a whole set of containers.
+func (daemon *Daemon) ContainersStats(ctx context.Context, config *backend.ContainersStatsConfig) error {
+	if err := config.Filters.Validate(acceptedStatsFilters); err != nil {
+		return err
+	}
+	interval := config.Interval
+	if interval == 0 {
+		interval = time.Second
+	}
+	if interval < 100*time.Millisecond {
+		return errdefs.InvalidParameter(errors.New("stats interval cannot be lower than 100ms"))
+	}
+
+	enc := json.NewEncoder(config.OutStream())
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	// Per-container bookkeeping for the PreCPUStats/PreRead fields; the
+	// first sample for a container has them zeroed, like one-shot stats.
+	type previous struct {
+		cpu  containertypes.CPUStats
+		read time.Time
+	}
+	pre := make(map[string]previous)
+
+	for {
+		var matched []*container.Container
+		for _, ctr := range daemon.containers.List() {
+			if !statsFiltersMatch(config.Filters, ctr) {
+				continue
+			}
+			if !ctr.IsRunning() || ctr.IsRestarting() {
+				continue
+			}
+			matched = append(matched, ctr)
+		}
+		for _, stats := range daemon.GetContainersStats(matched) {
+			p := pre[stats.ID]
+			stats.PreCPUStats = p.cpu
+			stats.PreRead = p.read
+			pre[stats.ID] = previous{cpu: stats.CPUStats, read: stats.Read}
+			if err := enc.Encode(stats); err != nil {
+				return err
+			}
+		}
+
+		if !config.Stream {
+			return nil
+		}
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
+
+// statsFiltersMatch reports whether the container matches the id, name and
+// label filters.
+func statsFiltersMatch(f filters.Args, ctr *container.Container) bool {
+	if !f.Match("id", ctr.ID) {
+		return false
+	}
+	if !f.Match("name", strings.TrimPrefix(ctr.Name, "/")) {
+		return false
+	}
+	if f.Contains("label") && !f.MatchKVList("label", ctr.Config.Labels) {
+		return false
+	}
+	return true
+}
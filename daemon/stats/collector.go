@@ -34,6 +34,14 @@ type supervisor interface {
 	GetContainerStats(container *container.Container) (*containertypes.StatsResponse, error)
 }
 
+// batchSupervisor is implemented by supervisors that can sample several
+// containers in one pass, amortizing per-sample costs such as reading
+// system-wide CPU usage. The returned slice has one entry per container,
+// in the same order.
+type batchSupervisor interface {
+	GetContainersStats(containers []*container.Container) []*containertypes.StatsResponse
+}
+
 // Collect registers the container with the collector and adds it to
 // the event loop for collection on the specified interval returning
 // a channel for the subscriber to receive on.
@@ -102,15 +110,25 @@ func (s *Collector) Run() {
 
 		s.cond.L.Unlock()
 
-		for _, pair := range pairs {
-			stats, err := s.supervisor.GetContainerStats(pair.container)
-			if err != nil {
-				stats = &containertypes.StatsResponse{
-					Name: pair.container.Name,
-					ID:   pair.container.ID,
+		if batch, ok := s.supervisor.(batchSupervisor); ok {
+			containers := make([]*container.Container, len(pairs))
+			for i, pair := range pairs {
+				containers[i] = pair.container
+			}
+			for i, stats := range batch.GetContainersStats(containers) {
+				pairs[i].publisher.Publish(*stats)
+			}
+		} else {
+			for _, pair := range pairs {
+				stats, err := s.supervisor.GetContainerStats(pair.container)
+				if err != nil {
+					stats = &containertypes.StatsResponse{
+						Name: pair.container.Name,
+						ID:   pair.container.ID,
+					}
 				}
+				pair.publisher.Publish(*stats)
 			}
-			pair.publisher.Publish(*stats)
 		}
 
 		time.Sleep(s.interval)
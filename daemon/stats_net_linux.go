@@ -0,0 +1,90 @@
+package daemon
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	containertypes "github.com/moby/moby/api/types/container"
+	"github.com/moby/moby/v2/daemon/container"
+)
+
+// procRoot is the mount point of procfs. It is a variable so that tests can
+// point it at a fake tree.
+var procRoot = "/proc"
+
+// netDetailStats gathers socket statistics from the container's network
+// namespace. The per-process files under /proc/<pid>/net reflect the network
+// namespace of the process, so reading them through the container's init
+// process requires no setns.
+func (daemon *Daemon) netDetailStats(c *container.Container) (*containertypes.NetDetailStats, error) {
+	c.Lock()
+	pid := c.State.Pid
+	running := c.State.Running
+	c.Unlock()
+	if !running || pid == 0 {
+		return nil, nil
+	}
+
+	netDir := filepath.Join(procRoot, strconv.Itoa(pid), "net")
+	snmp, err := parseProcNetStatFile(filepath.Join(netDir, "snmp"))
+	if err != nil {
+		return nil, err
+	}
+	netstat, err := parseProcNetStatFile(filepath.Join(netDir, "netstat"))
+	if err != nil {
+		return nil, err
+	}
+
+	return &containertypes.NetDetailStats{
+		TCPEstablished:     snmp["Tcp.CurrEstab"],
+		TCPRetransSegs:     snmp["Tcp.RetransSegs"],
+		TCPListenOverflows: netstat["TcpExt.ListenOverflows"],
+		TCPListenDrops:     netstat["TcpExt.ListenDrops"],
+		UDPInDatagrams:     snmp["Udp.InDatagrams"],
+		UDPOutDatagrams:    snmp["Udp.OutDatagrams"],
+		UDPRcvbufErrors:    snmp["Udp.RcvbufErrors"],
+	}, nil
+}
+
+func parseProcNetStatFile(path string) (map[string]uint64, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return parseProcNetStat(f)
+}
+
+// parseProcNetStat parses a file in the format of /proc/net/snmp and
+// /proc/net/netstat: pairs of lines sharing a protocol prefix, the first
+// naming the fields and the second holding their values. The returned map is
+// keyed by "<protocol>.<field>", e.g. "Tcp.CurrEstab". Fields that do not
+// parse as unsigned integers (some SNMP gauges are signed) are skipped.
+func parseProcNetStat(r io.Reader) (map[string]uint64, error) {
+	stats := make(map[string]uint64)
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		names := strings.Fields(scanner.Text())
+		if !scanner.Scan() {
+			return nil, fmt.Errorf("missing value line for %s", strings.TrimSuffix(names[0], ":"))
+		}
+		values := strings.Fields(scanner.Text())
+		if len(names) != len(values) || len(names) == 0 || names[0] != values[0] {
+			return nil, fmt.Errorf("mismatched header and value lines")
+		}
+		proto := strings.TrimSuffix(names[0], ":")
+		for i := 1; i < len(names); i++ {
+			v, err := strconv.ParseUint(values[i], 10, 64)
+			if err != nil {
+				continue
+			}
+			stats[proto+"."+names[i]] = v
+		}
+	}
+	return stats, scanner.Err()
+}
@@ -0,0 +1,77 @@
+package daemon
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/moby/moby/v2/daemon/container"
+	"gotest.tools/v3/assert"
+	is "gotest.tools/v3/assert/cmp"
+)
+
+const procNetSnmp = `Ip: Forwarding DefaultTTL InReceives
+Ip: 1 64 1000
+Tcp: RtoAlgorithm RtoMin RtoMax MaxConn ActiveOpens PassiveOpens AttemptFails EstabResets CurrEstab InSegs OutSegs RetransSegs
+Tcp: 1 200 120000 -1 10 20 0 0 7 500 600 42
+Udp: InDatagrams NoPorts InErrors OutDatagrams RcvbufErrors SndbufErrors
+Udp: 100 0 0 200 3 0
+`
+
+const procNetNetstat = `TcpExt: SyncookiesSent SyncookiesRecv ListenOverflows ListenDrops
+TcpExt: 0 0 5 9
+IpExt: InNoRoutes InTruncatedPkts
+IpExt: 0 0
+`
+
+func TestParseProcNetStat(t *testing.T) {
+	stats, err := parseProcNetStat(strings.NewReader(procNetSnmp))
+	assert.NilError(t, err)
+	assert.Check(t, is.Equal(stats["Tcp.CurrEstab"], uint64(7)))
+	assert.Check(t, is.Equal(stats["Tcp.RetransSegs"], uint64(42)))
+	assert.Check(t, is.Equal(stats["Udp.RcvbufErrors"], uint64(3)))
+	// MaxConn is -1; signed gauges are skipped rather than failing the parse.
+	_, ok := stats["Tcp.MaxConn"]
+	assert.Check(t, !ok)
+
+	_, err = parseProcNetStat(strings.NewReader("Tcp: A B\nTcp: 1\n"))
+	assert.Check(t, is.ErrorContains(err, "mismatched header and value lines"))
+
+	_, err = parseProcNetStat(strings.NewReader("Tcp: A B\n"))
+	assert.Check(t, is.ErrorContains(err, "missing value line for Tcp"))
+}
+
+func TestNetDetailStats(t *testing.T) {
+	oldRoot := procRoot
+	procRoot = t.TempDir()
+	defer func() { procRoot = oldRoot }()
+
+	netDir := filepath.Join(procRoot, "42", "net")
+	assert.NilError(t, os.MkdirAll(netDir, 0o755))
+	assert.NilError(t, os.WriteFile(filepath.Join(netDir, "snmp"), []byte(procNetSnmp), 0o644))
+	assert.NilError(t, os.WriteFile(filepath.Join(netDir, "netstat"), []byte(procNetNetstat), 0o644))
+
+	c := &container.Container{State: container.NewState()}
+	c.State.SetRunning(nil, nil, time.Now())
+	c.State.Pid = 42
+
+	d := &Daemon{}
+	stats, err := d.netDetailStats(c)
+	assert.NilError(t, err)
+	assert.Assert(t, stats != nil)
+	assert.Check(t, is.Equal(stats.TCPEstablished, uint64(7)))
+	assert.Check(t, is.Equal(stats.TCPRetransSegs, uint64(42)))
+	assert.Check(t, is.Equal(stats.TCPListenOverflows, uint64(5)))
+	assert.Check(t, is.Equal(stats.TCPListenDrops, uint64(9)))
+	assert.Check(t, is.Equal(stats.UDPInDatagrams, uint64(100)))
+	assert.Check(t, is.Equal(stats.UDPOutDatagrams, uint64(200)))
+	assert.Check(t, is.Equal(stats.UDPRcvbufErrors, uint64(3)))
+
+	// A stopped container yields no detail rather than an error.
+	c.State.SetStopped(&container.ExitStatus{})
+	stats, err = d.netDetailStats(c)
+	assert.NilError(t, err)
+	assert.Check(t, is.Nil(stats))
+}
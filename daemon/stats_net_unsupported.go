@@ -0,0 +1,14 @@
+//go:build !linux
+
+package daemon
+
+import (
+	containertypes "github.com/moby/moby/api/types/container"
+	"github.com/moby/moby/v2/daemon/container"
+)
+
+// netDetailStats is only supported on Linux; requests for detailed network
+// stats are rejected before it would be called on other platforms.
+func (daemon *Daemon) netDetailStats(c *container.Container) (*containertypes.NetDetailStats, error) {
+	return nil, nil
+}
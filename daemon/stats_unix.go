@@ -10,14 +10,55 @@ import (
 	"os"
 	"strconv"
 	"strings"
+	"time"
 
 	statsV1 "github.com/containerd/cgroups/v3/cgroup1/stats"
 	statsV2 "github.com/containerd/cgroups/v3/cgroup2/stats"
 	containertypes "github.com/moby/moby/api/types/container"
 	"github.com/moby/moby/v2/daemon/container"
+	libcontainerdtypes "github.com/moby/moby/v2/daemon/internal/libcontainerd/types"
 	"github.com/pkg/errors"
 )
 
+// taskResourceUsage captures a final resource usage summary from the task's
+// cgroup statistics. It is called when the task has exited but before it is
+// deleted, while the cgroup is still available. It returns nil when
+// statistics can no longer be collected.
+func taskResourceUsage(tsk libcontainerdtypes.Task) *containertypes.ResourceUsage {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	cs, err := tsk.Stats(ctx)
+	if err != nil {
+		return nil
+	}
+	switch t := cs.Metrics.(type) {
+	case *statsV1.Metrics:
+		u := &containertypes.ResourceUsage{}
+		if t.CPU != nil && t.CPU.Usage != nil {
+			u.CPUTotalNs = t.CPU.Usage.Total
+			u.CPUUserNs = t.CPU.Usage.User
+			u.CPUSystemNs = t.CPU.Usage.Kernel
+		}
+		if t.Memory != nil && t.Memory.Usage != nil {
+			u.MaxMemoryBytes = t.Memory.Usage.Max
+		}
+		return u
+	case *statsV2.Metrics:
+		u := &containertypes.ResourceUsage{}
+		if t.CPU != nil {
+			u.CPUTotalNs = t.CPU.UsageUsec * 1000
+			u.CPUUserNs = t.CPU.UserUsec * 1000
+			u.CPUSystemNs = t.CPU.SystemUsec * 1000
+		}
+		if t.Memory != nil {
+			u.MaxMemoryBytes = t.Memory.MaxUsage
+		}
+		return u
+	default:
+		return nil
+	}
+}
+
 func copyBlkioEntry(entries []*statsV1.BlkIOEntry) []containertypes.BlkioStatEntry {
 	out := make([]containertypes.BlkioStatEntry, len(entries))
 	for i, re := range entries {
@@ -2,13 +2,34 @@ package daemon
 
 import (
 	"context"
+	"time"
 
 	cerrdefs "github.com/containerd/errdefs"
 	containertypes "github.com/moby/moby/api/types/container"
 	"github.com/moby/moby/v2/daemon/container"
+	libcontainerdtypes "github.com/moby/moby/v2/daemon/internal/libcontainerd/types"
 	"github.com/moby/moby/v2/daemon/internal/platform"
 )
 
+// taskResourceUsage captures a final resource usage summary from HCS
+// statistics. It is called when the task has exited but before it is
+// deleted. It returns nil when statistics can no longer be collected.
+func taskResourceUsage(tsk libcontainerdtypes.Task) *containertypes.ResourceUsage {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	stats, err := tsk.Stats(ctx)
+	if err != nil || stats.HCSStats == nil {
+		return nil
+	}
+	hcss := stats.HCSStats
+	return &containertypes.ResourceUsage{
+		MaxMemoryBytes: hcss.Memory.UsageCommitPeakBytes,
+		CPUTotalNs:     hcss.Processor.TotalRuntime100ns * 100,
+		CPUUserNs:      hcss.Processor.RuntimeUser100ns * 100,
+		CPUSystemNs:    hcss.Processor.RuntimeKernel100ns * 100,
+	}
+}
+
 func (daemon *Daemon) stats(c *container.Container) (*containertypes.StatsResponse, error) {
 	c.Lock()
 	task, err := c.GetRunningTask()
@@ -0,0 +1,316 @@
+package daemon
+
+import (
+	"context"
+	"encoding/binary"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/moby/moby/v2/daemon/libnetwork"
+	"github.com/moby/moby/v2/daemon/server/backend"
+	"github.com/moby/moby/v2/errdefs"
+	"github.com/pkg/errors"
+	"github.com/vishvananda/netlink"
+	"golang.org/x/sys/unix"
+)
+
+const (
+	// mirrorMaxDuration bounds how long a single mirror session may run,
+	// so a forgotten capture cannot degrade the container indefinitely.
+	mirrorMaxDuration = 10 * time.Minute
+	// mirrorSnapLen is the per-packet capture size for streamed captures.
+	mirrorSnapLen = 65535
+)
+
+// ContainerMirrorTraffic mirrors traffic seen on one of the container's
+// interfaces for a bounded duration. If config.Target is set, packets are
+// duplicated to that interface inside the container's network namespace
+// using a tc mirred action; otherwise they are captured with a packet
+// socket and streamed to config.OutStream as a pcap.
+func (daemon *Daemon) ContainerMirrorTraffic(ctx context.Context, prefixOrName string, config *backend.ContainerMirrorConfig) error {
+	ctr, err := daemon.GetContainer(prefixOrName)
+	if err != nil {
+		return err
+	}
+	if !ctr.IsRunning() {
+		return errNotRunning(ctr.ID)
+	}
+	if ctr.IsRestarting() {
+		return errContainerIsRestarting(ctr.ID)
+	}
+	sb, err := daemon.netController.GetSandbox(ctr.ID)
+	if err != nil {
+		return errdefs.System(errors.Wrap(err, "no network sandbox for container"))
+	}
+
+	if config.Duration <= 0 || config.Duration > mirrorMaxDuration {
+		return errdefs.InvalidParameter(errors.Errorf("mirror duration must be between 0 and %v", mirrorMaxDuration))
+	}
+
+	srcName := config.Interface
+	if srcName == "" {
+		srcName = defaultMirrorInterface(sb)
+		if srcName == "" {
+			return errdefs.InvalidParameter(errors.New("container has no network interface to mirror"))
+		}
+	}
+
+	if config.Target != "" {
+		if config.Filter != "" {
+			return errdefs.InvalidParameter(errors.New("filter is only supported for streamed captures"))
+		}
+		return mirrorToInterface(ctx, sb, srcName, config.Target, config.Duration)
+	}
+	return mirrorToStream(ctx, sb, srcName, config)
+}
+
+// defaultMirrorInterface returns the in-sandbox name of the first endpoint
+// interface, or an empty string if the sandbox has none.
+func defaultMirrorInterface(sb *libnetwork.Sandbox) string {
+	for _, ep := range sb.Endpoints() {
+		if iface := ep.Iface(); iface != nil {
+			return iface.SrcName()
+		}
+	}
+	return ""
+}
+
+// mirrorToInterface duplicates all packets seen on srcName to targetName for
+// the given duration. Both interfaces must live in the container's network
+// namespace. The mirred filters hang off the clsact qdisc shared with the
+// network-rate policer (see clsact_linux.go); only the mirror's own filters
+// and its reference on the qdisc are torn down before returning.
+func mirrorToInterface(ctx context.Context, sb *libnetwork.Sandbox, srcName, targetName string, duration time.Duration) error {
+	mirrorFilter := func(linkIndex int, parent uint32) *netlink.MatchAll {
+		return &netlink.MatchAll{
+			FilterAttrs: netlink.FilterAttrs{
+				LinkIndex: linkIndex,
+				Parent:    parent,
+				Priority:  clsactMirrorPriority,
+				Protocol:  unix.ETH_P_ALL,
+				Handle:    1,
+			},
+		}
+	}
+	parents := []uint32{netlink.HANDLE_MIN_INGRESS, netlink.HANDLE_MIN_EGRESS}
+
+	user := newClsactUser("mirror")
+	var setupErr error
+	var src netlink.Link
+	err := sb.ExecFunc(func() {
+		src, setupErr = netlink.LinkByName(srcName)
+		if setupErr != nil {
+			setupErr = errors.Wrapf(setupErr, "interface %s not found in container", srcName)
+			return
+		}
+		target, err := netlink.LinkByName(targetName)
+		if err != nil {
+			setupErr = errors.Wrapf(err, "target interface %s not found in container", targetName)
+			return
+		}
+
+		if setupErr = acquireClsact(sb.ID(), src.Attrs().Index, srcName, user); setupErr != nil {
+			return
+		}
+		for i, parent := range parents {
+			action := netlink.NewMirredAction(target.Attrs().Index)
+			action.ActionAttrs.Action = netlink.TC_ACT_PIPE
+			action.MirredAction = netlink.TCA_EGRESS_MIRROR
+			filter := mirrorFilter(src.Attrs().Index, parent)
+			filter.Actions = []netlink.Action{action}
+			if err := netlink.FilterAdd(filter); err != nil {
+				for _, added := range parents[:i] {
+					_ = netlink.FilterDel(mirrorFilter(src.Attrs().Index, added))
+				}
+				releaseClsact(sb.ID(), src.Attrs().Index, srcName, user)
+				setupErr = errors.Wrap(err, "failed to add mirror filter")
+				return
+			}
+		}
+	})
+	if err != nil {
+		return errdefs.System(err)
+	}
+	if setupErr != nil {
+		return errdefs.System(setupErr)
+	}
+
+	timer := time.NewTimer(duration)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+	case <-timer.C:
+	}
+
+	return sb.ExecFunc(func() {
+		for _, parent := range parents {
+			_ = netlink.FilterDel(mirrorFilter(src.Attrs().Index, parent))
+		}
+		releaseClsact(sb.ID(), src.Attrs().Index, srcName, user)
+	})
+}
+
+// mirrorToStream captures packets from srcName inside the sandbox and writes
+// them to config.OutStream in pcap format until the configured duration
+// elapses or ctx is cancelled.
+func mirrorToStream(ctx context.Context, sb *libnetwork.Sandbox, srcName string, config *backend.ContainerMirrorConfig) error {
+	var filter []unix.SockFilter
+	if config.Filter != "" {
+		var err error
+		filter, err = parseClassicBPF(config.Filter)
+		if err != nil {
+			return errdefs.InvalidParameter(err)
+		}
+	}
+
+	var fd int
+	var setupErr error
+	err := sb.ExecFunc(func() {
+		link, err := netlink.LinkByName(srcName)
+		if err != nil {
+			setupErr = errors.Wrapf(err, "interface %s not found in container", srcName)
+			return
+		}
+		// The socket keeps the namespace it was created in, so it can be
+		// read from outside ExecFunc.
+		fd, err = unix.Socket(unix.AF_PACKET, unix.SOCK_RAW|unix.SOCK_CLOEXEC, int(htons(unix.ETH_P_ALL)))
+		if err != nil {
+			setupErr = errors.Wrap(err, "failed to create packet socket")
+			return
+		}
+		if filter != nil {
+			prog := &unix.SockFprog{Len: uint16(len(filter)), Filter: &filter[0]}
+			if err := unix.SetsockoptSockFprog(fd, unix.SOL_SOCKET, unix.SO_ATTACH_FILTER, prog); err != nil {
+				unix.Close(fd)
+				setupErr = errors.Wrap(err, "failed to attach BPF filter")
+				return
+			}
+		}
+		if err := unix.Bind(fd, &unix.SockaddrLinklayer{
+			Protocol: htons(unix.ETH_P_ALL),
+			Ifindex:  link.Attrs().Index,
+		}); err != nil {
+			unix.Close(fd)
+			setupErr = errors.Wrap(err, "failed to bind packet socket")
+		}
+	})
+	if err != nil {
+		return errdefs.System(err)
+	}
+	if setupErr != nil {
+		return errdefs.System(setupErr)
+	}
+	defer unix.Close(fd)
+
+	// Use a short receive timeout so the read loop can observe the capture
+	// deadline and context cancellation.
+	tv := unix.NsecToTimeval(int64(time.Second))
+	if err := unix.SetsockoptTimeval(fd, unix.SOL_SOCKET, unix.SO_RCVTIMEO, &tv); err != nil {
+		return errdefs.System(errors.Wrap(err, "failed to set receive timeout"))
+	}
+
+	out := config.OutStream()
+	if err := writePcapHeader(out); err != nil {
+		return err
+	}
+
+	deadline := time.Now().Add(config.Duration)
+	buf := make([]byte, mirrorSnapLen)
+	for time.Now().Before(deadline) {
+		select {
+		case <-ctx.Done():
+			return nil
+		default:
+		}
+		n, _, err := unix.Recvfrom(fd, buf, 0)
+		if err != nil {
+			if err == unix.EAGAIN || err == unix.EINTR {
+				continue
+			}
+			return errdefs.System(errors.Wrap(err, "packet capture read failed"))
+		}
+		if n == 0 {
+			continue
+		}
+		if err := writePcapRecord(out, time.Now(), buf[:n]); err != nil {
+			// The client went away; nothing left to stream to.
+			return nil
+		}
+	}
+	return nil
+}
+
+// writePcapHeader writes the pcap global header (little-endian, LINKTYPE_ETHERNET).
+func writePcapHeader(w io.Writer) error {
+	hdr := make([]byte, 24)
+	binary.LittleEndian.PutUint32(hdr[0:4], 0xa1b2c3d4) // magic
+	binary.LittleEndian.PutUint16(hdr[4:6], 2)          // version major
+	binary.LittleEndian.PutUint16(hdr[6:8], 4)          // version minor
+	binary.LittleEndian.PutUint32(hdr[16:20], mirrorSnapLen)
+	binary.LittleEndian.PutUint32(hdr[20:24], 1) // LINKTYPE_ETHERNET
+	_, err := w.Write(hdr)
+	return err
+}
+
+// writePcapRecord writes a single pcap record header followed by pkt.
+func writePcapRecord(w io.Writer, ts time.Time, pkt []byte) error {
+	hdr := make([]byte, 16)
+	binary.LittleEndian.PutUint32(hdr[0:4], uint32(ts.Unix()))
+	binary.LittleEndian.PutUint32(hdr[4:8], uint32(ts.Nanosecond()/1000))
+	binary.LittleEndian.PutUint32(hdr[8:12], uint32(len(pkt)))
+	binary.LittleEndian.PutUint32(hdr[12:16], uint32(len(pkt)))
+	if _, err := w.Write(hdr); err != nil {
+		return err
+	}
+	_, err := w.Write(pkt)
+	return err
+}
+
+// parseClassicBPF parses a classic BPF program in the numeric format emitted
+// by "tcpdump -ddd": an instruction count followed by one "code jt jf k"
+// quadruple per instruction, separated by newlines or commas.
+func parseClassicBPF(s string) ([]unix.SockFilter, error) {
+	fields := strings.FieldsFunc(s, func(r rune) bool {
+		return r == ' ' || r == '\t' || r == '\n' || r == ','
+	})
+	if len(fields) == 0 {
+		return nil, errors.New("empty BPF filter")
+	}
+	count, err := strconv.Atoi(fields[0])
+	if err != nil || count <= 0 {
+		return nil, errors.Errorf("invalid BPF instruction count %q", fields[0])
+	}
+	fields = fields[1:]
+	if len(fields) != count*4 {
+		return nil, errors.Errorf("BPF filter has %d values, expected %d for %d instructions", len(fields), count*4, count)
+	}
+	prog := make([]unix.SockFilter, count)
+	// Field widths of a BPF instruction: code is 16 bits, jt and jf are 8
+	// bits each, k is 32 bits. Parsing with the exact widths rejects
+	// out-of-range values instead of silently truncating them into a
+	// filter that matches the wrong traffic.
+	bitSizes := [4]int{16, 8, 8, 32}
+	for i := 0; i < count; i++ {
+		vals := make([]uint64, 4)
+		for j := range vals {
+			vals[j], err = strconv.ParseUint(fields[i*4+j], 10, bitSizes[j])
+			if err != nil {
+				return nil, errors.Errorf("invalid BPF instruction value %q", fields[i*4+j])
+			}
+		}
+		prog[i] = unix.SockFilter{
+			Code: uint16(vals[0]),
+			Jt:   uint8(vals[1]),
+			Jf:   uint8(vals[2]),
+			K:    uint32(vals[3]),
+		}
+	}
+	return prog, nil
+}
+
+// htons converts a short to network byte order for packet-socket protocols.
+func htons(v uint16) uint16 {
+	return (v << 8) | (v >> 8)
+}
@@ -0,0 +1,80 @@
+package daemon
+
+import (
+	"testing"
+
+	"golang.org/x/sys/unix"
+	"gotest.tools/v3/assert"
+	is "gotest.tools/v3/assert/cmp"
+)
+
+func TestParseClassicBPF(t *testing.T) {
+	tests := []struct {
+		name        string
+		in          string
+		expected    []unix.SockFilter
+		expectedErr string
+	}{
+		{
+			name: "tcpdump -ddd format",
+			in:   "4\n40 0 0 12\n21 0 1 2048\n6 0 0 65535\n6 0 0 0\n",
+			expected: []unix.SockFilter{
+				{Code: 40, Jt: 0, Jf: 0, K: 12},
+				{Code: 21, Jt: 0, Jf: 1, K: 2048},
+				{Code: 6, Jt: 0, Jf: 0, K: 65535},
+				{Code: 6},
+			},
+		},
+		{
+			name:     "comma separated",
+			in:       "1,6 0 0 65535",
+			expected: []unix.SockFilter{{Code: 6, K: 65535}},
+		},
+		{
+			name:        "empty",
+			in:          "",
+			expectedErr: "empty BPF filter",
+		},
+		{
+			name:        "invalid count",
+			in:          "x",
+			expectedErr: `invalid BPF instruction count "x"`,
+		},
+		{
+			name:        "value count mismatch",
+			in:          "2\n6 0 0 0",
+			expectedErr: "BPF filter has 4 values, expected 8 for 2 instructions",
+		},
+		{
+			name:        "jt out of range",
+			in:          "1\n21 300 0 2048",
+			expectedErr: `invalid BPF instruction value "300"`,
+		},
+		{
+			name:        "jf out of range",
+			in:          "1\n21 0 256 2048",
+			expectedErr: `invalid BPF instruction value "256"`,
+		},
+		{
+			name:        "code out of range",
+			in:          "1\n65536 0 0 0",
+			expectedErr: `invalid BPF instruction value "65536"`,
+		},
+		{
+			name:        "k out of range",
+			in:          "1\n6 0 0 4294967296",
+			expectedErr: `invalid BPF instruction value "4294967296"`,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			prog, err := parseClassicBPF(tc.in)
+			if tc.expectedErr != "" {
+				assert.Check(t, is.ErrorContains(err, tc.expectedErr))
+				return
+			}
+			assert.NilError(t, err)
+			assert.Check(t, is.DeepEqual(prog, tc.expected))
+		})
+	}
+}
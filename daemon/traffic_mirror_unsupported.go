@@ -0,0 +1,16 @@
+//go:build !linux
+
+package daemon
+
+import (
+	"context"
+	"errors"
+
+	"github.com/moby/moby/v2/daemon/server/backend"
+	"github.com/moby/moby/v2/errdefs"
+)
+
+// ContainerMirrorTraffic is not supported on this platform.
+func (daemon *Daemon) ContainerMirrorTraffic(ctx context.Context, prefixOrName string, config *backend.ContainerMirrorConfig) error {
+	return errdefs.NotImplemented(errors.New("traffic mirroring is only supported on Linux"))
+}
@@ -24,6 +24,12 @@ func (daemon *Daemon) ContainerUpdate(name string, hostConfig *container.HostCon
 		return container.UpdateResponse{Warnings: warnings}, err
 	}
 
+	if !hostConfig.CgroupnsMode.IsEmpty() {
+		if ctr, err := daemon.GetContainer(name); err == nil && ctr.IsRunning() {
+			warnings = append(warnings, "Updated cgroup namespace mode takes effect the next time the container is started")
+		}
+	}
+
 	return container.UpdateResponse{Warnings: warnings}, nil
 }
 
@@ -37,6 +43,13 @@ func (daemon *Daemon) update(name string, hostConfig *container.HostConfig) erro
 		return err
 	}
 
+	// Ulimits are applied to the container's init process when it is
+	// started; the kernel provides no way to change the resource limits
+	// of a running process from the outside.
+	if len(hostConfig.Ulimits) > 0 && ctr.IsRunning() {
+		return errCannotUpdate(ctr.ID, errdefs.InvalidParameter(errors.New("ulimits can only be updated on a stopped container; the kernel does not support changing the resource limits of a running process")))
+	}
+
 	restoreConfig := false
 	backupHostConfig := *ctr.HostConfig
 
@@ -93,7 +106,24 @@ func (daemon *Daemon) update(name string, hostConfig *container.HostConfig) erro
 		return err
 	}
 
-	if err := tsk.UpdateResources(context.TODO(), toContainerdResources(hostConfig.Resources)); err != nil {
+	if hostConfig.NetworkRate != nil {
+		if err := daemon.applyNetworkRate(ctr); err != nil {
+			restoreConfig = true
+			return errCannotUpdate(ctr.ID, err)
+		}
+	}
+
+	resources, err := toContainerdResources(hostConfig.Resources)
+	if err != nil {
+		restoreConfig = true
+		return errCannotUpdate(ctr.ID, err)
+	}
+	if err := daemon.addUpdatedDeviceRules(ctr, hostConfig, resources); err != nil {
+		restoreConfig = true
+		return errCannotUpdate(ctr.ID, err)
+	}
+
+	if err := tsk.UpdateResources(context.TODO(), resources); err != nil {
 		restoreConfig = true
 		// TODO: it would be nice if containerd responded with better errors here so we can classify this better.
 		return errCannotUpdate(ctr.ID, errdefs.System(err))
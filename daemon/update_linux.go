@@ -1,20 +1,41 @@
 package daemon
 
 import (
+	"strconv"
 	"time"
 
-	"github.com/moby/moby/api/types/container"
+	cdcgroups "github.com/containerd/cgroups/v3"
+	containertypes "github.com/moby/moby/api/types/container"
+	"github.com/moby/moby/v2/daemon/container"
 	libcontainerdtypes "github.com/moby/moby/v2/daemon/internal/libcontainerd/types"
+	"github.com/moby/moby/v2/daemon/pkg/oci"
+	"github.com/moby/moby/v2/errdefs"
 	"github.com/opencontainers/runtime-spec/specs-go"
 )
 
-func toContainerdResources(resources container.Resources) *libcontainerdtypes.Resources {
+func toContainerdResources(resources containertypes.Resources) (*libcontainerdtypes.Resources, error) {
 	var r libcontainerdtypes.Resources
 
 	if resources.BlkioWeight != 0 {
 		r.BlockIO = &specs.LinuxBlockIO{
 			Weight: &resources.BlkioWeight,
 		}
+		if cdcgroups.Mode() == cdcgroups.Unified && !cgroupv2IOFileExists("io.weight") && cgroupv2IOFileExists("io.bfq.weight") {
+			if r.Unified == nil {
+				r.Unified = make(map[string]string)
+			}
+			r.Unified["io.bfq.weight"] = strconv.FormatUint(uint64(resources.BlkioWeight), 10)
+		}
+	}
+	if len(resources.IOLatencyTarget) > 0 {
+		ioLatency, err := getIOLatencyUnified(resources)
+		if err != nil {
+			return nil, err
+		}
+		if r.Unified == nil {
+			r.Unified = make(map[string]string)
+		}
+		r.Unified["io.latency"] = ioLatency
 	}
 
 	cpu := specs.LinuxCPU{
@@ -71,5 +92,43 @@ func toContainerdResources(resources container.Resources) *libcontainerdtypes.Re
 	}
 
 	r.Pids = getPidsLimit(resources)
-	return &r
+	return &r, nil
+}
+
+// addUpdatedDeviceRules recomputes the container's device cgroup permissions
+// when an update changes its device cgroup rules. The device cgroup is
+// programmed with a complete rule set, so the update has to carry the default
+// permissions and the rules for the container's devices as well as the
+// updated rules, or the devices the container already had access to would be
+// revoked.
+func (daemon *Daemon) addUpdatedDeviceRules(c *container.Container, hostConfig *containertypes.HostConfig, r *libcontainerdtypes.Resources) error {
+	if len(hostConfig.Resources.DeviceCgroupRules) == 0 {
+		return nil
+	}
+
+	c.Lock()
+	privileged := c.HostConfig.Privileged
+	devices := c.HostConfig.Devices
+	rules := c.HostConfig.DeviceCgroupRules
+	c.Unlock()
+
+	if privileged {
+		// Privileged containers already have access to all devices.
+		return nil
+	}
+
+	devPermissions := oci.DefaultLinuxSpec().Linux.Resources.Devices
+	for _, deviceMapping := range devices {
+		_, dPermissions, err := oci.DevicesFromPath(deviceMapping.PathOnHost, deviceMapping.PathInContainer, deviceMapping.CgroupPermissions)
+		if err != nil {
+			return err
+		}
+		devPermissions = append(devPermissions, dPermissions...)
+	}
+	devPermissions, err := oci.AppendDevicePermissionsFromCgroupRules(devPermissions, rules)
+	if err != nil {
+		return errdefs.InvalidParameter(err)
+	}
+	r.Devices = devPermissions
+	return nil
 }
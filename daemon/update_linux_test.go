@@ -4,8 +4,66 @@ import (
 	"testing"
 
 	"github.com/moby/moby/api/types/container"
+	containerpkg "github.com/moby/moby/v2/daemon/container"
+	libcontainerdtypes "github.com/moby/moby/v2/daemon/internal/libcontainerd/types"
+	"github.com/moby/moby/v2/daemon/pkg/oci"
+	"gotest.tools/v3/assert"
+	is "gotest.tools/v3/assert/cmp"
 )
 
 func TestToContainerdResources_Defaults(t *testing.T) {
-	checkResourcesAreUnset(t, toContainerdResources(container.Resources{}))
+	r, err := toContainerdResources(container.Resources{})
+	assert.NilError(t, err)
+	checkResourcesAreUnset(t, r)
+}
+
+func TestAddUpdatedDeviceRules(t *testing.T) {
+	d := &Daemon{}
+	newCtr := func(hostConfig *container.HostConfig) *containerpkg.Container {
+		return &containerpkg.Container{State: containerpkg.NewState(), HostConfig: hostConfig}
+	}
+
+	t.Run("no rules in update", func(t *testing.T) {
+		r := &libcontainerdtypes.Resources{}
+		err := d.addUpdatedDeviceRules(newCtr(&container.HostConfig{}), &container.HostConfig{}, r)
+		assert.NilError(t, err)
+		assert.Check(t, is.Nil(r.Devices))
+	})
+
+	t.Run("privileged container", func(t *testing.T) {
+		hostConfig := &container.HostConfig{
+			Resources: container.Resources{DeviceCgroupRules: []string{"c 42:1 rwm"}},
+		}
+		r := &libcontainerdtypes.Resources{}
+		err := d.addUpdatedDeviceRules(newCtr(&container.HostConfig{Privileged: true}), hostConfig, r)
+		assert.NilError(t, err)
+		assert.Check(t, is.Nil(r.Devices))
+	})
+
+	t.Run("updated rules are appended to defaults", func(t *testing.T) {
+		hostConfig := &container.HostConfig{
+			Resources: container.Resources{DeviceCgroupRules: []string{"c 42:1 rwm"}},
+		}
+		r := &libcontainerdtypes.Resources{}
+		err := d.addUpdatedDeviceRules(newCtr(hostConfig), hostConfig, r)
+		assert.NilError(t, err)
+
+		numDefaults := len(oci.DefaultLinuxSpec().Linux.Resources.Devices)
+		assert.Assert(t, is.Len(r.Devices, numDefaults+1))
+		rule := r.Devices[numDefaults]
+		assert.Check(t, rule.Allow)
+		assert.Check(t, is.Equal(rule.Type, "c"))
+		assert.Check(t, is.Equal(*rule.Major, int64(42)))
+		assert.Check(t, is.Equal(*rule.Minor, int64(1)))
+		assert.Check(t, is.Equal(rule.Access, "rwm"))
+	})
+
+	t.Run("invalid rule", func(t *testing.T) {
+		hostConfig := &container.HostConfig{
+			Resources: container.Resources{DeviceCgroupRules: []string{"not a rule"}},
+		}
+		r := &libcontainerdtypes.Resources{}
+		err := d.addUpdatedDeviceRules(newCtr(hostConfig), hostConfig, r)
+		assert.Check(t, is.ErrorContains(err, "invalid device cgroup rule format"))
+	})
 }
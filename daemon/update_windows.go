@@ -1,11 +1,17 @@
 package daemon
 
 import (
-	"github.com/moby/moby/api/types/container"
+	containertypes "github.com/moby/moby/api/types/container"
+	"github.com/moby/moby/v2/daemon/container"
 	libcontainerdtypes "github.com/moby/moby/v2/daemon/internal/libcontainerd/types"
 )
 
-func toContainerdResources(resources container.Resources) *libcontainerdtypes.Resources {
+func toContainerdResources(resources containertypes.Resources) (*libcontainerdtypes.Resources, error) {
 	// We don't support update, so do nothing
+	return nil, nil
+}
+
+// addUpdatedDeviceRules is a no-op on Windows.
+func (daemon *Daemon) addUpdatedDeviceRules(c *container.Container, hostConfig *containertypes.HostConfig, r *libcontainerdtypes.Resources) error {
 	return nil
 }
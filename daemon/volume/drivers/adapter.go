@@ -17,6 +17,9 @@ type volumeDriverAdapter struct {
 	scopePath    func(s string) string
 	capabilities *volume.Capability
 	proxy        volumeDriver
+	// breaker is the plugin's circuit breaker; nil for drivers that are
+	// not backed by an external plugin.
+	breaker *circuitBreaker
 }
 
 func (a *volumeDriverAdapter) Name() string {
@@ -32,6 +35,7 @@ func (a *volumeDriverAdapter) Create(name string, opts map[string]string) (volum
 		name:       name,
 		driverName: a.name,
 		scopePath:  a.scopePath,
+		breaker:    a.breaker,
 	}, nil
 }
 
@@ -53,6 +57,7 @@ func (a *volumeDriverAdapter) List() ([]volume.Volume, error) {
 			scopePath:  a.scopePath,
 			driverName: a.name,
 			eMount:     a.scopePath(vp.Mountpoint),
+			breaker:    a.breaker,
 		})
 	}
 	return out, nil
@@ -77,6 +82,7 @@ func (a *volumeDriverAdapter) Get(name string) (volume.Volume, error) {
 		createdAt:  v.CreatedAt,
 		status:     v.Status,
 		scopePath:  a.scopePath,
+		breaker:    a.breaker,
 	}, nil
 }
 
@@ -120,6 +126,7 @@ type volumeAdapter struct {
 	eMount     string    // ephemeral host volume path
 	createdAt  time.Time // time the directory was created
 	status     map[string]interface{}
+	breaker    *circuitBreaker
 }
 
 type proxyVolume struct {
@@ -172,5 +179,19 @@ func (a *volumeAdapter) Status() map[string]interface{} {
 	for k, v := range a.status {
 		out[k] = v
 	}
+	for k, v := range a.DriverHealth() {
+		out[k] = v
+	}
 	return out
 }
+
+// DriverHealth reports the health of the volume's driver as derived from
+// its circuit breaker, for surfacing through the volume's Status in the
+// API. It returns nil for drivers that are not backed by an external
+// plugin.
+func (a *volumeAdapter) DriverHealth() map[string]interface{} {
+	if a.breaker == nil {
+		return nil
+	}
+	return a.breaker.health()
+}
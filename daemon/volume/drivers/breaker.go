@@ -0,0 +1,130 @@
+package drivers
+
+import (
+	"sync"
+	"time"
+
+	"github.com/moby/moby/v2/errdefs"
+	"github.com/moby/moby/v2/pkg/plugins"
+	"github.com/pkg/errors"
+)
+
+const (
+	// breakerThreshold is the number of consecutive transport failures
+	// after which the driver's circuit opens and calls fail fast.
+	breakerThreshold = 3
+	// breakerResetTimeout is how long an open circuit rejects calls
+	// before a single probe call is let through.
+	breakerResetTimeout = 30 * time.Second
+	// readRetryAttempts is the number of additional attempts made for
+	// idempotent (read-only) driver calls that fail with a transport
+	// error.
+	readRetryAttempts = 2
+	// readRetryDelay is the delay between such attempts.
+	readRetryDelay = 100 * time.Millisecond
+)
+
+// retriableMethods are the read-only driver calls that are safe to retry on
+// a transport error. Mutating calls (Create, Remove, Mount, Unmount) are not
+// retried, as the plugin may have acted on the request before failing.
+var retriableMethods = map[string]struct{}{
+	"VolumeDriver.Get":          {},
+	"VolumeDriver.List":         {},
+	"VolumeDriver.Path":         {},
+	"VolumeDriver.Capabilities": {},
+}
+
+// circuitBreaker tracks consecutive transport failures of a volume plugin.
+// Once open, calls to the plugin fail fast with an [errdefs.Unavailable]
+// error instead of blocking on a plugin that is hung or unreachable.
+type circuitBreaker struct {
+	mu       sync.Mutex
+	failures int
+	openedAt time.Time
+	lastErr  error
+}
+
+// allow returns an [errdefs.Unavailable] error when the circuit is open.
+// Once breakerResetTimeout has elapsed, a single probe call is let through;
+// concurrent callers keep failing fast until the probe succeeds.
+func (b *circuitBreaker) allow(driverName string) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.failures < breakerThreshold {
+		return nil
+	}
+	if time.Since(b.openedAt) >= breakerResetTimeout {
+		b.openedAt = time.Now()
+		return nil
+	}
+	return errdefs.Unavailable(errors.Wrapf(b.lastErr, "volume driver %s is unavailable (circuit open after %d consecutive failures)", driverName, b.failures))
+}
+
+// record updates the breaker state with the outcome of a plugin call. Only
+// transport errors count as failures; an error response from the plugin
+// proves it is alive and resets the breaker.
+func (b *circuitBreaker) record(err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if err == nil || plugins.IsStatusError(err) {
+		b.failures = 0
+		b.lastErr = nil
+		return
+	}
+	b.failures++
+	b.lastErr = err
+	if b.failures >= breakerThreshold {
+		b.openedAt = time.Now()
+	}
+}
+
+// health describes the breaker state in the form surfaced through the
+// volume's Status in the API.
+func (b *circuitBreaker) health() map[string]interface{} {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.failures < breakerThreshold {
+		return map[string]interface{}{"driver-health": "healthy"}
+	}
+	h := map[string]interface{}{
+		"driver-health":   "unavailable",
+		"driver-failures": b.failures,
+	}
+	if b.lastErr != nil {
+		h["driver-error"] = b.lastErr.Error()
+	}
+	return h
+}
+
+// checkedClient wraps a plugin client with the driver's circuit breaker and
+// a retry policy for idempotent calls. It operates below the generated
+// proxy, so errors returned by the plugin in a response body are never
+// mistaken for plugin unavailability.
+type checkedClient struct {
+	client
+	driverName string
+	breaker    *circuitBreaker
+}
+
+func (c *checkedClient) CallWithOptions(serviceMethod string, args interface{}, ret interface{}, opts ...func(*plugins.RequestOpts)) error {
+	attempts := 1
+	if _, ok := retriableMethods[serviceMethod]; ok {
+		attempts += readRetryAttempts
+	}
+
+	var err error
+	for i := 0; i < attempts; i++ {
+		if i > 0 {
+			time.Sleep(readRetryDelay)
+		}
+		if err = c.breaker.allow(c.driverName); err != nil {
+			return err
+		}
+		err = c.client.CallWithOptions(serviceMethod, args, ret, opts...)
+		c.breaker.record(err)
+		if err == nil || plugins.IsStatusError(err) {
+			return err
+		}
+	}
+	return err
+}
@@ -0,0 +1,80 @@
+package drivers
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	cerrdefs "github.com/containerd/errdefs"
+	"github.com/docker/go-connections/tlsconfig"
+	"github.com/moby/moby/v2/pkg/plugins"
+	"gotest.tools/v3/assert"
+	is "gotest.tools/v3/assert/cmp"
+)
+
+// fakeClient fails every call with the configured error and counts calls.
+type fakeClient struct {
+	calls int
+	err   error
+}
+
+func (c *fakeClient) CallWithOptions(serviceMethod string, args interface{}, ret interface{}, opts ...func(*plugins.RequestOpts)) error {
+	c.calls++
+	return c.err
+}
+
+func TestCircuitBreakerOpens(t *testing.T) {
+	fc := &fakeClient{err: errors.New("dial unix /run/docker/plugins/test.sock: connect: no such file or directory")}
+	cc := &checkedClient{client: fc, driverName: "test", breaker: &circuitBreaker{}}
+
+	// Mutating calls are not retried; each failure counts once.
+	for i := 0; i < breakerThreshold; i++ {
+		err := cc.CallWithOptions("VolumeDriver.Create", nil, nil)
+		assert.Check(t, err != nil)
+		assert.Check(t, !cerrdefs.IsUnavailable(err), "breaker must not trip before the threshold")
+	}
+	assert.Check(t, is.Equal(fc.calls, breakerThreshold))
+
+	// The circuit is now open: calls fail fast without reaching the plugin.
+	err := cc.CallWithOptions("VolumeDriver.Create", nil, nil)
+	assert.Check(t, cerrdefs.IsUnavailable(err), err)
+	assert.Check(t, is.Equal(fc.calls, breakerThreshold))
+
+	health := cc.breaker.health()
+	assert.Check(t, is.Equal(health["driver-health"], "unavailable"))
+}
+
+func TestCircuitBreakerRetriesReads(t *testing.T) {
+	fc := &fakeClient{err: errors.New("connection refused")}
+	cc := &checkedClient{client: fc, driverName: "test", breaker: &circuitBreaker{}}
+
+	// Read-only calls are retried on transport errors.
+	err := cc.CallWithOptions("VolumeDriver.Get", nil, nil)
+	assert.Check(t, err != nil)
+	assert.Check(t, is.Equal(fc.calls, 1+readRetryAttempts))
+}
+
+func TestCircuitBreakerIgnoresPluginErrors(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/VolumeDriver.Get", func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "something went wrong", http.StatusInternalServerError)
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	u, _ := url.Parse(server.URL)
+	client, err := plugins.NewClient("tcp://"+u.Host, &tlsconfig.Options{InsecureSkipVerify: true})
+	assert.NilError(t, err)
+
+	b := &circuitBreaker{}
+	cc := &checkedClient{client: client, driverName: "test", breaker: b}
+
+	// The plugin responds with an error status: the call fails, but the
+	// plugin is alive, so the breaker does not count it as a failure.
+	err = cc.CallWithOptions("VolumeDriver.Get", struct{}{}, &struct{}{})
+	assert.Check(t, err != nil)
+	assert.Check(t, is.Equal(b.failures, 0))
+	assert.Check(t, is.Equal(b.health()["driver-health"], "healthy"))
+}
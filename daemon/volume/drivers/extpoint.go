@@ -222,8 +222,10 @@ func (s *Store) GetAllDrivers() ([]volume.Driver, error) {
 }
 
 func makePluginAdapter(p plugingetter.CompatPlugin) (*volumeDriverAdapter, error) {
+	breaker := &circuitBreaker{}
 	if pc, ok := p.(plugingetter.PluginWithV1Client); ok {
-		return &volumeDriverAdapter{name: p.Name(), scopePath: p.ScopedPath, proxy: &volumeDriverProxy{pc.Client()}}, nil
+		proxy := &volumeDriverProxy{&checkedClient{client: pc.Client(), driverName: p.Name(), breaker: breaker}}
+		return &volumeDriverAdapter{name: p.Name(), scopePath: p.ScopedPath, proxy: proxy, breaker: breaker}, nil
 	}
 
 	pa, ok := p.(plugingetter.PluginAddr)
@@ -241,5 +243,6 @@ func makePluginAdapter(p plugingetter.CompatPlugin) (*volumeDriverAdapter, error
 		return nil, errors.Wrap(err, "error creating plugin client")
 	}
 
-	return &volumeDriverAdapter{name: p.Name(), scopePath: p.ScopedPath, proxy: &volumeDriverProxy{client}}, nil
+	proxy := &volumeDriverProxy{&checkedClient{client: client, driverName: p.Name(), breaker: breaker}}
+	return &volumeDriverAdapter{name: p.Name(), scopePath: p.ScopedPath, proxy: proxy, breaker: breaker}, nil
 }
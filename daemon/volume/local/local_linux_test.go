@@ -326,3 +326,81 @@ func TestVolMountOpts(t *testing.T) {
 		})
 	}
 }
+
+func TestVolumeSnapshots(t *testing.T) {
+	r, err := New(t.TempDir(), idtools.Identity{UID: os.Geteuid(), GID: os.Getegid()})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	vol, err := r.Create("testing", nil)
+	assert.NilError(t, err)
+	v := vol.(*localVolume)
+
+	snapshots, err := v.Snapshots()
+	assert.NilError(t, err)
+	assert.Check(t, is.Len(snapshots, 0))
+
+	_, err = v.CreateSnapshot("no spaces allowed")
+	assert.Check(t, is.ErrorContains(err, "includes invalid characters for a snapshot name"))
+	assert.Check(t, cerrdefs.IsInvalidArgument(err))
+
+	// An empty volume contains no regular files, so the snapshot succeeds
+	// regardless of whether the backing filesystem supports file clones.
+	info, err := v.CreateSnapshot("snap1")
+	assert.NilError(t, err)
+	assert.Check(t, is.Equal(info.Name, "snap1"))
+
+	_, err = v.CreateSnapshot("snap1")
+	assert.Check(t, is.ErrorContains(err, "already exists"))
+	assert.Check(t, cerrdefs.IsConflict(err))
+
+	snapshots, err = v.Snapshots()
+	assert.NilError(t, err)
+	assert.Assert(t, is.Len(snapshots, 1))
+	assert.Check(t, is.Equal(snapshots[0].Name, "snap1"))
+
+	p, err := v.SnapshotPath("snap1")
+	assert.NilError(t, err)
+	assert.Check(t, is.Equal(p, filepath.Join(v.rootPath, snapshotsPathName, "snap1")))
+
+	_, err = v.SnapshotPath("missing")
+	assert.Check(t, cerrdefs.IsNotFound(err))
+}
+
+func TestVolumeSnapshotRestore(t *testing.T) {
+	r, err := New(t.TempDir(), idtools.Identity{UID: os.Geteuid(), GID: os.Getegid()})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	vol, err := r.Create("testing", nil)
+	assert.NilError(t, err)
+	v := vol.(*localVolume)
+
+	assert.NilError(t, os.WriteFile(filepath.Join(v.path, "keep"), []byte("original"), 0o644))
+	_, err = v.CreateSnapshot("snap1")
+	assert.NilError(t, err)
+
+	// Change the volume's contents after taking the snapshot.
+	assert.NilError(t, os.WriteFile(filepath.Join(v.path, "keep"), []byte("modified"), 0o644))
+	assert.NilError(t, os.WriteFile(filepath.Join(v.path, "extra"), []byte("extra"), 0o644))
+
+	err = v.RestoreSnapshot("missing")
+	assert.Check(t, cerrdefs.IsNotFound(err))
+
+	assert.NilError(t, v.RestoreSnapshot("snap1"))
+
+	data, err := os.ReadFile(filepath.Join(v.path, "keep"))
+	assert.NilError(t, err)
+	assert.Check(t, is.Equal(string(data), "original"))
+
+	_, err = os.Stat(filepath.Join(v.path, "extra"))
+	assert.Check(t, is.ErrorIs(err, os.ErrNotExist))
+
+	// The snapshot must still exist after a restore.
+	snapshots, err := v.Snapshots()
+	assert.NilError(t, err)
+	assert.Assert(t, is.Len(snapshots, 1))
+	assert.Check(t, is.Equal(snapshots[0].Name, "snap1"))
+}
@@ -0,0 +1,209 @@
+package local
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"syscall"
+
+	"github.com/moby/moby/v2/daemon/names"
+	"github.com/moby/moby/v2/daemon/volume"
+	"github.com/moby/moby/v2/errdefs"
+	"github.com/moby/sys/user"
+	"github.com/pkg/errors"
+	"golang.org/x/sys/unix"
+)
+
+// snapshotsPathName is the name of the directory inside the volume's root
+// path where snapshots are stored, next to the volume data directory.
+const snapshotsPathName = "snapshots"
+
+var _ volume.Snapshotter = (*localVolume)(nil)
+
+// CreateSnapshot takes a snapshot of the volume's current data. The snapshot
+// is made with copy-on-write file clones (FICLONE), so on filesystems that
+// support file clones (such as btrfs, XFS, or OpenZFS) it shares its extents
+// with the volume's data and does not duplicate the data on disk. On other
+// filesystems the data is copied in full.
+func (v *localVolume) CreateSnapshot(name string) (volume.SnapshotInfo, error) {
+	if err := validateSnapshotName(name); err != nil {
+		return volume.SnapshotInfo{}, err
+	}
+
+	snapDir := filepath.Join(v.rootPath, snapshotsPathName)
+	if err := user.MkdirAllAndChown(snapDir, 0o701, os.Getuid(), os.Getegid()); err != nil {
+		return volume.SnapshotInfo{}, errors.Wrapf(errdefs.System(err), "error while creating snapshots path '%s'", snapDir)
+	}
+
+	target := filepath.Join(snapDir, name)
+	if _, err := os.Lstat(target); err == nil {
+		return volume.SnapshotInfo{}, errdefs.Conflict(errors.Errorf("snapshot %s already exists for volume %s", name, v.name))
+	}
+
+	if err := cloneTree(v.path, target); err != nil {
+		if rmErr := os.RemoveAll(target); rmErr != nil {
+			err = errors.Wrapf(err, "additionally, removing the partial snapshot failed: %v", rmErr)
+		}
+		return volume.SnapshotInfo{}, errors.Wrapf(err, "error while snapshotting volume %s", v.name)
+	}
+
+	fi, err := os.Lstat(target)
+	if err != nil {
+		return volume.SnapshotInfo{}, errdefs.System(err)
+	}
+	return volume.SnapshotInfo{Name: name, CreatedAt: fi.ModTime()}, nil
+}
+
+// Snapshots lists the volume's snapshots.
+func (v *localVolume) Snapshots() ([]volume.SnapshotInfo, error) {
+	dirs, err := os.ReadDir(filepath.Join(v.rootPath, snapshotsPathName))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, errdefs.System(err)
+	}
+
+	var snapshots []volume.SnapshotInfo
+	for _, d := range dirs {
+		if !d.IsDir() {
+			continue
+		}
+		fi, err := d.Info()
+		if err != nil {
+			return nil, errdefs.System(err)
+		}
+		snapshots = append(snapshots, volume.SnapshotInfo{Name: d.Name(), CreatedAt: fi.ModTime()})
+	}
+	return snapshots, nil
+}
+
+// SnapshotPath returns the absolute path to the named snapshot's data.
+func (v *localVolume) SnapshotPath(name string) (string, error) {
+	if err := validateSnapshotName(name); err != nil {
+		return "", err
+	}
+	p := filepath.Join(v.rootPath, snapshotsPathName, name)
+	if _, err := os.Lstat(p); err != nil {
+		if os.IsNotExist(err) {
+			return "", errdefs.NotFound(errors.Errorf("no such snapshot %s for volume %s", name, v.name))
+		}
+		return "", errdefs.System(err)
+	}
+	return p, nil
+}
+
+// RestoreSnapshot replaces the volume's data with the contents of the named
+// snapshot. The restore happens in place, within the volume's data directory,
+// so containers that have the volume mounted observe the restored data
+// without being restarted. The snapshot itself is left in place.
+func (v *localVolume) RestoreSnapshot(name string) error {
+	snapPath, err := v.SnapshotPath(name)
+	if err != nil {
+		return err
+	}
+
+	entries, err := os.ReadDir(v.path)
+	if err != nil {
+		return errdefs.System(err)
+	}
+	for _, entry := range entries {
+		if err := os.RemoveAll(filepath.Join(v.path, entry.Name())); err != nil {
+			return errors.Wrapf(errdefs.System(err), "error while clearing volume %s for restore", v.name)
+		}
+	}
+	if err := cloneTree(snapPath, v.path); err != nil {
+		return errors.Wrapf(err, "error while restoring volume %s from snapshot %s", v.name, name)
+	}
+	return nil
+}
+
+// validateSnapshotName checks that the name can safely be used as a directory
+// name under the volume's snapshots path.
+func validateSnapshotName(name string) error {
+	if len(name) == 1 {
+		return errdefs.InvalidParameter(errors.New("snapshot name is too short, names should be at least two alphanumeric characters"))
+	}
+	if !volumeNameRegex.MatchString(name) {
+		return errdefs.InvalidParameter(errors.Errorf("%q includes invalid characters for a snapshot name, only %q are allowed", name, names.RestrictedNameChars))
+	}
+	return nil
+}
+
+// cloneTree replicates the tree rooted at srcDir into dstDir, cloning regular
+// files with the FICLONE ioctl so the copies share extents with the
+// originals. It fails if the backing filesystem does not support file clones.
+func cloneTree(srcDir, dstDir string) error {
+	return filepath.Walk(srcDir, func(p string, fi os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(srcDir, p)
+		if err != nil {
+			return err
+		}
+		target := filepath.Join(dstDir, rel)
+
+		switch {
+		case fi.IsDir():
+			if err := os.Mkdir(target, fi.Mode().Perm()); err != nil && !os.IsExist(err) {
+				return err
+			}
+		case fi.Mode()&os.ModeSymlink != 0:
+			link, err := os.Readlink(p)
+			if err != nil {
+				return err
+			}
+			if err := os.Symlink(link, target); err != nil {
+				return err
+			}
+		case fi.Mode().IsRegular():
+			if err := cloneFile(p, target, fi.Mode().Perm()); err != nil {
+				return err
+			}
+		default:
+			// Sockets, devices and FIFOs are transient and not part of
+			// the snapshot.
+			return nil
+		}
+
+		if st, ok := fi.Sys().(*syscall.Stat_t); ok {
+			if err := os.Lchown(target, int(st.Uid), int(st.Gid)); err != nil {
+				return err
+			}
+		}
+		if fi.Mode()&os.ModeSymlink == 0 {
+			return os.Chtimes(target, fi.ModTime(), fi.ModTime())
+		}
+		return nil
+	})
+}
+
+// cloneFile makes a copy-on-write clone of the regular file at srcPath,
+// falling back to a full copy when the backing filesystem does not support
+// file clones.
+func cloneFile(srcPath, dstPath string, mode os.FileMode) error {
+	in, err := os.Open(srcPath)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	out, err := os.OpenFile(dstPath, os.O_WRONLY|os.O_CREATE|os.O_EXCL, mode)
+	if err != nil {
+		return err
+	}
+	if err := unix.IoctlFileClone(int(out.Fd()), int(in.Fd())); err != nil {
+		if !errors.Is(err, unix.EOPNOTSUPP) && !errors.Is(err, unix.EINVAL) && !errors.Is(err, unix.EXDEV) {
+			out.Close()
+			_ = os.Remove(dstPath)
+			return err
+		}
+		if _, err := io.Copy(out, in); err != nil {
+			out.Close()
+			_ = os.Remove(dstPath)
+			return err
+		}
+	}
+	return out.Close()
+}
@@ -63,6 +63,14 @@ func (p *linuxParser) validateMountConfigImpl(mnt *mount.Mount, validateBindSour
 		if mnt.Source == "" {
 			return &errMountConfig{mnt, errMissingField("Source")}
 		}
+		// Windows clients may hand a Linux daemon a Windows-style host
+		// path (Docker Desktop-style setups); translate it here so that
+		// validation and inspect see the normalized Linux path.
+		translated, err := translateWindowsSource(mnt.Source)
+		if err != nil {
+			return &errMountConfig{mnt, err}
+		}
+		mnt.Source = translated
 		// Don't error out just because the propagation mode is not supported on the platform
 		if opts := mnt.BindOptions; opts != nil {
 			if len(opts.Propagation) > 0 && len(linuxPropagationModes) > 0 {
@@ -155,6 +163,15 @@ var linuxLabelModes = map[string]bool{
 	"z": true,
 }
 
+// recursive read-only modes for read-only bind mounts. "ro-recursive" makes
+// the read-only mode apply to submounts of the bind (using mount_setattr,
+// which requires kernel and runtime support), "ro-non-recursive" restricts it
+// to the bind mount itself.
+var linuxRecursiveReadOnlyModes = map[string]bool{
+	"ro-recursive":     true,
+	"ro-non-recursive": true,
+}
+
 // consistency modes
 var linuxConsistencyModes = map[mount.Consistency]bool{
 	mount.ConsistencyFull:      true,
@@ -202,6 +219,7 @@ func linuxValidMountMode(mode string) bool {
 	propagationModeCount := 0
 	copyModeCount := 0
 	consistencyModeCount := 0
+	recursiveReadOnlyModeCount := 0
 
 	for _, o := range strings.Split(mode, ",") {
 		switch {
@@ -215,13 +233,15 @@ func linuxValidMountMode(mode string) bool {
 			copyModeCount++
 		case linuxConsistencyModes[mount.Consistency(o)]:
 			consistencyModeCount++
+		case linuxRecursiveReadOnlyModes[o]:
+			recursiveReadOnlyModeCount++
 		default:
 			return false
 		}
 	}
 
 	// Only one string for each mode is allowed.
-	if rwModeCount > 1 || labelModeCount > 1 || propagationModeCount > 1 || copyModeCount > 1 || consistencyModeCount > 1 {
+	if rwModeCount > 1 || labelModeCount > 1 || propagationModeCount > 1 || copyModeCount > 1 || consistencyModeCount > 1 || recursiveReadOnlyModeCount > 1 {
 		return false
 	}
 	return true
@@ -269,6 +289,11 @@ func (p *linuxParser) ParseMountRaw(raw, volumeDriver string) (*MountPoint, erro
 	if arr[0] == "" {
 		return nil, errInvalidSpec(raw)
 	}
+	if len(arr) > 2 && len(arr[0]) == 1 && isDriveLetter(arr[0][0]) && (strings.HasPrefix(arr[1], `\`) || strings.HasPrefix(arr[1], "/")) {
+		// A Windows-style source path embeds a ":" in its drive prefix
+		// (e.g. "C:\data:/data:ro"); re-join it into a single source.
+		arr = append([]string{arr[0] + ":" + arr[1]}, arr[2:]...)
+	}
 
 	var spec mount.Mount
 	var mode string
@@ -298,7 +323,7 @@ func (p *linuxParser) ParseMountRaw(raw, volumeDriver string) (*MountPoint, erro
 		return nil, errInvalidMode(mode)
 	}
 
-	if path.IsAbs(spec.Source) {
+	if path.IsAbs(spec.Source) || isWindowsStylePath(spec.Source) {
 		spec.Type = mount.TypeBind
 	} else {
 		spec.Type = mount.TypeVolume
@@ -324,6 +349,22 @@ func (p *linuxParser) ParseMountRaw(raw, volumeDriver string) (*MountPoint, erro
 			Propagation: linuxGetPropagation(mode),
 		}
 	}
+	for _, o := range strings.Split(mode, ",") {
+		if !linuxRecursiveReadOnlyModes[o] {
+			continue
+		}
+		if !spec.ReadOnly {
+			return nil, fmt.Errorf("%v: %s can only be used with ro", errInvalidSpec(raw), o)
+		}
+		if spec.BindOptions == nil {
+			spec.BindOptions = &mount.BindOptions{}
+		}
+		if o == "ro-recursive" {
+			spec.BindOptions.ReadOnlyForceRecursive = true
+		} else {
+			spec.BindOptions.ReadOnlyNonRecursive = true
+		}
+	}
 
 	mp, err := p.parseMountSpec(spec, false)
 	if mp != nil {
@@ -40,6 +40,9 @@ func TestLinuxParseMountRaw(t *testing.T) {
 		"/hostPath:/containerPath:rslave,ro,Z",
 		"/hostPath:/containerPath:ro,rshared,Z",
 		"/hostPath:/containerPath:ro,Z,rprivate",
+		"/hostPath:/containerPath:ro,ro-recursive",
+		"/hostPath:/containerPath:ro,ro-non-recursive",
+		"/hostPath:/containerPath:ro,ro-recursive,Z,rslave",
 	}
 
 	invalid := map[string]string{
@@ -66,15 +69,18 @@ func TestLinuxParseMountRaw(t *testing.T) {
 		"/path:/path:rwz":                 `invalid mode`,
 		"/path:/path:ro,rshared,rslave":   `invalid mode`,
 		"/path:/path:ro,z,rshared,rslave": `invalid mode`,
-		"/path:shared":                    "invalid volume specification",
-		"/path:slave":                     "invalid volume specification",
-		"/path:private":                   "invalid volume specification",
-		"name:/absolute-path:shared":      "invalid volume specification",
-		"name:/absolute-path:rshared":     "invalid volume specification",
-		"name:/absolute-path:slave":       "invalid volume specification",
-		"name:/absolute-path:rslave":      "invalid volume specification",
-		"name:/absolute-path:private":     "invalid volume specification",
-		"name:/absolute-path:rprivate":    "invalid volume specification",
+		"/path:/path:ro,ro-recursive,ro-non-recursive": `invalid mode`,
+		"/path:/path:ro-recursive":                     `ro-recursive can only be used with ro`,
+		"/path:/path:rw,ro-non-recursive":              `ro-non-recursive can only be used with ro`,
+		"/path:shared":                                 "invalid volume specification",
+		"/path:slave":                                  "invalid volume specification",
+		"/path:private":                                "invalid volume specification",
+		"name:/absolute-path:shared":                   "invalid volume specification",
+		"name:/absolute-path:rshared":                  "invalid volume specification",
+		"name:/absolute-path:slave":                    "invalid volume specification",
+		"name:/absolute-path:rslave":                   "invalid volume specification",
+		"name:/absolute-path:private":                  "invalid volume specification",
+		"name:/absolute-path:rprivate":                 "invalid volume specification",
 	}
 
 	parser := NewLinuxParser()
@@ -156,6 +162,46 @@ func TestLinuxParseMountRawSplit(t *testing.T) {
 				},
 			},
 		},
+		{
+			bind: "/tmp:/tmp4:ro,ro-recursive",
+			expected: &MountPoint{
+				Source:      "/tmp",
+				Destination: "/tmp4",
+				RW:          false,
+				Type:        mount.TypeBind,
+				Mode:        "ro,ro-recursive",
+				Propagation: "rprivate",
+				Spec: mount.Mount{
+					Source:   "/tmp",
+					Target:   "/tmp4",
+					ReadOnly: true,
+					Type:     mount.TypeBind,
+					BindOptions: &mount.BindOptions{
+						ReadOnlyForceRecursive: true,
+					},
+				},
+			},
+		},
+		{
+			bind: "/tmp:/tmp5:ro,ro-non-recursive",
+			expected: &MountPoint{
+				Source:      "/tmp",
+				Destination: "/tmp5",
+				RW:          false,
+				Type:        mount.TypeBind,
+				Mode:        "ro,ro-non-recursive",
+				Propagation: "rprivate",
+				Spec: mount.Mount{
+					Source:   "/tmp",
+					Target:   "/tmp5",
+					ReadOnly: true,
+					Type:     mount.TypeBind,
+					BindOptions: &mount.BindOptions{
+						ReadOnlyNonRecursive: true,
+					},
+				},
+			},
+		},
 		{
 			bind:   "/tmp:/tmp4:foo",
 			expErr: `invalid mode: foo`,
@@ -297,6 +343,56 @@ func TestLinuxParseMountSpecBindWithFileinfoError(t *testing.T) {
 	assert.ErrorContains(t, err, testErr.Error())
 }
 
+func TestLinuxValidateImageMountConfig(t *testing.T) {
+	parser := NewLinuxParser()
+
+	tests := []struct {
+		name        string
+		input       mount.Mount
+		expectedErr string
+	}{
+		{
+			name:  "valid",
+			input: mount.Mount{Type: mount.TypeImage, Source: "alpine:latest", Target: "/app"},
+		},
+		{
+			name:  "valid with subpath",
+			input: mount.Mount{Type: mount.TypeImage, Source: "alpine:latest", Target: "/app", ImageOptions: &mount.ImageOptions{Subpath: "etc"}},
+		},
+		{
+			name:        "missing source",
+			input:       mount.Mount{Type: mount.TypeImage, Target: "/app"},
+			expectedErr: "field Source must not be empty",
+		},
+		{
+			name:        "bind options not allowed",
+			input:       mount.Mount{Type: mount.TypeImage, Source: "alpine:latest", Target: "/app", BindOptions: &mount.BindOptions{}},
+			expectedErr: "field BindOptions must not be specified",
+		},
+		{
+			name:        "volume options not allowed",
+			input:       mount.Mount{Type: mount.TypeImage, Source: "alpine:latest", Target: "/app", VolumeOptions: &mount.VolumeOptions{}},
+			expectedErr: "field VolumeOptions must not be specified",
+		},
+		{
+			name:        "subpath must be local",
+			input:       mount.Mount{Type: mount.TypeImage, Source: "alpine:latest", Target: "/app", ImageOptions: &mount.ImageOptions{Subpath: "../escape"}},
+			expectedErr: "subpath must be a relative path within the volume",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := parser.ValidateMountConfig(&tc.input)
+			if tc.expectedErr == "" {
+				assert.NilError(t, err)
+			} else {
+				assert.ErrorContains(t, err, tc.expectedErr)
+			}
+		})
+	}
+}
+
 func TestConvertTmpfsOptions(t *testing.T) {
 	type testCase struct {
 		opt                  mount.TmpfsOptions
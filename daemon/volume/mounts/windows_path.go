@@ -0,0 +1,61 @@
+package mounts
+
+import (
+	"path"
+	"strings"
+)
+
+// ErrUnsupportedWindowsPath is returned when a Windows-style host path
+// supplied by a Windows client cannot be translated to a path on a Linux
+// daemon (e.g. a UNC share other than \\wsl$).
+type ErrUnsupportedWindowsPath struct {
+	Path   string
+	Reason string
+}
+
+func (e *ErrUnsupportedWindowsPath) Error() string {
+	return "unsupported Windows-style host path " + e.Path + ": " + e.Reason
+}
+
+// isWindowsStylePath reports whether source looks like a Windows-style host
+// path: a drive-letter path such as "C:\data", or a UNC share such as
+// "\\wsl$\distro\data".
+func isWindowsStylePath(source string) bool {
+	return hasDrivePrefix(source) || strings.HasPrefix(source, `\\`)
+}
+
+// hasDrivePrefix reports whether p starts with a Windows drive prefix
+// ("C:\" or "C:/").
+func hasDrivePrefix(p string) bool {
+	return len(p) >= 3 && isDriveLetter(p[0]) && p[1] == ':' && (p[2] == '\\' || p[2] == '/')
+}
+
+func isDriveLetter(c byte) bool {
+	return ('a' <= c && c <= 'z') || ('A' <= c && c <= 'Z')
+}
+
+// translateWindowsSource converts a Windows-style host path, as supplied by
+// a Windows client talking to a Linux daemon (Docker Desktop-style setups),
+// into the Linux path the host filesystem is exposed at: drive-letter paths
+// map below /mnt/<drive> and \\wsl$ (or \\wsl.localhost) shares below
+// /mnt/wsl/<distro>. Other UNC paths have no Linux equivalent and produce
+// an ErrUnsupportedWindowsPath. Paths that do not look like Windows paths
+// are returned unchanged.
+func translateWindowsSource(source string) (string, error) {
+	if hasDrivePrefix(source) {
+		drive := strings.ToLower(source[:1])
+		rest := strings.ReplaceAll(source[2:], `\`, "/")
+		return path.Join("/mnt", drive, path.Clean(rest)), nil
+	}
+	if strings.HasPrefix(source, `\\`) {
+		host, rest, _ := strings.Cut(strings.ReplaceAll(source[2:], `\`, "/"), "/")
+		if !strings.EqualFold(host, "wsl$") && !strings.EqualFold(host, "wsl.localhost") {
+			return "", &ErrUnsupportedWindowsPath{Path: source, Reason: `only \\wsl$ and \\wsl.localhost shares can be mapped to a Linux daemon path`}
+		}
+		if rest == "" {
+			return "", &ErrUnsupportedWindowsPath{Path: source, Reason: "missing WSL distribution name"}
+		}
+		return path.Join("/mnt/wsl", path.Clean("/"+rest)), nil
+	}
+	return source, nil
+}
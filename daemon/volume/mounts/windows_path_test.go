@@ -0,0 +1,133 @@
+package mounts
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/moby/moby/api/types/mount"
+	"gotest.tools/v3/assert"
+	is "gotest.tools/v3/assert/cmp"
+)
+
+func TestTranslateWindowsSource(t *testing.T) {
+	tests := []struct {
+		doc         string
+		source      string
+		expected    string
+		expectedErr string
+	}{
+		{
+			doc:      "drive-letter path",
+			source:   `C:\Users\someuser\data`,
+			expected: "/mnt/c/Users/someuser/data",
+		},
+		{
+			doc:      "drive-letter path with forward slashes",
+			source:   "D:/projects/app",
+			expected: "/mnt/d/projects/app",
+		},
+		{
+			doc:      "wsl$ share",
+			source:   `\\wsl$\Ubuntu\home\someuser`,
+			expected: "/mnt/wsl/Ubuntu/home/someuser",
+		},
+		{
+			doc:      "wsl.localhost share",
+			source:   `\\wsl.localhost\Debian\srv`,
+			expected: "/mnt/wsl/Debian/srv",
+		},
+		{
+			doc:      "linux path unchanged",
+			source:   "/var/lib/data",
+			expected: "/var/lib/data",
+		},
+		{
+			doc:      "volume name unchanged",
+			source:   "myvolume",
+			expected: "myvolume",
+		},
+		{
+			doc:         "unsupported UNC share",
+			source:      `\\fileserver\share\data`,
+			expectedErr: `unsupported Windows-style host path \\fileserver\share\data`,
+		},
+		{
+			doc:         "wsl share without distribution",
+			source:      `\\wsl$`,
+			expectedErr: "missing WSL distribution name",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.doc, func(t *testing.T) {
+			translated, err := translateWindowsSource(tc.source)
+			if tc.expectedErr != "" {
+				assert.ErrorContains(t, err, tc.expectedErr)
+				var upErr *ErrUnsupportedWindowsPath
+				assert.Check(t, errors.As(err, &upErr))
+				assert.Check(t, is.Equal(upErr.Path, tc.source))
+				return
+			}
+			assert.NilError(t, err)
+			assert.Check(t, is.Equal(translated, tc.expected))
+		})
+	}
+}
+
+// translatedFiProvider reports the translated Linux locations of the Windows
+// paths used in TestLinuxParserWindowsSource as existing directories.
+type translatedFiProvider struct{}
+
+func (translatedFiProvider) fileInfo(path string) (exists, isDir bool, _ error) {
+	dirs := map[string]struct{}{
+		"/mnt/c/Users/someuser": {},
+		"/mnt/wsl/Ubuntu/home":  {},
+		"/mnt/c/data":           {},
+	}
+	_, ok := dirs[path]
+	return ok, ok, nil
+}
+
+func TestLinuxParserWindowsSource(t *testing.T) {
+	parser := NewLinuxParser()
+	if p, ok := parser.(*linuxParser); ok {
+		p.fi = translatedFiProvider{}
+	}
+
+	t.Run("raw spec with drive-letter source", func(t *testing.T) {
+		mp, err := parser.ParseMountRaw(`C:\Users\someuser:/data:ro`, "")
+		assert.NilError(t, err)
+		assert.Check(t, is.Equal(mp.Type, mount.TypeBind))
+		assert.Check(t, is.Equal(mp.Source, "/mnt/c/Users/someuser"))
+		assert.Check(t, is.Equal(mp.Destination, "/data"))
+		assert.Check(t, is.Equal(mp.RW, false))
+	})
+
+	t.Run("raw spec with wsl share source", func(t *testing.T) {
+		mp, err := parser.ParseMountRaw(`\\wsl$\Ubuntu\home:/data`, "")
+		assert.NilError(t, err)
+		assert.Check(t, is.Equal(mp.Type, mount.TypeBind))
+		assert.Check(t, is.Equal(mp.Source, "/mnt/wsl/Ubuntu/home"))
+	})
+
+	t.Run("mount spec with drive-letter source", func(t *testing.T) {
+		mnt := mount.Mount{
+			Type:   mount.TypeBind,
+			Source: `c:\data`,
+			Target: "/data",
+		}
+		assert.NilError(t, parser.ValidateMountConfig(&mnt))
+		// The mount is normalized in place so that inspect shows the
+		// translated path.
+		assert.Check(t, is.Equal(mnt.Source, "/mnt/c/data"))
+	})
+
+	t.Run("mount spec with unsupported UNC source", func(t *testing.T) {
+		mnt := mount.Mount{
+			Type:   mount.TypeBind,
+			Source: `\\fileserver\share`,
+			Target: "/data",
+		}
+		err := parser.ValidateMountConfig(&mnt)
+		assert.ErrorContains(t, err, "unsupported Windows-style host path")
+	})
+}
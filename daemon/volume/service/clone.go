@@ -0,0 +1,234 @@
+package service
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/containerd/log"
+	"github.com/moby/moby/api/types/events"
+	volumetypes "github.com/moby/moby/api/types/volume"
+	"github.com/moby/moby/v2/daemon/internal/stringid"
+	"github.com/moby/moby/v2/daemon/volume"
+	"github.com/moby/moby/v2/daemon/volume/service/opts"
+	"github.com/moby/moby/v2/errdefs"
+	"github.com/pkg/errors"
+)
+
+const (
+	// cloneCopyAttempts is the number of times the data copy is attempted
+	// before a clone is given up on and the target volume removed.
+	cloneCopyAttempts = 3
+	// cloneRetryDelay is the delay between copy attempts.
+	cloneRetryDelay = time.Second
+)
+
+// Clone creates a new volume, optionally on a different driver, and copies
+// the source volume's data into it. The copy is retried on failure; retries
+// skip files that were already transferred unchanged, so only the delta is
+// copied again. If the data cannot be copied the target volume is removed.
+func (s *VolumesService) Clone(ctx context.Context, name string, cloneOpts volumetypes.CloneOptions) (*volumetypes.Volume, error) {
+	src, err := s.vs.Get(ctx, name)
+	if err != nil {
+		if IsNotExist(err) {
+			err = errdefs.NotFound(err)
+		}
+		return nil, err
+	}
+
+	// When cloning from a snapshot, the snapshot's data is copied instead
+	// of the volume's current data.
+	var srcDataPath string
+	if cloneOpts.Snapshot != "" {
+		sv, ok := src.(volume.Snapshotter)
+		if !ok {
+			return nil, errSnapshotsNotSupported(name)
+		}
+		srcDataPath, err = sv.SnapshotPath(cloneOpts.Snapshot)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	driverName := cloneOpts.Driver
+	if driverName == "" {
+		driverName = src.DriverName()
+	}
+	labels := cloneOpts.Labels
+	if labels == nil {
+		if dv, ok := src.(volume.DetailedVolume); ok {
+			labels = dv.Labels()
+		}
+	}
+
+	var createOpts []opts.CreateOption
+	if len(labels) > 0 {
+		createOpts = append(createOpts, opts.WithCreateLabels(labels))
+	}
+	if len(cloneOpts.DriverOpts) > 0 {
+		createOpts = append(createOpts, opts.WithCreateOptions(cloneOpts.DriverOpts))
+	}
+
+	clone, err := s.Create(ctx, cloneOpts.Name, driverName, createOpts...)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.cloneVolumeData(ctx, src, srcDataPath, clone); err != nil {
+		if rmErr := s.Remove(ctx, clone.Name); rmErr != nil {
+			log.G(ctx).WithError(rmErr).WithField("volume", clone.Name).Warn("Failed to remove volume after failed clone")
+		}
+		s.eventLogger.LogVolumeEvent(clone.Name, events.ActionClone, map[string]string{"source": name, "progress": "failed"})
+		return nil, errors.Wrapf(err, "failed to clone volume %s", name)
+	}
+
+	s.eventLogger.LogVolumeEvent(clone.Name, events.ActionClone, map[string]string{"source": name, "progress": "complete"})
+	return clone, nil
+}
+
+// cloneVolumeData mounts both volumes and copies the source's data into the
+// clone, retrying with a delta copy on transient failures. If srcPath is
+// non-empty it is used as the data to copy (e.g. a snapshot's path) and the
+// source volume is not mounted.
+func (s *VolumesService) cloneVolumeData(ctx context.Context, src volume.Volume, srcPath string, clone *volumetypes.Volume) error {
+	dst, err := s.vs.Get(ctx, clone.Name, opts.WithGetDriver(clone.Driver))
+	if err != nil {
+		return err
+	}
+
+	ref := "clone-" + stringid.GenerateRandomID()
+	if srcPath == "" {
+		srcPath, err = src.Mount(ref)
+		if err != nil {
+			return errors.Wrap(err, "error mounting source volume")
+		}
+		defer func() {
+			if err := src.Unmount(ref); err != nil {
+				log.G(ctx).WithError(err).WithField("volume", src.Name()).Warn("Failed to unmount source volume after clone")
+			}
+		}()
+	}
+
+	dstPath, err := dst.Mount(ref)
+	if err != nil {
+		return errors.Wrap(err, "error mounting target volume")
+	}
+	defer func() {
+		if err := dst.Unmount(ref); err != nil {
+			log.G(ctx).WithError(err).WithField("volume", dst.Name()).Warn("Failed to unmount target volume after clone")
+		}
+	}()
+
+	s.eventLogger.LogVolumeEvent(clone.Name, events.ActionClone, map[string]string{"source": src.Name(), "progress": "copying"})
+
+	for attempt := 0; ; attempt++ {
+		err = copyPathDelta(ctx, srcPath, dstPath)
+		if err == nil {
+			return nil
+		}
+		if attempt+1 >= cloneCopyAttempts || ctx.Err() != nil {
+			return err
+		}
+		log.G(ctx).WithError(err).WithFields(log.Fields{"volume": src.Name(), "clone": clone.Name}).Warn("Retrying volume clone copy")
+		s.eventLogger.LogVolumeEvent(clone.Name, events.ActionClone, map[string]string{
+			"source":   src.Name(),
+			"progress": "retrying",
+			"attempt":  strconv.Itoa(attempt + 2),
+		})
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(cloneRetryDelay):
+		}
+	}
+}
+
+// copyPathDelta copies the tree rooted at srcDir into dstDir. Regular files
+// already present in dstDir with a matching size and modification time are
+// skipped, so a retried copy only transfers what earlier attempts missed.
+func copyPathDelta(ctx context.Context, srcDir, dstDir string) error {
+	return filepath.Walk(srcDir, func(p string, fi os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+		}
+
+		rel, err := filepath.Rel(srcDir, p)
+		if err != nil {
+			return err
+		}
+		if rel == "." {
+			return nil
+		}
+		target := filepath.Join(dstDir, rel)
+
+		switch {
+		case fi.IsDir():
+			if err := os.Mkdir(target, fi.Mode().Perm()); err != nil && !os.IsExist(err) {
+				return err
+			}
+			return copyOwnership(target, fi)
+		case fi.Mode()&os.ModeSymlink != 0:
+			link, err := os.Readlink(p)
+			if err != nil {
+				return err
+			}
+			if existing, err := os.Readlink(target); err == nil && existing == link {
+				return nil
+			}
+			_ = os.Remove(target)
+			if err := os.Symlink(link, target); err != nil {
+				return err
+			}
+			return copyOwnership(target, fi)
+		case fi.Mode().IsRegular():
+			if dfi, err := os.Lstat(target); err == nil && dfi.Mode().IsRegular() && dfi.Size() == fi.Size() && dfi.ModTime().Equal(fi.ModTime()) {
+				return nil
+			}
+			if err := copyFileContents(p, target, fi); err != nil {
+				return err
+			}
+			if err := copyOwnership(target, fi); err != nil {
+				return err
+			}
+			// Preserve the modification time so a retried copy can
+			// recognize the file as already transferred.
+			return os.Chtimes(target, fi.ModTime(), fi.ModTime())
+		default:
+			// Sockets, devices and FIFOs are not copied; they are
+			// transient and cannot be created on all filesystems.
+			return nil
+		}
+	})
+}
+
+func copyFileContents(srcPath, dstPath string, fi os.FileInfo) error {
+	in, err := os.Open(srcPath)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	out, err := os.OpenFile(dstPath, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, fi.Mode().Perm())
+	if err != nil {
+		return err
+	}
+	// Prefer a copy-on-write clone over copying the contents; on backing
+	// filesystems that support it (btrfs, XFS, OpenZFS) the clone shares
+	// its extents with the source and completes without moving data.
+	if tryCloneFile(out, in) {
+		return out.Close()
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		return err
+	}
+	return out.Close()
+}
@@ -0,0 +1,14 @@
+package service
+
+import (
+	"os"
+
+	"golang.org/x/sys/unix"
+)
+
+// tryCloneFile attempts a copy-on-write clone of src into dst using the
+// FICLONE ioctl, and reports whether it succeeded. Failure is not an error;
+// the caller falls back to copying the file's contents.
+func tryCloneFile(dst, src *os.File) bool {
+	return unix.IoctlFileClone(int(dst.Fd()), int(src.Fd())) == nil
+}
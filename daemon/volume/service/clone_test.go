@@ -0,0 +1,52 @@
+package service
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"gotest.tools/v3/assert"
+	is "gotest.tools/v3/assert/cmp"
+)
+
+func TestCopyPathDelta(t *testing.T) {
+	t.Parallel()
+
+	src := t.TempDir()
+	dst := t.TempDir()
+
+	assert.NilError(t, os.MkdirAll(filepath.Join(src, "sub"), 0o755))
+	assert.NilError(t, os.WriteFile(filepath.Join(src, "a"), []byte("aaa"), 0o644))
+	assert.NilError(t, os.WriteFile(filepath.Join(src, "sub", "b"), []byte("bbb"), 0o600))
+
+	ctx := context.Background()
+	assert.NilError(t, copyPathDelta(ctx, src, dst))
+
+	data, err := os.ReadFile(filepath.Join(dst, "a"))
+	assert.NilError(t, err)
+	assert.Check(t, is.Equal(string(data), "aaa"))
+	data, err = os.ReadFile(filepath.Join(dst, "sub", "b"))
+	assert.NilError(t, err)
+	assert.Check(t, is.Equal(string(data), "bbb"))
+
+	// An unchanged file must be skipped on a second pass: its copy keeps
+	// the modification time applied here.
+	marker := time.Now().Add(-time.Hour).Truncate(time.Second)
+	assert.NilError(t, os.Chtimes(filepath.Join(dst, "a"), marker, marker))
+	assert.NilError(t, os.Chtimes(filepath.Join(src, "a"), marker, marker))
+
+	// A changed file must be copied again.
+	assert.NilError(t, os.WriteFile(filepath.Join(src, "sub", "b"), []byte("BBBB"), 0o600))
+
+	assert.NilError(t, copyPathDelta(ctx, src, dst))
+
+	fi, err := os.Stat(filepath.Join(dst, "a"))
+	assert.NilError(t, err)
+	assert.Check(t, fi.ModTime().Equal(marker), "unchanged file was copied again")
+
+	data, err = os.ReadFile(filepath.Join(dst, "sub", "b"))
+	assert.NilError(t, err)
+	assert.Check(t, is.Equal(string(data), "BBBB"))
+}
@@ -0,0 +1,17 @@
+//go:build !windows
+
+package service
+
+import (
+	"os"
+	"syscall"
+)
+
+// copyOwnership applies the source file's uid/gid to the copied file.
+func copyOwnership(dstPath string, fi os.FileInfo) error {
+	st, ok := fi.Sys().(*syscall.Stat_t)
+	if !ok {
+		return nil
+	}
+	return os.Lchown(dstPath, int(st.Uid), int(st.Gid))
+}
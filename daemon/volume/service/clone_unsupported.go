@@ -0,0 +1,11 @@
+//go:build !linux
+
+package service
+
+import "os"
+
+// tryCloneFile always reports false on platforms without copy-on-write file
+// clones; the caller falls back to copying the file's contents.
+func tryCloneFile(dst, src *os.File) bool {
+	return false
+}
@@ -0,0 +1,9 @@
+package service
+
+import "os"
+
+// copyOwnership is a no-op on Windows, which has no uid/gid ownership to
+// carry over.
+func copyOwnership(dstPath string, fi os.FileInfo) error {
+	return nil
+}
@@ -31,6 +31,12 @@ type pathCacher interface {
 	CachedPath() string
 }
 
+// driverHealthProvider is implemented by plugin-backed volumes that can
+// report the health of their driver.
+type driverHealthProvider interface {
+	DriverHealth() map[string]interface{}
+}
+
 func (s *VolumesService) volumesToAPI(ctx context.Context, volumes []volume.Volume, opts ...convertOpt) []*volumetypes.Volume {
 	var (
 		out        = make([]*volumetypes.Volume, 0, len(volumes))
@@ -75,6 +81,17 @@ func (s *VolumesService) volumesToAPI(ctx context.Context, volumes []volume.Volu
 			apiV.UsageData = &volumetypes.UsageData{Size: sz, RefCount: int64(s.vs.CountReferences(v))}
 		}
 
+		if u, ok := s.volumeUsageOf(v.Name()); ok {
+			if apiV.UsageData == nil {
+				// Size and reference count were not requested; mark them
+				// as not available so the attachment metadata can be
+				// surfaced on its own.
+				apiV.UsageData = &volumetypes.UsageData{Size: -1, RefCount: -1}
+			}
+			apiV.UsageData.AttachCount = u.attachCount
+			apiV.UsageData.LastAttachedAt = u.lastAttached.Format(time.RFC3339Nano)
+		}
+
 		out = append(out, &apiV)
 	}
 	return out
@@ -95,6 +112,14 @@ func volumeToAPIType(v volume.Volume) volumetypes.Volume {
 	if cp, ok := v.(pathCacher); ok {
 		tv.Mountpoint = cp.CachedPath()
 	}
+	if hp, ok := v.(driverHealthProvider); ok {
+		// Surface the driver's health (as tracked by its circuit breaker)
+		// through the volume's status, so "docker volume ls" shows volumes
+		// whose plugin is unreachable.
+		if h := hp.DriverHealth(); len(h) > 0 {
+			tv.Status = h
+		}
+	}
 	return tv
 }
 
@@ -52,6 +52,7 @@ type GetConfig struct {
 	Driver        string
 	Reference     string
 	ResolveStatus bool
+	ResolveSize   bool
 }
 
 // GetOption is passed to the service `Get` add extra details on the get request
@@ -81,6 +82,13 @@ func WithGetResolveStatus(cfg *GetConfig) {
 	cfg.ResolveStatus = true
 }
 
+// WithGetResolveSize indicates to `Get` to also determine the disk space used
+// by the volume. This can cause significant overhead in the volume lookup as
+// it may require walking the volume's filesystem.
+func WithGetResolveSize(cfg *GetConfig) {
+	cfg.ResolveSize = true
+}
+
 // RemoveConfig is used by `RemoveOption` to store config options for remove
 type RemoveConfig struct {
 	PurgeOnError bool
@@ -97,3 +105,21 @@ func WithPurgeOnError(b bool) RemoveOption {
 		o.PurgeOnError = b
 	}
 }
+
+// ListConfig is used with `ListOption` to set options for the volumes
+// service's `List` implementation.
+type ListConfig struct {
+	Size bool
+}
+
+// ListOption is passed to the service `List` to add extra details on the
+// list request.
+type ListOption func(*ListConfig)
+
+// WithListSize indicates to `List` to also compute each volume's size on
+// disk. This can cause significant overhead for volumes holding many files.
+func WithListSize(b bool) ListOption {
+	return func(o *ListConfig) {
+		o.Size = b
+	}
+}
@@ -3,7 +3,9 @@ package service
 import (
 	"context"
 	"strconv"
+	"sync"
 	"sync/atomic"
+	"time"
 
 	"github.com/containerd/log"
 	"github.com/moby/moby/api/types/events"
@@ -30,6 +32,25 @@ type VolumeEventLogger interface {
 	LogVolumeEvent(volumeID string, action events.Action, attributes map[string]string)
 }
 
+// ImageSeeder populates a directory with the contents of a path inside an
+// image's filesystem. It is registered by the daemon once the image service
+// is available, and is used to seed volumes created with the "from-image"
+// option.
+type ImageSeeder interface {
+	SeedVolume(ctx context.Context, imageRef, imagePath, volumePath string) error
+}
+
+const (
+	// seedFromImageOpt is the volume creation option naming the image a
+	// volume is seeded from.
+	seedFromImageOpt = "from-image"
+	// seedPathOpt is the volume creation option selecting the directory
+	// inside the seed image to populate the volume from. Defaults to the
+	// image's root when unset. It is only interpreted together with
+	// seedFromImageOpt.
+	seedPathOpt = "path"
+)
+
 // VolumesService manages access to volumes
 // This is used as the main access point for volumes to higher level services and the API.
 type VolumesService struct {
@@ -37,6 +58,36 @@ type VolumesService struct {
 	ds           driverLister
 	pruneRunning atomic.Bool
 	eventLogger  VolumeEventLogger
+	imageSeeder  ImageSeeder
+	createCheck  CreateCheck
+
+	usageMu sync.Mutex
+	// usage tracks per-volume attachment metadata, keyed by volume name.
+	// It is maintained in memory only, so counts and timestamps reset
+	// when the daemon restarts.
+	usage map[string]*volumeUsage
+
+	sizeCacheMu sync.Mutex
+	// sizeCache holds recently computed volume sizes, keyed by volume name,
+	// so that repeated size lookups don't walk the volume's filesystem each
+	// time.
+	sizeCache map[string]volumeSizeCacheEntry
+}
+
+// volumeSizeCacheEntry is a cached volume size and the time it was computed.
+type volumeSizeCacheEntry struct {
+	size       int64
+	computedAt time.Time
+}
+
+// volumeSizeCacheTTL is how long a cached volume size remains valid.
+const volumeSizeCacheTTL = 1 * time.Minute
+
+// volumeUsage is the attachment metadata tracked for a volume. An entry is
+// kept after the last detach so the last-attached time remains available.
+type volumeUsage struct {
+	attachCount  int64
+	lastAttached time.Time
 }
 
 // NewVolumeService creates a new volume service
@@ -50,7 +101,7 @@ func NewVolumeService(root string, pg plugingetter.PluginGetter, rootIDs idtools
 	if err != nil {
 		return nil, err
 	}
-	return &VolumesService{vs: vs, ds: ds, eventLogger: logger}, nil
+	return &VolumesService{vs: vs, ds: ds, eventLogger: logger, usage: make(map[string]*volumeUsage), sizeCache: make(map[string]volumeSizeCacheEntry)}, nil
 }
 
 // GetDriverList gets the list of registered volume drivers
@@ -58,6 +109,21 @@ func (s *VolumesService) GetDriverList() []string {
 	return s.ds.GetDriverList()
 }
 
+// RegisterImageSeeder attaches the seeder used to populate volumes created
+// with the "from-image" option.
+func (s *VolumesService) RegisterImageSeeder(seeder ImageSeeder) {
+	s.imageSeeder = seeder
+}
+
+// CreateCheck is consulted before a volume is created. It can reject the
+// creation, and returns extra labels to apply to the volume.
+type CreateCheck func(ctx context.Context) (extraLabels map[string]string, _ error)
+
+// RegisterCreateCheck attaches a check that every volume creation must pass.
+func (s *VolumesService) RegisterCreateCheck(check CreateCheck) {
+	s.createCheck = check
+}
+
 // AnonymousLabel is the label used to indicate that a volume is anonymous
 // This is set automatically on a volume when a volume is created without a name specified, and as such an id is generated for it.
 const AnonymousLabel = "com.docker.volume.anonymous"
@@ -80,15 +146,71 @@ func (s *VolumesService) Create(ctx context.Context, name, driverName string, op
 	} else {
 		log.G(ctx).WithField("volume-name", name).Debug("Creating named volume")
 	}
+	// Extract the seed options before the driver sees them; they are
+	// interpreted by the daemon, not by the volume driver.
+	var cfg opts.CreateConfig
+	for _, o := range options {
+		o(&cfg)
+	}
+	seedImage := cfg.Options[seedFromImageOpt]
+	seedPath := "/"
+	if seedImage != "" {
+		if s.imageSeeder == nil {
+			return nil, errdefs.InvalidParameter(errors.Errorf("volume option %q is not supported by this daemon", seedFromImageOpt))
+		}
+		if p := cfg.Options[seedPathOpt]; p != "" {
+			seedPath = p
+		}
+		// cfg.Options aliases the map captured by WithCreateOptions, so
+		// deleting here strips the options from what the driver receives.
+		delete(cfg.Options, seedFromImageOpt)
+		delete(cfg.Options, seedPathOpt)
+	}
+
+	if s.createCheck != nil {
+		extraLabels, err := s.createCheck(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for k, v := range extraLabels {
+			options = append(options, opts.WithCreateLabel(k, v))
+		}
+	}
+
 	v, err := s.vs.Create(ctx, name, driverName, options...)
 	if err != nil {
 		return nil, err
 	}
 
+	if seedImage != "" {
+		if err := s.seedVolume(ctx, v, seedImage, seedPath); err != nil {
+			if rmErr := s.vs.Remove(ctx, v); rmErr != nil {
+				log.G(ctx).WithError(rmErr).WithField("volume", v.Name()).Warn("Failed to remove volume after failed seeding")
+			}
+			return nil, errors.Wrapf(err, "failed to seed volume from image %s", seedImage)
+		}
+	}
+
 	apiV := volumeToAPIType(v)
 	return &apiV, nil
 }
 
+// seedVolume mounts the freshly created volume and populates it with the
+// contents of imagePath inside imageRef's filesystem.
+func (s *VolumesService) seedVolume(ctx context.Context, v volume.Volume, imageRef, imagePath string) error {
+	ref := "seed-" + stringid.GenerateRandomID()
+	mountPath, err := v.Mount(ref)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := v.Unmount(ref); err != nil {
+			log.G(ctx).WithError(err).WithField("volume", v.Name()).Warn("Failed to unmount volume after seeding")
+		}
+	}()
+	return s.imageSeeder.SeedVolume(ctx, imageRef, imagePath, mountPath)
+}
+
 // Get returns details about a volume
 func (s *VolumesService) Get(ctx context.Context, name string, getOpts ...opts.GetOption) (*volumetypes.Volume, error) {
 	v, err := s.vs.Get(ctx, name, getOpts...)
@@ -105,9 +227,54 @@ func (s *VolumesService) Get(ctx context.Context, name string, getOpts ...opts.G
 	if cfg.ResolveStatus {
 		vol.Status = v.Status()
 	}
+	if cfg.ResolveSize {
+		vol.UsageData = &volumetypes.UsageData{
+			Size:     s.volumeSize(ctx, v),
+			RefCount: int64(s.vs.CountReferences(v)),
+		}
+	}
 	return &vol, nil
 }
 
+// sizeProvider is implemented by volumes whose driver can report the disk
+// space used by the volume directly, avoiding a filesystem walk.
+type sizeProvider interface {
+	Size(ctx context.Context) (int64, error)
+}
+
+// volumeSize returns the disk space used by the volume, preferring the
+// driver's own accounting when available and falling back to walking the
+// volume's filesystem. Computed sizes are cached for a short period as the
+// walk can be expensive for large volumes. It returns -1 if the size could
+// not be determined.
+func (s *VolumesService) volumeSize(ctx context.Context, v volume.Volume) int64 {
+	s.sizeCacheMu.Lock()
+	if e, ok := s.sizeCache[v.Name()]; ok && time.Since(e.computedAt) < volumeSizeCacheTTL {
+		s.sizeCacheMu.Unlock()
+		return e.size
+	}
+	s.sizeCacheMu.Unlock()
+
+	var (
+		sz  int64
+		err error
+	)
+	if sp, ok := v.(sizeProvider); ok {
+		sz, err = sp.Size(ctx)
+	} else {
+		sz, err = directory.Size(ctx, v.Path())
+	}
+	if err != nil {
+		log.G(ctx).WithError(err).WithField("volume", v.Name()).Warn("Failed to determine size of volume")
+		return -1
+	}
+
+	s.sizeCacheMu.Lock()
+	s.sizeCache[v.Name()] = volumeSizeCacheEntry{size: sz, computedAt: time.Now()}
+	s.sizeCacheMu.Unlock()
+	return sz
+}
+
 // Mount mounts the volume
 // Callers should specify a unique reference for each Mount/Unmount pair.
 //
@@ -125,7 +292,12 @@ func (s *VolumesService) Mount(ctx context.Context, vol *volumetypes.Volume, ref
 		}
 		return "", err
 	}
-	return v.Mount(ref)
+	mountPath, err := v.Mount(ref)
+	if err != nil {
+		return "", err
+	}
+	s.volumeAttached(v.Name())
+	return mountPath, nil
 }
 
 // Unmount unmounts the volume.
@@ -142,7 +314,49 @@ func (s *VolumesService) Unmount(ctx context.Context, vol *volumetypes.Volume, r
 		}
 		return err
 	}
-	return v.Unmount(ref)
+	if err := v.Unmount(ref); err != nil {
+		return err
+	}
+	s.volumeDetached(v.Name())
+	return nil
+}
+
+// volumeAttached records a successful mount of the named volume.
+func (s *VolumesService) volumeAttached(name string) {
+	s.usageMu.Lock()
+	defer s.usageMu.Unlock()
+	if s.usage == nil {
+		s.usage = make(map[string]*volumeUsage)
+	}
+	u, ok := s.usage[name]
+	if !ok {
+		u = &volumeUsage{}
+		s.usage[name] = u
+	}
+	u.attachCount++
+	u.lastAttached = time.Now()
+}
+
+// volumeDetached records a successful unmount of the named volume.
+func (s *VolumesService) volumeDetached(name string) {
+	s.usageMu.Lock()
+	defer s.usageMu.Unlock()
+	if u, ok := s.usage[name]; ok && u.attachCount > 0 {
+		u.attachCount--
+	}
+}
+
+// volumeUsageOf returns a copy of the attachment metadata tracked for the
+// named volume, or false if the volume has not been attached since the
+// daemon started.
+func (s *VolumesService) volumeUsageOf(name string) (volumeUsage, bool) {
+	s.usageMu.Lock()
+	defer s.usageMu.Unlock()
+	u, ok := s.usage[name]
+	if !ok {
+		return volumeUsage{}, false
+	}
+	return *u, true
 }
 
 // Release releases a volume reference
@@ -174,6 +388,11 @@ func (s *VolumesService) Remove(ctx context.Context, name string, rmOpts ...opts
 	} else if IsNotExist(err) && cfg.PurgeOnError {
 		err = nil
 	}
+	if err == nil {
+		s.usageMu.Lock()
+		delete(s.usage, v.Name())
+		s.usageMu.Unlock()
+	}
 	return err
 }
 
@@ -262,18 +481,23 @@ func (s *VolumesService) Prune(ctx context.Context, filter filters.Args) (*volum
 
 // List gets the list of volumes which match the past in filters
 // If filters is nil or empty all volumes are returned.
-func (s *VolumesService) List(ctx context.Context, filter filters.Args) (volumes []*volumetypes.Volume, warnings []string, _ error) {
+func (s *VolumesService) List(ctx context.Context, filter filters.Args, listOpts ...opts.ListOption) (volumes []*volumetypes.Volume, warnings []string, _ error) {
 	by, err := filtersToBy(filter, acceptedListFilters)
 	if err != nil {
 		return nil, nil, err
 	}
 
+	var cfg opts.ListConfig
+	for _, o := range listOpts {
+		o(&cfg)
+	}
+
 	vols, warns, err := s.vs.Find(ctx, by)
 	if err != nil {
 		return nil, nil, err
 	}
 
-	return s.volumesToAPI(ctx, vols, useCachedPath(true)), warns, nil
+	return s.volumesToAPI(ctx, vols, useCachedPath(true), calcSize(cfg.Size)), warns, nil
 }
 
 // Shutdown shuts down the image service and dependencies
@@ -6,6 +6,8 @@ import (
 	"path/filepath"
 	"testing"
 
+	cerrdefs "github.com/containerd/errdefs"
+	volumetypes "github.com/moby/moby/api/types/volume"
 	"github.com/moby/moby/v2/daemon/internal/idtools"
 	"github.com/moby/moby/v2/daemon/volume"
 	volumedrivers "github.com/moby/moby/v2/daemon/volume/drivers"
@@ -61,3 +63,51 @@ func TestLocalVolumeSize(t *testing.T) {
 		}
 	}
 }
+
+func TestServiceClone(t *testing.T) {
+	t.Parallel()
+
+	tmpDir := t.TempDir()
+	l, err := local.New(tmpDir, idtools.Identity{UID: os.Getuid(), GID: os.Getegid()})
+	assert.NilError(t, err)
+
+	ds := volumedrivers.NewStore(nil)
+	assert.Assert(t, ds.Register(l, volume.DefaultDriverName))
+
+	service, cleanup := newTestService(t, ds)
+	defer cleanup()
+
+	ctx := context.Background()
+	src, err := service.Create(ctx, "src", volume.DefaultDriverName, opts.WithCreateLabel("foo", "bar"))
+	assert.NilError(t, err)
+
+	assert.NilError(t, os.MkdirAll(filepath.Join(src.Mountpoint, "sub"), 0o755))
+	assert.NilError(t, os.WriteFile(filepath.Join(src.Mountpoint, "data"), []byte("hello"), 0o644))
+	assert.NilError(t, os.WriteFile(filepath.Join(src.Mountpoint, "sub", "nested"), []byte("world"), 0o600))
+	assert.NilError(t, os.Symlink("data", filepath.Join(src.Mountpoint, "link")))
+
+	clone, err := service.Clone(ctx, "src", volumetypes.CloneOptions{Name: "dst"})
+	assert.NilError(t, err)
+	assert.Check(t, is.Equal(clone.Name, "dst"))
+	assert.Check(t, is.Equal(clone.Driver, volume.DefaultDriverName))
+	// Labels are carried over from the source when none are given.
+	assert.Check(t, is.Equal(clone.Labels["foo"], "bar"))
+
+	cloneV, err := service.Get(ctx, "dst")
+	assert.NilError(t, err)
+	data, err := os.ReadFile(filepath.Join(cloneV.Mountpoint, "data"))
+	assert.NilError(t, err)
+	assert.Check(t, is.Equal(string(data), "hello"))
+	data, err = os.ReadFile(filepath.Join(cloneV.Mountpoint, "sub", "nested"))
+	assert.NilError(t, err)
+	assert.Check(t, is.Equal(string(data), "world"))
+	link, err := os.Readlink(filepath.Join(cloneV.Mountpoint, "link"))
+	assert.NilError(t, err)
+	assert.Check(t, is.Equal(link, "data"))
+
+	// Cloning a missing volume must not leave a target volume behind.
+	_, err = service.Clone(ctx, "missing", volumetypes.CloneOptions{Name: "never"})
+	assert.Assert(t, cerrdefs.IsNotFound(err), err)
+	_, err = service.Get(ctx, "never")
+	assert.Assert(t, cerrdefs.IsNotFound(err), err)
+}
@@ -2,6 +2,7 @@ package service
 
 import (
 	"context"
+	"errors"
 	"testing"
 
 	cerrdefs "github.com/containerd/errdefs"
@@ -247,3 +248,122 @@ func newTestService(t *testing.T, ds *volumedrivers.Store) (*VolumesService, fun
 type dummyEventLogger struct{}
 
 func (dummyEventLogger) LogVolumeEvent(_ string, _ events.Action, _ map[string]string) {}
+
+type fakeSeeder struct {
+	image, path, volumePath string
+	err                     error
+}
+
+func (s *fakeSeeder) SeedVolume(_ context.Context, imageRef, imagePath, volumePath string) error {
+	s.image, s.path, s.volumePath = imageRef, imagePath, volumePath
+	return s.err
+}
+
+func TestServiceCreateSeedFromImage(t *testing.T) {
+	t.Parallel()
+
+	ds := volumedrivers.NewStore(nil)
+	assert.Assert(t, ds.Register(testutils.NewFakeDriver("d1"), "d1"))
+
+	ctx := context.Background()
+	service, cleanup := newTestService(t, ds)
+	defer cleanup()
+
+	// Seeding is rejected when no seeder is registered.
+	_, err := service.Create(ctx, "v1", "d1", opts.WithCreateOptions(map[string]string{"from-image": "busybox:latest"}))
+	assert.Check(t, cerrdefs.IsInvalidArgument(err), err)
+
+	seeder := &fakeSeeder{}
+	service.RegisterImageSeeder(seeder)
+
+	driverOpts := map[string]string{"from-image": "busybox:latest", "path": "/seed"}
+	_, err = service.Create(ctx, "v2", "d1", opts.WithCreateOptions(driverOpts))
+	assert.NilError(t, err)
+	assert.Check(t, is.Equal(seeder.image, "busybox:latest"))
+	assert.Check(t, is.Equal(seeder.path, "/seed"))
+	assert.Check(t, is.Equal(seeder.volumePath, "fake"))
+	// The seed options must not reach the volume driver.
+	assert.Check(t, is.Len(driverOpts, 0))
+
+	// A failed seeding removes the volume again.
+	seeder.err = errors.New("no such path")
+	_, err = service.Create(ctx, "v3", "d1", opts.WithCreateOptions(map[string]string{"from-image": "busybox:latest"}))
+	assert.Check(t, is.ErrorContains(err, "no such path"))
+	_, err = service.Get(ctx, "v3")
+	assert.Check(t, cerrdefs.IsNotFound(err), err)
+}
+
+func TestServiceAttachUsage(t *testing.T) {
+	t.Parallel()
+
+	ds := volumedrivers.NewStore(nil)
+	assert.Assert(t, ds.Register(testutils.NewFakeDriver("d1"), "d1"))
+
+	ctx := context.Background()
+	service, cleanup := newTestService(t, ds)
+	defer cleanup()
+
+	v, err := service.Create(ctx, "v1", "d1")
+	assert.NilError(t, err)
+
+	// Attachment metadata is only reported once the volume has been
+	// attached.
+	ls, _, err := service.List(ctx, filters.NewArgs())
+	assert.NilError(t, err)
+	assert.Assert(t, is.Len(ls, 1))
+	assert.Check(t, is.Nil(ls[0].UsageData))
+
+	_, err = service.Mount(ctx, v, "ref1")
+	assert.NilError(t, err)
+	_, err = service.Mount(ctx, v, "ref2")
+	assert.NilError(t, err)
+
+	ls, _, err = service.List(ctx, filters.NewArgs())
+	assert.NilError(t, err)
+	assert.Assert(t, is.Len(ls, 1))
+	assert.Assert(t, ls[0].UsageData != nil)
+	assert.Check(t, is.Equal(ls[0].UsageData.AttachCount, int64(2)))
+	assert.Check(t, ls[0].UsageData.LastAttachedAt != "")
+	// Size and reference count are not computed unless requested.
+	assert.Check(t, is.Equal(ls[0].UsageData.Size, int64(-1)))
+	assert.Check(t, is.Equal(ls[0].UsageData.RefCount, int64(-1)))
+
+	assert.NilError(t, service.Unmount(ctx, v, "ref1"))
+	assert.NilError(t, service.Unmount(ctx, v, "ref2"))
+
+	// The last-attached time survives the volume being fully detached.
+	ls, _, err = service.List(ctx, filters.NewArgs())
+	assert.NilError(t, err)
+	assert.Assert(t, is.Len(ls, 1))
+	assert.Assert(t, ls[0].UsageData != nil)
+	assert.Check(t, is.Equal(ls[0].UsageData.AttachCount, int64(0)))
+	assert.Check(t, ls[0].UsageData.LastAttachedAt != "")
+
+	assert.NilError(t, service.Remove(ctx, v.Name))
+	service.usageMu.Lock()
+	assert.Check(t, is.Len(service.usage, 0))
+	service.usageMu.Unlock()
+}
+
+func TestServiceListSize(t *testing.T) {
+	t.Parallel()
+
+	ds := volumedrivers.NewStore(nil)
+	assert.Assert(t, ds.Register(testutils.NewFakeDriver("d1"), "d1"))
+
+	ctx := context.Background()
+	service, cleanup := newTestService(t, ds)
+	defer cleanup()
+
+	_, err := service.Create(ctx, "v1", "d1")
+	assert.NilError(t, err)
+
+	ls, _, err := service.List(ctx, filters.NewArgs(), opts.WithListSize(true))
+	assert.NilError(t, err)
+	assert.Assert(t, is.Len(ls, 1))
+	assert.Assert(t, ls[0].UsageData != nil)
+	// The fake volume's path does not exist, so the size is reported as
+	// not available; the reference count is still computed.
+	assert.Check(t, is.Equal(ls[0].UsageData.Size, int64(-1)))
+	assert.Check(t, is.Equal(ls[0].UsageData.RefCount, int64(0)))
+}
@@ -0,0 +1,100 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/moby/moby/api/types/events"
+	volumetypes "github.com/moby/moby/api/types/volume"
+	"github.com/moby/moby/v2/daemon/internal/stringid"
+	"github.com/moby/moby/v2/daemon/volume"
+	"github.com/moby/moby/v2/errdefs"
+	"github.com/pkg/errors"
+)
+
+// CreateSnapshot takes a point-in-time snapshot of the named volume's data.
+// The volume's driver must support snapshots; the local driver does so when
+// the volume is backed by a filesystem with copy-on-write file clones, such
+// as btrfs, XFS, or OpenZFS.
+func (s *VolumesService) CreateSnapshot(ctx context.Context, name string, options volumetypes.SnapshotCreateOptions) (*volumetypes.Snapshot, error) {
+	v, err := s.vs.Get(ctx, name)
+	if err != nil {
+		if IsNotExist(err) {
+			err = errdefs.NotFound(err)
+		}
+		return nil, err
+	}
+	sv, ok := v.(volume.Snapshotter)
+	if !ok {
+		return nil, errSnapshotsNotSupported(name)
+	}
+
+	snapName := options.Name
+	if snapName == "" {
+		snapName = stringid.GenerateRandomID()
+	}
+	info, err := sv.CreateSnapshot(snapName)
+	if err != nil {
+		return nil, err
+	}
+	s.eventLogger.LogVolumeEvent(name, events.ActionSnapshot, map[string]string{"snapshot": info.Name})
+	return snapshotToAPI(info), nil
+}
+
+// Snapshots lists the snapshots of the named volume.
+func (s *VolumesService) Snapshots(ctx context.Context, name string) ([]*volumetypes.Snapshot, error) {
+	v, err := s.vs.Get(ctx, name)
+	if err != nil {
+		if IsNotExist(err) {
+			err = errdefs.NotFound(err)
+		}
+		return nil, err
+	}
+	sv, ok := v.(volume.Snapshotter)
+	if !ok {
+		return nil, errSnapshotsNotSupported(name)
+	}
+
+	infos, err := sv.Snapshots()
+	if err != nil {
+		return nil, err
+	}
+	snapshots := make([]*volumetypes.Snapshot, 0, len(infos))
+	for _, info := range infos {
+		snapshots = append(snapshots, snapshotToAPI(info))
+	}
+	return snapshots, nil
+}
+
+// RestoreSnapshot replaces the named volume's data with the contents of one
+// of its snapshots. The restore happens in place, so containers using the
+// volume observe the restored data without being restarted.
+func (s *VolumesService) RestoreSnapshot(ctx context.Context, name string, options volumetypes.SnapshotRestoreOptions) error {
+	if options.Name == "" {
+		return errdefs.InvalidParameter(errors.New("snapshot name is required"))
+	}
+	v, err := s.vs.Get(ctx, name)
+	if err != nil {
+		if IsNotExist(err) {
+			err = errdefs.NotFound(err)
+		}
+		return err
+	}
+	sv, ok := v.(volume.Snapshotter)
+	if !ok {
+		return errSnapshotsNotSupported(name)
+	}
+
+	if err := sv.RestoreSnapshot(options.Name); err != nil {
+		return err
+	}
+	s.eventLogger.LogVolumeEvent(name, events.ActionRestore, map[string]string{"snapshot": options.Name})
+	return nil
+}
+
+func snapshotToAPI(info volume.SnapshotInfo) *volumetypes.Snapshot {
+	return &volumetypes.Snapshot{
+		Name:      info.Name,
+		CreatedAt: info.CreatedAt.Format(time.RFC3339Nano),
+	}
+}
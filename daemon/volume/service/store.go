@@ -25,7 +25,10 @@ const (
 	volumeDataDir = "volumes"
 )
 
-var _ volume.LiveRestorer = (*volumeWrapper)(nil)
+var (
+	_ volume.LiveRestorer = (*volumeWrapper)(nil)
+	_ volume.Snapshotter  = (*volumeWrapper)(nil)
+)
 
 type volumeWrapper struct {
 	volume.Volume
@@ -77,6 +80,38 @@ func (v volumeWrapper) LiveRestoreVolume(ctx context.Context, ref string) error
 	return nil
 }
 
+func (v volumeWrapper) CreateSnapshot(name string) (volume.SnapshotInfo, error) {
+	if vv, ok := v.Volume.(volume.Snapshotter); ok {
+		return vv.CreateSnapshot(name)
+	}
+	return volume.SnapshotInfo{}, errSnapshotsNotSupported(v.Name())
+}
+
+func (v volumeWrapper) Snapshots() ([]volume.SnapshotInfo, error) {
+	if vv, ok := v.Volume.(volume.Snapshotter); ok {
+		return vv.Snapshots()
+	}
+	return nil, errSnapshotsNotSupported(v.Name())
+}
+
+func (v volumeWrapper) SnapshotPath(name string) (string, error) {
+	if vv, ok := v.Volume.(volume.Snapshotter); ok {
+		return vv.SnapshotPath(name)
+	}
+	return "", errSnapshotsNotSupported(v.Name())
+}
+
+func (v volumeWrapper) RestoreSnapshot(name string) error {
+	if vv, ok := v.Volume.(volume.Snapshotter); ok {
+		return vv.RestoreSnapshot(name)
+	}
+	return errSnapshotsNotSupported(v.Name())
+}
+
+func errSnapshotsNotSupported(name string) error {
+	return errdefs.NotImplemented(errors.Errorf("volume %s does not support snapshots", name))
+}
+
 // StoreOpt sets options for a VolumeStore
 type StoreOpt func(store *VolumeStore) error
 
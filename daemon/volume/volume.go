@@ -70,6 +70,32 @@ type LiveRestorer interface {
 	LiveRestoreVolume(_ context.Context, ref string) error
 }
 
+// SnapshotInfo describes a point-in-time snapshot of a volume's data.
+type SnapshotInfo struct {
+	// Name of the snapshot, unique within its volume.
+	Name string
+	// CreatedAt is the time the snapshot was taken.
+	CreatedAt time.Time
+}
+
+// Snapshotter is an optional interface implemented by volumes that can take
+// point-in-time snapshots of their data using the backing filesystem's native
+// copy-on-write facilities (such as file clones on btrfs, XFS, or OpenZFS).
+// A snapshot shares its extents with the volume's data, so taking one does
+// not duplicate the data on disk.
+type Snapshotter interface {
+	// CreateSnapshot takes a snapshot of the volume's current data under
+	// the given name.
+	CreateSnapshot(name string) (SnapshotInfo, error)
+	// Snapshots lists the volume's snapshots.
+	Snapshots() ([]SnapshotInfo, error)
+	// SnapshotPath returns the absolute path to the named snapshot's data.
+	SnapshotPath(name string) (string, error)
+	// RestoreSnapshot replaces the volume's data with the contents of the
+	// named snapshot. The snapshot itself is left in place.
+	RestoreSnapshot(name string) error
+}
+
 // DetailedVolume wraps a Volume with user-defined labels, options, and cluster scope (e.g., `local` or `global`)
 type DetailedVolume interface {
 	Labels() map[string]string
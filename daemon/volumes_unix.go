@@ -6,19 +6,31 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"path/filepath"
 	"strconv"
 	"strings"
+	"syscall"
 
+	"github.com/containerd/continuity/fs"
 	"github.com/containerd/log"
 	"github.com/moby/moby/api/types/events"
 	mounttypes "github.com/moby/moby/api/types/mount"
 	"github.com/moby/moby/v2/daemon/container"
 	"github.com/moby/moby/v2/daemon/internal/cleanups"
 	"github.com/moby/moby/v2/daemon/internal/idtools"
+	"github.com/moby/moby/v2/daemon/internal/layer"
+	"github.com/moby/moby/v2/daemon/internal/stringid"
+	"github.com/moby/moby/v2/daemon/server/backend"
 	volumemounts "github.com/moby/moby/v2/daemon/volume/mounts"
+	"github.com/moby/moby/v2/errdefs"
+	"github.com/moby/sys/symlink"
 	"github.com/pkg/errors"
 )
 
+// coreDumpMountPath is the path inside the container where the host directory
+// configured in HostConfig.CoreDump.Directory is bind-mounted.
+const coreDumpMountPath = "/cores"
+
 // setupMounts iterates through each of the mount points for a container and
 // calls Setup() on each. It also looks to see if is a network mount such as
 // /etc/resolv.conf, and if it is not, appends it to the array of mounts.
@@ -103,6 +115,17 @@ func (daemon *Daemon) setupMounts(ctx context.Context, c *container.Container) (
 		}
 	}
 
+	if cd := c.HostConfig.CoreDump; cd != nil && cd.Directory != "" {
+		if err := os.MkdirAll(cd.Directory, 0o755); err != nil {
+			return nil, nil, err
+		}
+		mounts = append(mounts, container.Mount{
+			Source:      cd.Directory,
+			Destination: coreDumpMountPath,
+			Writable:    true,
+		})
+	}
+
 	mounts = sortMounts(mounts)
 	netMounts := c.NetworkMounts()
 	// if we are going to mount any of the network files from container
@@ -130,3 +153,56 @@ func setBindModeIfNull(bind *volumemounts.MountPoint) {
 		bind.Mode = "z"
 	}
 }
+
+// SeedVolume populates volumePath with the contents of imagePath inside
+// imageRef's filesystem, preserving ownership and permissions. It implements
+// [volumesservice.ImageSeeder] and backs the "from-image" volume creation
+// option.
+func (daemon *Daemon) SeedVolume(ctx context.Context, imageRef, imagePath, volumePath string) error {
+	img, err := daemon.imageService.GetImage(ctx, imageRef, backend.GetImageOpts{})
+	if err != nil {
+		return err
+	}
+
+	rwLayer, err := daemon.imageService.CreateLayerFromImage(img, "volume-seed-"+stringid.GenerateRandomID(), &layer.CreateRWLayerOpts{})
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := daemon.imageService.ReleaseLayer(rwLayer); err != nil {
+			log.G(ctx).WithError(err).Warn("Failed to release layer after seeding volume")
+		}
+	}()
+
+	root, err := rwLayer.Mount("")
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := rwLayer.Unmount(); err != nil {
+			log.G(ctx).WithError(err).Warn("Failed to unmount layer after seeding volume")
+		}
+	}()
+
+	src, err := symlink.FollowSymlinkInScope(filepath.Join(root, imagePath), root)
+	if err != nil {
+		return err
+	}
+	stat, err := os.Stat(src)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return errdefs.InvalidParameter(errors.Errorf("path %s does not exist in image %s", imagePath, imageRef))
+		}
+		return err
+	}
+	if !stat.IsDir() {
+		return errdefs.InvalidParameter(errors.Errorf("path %s in image %s is not a directory", imagePath, imageRef))
+	}
+
+	return fs.CopyDir(volumePath, src, fs.WithXAttrErrorHandler(func(dst, src, xattrKey string, err error) error {
+		if !errors.Is(err, syscall.ENOTSUP) {
+			return err
+		}
+		return nil
+	}))
+}
@@ -9,6 +9,8 @@ import (
 	"github.com/moby/moby/v2/daemon/internal/cleanups"
 	"github.com/moby/moby/v2/daemon/internal/idtools"
 	volumemounts "github.com/moby/moby/v2/daemon/volume/mounts"
+	"github.com/moby/moby/v2/errdefs"
+	"github.com/pkg/errors"
 )
 
 // setupMounts configures the mount points for a container by appending each
@@ -60,3 +62,9 @@ func setBindModeIfNull(bind *volumemounts.MountPoint) {
 func (daemon *Daemon) validateBindDaemonRoot(m mount.Mount) (bool, error) {
 	return false, nil
 }
+
+// SeedVolume implements [volumesservice.ImageSeeder]. Seeding volumes from
+// an image is not supported on Windows.
+func (daemon *Daemon) SeedVolume(ctx context.Context, imageRef, imagePath, volumePath string) error {
+	return errdefs.InvalidParameter(errors.New("seeding volumes from an image is not supported on Windows"))
+}
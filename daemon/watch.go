@@ -0,0 +1,15 @@
+package daemon
+
+import (
+	"context"
+
+	containertypes "github.com/moby/moby/api/types/container"
+)
+
+// ContainersWatch returns the current state of all containers followed by a
+// stream of incremental change events; see the container watch endpoint
+// (GET "/containers/watch"). sinceVersion, when non-zero, resumes the watch
+// from a previously observed resource version instead of relisting.
+func (daemon *Daemon) ContainersWatch(ctx context.Context, sinceVersion uint64) ([]containertypes.WatchEvent, <-chan containertypes.WatchEvent, func(), error) {
+	return daemon.containersReplica.Watch(sinceVersion)
+}
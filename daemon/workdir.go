@@ -1,5 +1,15 @@
 package daemon
 
+import (
+	"context"
+	"fmt"
+
+	"github.com/containerd/log"
+	"github.com/moby/moby/v2/daemon/config"
+	"github.com/moby/moby/v2/daemon/container"
+	"github.com/moby/moby/v2/errdefs"
+)
+
 // ContainerCreateWorkdir creates the working directory. This solves the
 // issue arising from https://github.com/moby/moby/issues/27545,
 // which was initially fixed by https://github.com/moby/moby/pull/27884. But that fix
@@ -16,5 +26,45 @@ func (daemon *Daemon) ContainerCreateWorkdir(cID string) error {
 		return err
 	}
 	defer daemon.Unmount(container)
-	return container.SetupWorkingDirectory(daemon.idMapping.RootPair())
+	return daemon.setupWorkingDirectory(&daemon.config().Config, container)
+}
+
+// setupWorkingDirectory sets up the container's working directory, applying
+// the daemon's workdir-create-policy when the directory does not exist in
+// the container's filesystem. Auto-creating a missing directory records a
+// warning on the container, surfaced through the inspect endpoint. The
+// container's filesystem must be mounted.
+func (daemon *Daemon) setupWorkingDirectory(daemonCfg *config.Config, c *container.Container) error {
+	if c.Config.WorkingDir == "" {
+		return nil
+	}
+
+	policy := daemonCfg.WorkdirCreatePolicy
+	if policy == "" {
+		policy = config.WorkdirCreateAsUser
+	}
+
+	uid, gid := daemon.idMapping.RootPair()
+	if policy == config.WorkdirCreateAsUser && c.Config.User != "" {
+		if usr, err := getUser(c, c.Config.User); err != nil {
+			// Fall back to root ownership rather than failing creation;
+			// the same lookup failure surfaces when building the spec.
+			log.G(context.TODO()).WithError(err).WithField("container", c.ID).
+				Warn("could not resolve container user for working directory ownership")
+		} else if hostUID, hostGID, err := daemon.idMapping.ToHost(int(usr.UID), int(usr.GID)); err == nil {
+			uid, gid = hostUID, hostGID
+		}
+	}
+
+	created, err := c.SetupWorkingDirectory(uid, gid, policy == config.WorkdirCreateFail)
+	if err != nil {
+		if policy == config.WorkdirCreateFail {
+			return errdefs.InvalidParameter(err)
+		}
+		return err
+	}
+	if created {
+		c.Warnings = append(c.Warnings, fmt.Sprintf("working directory %q did not exist in the image and was created with ownership %d:%d (workdir-create-policy=%s)", c.Config.WorkingDir, uid, gid, policy))
+	}
+	return nil
 }
@@ -1,6 +1,7 @@
 package plugins
 
 import (
+	"errors"
 	"fmt"
 	"net/http"
 )
@@ -21,6 +22,14 @@ func IsNotFound(err error) bool {
 	return isStatusError(err, http.StatusNotFound)
 }
 
+// IsStatusError indicates if the passed in error is an error response from
+// the plugin, i.e. the plugin was reachable and responded, as opposed to a
+// transport error where the plugin could not be reached at all.
+func IsStatusError(err error) bool {
+	var e *statusError
+	return errors.As(err, &e)
+}
+
 func isStatusError(err error, status int) bool {
 	if err == nil {
 		return false
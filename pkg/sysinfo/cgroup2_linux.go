@@ -104,12 +104,28 @@ func applyIOCgroupInfoV2(info *SysInfo) {
 		return
 	}
 
-	info.BlkioWeight = true
-	info.BlkioWeightDevice = true
+	// The io.weight, io.bfq.weight and io.latency interface files are
+	// optional; their availability depends on the kernel configuration and
+	// the active I/O scheduler, so probe for them instead of assuming the
+	// io controller provides them.
+	info.BlkioWeight = cgroupv2HasFile("io.weight") || cgroupv2HasFile("io.bfq.weight")
+	info.BlkioWeightDevice = info.BlkioWeight
 	info.BlkioReadBpsDevice = true
 	info.BlkioWriteBpsDevice = true
 	info.BlkioReadIOpsDevice = true
 	info.BlkioWriteIOpsDevice = true
+	info.IOLatency = cgroupv2HasFile("io.latency")
+}
+
+// cgroupv2HasFile reports whether the given cgroup v2 interface file exists
+// in the cgroup of the current process.
+func cgroupv2HasFile(file string) bool {
+	_, g, err := cgroups.ParseCgroupFileUnified("/proc/self/cgroup")
+	if err != nil || g == "" {
+		return false
+	}
+	_, err = os.Stat(path.Join("/sys/fs/cgroup", g, file))
+	return err == nil
 }
 
 func applyCPUSetCgroupInfoV2(info *SysInfo) {
@@ -104,6 +104,9 @@ type cgroupBlkioInfo struct {
 
 	// Whether Block IO write limit in IO per second is supported or not
 	BlkioWriteIOpsDevice bool
+
+	// Whether the io.latency controller is supported (cgroups v2 only)
+	IOLatency bool
 }
 
 type cgroupCpusetInfo struct {